@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	rcvr "go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// Attributes set on Access authentication event log records.
+const (
+	attrAccessAction    = "cloudflare.access.action"
+	attrAccessAllowed   = "cloudflare.access.allowed"
+	attrAccessAppDomain = "cloudflare.access.app.domain"
+	attrAccessAppUID    = "cloudflare.access.app.uid"
+	attrAccessIDP       = "cloudflare.access.identity_provider"
+	attrAccessCountry   = "cloudflare.access.country"
+)
+
+// accessRequestsReceiver polls the Cloudflare API for Zero Trust Access authentication events
+// and emits them as log records.
+type accessRequestsReceiver struct {
+	*accountPoller
+	cfg *AccessRequestsConfig
+
+	// checkpoints holds the position of the next poll, keyed by account ID.
+	checkpoints map[string]*eventCheckpoint
+	// clamped holds the accounts whose checkpoint was clamped to the retention, which is only logged once.
+	clamped map[string]bool
+}
+
+func newAccessRequestsReceiver(params rcvr.Settings, cfg *AccessRequestsConfig, consumer consumer.Logs) (*accessRequestsReceiver, error) {
+	poller, err := newAccountPoller(params, consumer)
+	if err != nil {
+		return nil, err
+	}
+	poller.apiCfg = &cfg.APIConfig
+	poller.pollInterval = cfg.PollInterval
+	poller.alignWindow = cfg.AlignWindow
+	poller.section = "access_requests"
+	poller.permission = accessRequestsPermission
+	poller.events = "Access authentication events"
+	poller.accountIDs = cfg.Accounts
+	poller.pageSize = cfg.PageSize
+
+	r := &accessRequestsReceiver{
+		accountPoller: poller,
+		cfg:           cfg,
+		checkpoints:   map[string]*eventCheckpoint{},
+		clamped:       map[string]bool{},
+	}
+	poller.pollAccount = r.pollAccount
+	return r, nil
+}
+
+// pollAccount emits the events of the account created up to until. The checkpoint only moves
+// forward once a page of events has been consumed, so failed pages are retried on the next poll.
+func (r *accessRequestsReceiver) pollAccount(ctx context.Context, accountID string, until time.Time) error {
+	cp, ok := r.checkpoints[accountID]
+	if !ok {
+		cp = newEventCheckpoint(r.started)
+		r.checkpoints[accountID] = cp
+	}
+	if r.cfg.Retention > 0 && cp.clamp(until.Add(-r.cfg.Retention)) && !r.clamped[accountID] {
+		r.clamped[accountID] = true
+		r.logger.Warn("Skipping the Access authentication events older than the retention, which can't be collected anymore",
+			zap.String("account", accountID),
+			zap.Duration("retention", r.cfg.Retention))
+	}
+	// Aligned windows may not have moved since the last poll.
+	if !until.After(cp.since) {
+		return nil
+	}
+	for {
+		events, err := r.client.ListAccessRequests(ctx, accountID, cp.since, until, r.pageSize)
+		if shrinkPageSize(r.logger, &r.pageSize, err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		fresh := make([]accessRequest, 0, len(events))
+		for _, event := range events {
+			if cp.emitted(event.RayID, event.CreatedAt) {
+				continue
+			}
+			fresh = append(fresh, event)
+		}
+
+		if len(events) == 0 {
+			cp.reset(until)
+			return nil
+		}
+
+		if len(fresh) > 0 {
+			if err := r.consume(ctx, r.processEvents(pcommon.NewTimestampFromTime(time.Now()), accountID, fresh)); err != nil {
+				return err
+			}
+		}
+
+		for _, event := range fresh {
+			cp.advance(event.RayID, event.CreatedAt)
+		}
+
+		if len(events) < r.pageSize {
+			return nil
+		}
+		if len(fresh) == 0 {
+			return fmt.Errorf("more than %d events were created at %s, increase page_size to collect them", r.pageSize, cp.since.Format(time.RFC3339Nano))
+		}
+	}
+}
+
+func (r *accessRequestsReceiver) processEvents(now pcommon.Timestamp, accountID string, events []accessRequest) plog.Logs {
+	logs := plog.NewLogs()
+	resourceLogs, scopeLogs := appendResourceLogs(logs, r.buildInfo)
+	resourceLogs.Resource().Attributes().PutStr(attrAccountID, accountID)
+
+	for _, event := range events {
+		logRecord := scopeLogs.LogRecords().AppendEmpty()
+		logRecord.SetObservedTimestamp(now)
+		logRecord.SetTimestamp(pcommon.NewTimestampFromTime(event.CreatedAt))
+
+		sev := plog.SeverityNumberInfo
+		if !event.Allowed {
+			sev = plog.SeverityNumberWarn
+		}
+		logRecord.SetSeverityNumber(sev)
+		logRecord.SetSeverityText(sev.String())
+
+		attrs := logRecord.Attributes()
+		attrs.PutStr(attrUserEmail, event.UserEmail)
+		attrs.PutStr(attrClientAddress, event.IPAddress)
+		attrs.PutStr(attrRayID, event.RayID)
+		attrs.PutStr(attrAccessAction, event.Action)
+		attrs.PutBool(attrAccessAllowed, event.Allowed)
+		attrs.PutStr(attrAccessAppDomain, event.AppDomain)
+		attrs.PutStr(attrAccessAppUID, event.AppUID)
+		attrs.PutStr(attrAccessIDP, event.Connection)
+		if event.Country != "" {
+			attrs.PutStr(attrAccessCountry, event.Country)
+		}
+
+		if err := logRecord.Body().SetEmptyMap().FromRaw(event.raw); err != nil {
+			r.logger.Warn("unable to set body", zap.Error(err))
+		}
+	}
+
+	return logs
+}
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// fakeAccessRequestsClient serves a fixed list of events, emulating the filtering of the API, and
+// failing requests for more than maxLimit events as too large if set.
+type fakeAccessRequestsClient struct {
+	client
+	events   []accessRequest
+	calls    int
+	maxLimit int
+}
+
+func (f *fakeAccessRequestsClient) ListAccessRequests(_ context.Context, _ string, since, until time.Time, limit int) ([]accessRequest, error) {
+	f.calls++
+	if f.maxLimit > 0 && limit > f.maxLimit {
+		return nil, fmt.Errorf("response exceeds max_response_size: %w", errResponseTooLarge)
+	}
+	var result []accessRequest
+	for _, event := range f.events {
+		if event.CreatedAt.Before(since) || !event.CreatedAt.Before(until) {
+			continue
+		}
+		if len(result) == limit {
+			break
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+func newAccessRequest(rayID string, createdAt time.Time, allowed bool) accessRequest {
+	return accessRequest{
+		Action:     "login",
+		Allowed:    allowed,
+		AppDomain:  "app.example.com",
+		AppUID:     "df7e2w5f-02b7-4d9d-af26-8d1988fca630",
+		Connection: "saml",
+		CreatedAt:  createdAt,
+		IPAddress:  "198.41.129.166",
+		RayID:      rayID,
+		UserEmail:  "user@example.com",
+		raw:        map[string]any{"ray_id": rayID},
+	}
+}
+
+func newTestAccessRequestsReceiver(t *testing.T, next consumer.Logs, c client, pageSize int) *accessRequestsReceiver {
+	r, err := newAccessRequestsReceiver(receivertest.NewNopSettings(metadata.Type), &AccessRequestsConfig{
+		Accounts:     []string{testAccountID},
+		PollInterval: time.Minute,
+		PageSize:     pageSize,
+	}, next)
+	require.NoError(t, err)
+	r.client = c
+	return r
+}
+
+func TestAccessRequestsPollAccount(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAccessRequestsClient{
+		events: []accessRequest{
+			newAccessRequest("ray1", start.Add(time.Second), true),
+			newAccessRequest("ray2", start.Add(2*time.Second), true),
+			newAccessRequest("ray3", start.Add(2*time.Second), false),
+			newAccessRequest("ray4", start.Add(3*time.Second), true),
+		},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestAccessRequestsReceiver(t, sink, fake, 3)
+	r.checkpoints[testAccountID] = newEventCheckpoint(start)
+
+	until := start.Add(time.Minute)
+	require.NoError(t, r.pollAccount(t.Context(), testAccountID, until))
+	require.Equal(t, []string{"ray1", "ray2", "ray3", "ray4"}, emittedRayIDs(sink))
+
+	// Polling again only returns already emitted events, which are skipped.
+	require.NoError(t, r.pollAccount(t.Context(), testAccountID, until))
+	require.Len(t, emittedRayIDs(sink), 4)
+
+	fake.events = append(fake.events, newAccessRequest("ray5", start.Add(4*time.Second), true))
+	require.NoError(t, r.pollAccount(t.Context(), testAccountID, until))
+	require.Equal(t, []string{"ray1", "ray2", "ray3", "ray4", "ray5"}, emittedRayIDs(sink))
+}
+
+func TestAccessRequestsPollAccountTooManyEventsAtOnce(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAccessRequestsClient{
+		events: []accessRequest{
+			newAccessRequest("ray1", start.Add(time.Second), true),
+			newAccessRequest("ray2", start.Add(time.Second), true),
+			newAccessRequest("ray3", start.Add(time.Second), true),
+		},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestAccessRequestsReceiver(t, sink, fake, 2)
+	r.checkpoints[testAccountID] = newEventCheckpoint(start)
+
+	err := r.pollAccount(t.Context(), testAccountID, start.Add(time.Minute))
+	require.ErrorContains(t, err, "more than 2 events were created at")
+	require.Equal(t, []string{"ray1", "ray2"}, emittedRayIDs(sink))
+}
+
+func TestAccessRequestsPollAccountShrinksPageSize(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAccessRequestsClient{
+		events: []accessRequest{
+			newAccessRequest("ray1", start.Add(time.Second), true),
+			newAccessRequest("ray2", start.Add(2*time.Second), true),
+			newAccessRequest("ray3", start.Add(3*time.Second), true),
+		},
+		maxLimit: 2,
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestAccessRequestsReceiver(t, sink, fake, 8)
+	r.checkpoints[testAccountID] = newEventCheckpoint(start)
+
+	require.NoError(t, r.pollAccount(t.Context(), testAccountID, start.Add(time.Minute)))
+	require.Equal(t, []string{"ray1", "ray2", "ray3"}, emittedRayIDs(sink))
+	require.Equal(t, 2, r.pageSize)
+}
+
+func TestAccessRequestsPollAccountConsumerError(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAccessRequestsClient{
+		events: []accessRequest{newAccessRequest("ray1", start.Add(time.Second), true)},
+	}
+	r := newTestAccessRequestsReceiver(t, consumertest.NewErr(errors.New("consumer failed")), fake, 2)
+	r.checkpoints[testAccountID] = newEventCheckpoint(start)
+
+	require.ErrorContains(t, r.pollAccount(t.Context(), testAccountID, start.Add(time.Minute)), "consumer failed")
+	// The checkpoint is not moved, so the events are retried on the next poll.
+	require.Equal(t, start, r.checkpoints[testAccountID].since)
+	require.Empty(t, r.checkpoints[testAccountID].seen)
+}
+
+func TestAccessRequestsPollAccountRetention(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAccessRequestsClient{
+		events: []accessRequest{
+			newAccessRequest("ray1", start.Add(time.Hour), true),
+			newAccessRequest("ray2", start.Add(3*time.Hour), true),
+		},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestAccessRequestsReceiver(t, sink, fake, 10)
+	r.cfg.Retention = 2 * time.Hour
+	r.checkpoints[testAccountID] = newEventCheckpoint(start)
+
+	// The events older than the retention are no longer requested.
+	until := start.Add(4 * time.Hour)
+	require.NoError(t, r.pollAccount(t.Context(), testAccountID, until))
+	require.Equal(t, []string{"ray2"}, emittedRayIDs(sink))
+	require.True(t, r.clamped[testAccountID])
+}
+
+func TestAccessRequestsPollAlignWindow(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	r := newTestAccessRequestsReceiver(t, sink, &fakeAccessRequestsClient{}, 10)
+	r.pollInterval = time.Hour
+	r.alignWindow = true
+	r.checkpoints[testAccountID] = newEventCheckpoint(time.Now().Add(-3 * time.Hour))
+
+	// The window ends on the last hour boundary, which the checkpoint moves to.
+	r.poll(t.Context())
+	require.Equal(t, time.Now().Truncate(time.Hour), r.checkpoints[testAccountID].since)
+}
+
+func TestAccessRequestsProcessEvents(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	denied := newAccessRequest("187d944c61940c77", createdAt, false)
+	denied.Country = "US"
+
+	r := newTestAccessRequestsReceiver(t, consumertest.NewNop(), nil, 2)
+	logs := r.processEvents(pcommon.NewTimestampFromTime(time.Now()), testAccountID, []accessRequest{denied})
+
+	expected := plog.NewLogs()
+	rl := expected.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl(conventions.SchemaURL)
+	rl.Resource().Attributes().PutStr("cloudflare.account.id", testAccountID)
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(metadata.ScopeName)
+	sl.Scope().SetVersion("latest")
+	sl.SetSchemaUrl(conventions.SchemaURL)
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(createdAt))
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText(plog.SeverityNumberWarn.String())
+	require.NoError(t, lr.Attributes().FromRaw(map[string]any{
+		"user.email":                          "user@example.com",
+		"client.address":                      "198.41.129.166",
+		"cloudflare.ray_id":                   "187d944c61940c77",
+		"cloudflare.access.action":            "login",
+		"cloudflare.access.allowed":           false,
+		"cloudflare.access.app.domain":        "app.example.com",
+		"cloudflare.access.app.uid":           "df7e2w5f-02b7-4d9d-af26-8d1988fca630",
+		"cloudflare.access.identity_provider": "saml",
+		"cloudflare.access.country":           "US",
+	}))
+	require.NoError(t, lr.Body().SetEmptyMap().FromRaw(map[string]any{"ray_id": "187d944c61940c77"}))
+
+	require.NoError(t, plogtest.CompareLogs(expected, logs, plogtest.IgnoreObservedTimestamp()))
+}
+
+func TestListAccessRequests(t *testing.T) {
+	since := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	until := since.Add(time.Minute)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/accounts/"+testAccountID+"/access/logs/access_requests", req.URL.Path)
+		require.Equal(t, "2024-05-01T10:00:00Z", req.URL.Query().Get("since"))
+		require.Equal(t, "2024-05-01T10:01:00Z", req.URL.Query().Get("until"))
+		require.Equal(t, "50", req.URL.Query().Get("limit"))
+		require.Equal(t, "asc", req.URL.Query().Get("direction"))
+		require.NoError(t, json.NewEncoder(rw).Encode(map[string]any{
+			"success": true,
+			"result": []map[string]any{{
+				"action":     "login",
+				"allowed":    true,
+				"app_domain": "app.example.com",
+				"created_at": "2024-05-01T10:00:30.12345Z",
+				"ray_id":     "187d944c61940c77",
+				"user_email": "user@example.com",
+			}},
+		}))
+	}))
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	c, err := newClient(t.Context(), &APIConfig{ClientConfig: clientConfig, APIToken: "abc123"}, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	events, err := c.ListAccessRequests(t.Context(), testAccountID, since, until, 50)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "187d944c61940c77", events[0].RayID)
+	require.True(t, events[0].Allowed)
+	require.Equal(t, since.Add(30*time.Second+123450*time.Microsecond), events[0].CreatedAt)
+	require.Equal(t, "app.example.com", events[0].raw["app_domain"])
+}
+
+func emittedRayIDs(sink *consumertest.LogsSink) []string {
+	var rayIDs []string
+	for _, logs := range sink.AllLogs() {
+		records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < records.Len(); i++ {
+			rayID, _ := records.At(i).Attributes().Get("cloudflare.ray_id")
+			rayIDs = append(rayIDs, rayID.Str())
+		}
+	}
+	return rayIDs
+}
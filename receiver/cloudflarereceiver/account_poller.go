@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	rcvr "go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// accountPoller periodically collects the events of accounts from the Cloudflare API and emits them as
+// log records. It's embedded by the receivers of the account event APIs, which collect the events of
+// an account in pollAccount.
+type accountPoller struct {
+	settings  component.TelemetrySettings
+	logger    *zap.Logger
+	consumer  consumer.Logs
+	obsrecv   *receiverhelper.ObsReport
+	buildInfo component.BuildInfo
+	client    client
+
+	apiCfg       *APIConfig
+	pollInterval time.Duration
+	alignWindow  bool
+	// section is the configuration section of the receiver, and permission the permission its API
+	// token needs.
+	section    string
+	permission string
+	// events names the collected events in logs and errors.
+	events string
+
+	// accountIDs holds the IDs of the accounts, the configured names being resolved on start.
+	accountIDs []string
+	// pageSize starts at the configured page size, and is halved whenever a response exceeds
+	// max_response_size.
+	pageSize int
+	// started is the time the receiver started. Events created before are not collected.
+	started time.Time
+	// pollAccount emits the events of the account created up to until.
+	pollAccount func(ctx context.Context, accountID string, until time.Time) error
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+func newAccountPoller(params rcvr.Settings, consumer consumer.Logs) (*accountPoller, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             params.ID,
+		Transport:              "http",
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountPoller{
+		settings:  params.TelemetrySettings,
+		logger:    params.Logger,
+		consumer:  consumer,
+		obsrecv:   obsrecv,
+		buildInfo: params.BuildInfo,
+	}, nil
+}
+
+func (p *accountPoller) Start(ctx context.Context, host component.Host) error {
+	var err error
+	p.client, err = newClient(ctx, p.apiCfg, host, p.settings)
+	if err != nil {
+		return err
+	}
+	verifyToken(ctx, p.client, p.logger, p.section, p.permission)
+	if p.accountIDs, err = resolveAccountIDs(ctx, p.client, p.accountIDs); err != nil {
+		return err
+	}
+	p.started = time.Now()
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.wg.Add(1)
+	go p.startPolling(pollCtx)
+	return nil
+}
+
+func (p *accountPoller) Shutdown(_ context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+	return nil
+}
+
+func (p *accountPoller) startPolling(ctx context.Context) {
+	defer p.wg.Done()
+
+	t := time.NewTicker(p.pollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			p.poll(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *accountPoller) poll(ctx context.Context) {
+	until := time.Now()
+	if p.alignWindow {
+		until = until.Truncate(p.pollInterval)
+	}
+	for _, accountID := range p.accountIDs {
+		if err := p.pollAccount(ctx, accountID, until); err != nil {
+			p.logger.Error("Failed to collect "+p.events, zap.String("account", accountID), zap.Error(err))
+		}
+	}
+}
+
+func (p *accountPoller) consume(ctx context.Context, logs plog.Logs) error {
+	obsCtx := p.obsrecv.StartLogsOp(ctx)
+	err := p.consumer.ConsumeLogs(obsCtx, logs)
+	p.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), logs.LogRecordCount(), err)
+	if err != nil {
+		return errors.Join(errors.New("failed to consume "+p.events), err)
+	}
+	return nil
+}
@@ -0,0 +1,669 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// analyticsScraper polls the GraphQL Analytics API for the analytics datasets of zones and accounts.
+// Every scrape polls the window following the one polled by the previous scrape, and emits the metrics
+// of the groups of the window with the window as their time range.
+type analyticsScraper struct {
+	cfg       *AnalyticsConfig
+	settings  component.TelemetrySettings
+	buildInfo component.BuildInfo
+	logger    *zap.Logger
+	mb        *metadata.MetricsBuilder
+
+	telemetryBuilder *metadata.TelemetryBuilder
+
+	tenants []*analyticsTenant
+	// datasets holds the datasets collected, configured and with their options applied, by name.
+	datasets map[string]analyticsDataset
+	// cardinalityLimits holds the cardinality limits of the attributes, including the ones of the
+	// fingerprints limited by top_fingerprints.
+	cardinalityLimits map[string]int
+	// windowEnd is the end of the window polled by the last scrape, where the next window starts.
+	windowEnd time.Time
+	// clamped is whether the last window was clamped to the retention, which is only logged once.
+	clamped bool
+	// breaker pauses the zones and accounts whose analytics repeatedly fail to be queried.
+	breaker *circuitBreaker
+	// unavailable holds the time at which the datasets missing from the plan of a zone or account are
+	// queried again, by tenant, dataset and zone or account.
+	unavailable map[string]time.Time
+	// limits holds the limits of the datasets and custom queries halved after their queries requested
+	// too many groups, by name.
+	limits map[string]int
+	// cumulative holds the running totals of the counts, when they are emitted as cumulative sums.
+	cumulative cumulativeSums
+	// id identifies the receiver in the storage of the running totals.
+	id      component.ID
+	storage storage.Client
+	// exemplarsMB records the groups with exemplars on their own, to find the data points recorded for
+	// them.
+	exemplarsMB *metadata.MetricsBuilder
+	// exemplars holds the exemplars of the data points recorded by the current scrape.
+	exemplars map[exemplarKey]analyticsExemplar
+}
+
+// exemplarKey identifies a data point of a metric of a zone or account by its attributes.
+type exemplarKey struct {
+	tag        string
+	metric     string
+	attributes [16]byte
+}
+
+// analyticsExemplar is an event counted by a data point, identified by its Ray ID.
+type analyticsExemplar struct {
+	rayID   pcommon.SpanID
+	created pcommon.Timestamp
+}
+
+// analyticsTenant holds the configuration of a tenant and the client querying its zones and accounts
+// with the API token of the tenant.
+type analyticsTenant struct {
+	cfg    AnalyticsTenantConfig
+	client client
+	// zones caches the details of the zones of the tenant.
+	zones *zoneCache
+
+	// zoneIDs holds the IDs of the zones, the configured names being resolved on start.
+	zoneIDs []string
+	// accountIDs holds the IDs of the accounts, the configured names being resolved on start.
+	accountIDs []string
+}
+
+func newAnalyticsScraper(settings receiver.Settings, cfg *AnalyticsConfig) (*analyticsScraper, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(settings.TelemetrySettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telemetry builder: %w", err)
+	}
+	s := &analyticsScraper{
+		cfg:               cfg,
+		settings:          settings.TelemetrySettings,
+		buildInfo:         settings.BuildInfo,
+		logger:            settings.Logger,
+		breaker:           newCircuitBreaker(cfg.CircuitBreaker),
+		limits:            map[string]int{},
+		unavailable:       map[string]time.Time{},
+		cumulative:        cumulativeSums{},
+		id:                settings.ID,
+		storage:           storage.NewNopClient(),
+		mb:                metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
+		telemetryBuilder:  telemetryBuilder,
+		datasets:          map[string]analyticsDataset{},
+		cardinalityLimits: cfg.cardinalityLimits(),
+	}
+	if cfg.Exemplars {
+		s.exemplarsMB = metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings)
+	}
+	for _, tenant := range cfg.tenants() {
+		for _, name := range tenant.Datasets {
+			dataset := analyticsDatasets[name]
+			if dataset.configure != nil {
+				dataset = dataset.configure(cfg)
+			}
+			dataset = dataset.withOptions(cfg.DatasetOptions[name])
+			dataset.exemplars = cfg.Exemplars
+			s.datasets[name] = dataset
+		}
+		t := &analyticsTenant{cfg: tenant, zoneIDs: tenant.Zones, accountIDs: tenant.Accounts}
+		t.zones = newZoneCache(cfg.ZoneCacheTTL, func(ctx context.Context, zoneID string) (zone, error) {
+			queryCtx, cancel := s.queryContext(ctx)
+			defer cancel()
+			return t.client.GetZone(queryCtx, zoneID)
+		}, settings.Logger)
+		s.tenants = append(s.tenants, t)
+	}
+	return s, nil
+}
+
+func (s *analyticsScraper) start(ctx context.Context, host component.Host) (err error) {
+	if s.cfg.AggregationTemporality == temporalityCumulative {
+		if s.storage, err = getStorageClient(ctx, host, s.cfg.StorageID, s.id, "analytics"); err != nil {
+			return err
+		}
+		if err = s.cumulative.load(ctx, s.storage); err != nil {
+			return err
+		}
+	}
+	for _, t := range s.tenants {
+		apiCfg := s.cfg.APIConfig
+		apiCfg.APIToken = t.cfg.APIToken
+		if t.client, err = newClient(ctx, &apiCfg, host, s.settings); err != nil {
+			return err
+		}
+		section := "analytics"
+		if t.cfg.Name != "" {
+			section = "analytics tenant " + t.cfg.Name
+		}
+		verifyToken(ctx, t.client, s.logger, section, analyticsPermission)
+		if t.zoneIDs, err = resolveZoneIDs(ctx, t.client, t.cfg.Zones); err != nil {
+			return err
+		}
+		if t.accountIDs, err = resolveAccountIDs(ctx, t.client, t.cfg.Accounts); err != nil {
+			return err
+		}
+	}
+	s.probe(ctx)
+	return nil
+}
+
+// probe queries every dataset of every zone and account once over the last minute, so that the
+// datasets missing from the plan of a zone or account are reported on start rather than by the first
+// scrape. Other failures are left for the scrapes to report. The queries aren't retried, and probing
+// stops once the API can't be reached, so that an unreachable API doesn't delay the start of the
+// receiver.
+func (s *analyticsScraper) probe(ctx context.Context) {
+	ctx = withoutRetries(ctx)
+	until := time.Now().Add(-s.cfg.Delay)
+	for _, t := range s.tenants {
+		for _, name := range t.cfg.Datasets {
+			dataset := s.datasets[name]
+			tags := t.zoneIDs
+			if dataset.account {
+				tags = t.accountIDs
+			}
+			for _, tag := range tags {
+				queryCtx, cancel := s.queryContext(ctx)
+				var data analyticsData
+				err := t.client.QueryGraphQL(queryCtx, dataset.query(), map[string]any{
+					"tag":   tag,
+					"since": until.Add(-analyticsGranularity).UTC().Format(time.RFC3339),
+					"until": until.UTC().Format(time.RFC3339),
+					"limit": 1,
+				}, &data)
+				cancel()
+				s.checkPlan(t, name, tag, time.Now(), err)
+				var statusErr *statusError
+				var partialErr *partialResultError
+				if err != nil && !errors.As(err, &statusErr) && !errors.As(err, &partialErr) {
+					s.logger.Debug("Failed to probe the analytics", zap.String("dataset", name), zap.String("target", tag), zap.Error(err))
+					return
+				}
+			}
+		}
+	}
+}
+
+// checkPlan pauses the dataset of the zone or account once its query failed because the dataset
+// isn't available on the plan, and resumes it once queried successfully. It returns whether the
+// dataset isn't available on the plan.
+func (s *analyticsScraper) checkPlan(t *analyticsTenant, name, tag string, now time.Time, err error) bool {
+	key := t.cfg.Name + "/" + name + "/" + tag
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.errorType == metadata.AttributeErrorTypePlan {
+		// Plans rarely change, so the dataset is only queried again every recheck interval rather
+		// than failing every scrape.
+		if _, ok := s.unavailable[key]; !ok {
+			s.logger.Warn("The dataset isn't available on the plan, pausing its analytics queries",
+				zap.String("dataset", name),
+				zap.String("target", tag),
+				zap.Duration("plan_recheck_interval", s.cfg.PlanRecheckInterval),
+				zap.Error(err))
+		}
+		s.unavailable[key] = now.Add(s.cfg.PlanRecheckInterval)
+		return true
+	}
+	var partialErr *partialResultError
+	if err == nil || errors.As(err, &partialErr) {
+		if _, ok := s.unavailable[key]; ok {
+			delete(s.unavailable, key)
+			s.logger.Info("The dataset is now available on the plan, resuming its analytics queries",
+				zap.String("dataset", name),
+				zap.String("target", tag))
+		}
+	}
+	return false
+}
+
+// isUnavailable returns whether the dataset of the zone or account isn't available on its plan and
+// isn't due to be queried again.
+func (s *analyticsScraper) isUnavailable(t *analyticsTenant, name, tag string, now time.Time) bool {
+	recheckAt, ok := s.unavailable[t.cfg.Name+"/"+name+"/"+tag]
+	return ok && (s.cfg.PlanRecheckInterval <= 0 || now.Before(recheckAt))
+}
+
+func (s *analyticsScraper) shutdown(ctx context.Context) error {
+	for _, t := range s.tenants {
+		t.zones.shutdown()
+	}
+	return s.storage.Close(ctx)
+}
+
+func (s *analyticsScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	for _, t := range s.tenants {
+		if t.client == nil {
+			return pmetric.NewMetrics(), errClientNotInit
+		}
+	}
+
+	until := time.Now().Add(-s.cfg.Delay)
+	if s.cfg.AlignWindow {
+		until = until.Truncate(s.cfg.CollectionInterval)
+	}
+	since := s.windowEnd
+	if since.IsZero() {
+		since = until.Add(-s.cfg.CollectionInterval)
+	}
+	// Aligned windows may not have moved since the last scrape.
+	if !until.After(since) {
+		return pmetric.NewMetrics(), nil
+	}
+	oldest := until.Add(-s.cfg.Retention)
+	clamped := s.cfg.Retention > 0 && since.Before(oldest)
+	if clamped {
+		since = oldest
+		if !s.clamped {
+			s.logger.Warn("Skipping the analytics older than the retention, which can't be queried anymore",
+				zap.Duration("retention", s.cfg.Retention))
+		}
+	}
+	s.clamped = clamped
+	s.windowEnd = until
+	s.exemplars = map[exemplarKey]analyticsExemplar{}
+	var scrapeErrors scrapererror.ScrapeErrors
+	// custom holds the metrics of the custom queries, which aren't recorded by the metrics builder.
+	custom := pmetric.NewMetrics()
+
+	// A failing tenant, zone, account or dataset must not prevent the others from being reported.
+	for _, t := range s.tenants {
+		for _, zoneID := range t.zoneIDs {
+			rb := s.mb.NewResourceBuilder()
+			rb.SetCloudflareZoneID(zoneID)
+			if z, ok := t.zones.get(ctx, zoneID, time.Now()); ok {
+				rb.SetCloudflareZoneName(z.Name)
+				rb.SetCloudflareZonePlan(z.Plan.Name)
+				rb.SetCloudflareAccountID(z.Account.ID)
+			}
+			if err := s.queryTarget(ctx, t, zoneID, false, rb.Emit(), since, until, custom); err != nil {
+				scrapeErrors.AddPartial(0, err)
+			}
+		}
+		for _, accountID := range t.accountIDs {
+			rb := s.mb.NewResourceBuilder()
+			rb.SetCloudflareAccountID(accountID)
+			if err := s.queryTarget(ctx, t, accountID, true, rb.Emit(), since, until, custom); err != nil {
+				scrapeErrors.AddPartial(0, err)
+			}
+		}
+	}
+
+	md := s.mb.Emit()
+	if len(s.exemplars) > 0 {
+		s.addExemplars(md)
+	}
+	custom.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	if len(s.cfg.Attributes) > 0 {
+		mapDataPointAttributes(md, s.cfg.Attributes)
+	}
+	if len(s.cardinalityLimits) > 0 {
+		limitCardinality(md, s.cardinalityLimits)
+	}
+	if s.cfg.AggregationTemporality == temporalityCumulative {
+		s.cumulative.accumulate(md)
+		if err := s.cumulative.save(ctx, s.storage); err != nil {
+			s.logger.Warn("Failed to persist the cumulative sums", zap.Error(err))
+		}
+	}
+	return md, scrapeErrors.Combine()
+}
+
+// emit emits the recorded metrics under the resource, carrying the resource attributes of the tenant.
+func (s *analyticsScraper) emit(t *analyticsTenant, res pcommon.Resource, since time.Time) {
+	for k, v := range t.cfg.ResourceAttributes {
+		res.Attributes().PutStr(k, v)
+	}
+	s.mb.EmitForResource(metadata.WithResource(res), metadata.WithStartTimeOverride(pcommon.NewTimestampFromTime(since)))
+}
+
+// queryTarget queries the datasets and custom queries of the tenant for the zone, or for the account
+// if account is true, over [since, until), and emits their metrics under res, unless the target is
+// paused after repeated failures.
+func (s *analyticsScraper) queryTarget(ctx context.Context, t *analyticsTenant, tag string, account bool, res pcommon.Resource, since, until time.Time, custom pmetric.Metrics) error {
+	now := time.Now()
+	// Tenants may share a zone or account with a token of their own.
+	target := t.cfg.Name + "/" + tag
+	if ok, probeAt := s.breaker.allow(target, now); !ok {
+		return fmt.Errorf("%s is paused until %s after repeated failures", tag, probeAt.Format(time.RFC3339))
+	}
+
+	err := s.queryDatasets(ctx, t, tag, account, since, until, pcommon.NewTimestampFromTime(until))
+	s.emit(t, res, since)
+	err = multierr.Append(err, s.queryCustom(ctx, t.client, tag, account, res, since, until, custom))
+	switch {
+	case !failed(err):
+		// Partial results and datasets missing from the plan aren't failures of the target.
+		s.breaker.recordSuccess(target)
+	// Running out of scrape time isn't a failure of the target either.
+	case ctx.Err() == nil && s.breaker.recordFailure(target, now):
+		s.logger.Warn("Pausing the analytics queries after repeated failures",
+			zap.String("target", tag),
+			zap.Duration("cooldown", s.cfg.CircuitBreaker.Cooldown),
+			zap.Error(err))
+	}
+	return err
+}
+
+// failed returns whether any of the errors is a failure, rather than the errors of a partial result
+// or of a dataset that isn't available on the plan.
+func failed(err error) bool {
+	for _, err := range multierr.Errors(err) {
+		var partialErr *partialResultError
+		var statusErr *statusError
+		if !errors.As(err, &partialErr) && (!errors.As(err, &statusErr) || statusErr.errorType != metadata.AttributeErrorTypePlan) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryDatasets queries the datasets of the tenant for the zone, or for the account if account is
+// true, over [since, until), returning their failures.
+func (s *analyticsScraper) queryDatasets(ctx context.Context, t *analyticsTenant, tag string, account bool, since, until time.Time, ts pcommon.Timestamp) error {
+	kind := "zone"
+	if account {
+		kind = "account"
+	}
+	var errs error
+	for _, name := range t.cfg.Datasets {
+		dataset := s.datasets[name]
+		if dataset.account != account || s.isUnavailable(t, name, tag, time.Now()) {
+			continue
+		}
+		err := s.queryDataset(ctx, t.client, name, tag, dataset, since, until, ts)
+		switch {
+		case s.checkPlan(t, name, tag, time.Now(), err):
+			errs = multierr.Append(errs, fmt.Errorf("the %s analytics aren't available for %s %s: %w", name, kind, tag, err))
+		case err != nil:
+			errs = multierr.Append(errs, fmt.Errorf("failed to query the %s analytics of %s %s: %w", name, kind, tag, err))
+		}
+	}
+	return errs
+}
+
+// queryDataset queries the groups of the nodes of the dataset for the zone or account over
+// [since, until), and records their metrics. The groups of a partial result are recorded as well,
+// alongside its errors.
+func (s *analyticsScraper) queryDataset(ctx context.Context, c client, name, tag string, dataset analyticsDataset, since, until time.Time, ts pcommon.Timestamp) error {
+	data, err := s.queryAnalytics(ctx, c, name, dataset, tag, since, until)
+	var partialErr *partialResultError
+	if err != nil && !errors.As(err, &partialErr) {
+		return err
+	}
+	var rows int64
+	nodeGroups := make([][]analyticsGroup, len(dataset.nodes))
+	for i, node := range dataset.nodes {
+		events := data.groups(dataset.account, fmt.Sprintf("e%d", i))
+		groups := data.groups(dataset.account, fmt.Sprintf("n%d", i))
+		if node.record != nil {
+			for _, group := range groups {
+				node.record(s.mb, ts, group)
+				if event, ok := group.exemplar(events); ok {
+					s.recordExemplar(tag, node, ts, group, event)
+				}
+			}
+		}
+		nodeGroups[i] = groups
+		rows += int64(len(groups))
+	}
+	if dataset.record != nil {
+		dataset.record(s.mb, ts, nodeGroups)
+	}
+	s.recordRows(ctx, name, rows)
+	return err
+}
+
+// recordRows counts the groups returned for the dataset or custom query.
+func (s *analyticsScraper) recordRows(ctx context.Context, name string, rows int64) {
+	s.telemetryBuilder.CloudflareAPIGraphqlRows.Add(ctx, rows, metric.WithAttributes(attribute.String(attrDataset, name)))
+}
+
+// recordExemplar records the event as the exemplar of the data points recorded for the group of the
+// node, which are found by recording the group on its own.
+func (s *analyticsScraper) recordExemplar(tag string, node analyticsNode, ts pcommon.Timestamp, group, event analyticsGroup) {
+	rayID, ok := parseRayID(event["rayName"])
+	if !ok {
+		return
+	}
+	exemplar := analyticsExemplar{rayID: rayID, created: ts}
+	if created, err := time.Parse(time.RFC3339, event.str("datetime")); err == nil {
+		exemplar.created = pcommon.NewTimestampFromTime(created)
+	}
+
+	node.record(s.exemplarsMB, ts, group)
+	for _, rm := range s.exemplarsMB.Emit().ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				for _, dp := range numberDataPoints(m).All() {
+					s.exemplars[exemplarKey{tag: tag, metric: m.Name(), attributes: pdatautil.MapHash(dp.Attributes())}] = exemplar
+				}
+			}
+		}
+	}
+}
+
+// addExemplars attaches the exemplars recorded by the scrape to their data points, whose trace and
+// span IDs are derived from the Ray ID of the event like those of the log records of the events.
+func (s *analyticsScraper) addExemplars(md pmetric.Metrics) {
+	for _, rm := range md.ResourceMetrics().All() {
+		tag, ok := rm.Resource().Attributes().Get(attrZoneID)
+		if !ok {
+			tag, _ = rm.Resource().Attributes().Get(attrAccountID)
+		}
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				for _, dp := range numberDataPoints(m).All() {
+					exemplar, ok := s.exemplars[exemplarKey{tag: tag.Str(), metric: m.Name(), attributes: pdatautil.MapHash(dp.Attributes())}]
+					if !ok {
+						continue
+					}
+					e := dp.Exemplars().AppendEmpty()
+					e.SetTimestamp(exemplar.created)
+					e.SetIntValue(1)
+					e.SetTraceID(traceIDFromRayID(exemplar.rayID))
+					e.SetSpanID(exemplar.rayID)
+				}
+			}
+		}
+	}
+}
+
+// numberDataPoints returns the data points of the sum or gauge, empty for the other types of metrics.
+func numberDataPoints(m pmetric.Metric) pmetric.NumberDataPointSlice {
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints()
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints()
+	default:
+		return pmetric.NewNumberDataPointSlice()
+	}
+}
+
+// queryAnalytics queries the nodes of the dataset for the zone or account over [since, until) within
+// the query timeout, unless the scrape deadline is exceeded.
+func (s *analyticsScraper) queryAnalytics(ctx context.Context, c client, name string, dataset analyticsDataset, tag string, since, until time.Time) (analyticsData, error) {
+	if err := ctx.Err(); err != nil {
+		return analyticsData{}, fmt.Errorf("skipped: %w", err)
+	}
+
+	queryCtx, cancel := s.queryContext(ctx)
+	defer cancel()
+	limit := cmp.Or(s.limits[name], dataset.limit, analyticsLimit)
+	for {
+		var data analyticsData
+		err := c.QueryGraphQL(queryCtx, dataset.query(), map[string]any{
+			"tag":   tag,
+			"since": since.UTC().Format(time.RFC3339),
+			"until": until.UTC().Format(time.RFC3339),
+			"limit": limit,
+		}, &data)
+		if shrinkLimit(s.logger, name, &limit, err) {
+			s.limits[name] = limit
+			continue
+		}
+		return data, err
+	}
+}
+
+// queryContext returns the context of a query, bounded by the query timeout.
+func (s *analyticsScraper) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.cfg.QueryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.cfg.QueryTimeout)
+}
+
+// otherValue is the value of the attributes of the data points merged beyond a cardinality limit.
+const otherValue = "other"
+
+// mapDataPointAttributes renames the attributes of the data points of the metrics after the mapping,
+// dropping those mapped to an empty name. The data points of an additive metric left with the same
+// attributes are merged by summing their values.
+func mapDataPointAttributes(md pmetric.Metrics, mapping map[string]string) {
+	for _, rm := range md.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					mergeDataPoints(m.Sum().DataPoints(), mapping, true)
+				case pmetric.MetricTypeGauge:
+					mergeDataPoints(m.Gauge().DataPoints(), mapping, additiveGauge(m.Gauge().DataPoints()))
+				}
+			}
+		}
+	}
+}
+
+// additiveGauge returns whether the values of the data points of a gauge can be summed. The integer
+// gauges of the datasets count things, such as users, devices or bytes, whose counts of several
+// values of an attribute add up. The double ones are averages, quantiles or ratios, which don't.
+func additiveGauge(dps pmetric.NumberDataPointSlice) bool {
+	return dps.Len() == 0 || dps.At(0).ValueType() == pmetric.NumberDataPointValueTypeInt
+}
+
+// mergeDataPoints maps the attributes of the data points, then merges the data points left with the
+// same attributes by summing their values if merge is true. Otherwise, the data points are kept as
+// they are, since averaging them would misrepresent them as much as summing them.
+func mergeDataPoints(dps pmetric.NumberDataPointSlice, mapping map[string]string, merge bool) {
+	merged := map[[16]byte]pmetric.NumberDataPoint{}
+	dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+		for from, to := range mapping {
+			value, ok := dp.Attributes().Get(from)
+			if !ok || from == to {
+				continue
+			}
+			if to != "" {
+				value.CopyTo(dp.Attributes().PutEmpty(to))
+			}
+			dp.Attributes().Remove(from)
+		}
+		if !merge {
+			return false
+		}
+		key := pdatautil.MapHash(dp.Attributes())
+		into, ok := merged[key]
+		if !ok {
+			merged[key] = dp
+			return false
+		}
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			into.SetIntValue(into.IntValue() + dp.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			into.SetDoubleValue(into.DoubleValue() + dp.DoubleValue())
+		}
+		return true
+	})
+}
+
+// limitCardinality limits the number of values of the attributes of every metric after the limits.
+// The values of an attribute are ranked by the sum of the values of their data points, and the data
+// points of the values beyond the limit are merged under the value other. The data points of the
+// gauges that can't be summed are dropped instead.
+func limitCardinality(md pmetric.Metrics, limits map[string]int) {
+	for _, rm := range md.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					limitDataPoints(m.Sum().DataPoints(), limits, true)
+				case pmetric.MetricTypeGauge:
+					limitDataPoints(m.Gauge().DataPoints(), limits, additiveGauge(m.Gauge().DataPoints()))
+				}
+			}
+		}
+	}
+}
+
+func limitDataPoints(dps pmetric.NumberDataPointSlice, limits map[string]int, merge bool) {
+	limited := false
+	for attr, limit := range limits {
+		totals := map[string]float64{}
+		for _, dp := range dps.All() {
+			if value, ok := dp.Attributes().Get(attr); ok {
+				totals[value.AsString()] += dataPointValue(dp)
+			}
+		}
+		if len(totals) <= limit {
+			continue
+		}
+		values := slices.SortedFunc(maps.Keys(totals), func(a, b string) int {
+			if c := cmp.Compare(totals[b], totals[a]); c != 0 {
+				return c
+			}
+			return strings.Compare(a, b)
+		})
+		top := map[string]bool{}
+		for _, value := range values[:limit] {
+			top[value] = true
+		}
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			value, ok := dp.Attributes().Get(attr)
+			if !ok || top[value.AsString()] {
+				return false
+			}
+			if !merge {
+				return true
+			}
+			dp.Attributes().PutStr(attr, otherValue)
+			return false
+		})
+		limited = true
+	}
+	if limited {
+		mergeDataPoints(dps, nil, merge)
+	}
+}
+
+func dataPointValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// cumulativeSeries is the running total of a series of delta sums.
+type cumulativeSeries struct {
+	// Start is the start of the first window counted in the total.
+	Start pcommon.Timestamp
+	// Int and Double are the totals of the series of int and double values.
+	Int    int64
+	Double float64
+}
+
+// cumulativeSumsKey is the storage key of the running totals of the counts.
+const cumulativeSumsKey = "cumulative_sums"
+
+// cumulativeSums holds the running totals of the series of delta sums, by series.
+type cumulativeSums map[string]*cumulativeSeries
+
+// load restores the running totals persisted in storage, if any.
+func (c cumulativeSums) load(ctx context.Context, client storage.Client) error {
+	data, err := client.Get(ctx, cumulativeSumsKey)
+	if err != nil {
+		return fmt.Errorf("failed to load the cumulative sums: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return fmt.Errorf("failed to decode the cumulative sums: %w", err)
+	}
+	return nil
+}
+
+// save persists the running totals in storage.
+func (c cumulativeSums) save(ctx context.Context, client storage.Client) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return client.Set(ctx, cumulativeSumsKey, data)
+}
+
+// accumulate turns the delta sums of the metrics into cumulative sums, whose values are the running
+// totals of their series since the first window counted.
+func (c cumulativeSums) accumulate(md pmetric.Metrics) {
+	for _, rm := range md.ResourceMetrics().All() {
+		resource := pdatautil.MapHash(rm.Resource().Attributes())
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				if m.Type() != pmetric.MetricTypeSum || m.Sum().AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+					continue
+				}
+				m.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				for _, dp := range m.Sum().DataPoints().All() {
+					key := fmt.Sprintf("%x/%s/%x", resource, m.Name(), pdatautil.MapHash(dp.Attributes()))
+					series, ok := c[key]
+					if !ok {
+						series = &cumulativeSeries{Start: dp.StartTimestamp()}
+						c[key] = series
+					}
+					dp.SetStartTimestamp(series.Start)
+					switch dp.ValueType() {
+					case pmetric.NumberDataPointValueTypeInt:
+						series.Int += dp.IntValue()
+						dp.SetIntValue(series.Int)
+					case pmetric.NumberDataPointValueTypeDouble:
+						series.Double += dp.DoubleValue()
+						dp.SetDoubleValue(series.Double)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,588 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// analyticsLimit is the maximum number of groups Cloudflare returns for a node of the GraphQL
+// Analytics API.
+const analyticsLimit = 10000
+
+// analyticsGranularity is the finest granularity of the datasets of the GraphQL Analytics API, whose
+// events are only available once their minute has passed.
+const analyticsGranularity = time.Minute
+
+// analyticsExemplarLimit is the maximum number of events queried for the exemplars of the groups of a
+// node.
+const analyticsExemplarLimit = 1000
+
+// analyticsDataset is a dataset of the GraphQL Analytics API, made of the nodes queried at once for
+// every zone, or every account for the datasets of accounts.
+type analyticsDataset struct {
+	account bool
+	nodes   []analyticsNode
+	// limit is the maximum number of groups returned for every node, analyticsLimit when 0.
+	limit int
+	// exemplars queries the latest events of the nodes with events along with their groups, as the
+	// exemplars of the groups.
+	exemplars bool
+	// record records the metrics computed from the groups of several nodes or groups, such as ratios,
+	// the groups being passed by node.
+	record func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, groups [][]analyticsGroup)
+	// configure returns the dataset queried after the configuration, for the datasets whose nodes
+	// depend on it.
+	configure func(cfg *AnalyticsConfig) analyticsDataset
+}
+
+// analyticsNode is a node of the GraphQL Analytics API, such as firewallEventsAdaptiveGroups, whose
+// groups cover the polled window.
+type analyticsNode struct {
+	// name is the name of the node in the GraphQL schema.
+	name string
+	// fields is the selection of the groups of the node, such as count dimensions { action }.
+	fields string
+	// filter holds the conditions added to the filter of the node, such as botScore_leq: 29.
+	filter string
+	// orderBy holds the orderings of the groups of the node, such as count_DESC, the groups being
+	// unordered when empty.
+	orderBy []string
+	// record records the metrics of a group of the node, if any.
+	record func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup)
+	// events is the node of the events counted by the groups, such as firewallEventsAdaptive, whose
+	// Ray IDs are attached to the data points of the groups as exemplars. The groups of the nodes
+	// without events get no exemplars.
+	events string
+}
+
+// analyticsDatasets holds the datasets of the GraphQL Analytics API that can be collected, by name.
+var analyticsDatasets = map[string]analyticsDataset{
+	"waiting_room": {nodes: []analyticsNode{{
+		name:   "waitingRoomAnalyticsAdaptiveGroups",
+		fields: "dimensions { waitingRoomId } max { totalQueuedUsers totalActiveUsers estimatedWaitTime } sum { totalAcceptedUsers }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			waitingRoomID := group.str("dimensions", "waitingRoomId")
+			mb.RecordCloudflareWaitingRoomQueuedUsersDataPoint(ts, group.int("max", "totalQueuedUsers"), waitingRoomID)
+			mb.RecordCloudflareWaitingRoomActiveUsersDataPoint(ts, group.int("max", "totalActiveUsers"), waitingRoomID)
+			mb.RecordCloudflareWaitingRoomAcceptedUsersDataPoint(ts, group.int("sum", "totalAcceptedUsers"), waitingRoomID)
+			mb.RecordCloudflareWaitingRoomEstimatedWaitTimeDataPoint(ts, group.int("max", "estimatedWaitTime"), waitingRoomID)
+		},
+	}}},
+	"workers": {account: true, nodes: []analyticsNode{{
+		name:   "workersInvocationsAdaptive",
+		fields: "dimensions { scriptName } sum { requests errors } quantiles { cpuTimeP50 cpuTimeP99 }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			scriptName := group.str("dimensions", "scriptName")
+			mb.RecordCloudflareWorkersRequestsDataPoint(ts, group.int("sum", "requests"), scriptName)
+			mb.RecordCloudflareWorkersErrorsDataPoint(ts, group.int("sum", "errors"), scriptName)
+			mb.RecordCloudflareWorkersCPUTimeDataPoint(ts, group.float("quantiles", "cpuTimeP50"), scriptName, metadata.AttributeQuantileP50)
+			mb.RecordCloudflareWorkersCPUTimeDataPoint(ts, group.float("quantiles", "cpuTimeP99"), scriptName, metadata.AttributeQuantileP99)
+		},
+	}}},
+	"bot_management": {nodes: []analyticsNode{
+		botScoreNode("botScore_geq: 1, botScore_leq: 1", metadata.AttributeBotScoreClassAutomated),
+		botScoreNode("botScore_geq: 2, botScore_leq: 29", metadata.AttributeBotScoreClassLikelyAutomated),
+		botScoreNode("botScore_geq: 30, botScore_leq: 99", metadata.AttributeBotScoreClassLikelyHuman),
+	}},
+	"turnstile": {account: true, nodes: []analyticsNode{{
+		name:   "turnstileAdaptiveGroups",
+		fields: "count dimensions { siteKey eventType }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			mb.RecordCloudflareTurnstileChallengesDataPoint(ts, group.int("count"), group.str("dimensions", "siteKey"), group.str("dimensions", "eventType"))
+		},
+	}}},
+	"http_requests":   {configure: httpRequestsDataset},
+	"firewall_events": {configure: firewallEventsDataset},
+	"page_shield": {nodes: []analyticsNode{{
+		name:   "pageShieldReportsAdaptiveGroups",
+		fields: "count dimensions { host directive }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			mb.RecordCloudflarePageShieldViolationsDataPoint(ts, group.int("count"), group.str("dimensions", "host"), group.str("dimensions", "directive"))
+		},
+	}}},
+	"api_gateway": {nodes: []analyticsNode{
+		{
+			name:   "httpRequestsAdaptiveGroups",
+			fields: "count dimensions { apiGatewayMatchedHost apiGatewayMatchedEndpoint }",
+			filter: `apiGatewayMatchedEndpoint_neq: ""`,
+			events: "httpRequestsAdaptive",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareAPIGatewayRequestsDataPoint(ts, group.int("count"),
+					group.str("dimensions", "apiGatewayMatchedHost"), group.str("dimensions", "apiGatewayMatchedEndpoint"))
+			},
+		},
+		{
+			name:   "firewallEventsAdaptiveGroups",
+			fields: "count dimensions { clientRequestHTTPHost }",
+			filter: `source: "apiShieldSchemaValidation"`,
+			events: "firewallEventsAdaptive",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareAPIGatewaySchemaValidationFailuresDataPoint(ts, group.int("count"), group.str("dimensions", "clientRequestHTTPHost"))
+			},
+		},
+		{
+			name:   "firewallEventsAdaptiveGroups",
+			fields: "count dimensions { clientRequestHTTPHost source }",
+			filter: `source_in: ["apiShieldSequenceMitigation", "apiShieldVolumetricAbuseDetection"]`,
+			events: "firewallEventsAdaptive",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareAPIGatewayAbuseAnomaliesDataPoint(ts, group.int("count"),
+					group.str("dimensions", "clientRequestHTTPHost"), group.str("dimensions", "source"))
+			},
+		},
+	}},
+	"rate_limiting": {nodes: []analyticsNode{{
+		name:   "firewallEventsAdaptiveGroups",
+		fields: "count dimensions { ruleId action }",
+		filter: `source: "ratelimit"`,
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			mb.RecordCloudflareRateLimitingRequestsDataPoint(ts, group.int("count"), group.str("dimensions", "ruleId"), group.str("dimensions", "action"))
+		},
+	}}},
+	"access_logins": {account: true, nodes: []analyticsNode{{
+		name:   "accessLoginRequestsAdaptiveGroups",
+		fields: "count dimensions { isSuccessfulLogin appId identityProvider country }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			mb.RecordCloudflareAccessLoginsDataPoint(ts, group.int("count"), group.bool("dimensions", "isSuccessfulLogin"),
+				group.str("dimensions", "appId"), group.str("dimensions", "identityProvider"), group.str("dimensions", "country"))
+		},
+	}}},
+	"gateway_dns": {account: true, nodes: []analyticsNode{{
+		name:   "gatewayResolverQueriesAdaptiveGroups",
+		fields: "count dimensions { resolverDecision categoryNames locationName }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			mb.RecordCloudflareGatewayDNSQueriesDataPoint(ts, group.int("count"), group.str("dimensions", "resolverDecision"),
+				group.str("dimensions", "categoryNames"), group.str("dimensions", "locationName"))
+		},
+	}}},
+	"gateway_http": {account: true, nodes: []analyticsNode{{
+		name:   "gatewayL7RequestsAdaptiveGroups",
+		fields: "count dimensions { action categoryNames email }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			mb.RecordCloudflareGatewayHTTPRequestsDataPoint(ts, group.int("count"), group.str("dimensions", "action"),
+				group.str("dimensions", "categoryNames"), group.str("dimensions", "email"))
+		},
+	}}},
+	"gateway_network": {account: true, nodes: []analyticsNode{{
+		name:   "gatewayL4SessionsAdaptiveGroups",
+		fields: "count dimensions { action transport } sum { bytesReceived bytesSent }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			action, transport := group.str("dimensions", "action"), group.str("dimensions", "transport")
+			mb.RecordCloudflareGatewayNetworkSessionsDataPoint(ts, group.int("count"), action, transport)
+			mb.RecordCloudflareGatewayNetworkIoDataPoint(ts, group.int("sum", "bytesReceived"), action, transport, metadata.AttributeDirectionReceive)
+			mb.RecordCloudflareGatewayNetworkIoDataPoint(ts, group.int("sum", "bytesSent"), action, transport, metadata.AttributeDirectionTransmit)
+		},
+	}}},
+	"dex": {account: true, nodes: []analyticsNode{
+		dexTestNode("dexHttpTestResultsAdaptiveGroups", "resourceFetchTimeMs", metadata.AttributeDexTestTypeHttp),
+		dexTestNode("dexTracerouteTestResultsAdaptiveGroups", "roundTripTimeMs", metadata.AttributeDexTestTypeTraceroute),
+	}},
+	"warp": {account: true, nodes: []analyticsNode{{
+		name:   "dexFleetStatusDevicesAdaptiveGroups",
+		fields: "uniq { deviceIdCount } dimensions { status platform osVersion clientVersion }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			mb.RecordCloudflareWarpDevicesDataPoint(ts, group.int("uniq", "deviceIdCount"), group.str("dimensions", "status"),
+				group.str("dimensions", "platform"), group.str("dimensions", "osVersion"), group.str("dimensions", "clientVersion"))
+		},
+	}}},
+	"images": {account: true, nodes: []analyticsNode{
+		{
+			name:   "imagesRequestsAdaptiveGroups",
+			fields: "sum { requests }",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareImagesRequestsDataPoint(ts, group.int("sum", "requests"))
+			},
+		},
+		{
+			name:   "imagesUniqueTransformationsAdaptiveGroups",
+			fields: "sum { transformations }",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareImagesTransformationsDataPoint(ts, group.int("sum", "transformations"))
+			},
+		},
+		{
+			name:   "imagesStorageAdaptiveGroups",
+			fields: "max { storedImages }",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareImagesStoredDataPoint(ts, group.int("max", "storedImages"))
+			},
+		},
+	}},
+	"stream": {account: true, nodes: []analyticsNode{
+		{
+			name:   "streamMinutesViewedAdaptiveGroups",
+			fields: "dimensions { uid } sum { minutesViewed }",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareStreamMinutesViewedDataPoint(ts, group.int("sum", "minutesViewed"), group.str("dimensions", "uid"))
+			},
+		},
+		{
+			name:   "videoPlaybackEventsAdaptiveGroups",
+			fields: "dimensions { uid } uniq { viewers }",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareStreamViewersDataPoint(ts, group.int("uniq", "viewers"), group.str("dimensions", "uid"))
+			},
+		},
+		{
+			name:   "streamStorageAdaptiveGroups",
+			fields: "max { storedMinutes }",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareStreamStorageDataPoint(ts, group.int("max", "storedMinutes"))
+			},
+		},
+	}},
+	"pages_functions": {account: true, nodes: []analyticsNode{{
+		name:   "pagesFunctionsInvocationsAdaptiveGroups",
+		fields: "dimensions { projectName } sum { requests errors } quantiles { cpuTimeP50 cpuTimeP99 }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			project := group.str("dimensions", "projectName")
+			mb.RecordCloudflarePagesFunctionsRequestsDataPoint(ts, group.int("sum", "requests"), project)
+			mb.RecordCloudflarePagesFunctionsErrorsDataPoint(ts, group.int("sum", "errors"), project)
+			mb.RecordCloudflarePagesFunctionsCPUTimeDataPoint(ts, group.float("quantiles", "cpuTimeP50"), project, metadata.AttributeQuantileP50)
+			mb.RecordCloudflarePagesFunctionsCPUTimeDataPoint(ts, group.float("quantiles", "cpuTimeP99"), project, metadata.AttributeQuantileP99)
+		},
+	}}},
+	"argo": {nodes: []analyticsNode{{
+		name:   "argoSmartRoutingAdaptiveGroups",
+		fields: "count dimensions { routed } avg { originResponseDurationMs }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			routed := group.bool("dimensions", "routed")
+			mb.RecordCloudflareArgoRequestsDataPoint(ts, group.int("count"), routed)
+			mb.RecordCloudflareArgoOriginResponseTimeDataPoint(ts, group.float("avg", "originResponseDurationMs"), routed)
+		},
+	}}},
+	"cache_reserve": {nodes: []analyticsNode{
+		{
+			name:   "cacheReserveOperationsAdaptiveGroups",
+			fields: "dimensions { operationClass } sum { requests }",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareCacheReserveOperationsDataPoint(ts, group.int("sum", "requests"), group.str("dimensions", "operationClass"))
+			},
+		},
+		{
+			name:   "cacheReserveStorageAdaptiveGroups",
+			fields: "max { storedBytes }",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareCacheReserveStorageDataPoint(ts, group.int("max", "storedBytes"))
+			},
+		},
+	}},
+	"ddos": {account: true, nodes: []analyticsNode{{
+		name:   "dosdAttackAnalyticsGroups",
+		fields: "count dimensions { attackVector } max { bitsPerSecond packetsPerSecond }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			vector := group.str("dimensions", "attackVector")
+			mb.RecordCloudflareDdosAttackEventsDataPoint(ts, group.int("count"), vector)
+			mb.RecordCloudflareDdosMitigatedBitRateDataPoint(ts, group.int("max", "bitsPerSecond"), vector)
+			mb.RecordCloudflareDdosMitigatedPacketRateDataPoint(ts, group.int("max", "packetsPerSecond"), vector)
+		},
+	}}},
+	"email_security": {account: true, nodes: []analyticsNode{{
+		name:   "emailSecurityMessagesAdaptiveGroups",
+		fields: "count dimensions { disposition action }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			mb.RecordCloudflareEmailSecurityMessagesDataPoint(ts, group.int("count"), group.str("dimensions", "disposition"), group.str("dimensions", "action"))
+		},
+	}}},
+	"ai_gateway": {account: true, nodes: []analyticsNode{{
+		name:   "aiGatewayRequestsAdaptiveGroups",
+		fields: "count dimensions { gateway provider model } sum { cachedRequests erroredRequests uncachedTokensIn uncachedTokensOut cost }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			gateway, provider, model := group.str("dimensions", "gateway"), group.str("dimensions", "provider"), group.str("dimensions", "model")
+			mb.RecordCloudflareAiGatewayRequestsDataPoint(ts, group.int("count"), gateway, provider, model)
+			mb.RecordCloudflareAiGatewayCachedRequestsDataPoint(ts, group.int("sum", "cachedRequests"), gateway, provider, model)
+			mb.RecordCloudflareAiGatewayErrorsDataPoint(ts, group.int("sum", "erroredRequests"), gateway, provider, model)
+			mb.RecordCloudflareAiGatewayTokensDataPoint(ts, group.int("sum", "uncachedTokensIn"), gateway, provider, model, metadata.AttributeTokenTypeInput)
+			mb.RecordCloudflareAiGatewayTokensDataPoint(ts, group.int("sum", "uncachedTokensOut"), gateway, provider, model, metadata.AttributeTokenTypeOutput)
+			mb.RecordCloudflareAiGatewayCostDataPoint(ts, group.float("sum", "cost"), gateway, provider, model)
+		},
+	}}},
+	"workers_ai": {account: true, nodes: []analyticsNode{{
+		name:   "aiInferenceAdaptiveGroups",
+		fields: "count dimensions { modelId } sum { totalNeurons } quantiles { inferenceTimeMsP50 inferenceTimeMsP99 }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			model := group.str("dimensions", "modelId")
+			mb.RecordCloudflareWorkersAiRequestsDataPoint(ts, group.int("count"), model)
+			mb.RecordCloudflareWorkersAiNeuronsDataPoint(ts, group.float("sum", "totalNeurons"), model)
+			mb.RecordCloudflareWorkersAiInferenceTimeDataPoint(ts, group.float("quantiles", "inferenceTimeMsP50"), model, metadata.AttributeQuantileP50)
+			mb.RecordCloudflareWorkersAiInferenceTimeDataPoint(ts, group.float("quantiles", "inferenceTimeMsP99"), model, metadata.AttributeQuantileP99)
+		},
+	}}},
+	"hyperdrive": {account: true, nodes: []analyticsNode{
+		{
+			name:   "hyperdriveQueriesAdaptiveGroups",
+			fields: "count dimensions { configId cacheStatus }",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareHyperdriveQueriesDataPoint(ts, group.int("count"), group.str("dimensions", "configId"), group.str("dimensions", "cacheStatus"))
+			},
+		},
+		{
+			name:   "hyperdriveQueriesAdaptiveGroups",
+			fields: "dimensions { configId } quantiles { originLatencyP50 originLatencyP99 }",
+			filter: `cacheStatus: "miss"`,
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				configID := group.str("dimensions", "configId")
+				mb.RecordCloudflareHyperdriveOriginLatencyDataPoint(ts, group.float("quantiles", "originLatencyP50"), configID, metadata.AttributeQuantileP50)
+				mb.RecordCloudflareHyperdriveOriginLatencyDataPoint(ts, group.float("quantiles", "originLatencyP99"), configID, metadata.AttributeQuantileP99)
+			},
+		},
+	}},
+}
+
+// query returns the GraphQL query of the nodes of the dataset for a zone, or an account for the
+// datasets of accounts. The groups of every node are returned under the alias n followed by the index
+// of the node, and the events of its exemplars under the alias e followed by the index of the node.
+func (d analyticsDataset) query() string {
+	var b strings.Builder
+	for i, node := range d.nodes {
+		var orderBy string
+		if len(node.orderBy) > 0 {
+			orderBy = ", orderBy: [" + strings.Join(node.orderBy, ", ") + "]"
+		}
+		fmt.Fprintf(&b, "      n%d: %s(limit: $limit, filter: {%s}%s) {\n        %s\n      }\n",
+			i, node.name, windowFilter(node.filter), orderBy, node.fields)
+		if d.exemplars && node.events != "" {
+			fmt.Fprintf(&b, "      e%d: %s(limit: %d, filter: {%s}, orderBy: [datetime_DESC]) {\n        %s\n      }\n",
+				i, node.events, analyticsExemplarLimit, windowFilter(node.filter), strings.Join(append([]string{"datetime", "rayName"}, node.dimensions()...), " "))
+		}
+	}
+	return graphQLQuery(d.account, b.String())
+}
+
+// dimensionsPattern matches the selection of the dimensions of the groups of a node.
+var dimensionsPattern = regexp.MustCompile(`dimensions\s*\{([^}]*)\}`)
+
+// dimensions returns the dimensions the groups of the node are grouped by, which are fields of its
+// events.
+func (n analyticsNode) dimensions() []string {
+	match := dimensionsPattern.FindStringSubmatch(n.fields)
+	if match == nil {
+		return nil
+	}
+	return strings.Fields(match[1])
+}
+
+// exemplar returns the latest of the events, ordered by time descending, whose fields hold the
+// dimensions of the group.
+func (g analyticsGroup) exemplar(events []analyticsGroup) (analyticsGroup, bool) {
+	dimensions, _ := g["dimensions"].(map[string]any)
+	for _, event := range events {
+		matches := true
+		for field, value := range dimensions {
+			if format(event[field]) != format(value) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return event, true
+		}
+	}
+	return nil, false
+}
+
+// withOptions returns the dataset queried with the options.
+func (d analyticsDataset) withOptions(options AnalyticsDatasetOptions) analyticsDataset {
+	d.limit = options.Limit
+	if len(options.OrderBy) > 0 {
+		d.nodes = slices.Clone(d.nodes)
+		for i := range d.nodes {
+			d.nodes[i].orderBy = options.OrderBy
+		}
+	}
+	return d
+}
+
+// graphQLQuery returns the query of the nodes for a zone, or an account if account is true, whose
+// tag, window and limit are variables.
+func graphQLQuery(account bool, nodes string) string {
+	var b strings.Builder
+	b.WriteString("query ($tag: string!, $since: Time!, $until: Time!, $limit: uint64!) {\n")
+	if account {
+		b.WriteString("  viewer {\n    accounts(filter: {accountTag: $tag}) {\n")
+	} else {
+		b.WriteString("  viewer {\n    zones(filter: {zoneTag: $tag}) {\n")
+	}
+	b.WriteString(nodes)
+	b.WriteString("    }\n  }\n}")
+	return b.String()
+}
+
+// windowFilter returns the filter of a node on the polled window, with the conditions of filter added.
+func windowFilter(filter string) string {
+	if filter == "" {
+		return "datetime_geq: $since, datetime_lt: $until"
+	}
+	return "datetime_geq: $since, datetime_lt: $until, " + filter
+}
+
+// analyticsData is the data of the response to the query of a dataset, holding the groups of every
+// node of the dataset by alias, under the zone or account.
+type analyticsData struct {
+	Viewer struct {
+		Zones    []map[string][]analyticsGroup `json:"zones"`
+		Accounts []map[string][]analyticsGroup `json:"accounts"`
+	} `json:"viewer"`
+}
+
+func (d *analyticsData) decodeMember(dec *json.Decoder, key string) error {
+	if key != "viewer" {
+		return skipValue(dec)
+	}
+	_, err := decodeObject(dec, func(key string) error {
+		switch key {
+		case "zones":
+			return decodeAnalyticsTargets(dec, &d.Viewer.Zones)
+		case "accounts":
+			return decodeAnalyticsTargets(dec, &d.Viewer.Accounts)
+		default:
+			return skipValue(dec)
+		}
+	})
+	return err
+}
+
+// decodeAnalyticsTargets decodes the nodes of the zones or accounts of a response, one group at a
+// time.
+func decodeAnalyticsTargets(dec *json.Decoder, targets *[]map[string][]analyticsGroup) error {
+	return decodeArray(dec, func() error {
+		nodes := map[string][]analyticsGroup{}
+		_, err := decodeObject(dec, func(alias string) error {
+			var groups []analyticsGroup
+			err := decodeArray(dec, func() error {
+				var group analyticsGroup
+				if err := dec.Decode(&group); err != nil {
+					return err
+				}
+				groups = append(groups, group)
+				return nil
+			})
+			nodes[alias] = groups
+			return err
+		})
+		*targets = append(*targets, nodes)
+		return err
+	})
+}
+
+// groups returns the groups of the node with the alias, under the zone, or the account if account is
+// true.
+func (d analyticsData) groups(account bool, alias string) []analyticsGroup {
+	targets := d.Viewer.Zones
+	if account {
+		targets = d.Viewer.Accounts
+	}
+	var groups []analyticsGroup
+	for _, nodes := range targets {
+		groups = append(groups, nodes[alias]...)
+	}
+	return groups
+}
+
+// analyticsGroup is a group of a node of the GraphQL Analytics API, holding the dimensions and the
+// aggregates of its events, such as {"count": 3, "dimensions": {"action": "block"}}.
+type analyticsGroup map[string]any
+
+// value returns the value of the field at the path, such as dimensions, action, or nil if absent.
+func (g analyticsGroup) value(path ...string) any {
+	var value any = map[string]any(g)
+	for _, field := range path {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = fields[field]
+	}
+	return value
+}
+
+// str returns the value of the field at the path as a string, empty if absent. The values of lists
+// are joined with commas.
+func (g analyticsGroup) str(path ...string) string {
+	return format(g.value(path...))
+}
+
+// format returns a value of a group as a string, empty if it isn't a string, number, boolean or list.
+func format(value any) string {
+	switch v := value.(type) {
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			values = append(values, format(value))
+		}
+		return strings.Join(values, ",")
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
+
+// int returns the value of the numeric field at the path, 0 if absent.
+func (g analyticsGroup) int(path ...string) int64 {
+	return int64(g.float(path...))
+}
+
+// bool returns the value of the boolean field at the path, which the API may return as 0 or 1, false
+// if absent.
+func (g analyticsGroup) bool(path ...string) bool {
+	switch v := g.value(path...).(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	default:
+		return false
+	}
+}
+
+// float returns the value of the numeric field at the path, 0 if absent.
+func (g analyticsGroup) float(path ...string) float64 {
+	v, _ := g.value(path...).(float64)
+	return v
+}
+
+// botScoreNode returns the node counting the requests whose bot score matches the filter, which are
+// recorded with the class of the filter. Every class is queried with its own node, since the groups
+// of the API are by score, not by class.
+func botScoreNode(filter string, class metadata.AttributeBotScoreClass) analyticsNode {
+	return analyticsNode{
+		name:   "httpRequestsAdaptiveGroups",
+		fields: "count dimensions { botScoreSrcName }",
+		filter: filter,
+		events: "httpRequestsAdaptive",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			mb.RecordCloudflareBotManagementRequestsDataPoint(ts, group.int("count"), class, group.str("dimensions", "botScoreSrcName"))
+		},
+	}
+}
+
+// dexTestNode returns the node of the results of the Digital Experience Monitoring tests of the type,
+// whose duration is read from the duration field.
+func dexTestNode(name, duration string, testType metadata.AttributeDexTestType) analyticsNode {
+	return analyticsNode{
+		name:   name,
+		fields: "dimensions { testName coloCode } avg { availability " + duration + " }",
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+			testName, colo := group.str("dimensions", "testName"), group.str("dimensions", "coloCode")
+			mb.RecordCloudflareDexTestAvailabilityDataPoint(ts, group.float("avg", "availability"), testName, testType, colo)
+			mb.RecordCloudflareDexTestDurationDataPoint(ts, group.float("avg", duration), testName, testType, colo)
+		},
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	attrGatewayDecision   = "cloudflare.gateway.decision"
+	attrGatewayCategories = "cloudflare.gateway.categories"
+	attrGatewayLocation   = "cloudflare.gateway.location"
+	attrEventAction       = "event.action"
+	attrRuleID            = "rule.id"
+	attrRuleName          = "rule.name"
+
+	attrPageShieldDirective  = "cloudflare.page_shield.directive"
+	attrPageShieldBlockedURL = "cloudflare.page_shield.blocked_url"
+)
+
+// analyticsLogDataset is a dataset of the GraphQL Analytics API whose events are emitted as log
+// records, named after the Logpush dataset holding the same events.
+type analyticsLogDataset struct {
+	account bool
+	// node is the name of the node of the events in the GraphQL schema, such as firewallEventsAdaptive.
+	node string
+	// fields is the selection of the events, which includes their datetime.
+	fields string
+	// filter holds the conditions added to the filter of the node.
+	filter string
+	// id is the field identifying an event among the events of the same time, the events of the
+	// datasets without such a field being identified by their content.
+	id string
+	// attributes maps the fields of the events to the attributes of their log records.
+	attributes map[string]string
+	// record sets the attributes of the log record of an event that aren't copied from a field, and
+	// its severity.
+	record func(logRecord plog.LogRecord, event analyticsGroup)
+	// span sets the span of an event when the receiver is part of a traces pipeline, the events of
+	// the datasets without it not being converted into spans.
+	span func(span ptrace.Span, start time.Time, event analyticsGroup)
+}
+
+// analyticsLogDatasets holds the event datasets of the GraphQL Analytics API that can be collected,
+// by name.
+var analyticsLogDatasets = map[string]analyticsLogDataset{
+	"firewall_events": {
+		node: "firewallEventsAdaptive",
+		fields: "datetime rayName sampleInterval action source ruleId description clientIP clientCountryName " +
+			"clientRequestHTTPHost clientRequestHTTPMethodName clientRequestPath clientRequestQuery userAgent",
+		id: "rayName",
+		attributes: map[string]string{
+			"rayName":                     attrRayID,
+			"clientIP":                    attrClientAddress,
+			"clientCountryName":           "geo.country.iso_code",
+			"clientRequestHTTPHost":       "server.address",
+			"clientRequestHTTPMethodName": "http.request.method",
+			"clientRequestPath":           "url.path",
+			"clientRequestQuery":          "url.query",
+			"userAgent":                   "user_agent.original",
+		},
+		record: func(logRecord plog.LogRecord, event analyticsGroup) {
+			// The events are given the attributes of the events of the Logpush dataset.
+			action := event.str("action")
+			addFirewallEventAttributes(logRecord.Attributes(), map[string]any{
+				"Action":      action,
+				"RuleID":      event.str("ruleId"),
+				"Description": event.str("description"),
+				"Source":      event.str("source"),
+			})
+			// Every event sampled by the API stands for sampleInterval events.
+			logRecord.Attributes().PutInt(attrSampleInterval, max(event.int("sampleInterval"), 1))
+			sev := plog.SeverityNumberInfo
+			if firewallEventOutcomes[action] == "failure" {
+				sev = plog.SeverityNumberWarn
+			}
+			logRecord.SetSeverityNumber(sev)
+			logRecord.SetSeverityText(sev.String())
+		},
+	},
+	"http_requests": {
+		node: "httpRequestsAdaptive",
+		fields: "datetime rayName sampleInterval clientIP clientCountryName clientRequestHTTPHost clientRequestHTTPMethodName " +
+			"clientRequestHTTPProtocol clientRequestPath clientRequestQuery userAgent edgeResponseStatus edgeResponseBytes " +
+			"cacheStatus securityAction edgeTimeToFirstByteMs originResponseDurationMs",
+		id: "rayName",
+		attributes: map[string]string{
+			"rayName":                     attrRayID,
+			"clientIP":                    attrClientAddress,
+			"clientCountryName":           "geo.country.iso_code",
+			"clientRequestHTTPHost":       "server.address",
+			"clientRequestHTTPMethodName": "http.request.method",
+			"clientRequestPath":           "url.path",
+			"clientRequestQuery":          "url.query",
+			"userAgent":                   "user_agent.original",
+		},
+		record: func(logRecord plog.LogRecord, event analyticsGroup) {
+			attrs := logRecord.Attributes()
+			attrs.PutInt("http.response.status_code", event.int("edgeResponseStatus"))
+			attrs.PutInt("http.response.body.size", event.int("edgeResponseBytes"))
+			// Every request sampled by the API stands for sampleInterval requests.
+			attrs.PutInt(attrSampleInterval, max(event.int("sampleInterval"), 1))
+		},
+		span: httpRequestSpan,
+	},
+	"gateway_dns": {
+		account: true,
+		node:    "gatewayResolverQueriesAdaptive",
+		fields:  "datetime queryName queryTypeName resolverDecision policyId policyName categoryNames email srcIpAddress locationName",
+		attributes: map[string]string{
+			"queryName":        "dns.question.name",
+			"resolverDecision": attrGatewayDecision,
+			"policyId":         attrRuleID,
+			"policyName":       attrRuleName,
+			"categoryNames":    attrGatewayCategories,
+			"email":            attrUserEmail,
+			"srcIpAddress":     attrClientAddress,
+			"locationName":     attrGatewayLocation,
+		},
+		record: func(logRecord plog.LogRecord, event analyticsGroup) {
+			setGatewaySeverity(logRecord, strings.HasPrefix(event.str("resolverDecision"), "blocked"))
+		},
+	},
+	"gateway_http": {
+		account: true,
+		node:    "gatewayL7RequestsAdaptive",
+		fields:  "datetime action url httpHost httpMethod policyId policyName categoryNames email srcIp",
+		attributes: map[string]string{
+			"action":        attrEventAction,
+			"url":           "url.full",
+			"httpHost":      "server.address",
+			"httpMethod":    "http.request.method",
+			"policyId":      attrRuleID,
+			"policyName":    attrRuleName,
+			"categoryNames": attrGatewayCategories,
+			"email":         attrUserEmail,
+			"srcIp":         attrClientAddress,
+		},
+		record: func(logRecord plog.LogRecord, event analyticsGroup) {
+			setGatewaySeverity(logRecord, event.str("action") == "block")
+		},
+	},
+	"page_shield_events": {
+		node:   "pageShieldReportsAdaptive",
+		fields: "datetime host url directive blockedURL disposition",
+		attributes: map[string]string{
+			"host":       "server.address",
+			"url":        "url.full",
+			"directive":  attrPageShieldDirective,
+			"blockedURL": attrPageShieldBlockedURL,
+		},
+		record: func(logRecord plog.LogRecord, _ analyticsGroup) {
+			logRecord.SetSeverityNumber(plog.SeverityNumberWarn)
+			logRecord.SetSeverityText(plog.SeverityNumberWarn.String())
+		},
+	},
+}
+
+// query returns the GraphQL query of the events of the dataset for a zone, or an account for the
+// datasets of accounts, ordered by time. The events are returned under the alias events.
+func (d analyticsLogDataset) query() string {
+	return graphQLQuery(d.account, fmt.Sprintf("      events: %s(limit: $limit, filter: {%s}, orderBy: [datetime_ASC]) {\n        %s\n      }\n",
+		d.node, windowFilter(d.filter), d.fields))
+}
+
+// eventID returns the ID of the event among the events of the same time.
+func (d analyticsLogDataset) eventID(event analyticsGroup) string {
+	if d.id != "" {
+		return event.str(d.id)
+	}
+	id, _ := json.Marshal(map[string]any(event))
+	return string(id)
+}
+
+// setGatewaySeverity sets the severity of the log record of a Gateway event, WARN when the query or
+// request was blocked, INFO otherwise.
+func setGatewaySeverity(logRecord plog.LogRecord, blocked bool) {
+	sev := plog.SeverityNumberInfo
+	if blocked {
+		sev = plog.SeverityNumberWarn
+	}
+	logRecord.SetSeverityNumber(sev)
+	logRecord.SetSeverityText(sev.String())
+}
+
+// dataset returns the custom query as a dataset of events.
+func (q AnalyticsEventQueryConfig) dataset() analyticsLogDataset {
+	return analyticsLogDataset{
+		account:    q.Scope == "account",
+		node:       q.Node,
+		fields:     q.Fields,
+		filter:     q.Filter,
+		id:         q.ID,
+		attributes: q.Attributes,
+	}
+}
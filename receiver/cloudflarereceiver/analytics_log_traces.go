@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// Attributes set on the spans of the requests sampled by the GraphQL Analytics API.
+const (
+	attrCacheStatus        = "cloudflare.cache.status"
+	attrOriginResponseTime = "cloudflare.origin.response_time"
+)
+
+// processSpans converts the events of the dataset into spans, in a single resource of the zone or
+// account.
+func (r *analyticsLogsReceiver) processSpans(name, tag string, events []analyticsEvent) ptrace.Traces {
+	dataset := r.datasets[name]
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.SetSchemaUrl(conventions.SchemaURL)
+	if dataset.account {
+		resourceSpans.Resource().Attributes().PutStr(attrAccountID, tag)
+	} else {
+		resourceSpans.Resource().Attributes().PutStr(attrZoneID, tag)
+	}
+	resourceSpans.Resource().Attributes().PutStr(attrDataset, name)
+	scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+	scopeSpans.SetSchemaUrl(conventions.SchemaURL)
+	scopeSpans.Scope().SetName(metadata.ScopeName)
+	scopeSpans.Scope().SetVersion(r.buildInfo.Version)
+
+	// The spans are given the attributes of the log records of the events.
+	attributes := dataset.attributes
+	if len(r.cfg.Attributes) > 0 {
+		attributes = maps.Clone(attributes)
+		maps.Copy(attributes, r.cfg.Attributes)
+	}
+	for _, event := range events {
+		span := scopeSpans.Spans().AppendEmpty()
+		attrs := span.Attributes()
+		for field, attr := range attributes {
+			if value := event.group.str(field); attr != "" && value != "" {
+				attrs.PutStr(attr, value)
+			}
+		}
+		dataset.span(span, event.created, event.group)
+	}
+	return traces
+}
+
+// httpRequestSpan sets the server span of a request handled by the edge. The span lasts until the
+// first byte of the response was sent by the edge, and shares the trace of the origin spans when the
+// origin propagates the Ray ID of the request.
+func httpRequestSpan(span ptrace.Span, start time.Time, event analyticsGroup) {
+	if rayID, ok := parseRayID(event.str("rayName")); ok {
+		span.SetTraceID(traceIDFromRayID(rayID))
+		span.SetSpanID(rayID)
+	} else {
+		span.SetTraceID(newTraceID())
+		span.SetSpanID(newSpanID())
+	}
+
+	method := event.str("clientRequestHTTPMethodName")
+	span.SetName(method)
+	if span.Name() == "" {
+		span.SetName("HTTP")
+	}
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	ttfb := time.Duration(event.float("edgeTimeToFirstByteMs") * float64(time.Millisecond))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(ttfb)))
+
+	status := event.int("edgeResponseStatus")
+	if status >= 500 {
+		span.Status().SetCode(ptrace.StatusCodeError)
+	}
+
+	attrs := span.Attributes()
+	attrs.PutInt("http.response.status_code", status)
+	attrs.PutInt("http.response.body.size", event.int("edgeResponseBytes"))
+	if cacheStatus := event.str("cacheStatus"); cacheStatus != "" {
+		attrs.PutStr(attrCacheStatus, cacheStatus)
+	}
+	// Requests served from the cache don't reach the origin.
+	if originTime := event.float("originResponseDurationMs"); originTime > 0 {
+		attrs.PutDouble(attrOriginResponseTime, originTime/1000)
+	}
+	attrs.PutInt(attrSampleInterval, max(event.int("sampleInterval"), 1))
+}
+
+func (r *analyticsLogsReceiver) consumeSpans(ctx context.Context, traces ptrace.Traces) error {
+	obsCtx := r.obsrecv.StartTracesOp(ctx)
+	err := r.traces.ConsumeTraces(obsCtx, traces)
+	r.obsrecv.EndTracesOp(obsCtx, metadata.Type.String(), traces.SpanCount(), err)
+	if err != nil {
+		return errors.Join(errors.New("failed to consume spans"), err)
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	rcvr "go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// attrRayID is the log attribute holding the Ray ID of the request of an event of the GraphQL Analytics API.
+const attrRayID = "cloudflare.ray_id"
+
+// analyticsLogsReceiver polls the GraphQL Analytics API for the events of zones and accounts and
+// emits them as log records. Every poll collects the events created since the previous poll, up to
+// delay ago.
+type analyticsLogsReceiver struct {
+	settings component.TelemetrySettings
+	logger   *zap.Logger
+	consumer consumer.Logs
+	// traces is the consumer of the spans of the requests when the receiver is part of a traces pipeline.
+	traces    consumer.Traces
+	obsrecv   *receiverhelper.ObsReport
+	buildInfo component.BuildInfo
+
+	telemetryBuilder *metadata.TelemetryBuilder
+	client           client
+	cfg              *AnalyticsLogsConfig
+
+	// zoneIDs holds the IDs of the zones, the configured names being resolved on start.
+	zoneIDs []string
+	// accountIDs holds the IDs of the accounts, the configured names being resolved on start.
+	accountIDs []string
+	// datasets holds the datasets collected, including those of the custom queries, by name.
+	datasets map[string]analyticsLogDataset
+	// checkpoints holds the position of the next poll, keyed by dataset and zone or account ID.
+	checkpoints map[string]*eventCheckpoint
+	// clamped holds the checkpoints clamped to the retention, which is only logged once.
+	clamped map[string]bool
+	// started is the time the receiver started. Events created delay before are not collected.
+	started time.Time
+	// limit is the maximum number of events queried at once.
+	limit int
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+func newAnalyticsLogsReceiver(params rcvr.Settings, cfg *AnalyticsLogsConfig, consumer consumer.Logs) (*analyticsLogsReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             params.ID,
+		Transport:              "http",
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(params.TelemetrySettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telemetry builder: %w", err)
+	}
+
+	datasets := map[string]analyticsLogDataset{}
+	for _, name := range cfg.Datasets {
+		datasets[name] = analyticsLogDatasets[name]
+	}
+	for _, query := range cfg.CustomQueries {
+		datasets[query.Name] = query.dataset()
+	}
+
+	return &analyticsLogsReceiver{
+		settings:         params.TelemetrySettings,
+		logger:           params.Logger,
+		consumer:         consumer,
+		obsrecv:          obsrecv,
+		buildInfo:        params.BuildInfo,
+		telemetryBuilder: telemetryBuilder,
+		cfg:              cfg,
+		zoneIDs:          cfg.Zones,
+		accountIDs:       cfg.Accounts,
+		datasets:         datasets,
+		checkpoints:      map[string]*eventCheckpoint{},
+		clamped:          map[string]bool{},
+		limit:            analyticsLimit,
+	}, nil
+}
+
+func (r *analyticsLogsReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.client, err = newClient(ctx, &r.cfg.APIConfig, host, r.settings)
+	if err != nil {
+		return err
+	}
+	verifyToken(ctx, r.client, r.logger, "analytics_logs", analyticsPermission)
+	if r.zoneIDs, err = resolveZoneIDs(ctx, r.client, r.cfg.Zones); err != nil {
+		return err
+	}
+	if r.accountIDs, err = resolveAccountIDs(ctx, r.client, r.cfg.Accounts); err != nil {
+		return err
+	}
+	r.started = time.Now()
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.startPolling(pollCtx)
+	return nil
+}
+
+func (r *analyticsLogsReceiver) Shutdown(_ context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *analyticsLogsReceiver) startPolling(ctx context.Context) {
+	defer r.wg.Done()
+
+	t := time.NewTicker(r.cfg.PollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			r.poll(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll emits the events of every dataset of every zone and account. A failing zone, account or
+// dataset doesn't prevent the others from being collected.
+func (r *analyticsLogsReceiver) poll(ctx context.Context) {
+	until := time.Now().Add(-r.cfg.Delay)
+	for _, name := range slices.Sorted(maps.Keys(r.datasets)) {
+		dataset := r.datasets[name]
+		if !r.emits(dataset) {
+			continue
+		}
+		kind, tags := "zone", r.zoneIDs
+		if dataset.account {
+			kind, tags = "account", r.accountIDs
+		}
+		for _, tag := range tags {
+			if err := r.pollDataset(ctx, name, tag, until); err != nil {
+				r.logger.Error("Failed to collect the events", zap.String("dataset", name), zap.String(kind, tag), zap.Error(err))
+			}
+		}
+	}
+}
+
+// pollDataset emits the events of the dataset of the zone or account created up to until. The
+// checkpoint only moves forward once a page of events has been consumed, so failed pages are retried
+// on the next poll.
+func (r *analyticsLogsReceiver) pollDataset(ctx context.Context, name, tag string, until time.Time) error {
+	dataset := r.datasets[name]
+	key := name + "/" + tag
+	cp, ok := r.checkpoints[key]
+	if !ok {
+		cp = newEventCheckpoint(r.started.Add(-r.cfg.Delay))
+		r.checkpoints[key] = cp
+	}
+	if r.cfg.Retention > 0 && cp.clamp(until.Add(-r.cfg.Retention)) && !r.clamped[key] {
+		r.clamped[key] = true
+		r.logger.Warn("Skipping the events older than the retention, which can't be collected anymore",
+			zap.String("dataset", name),
+			zap.String("target", tag),
+			zap.Duration("retention", r.cfg.Retention))
+	}
+	if !until.After(cp.since) {
+		return nil
+	}
+	for {
+		var data analyticsData
+		err := r.client.QueryGraphQL(ctx, dataset.query(), map[string]any{
+			"tag":   tag,
+			"since": cp.since.UTC().Format(time.RFC3339),
+			"until": until.UTC().Format(time.RFC3339),
+			"limit": r.limit,
+		}, &data)
+		if shrinkLimit(r.logger, name, &r.limit, err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		events := data.groups(dataset.account, "events")
+		r.telemetryBuilder.CloudflareAPIGraphqlRows.Add(ctx, int64(len(events)), metric.WithAttributes(attribute.String(attrDataset, name)))
+
+		fresh := make([]analyticsEvent, 0, len(events))
+		for _, group := range events {
+			created, err := time.Parse(time.RFC3339, group.str("datetime"))
+			if err != nil {
+				return fmt.Errorf("invalid datetime of event: %w", err)
+			}
+			event := analyticsEvent{group: group, id: dataset.eventID(group), created: created}
+			if cp.emitted(event.id, event.created) {
+				continue
+			}
+			fresh = append(fresh, event)
+		}
+
+		if len(fresh) > 0 {
+			if err := r.emit(ctx, name, tag, fresh); err != nil {
+				return err
+			}
+		}
+
+		for _, event := range fresh {
+			cp.advance(event.id, event.created)
+		}
+
+		if len(events) < r.limit {
+			cp.reset(until)
+			return nil
+		}
+		if len(fresh) == 0 {
+			return fmt.Errorf("more than %d events were created at %s", r.limit, cp.since.Format(time.RFC3339))
+		}
+	}
+}
+
+// analyticsEvent is an event of a dataset of the GraphQL Analytics API.
+type analyticsEvent struct {
+	group   analyticsGroup
+	id      string
+	created time.Time
+}
+
+func (r *analyticsLogsReceiver) processEvents(now pcommon.Timestamp, name, tag string, events []analyticsEvent) plog.Logs {
+	dataset := r.datasets[name]
+	logs := plog.NewLogs()
+	resourceLogs, scopeLogs := appendResourceLogs(logs, r.buildInfo)
+	if dataset.account {
+		resourceLogs.Resource().Attributes().PutStr(attrAccountID, tag)
+	} else {
+		resourceLogs.Resource().Attributes().PutStr(attrZoneID, tag)
+	}
+	resourceLogs.Resource().Attributes().PutStr(attrDataset, name)
+
+	attributes := dataset.attributes
+	if len(r.cfg.Attributes) > 0 {
+		attributes = maps.Clone(attributes)
+		maps.Copy(attributes, r.cfg.Attributes)
+	}
+	for _, event := range events {
+		logRecord := scopeLogs.LogRecords().AppendEmpty()
+		logRecord.SetObservedTimestamp(now)
+		logRecord.SetTimestamp(pcommon.NewTimestampFromTime(event.created))
+		// The events with a Ray ID share the trace context of the exemplars of the analytics metrics.
+		if rayID, ok := parseRayID(event.group["rayName"]); ok {
+			logRecord.SetTraceID(traceIDFromRayID(rayID))
+			logRecord.SetSpanID(rayID)
+		}
+
+		attrs := logRecord.Attributes()
+		for field, attr := range attributes {
+			if value := event.group.str(field); attr != "" && value != "" {
+				attrs.PutStr(attr, value)
+			}
+		}
+		if dataset.record != nil {
+			dataset.record(logRecord, event.group)
+		}
+
+		if err := logRecord.Body().SetEmptyMap().FromRaw(event.group); err != nil {
+			r.logger.Warn("unable to set body", zap.Error(err))
+		}
+	}
+
+	return logs
+}
+
+// emits reports whether the events of the dataset are consumed by any pipeline of the receiver.
+func (r *analyticsLogsReceiver) emits(dataset analyticsLogDataset) bool {
+	return r.consumer != nil || (r.traces != nil && dataset.span != nil)
+}
+
+// emit consumes the events as log records, and as spans for the datasets of requests.
+func (r *analyticsLogsReceiver) emit(ctx context.Context, name, tag string, events []analyticsEvent) error {
+	var errs error
+	if r.consumer != nil {
+		errs = r.consume(ctx, r.processEvents(pcommon.NewTimestampFromTime(time.Now()), name, tag, events))
+	}
+	if r.traces != nil && r.datasets[name].span != nil {
+		errs = errors.Join(errs, r.consumeSpans(ctx, r.processSpans(name, tag, events)))
+	}
+	return errs
+}
+
+func (r *analyticsLogsReceiver) consume(ctx context.Context, logs plog.Logs) error {
+	obsCtx := r.obsrecv.StartLogsOp(ctx)
+	err := r.consumer.ConsumeLogs(obsCtx, logs)
+	r.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), logs.LogRecordCount(), err)
+	if err != nil {
+		return errors.Join(errors.New("failed to consume events"), err)
+	}
+	return nil
+}
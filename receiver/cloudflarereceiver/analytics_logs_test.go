@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/ptracetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+func TestAnalyticsLogsReceiver(t *testing.T) {
+	for _, name := range slices.Sorted(maps.Keys(analyticsLogDatasets)) {
+		t.Run(name, func(t *testing.T) {
+			dataset := analyticsLogDatasets[name]
+			response, err := os.ReadFile(filepath.Join("testdata", "analytics_logs", name+".json"))
+			require.NoError(t, err)
+
+			tag := testZoneID
+			if dataset.account {
+				tag = testAccountID
+			}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/graphql", func(rw http.ResponseWriter, req *http.Request) {
+				var body graphQLRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				require.Equal(t, dataset.query(), body.Query)
+				require.Equal(t, tag, body.Variables["tag"])
+				_, _ = rw.Write(response)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			clientConfig := confighttp.NewDefaultClientConfig()
+			clientConfig.Endpoint = server.URL
+			sink := &consumertest.LogsSink{}
+			r, err := newAnalyticsLogsReceiver(receivertest.NewNopSettings(metadata.Type), &AnalyticsLogsConfig{
+				APIConfig:    APIConfig{ClientConfig: clientConfig, APIToken: "abc123"},
+				Zones:        []string{testZoneID},
+				Accounts:     []string{testAccountID},
+				Datasets:     []string{name},
+				PollInterval: time.Hour,
+			}, sink)
+			require.NoError(t, err)
+			require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, r.Shutdown(t.Context())) }()
+
+			r.checkpoints[name+"/"+tag] = newEventCheckpoint(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+			require.NoError(t, r.pollDataset(t.Context(), name, tag, time.Date(2024, 5, 1, 10, 1, 0, 0, time.UTC)))
+			require.Len(t, sink.AllLogs(), 1)
+
+			expectedLogs, err := golden.ReadLogs(filepath.Join("testdata", "analytics_logs", name+"_expected.yaml"))
+			require.NoError(t, err)
+			require.NoError(t, plogtest.CompareLogs(expectedLogs, sink.AllLogs()[0],
+				plogtest.IgnoreObservedTimestamp(),
+			))
+		})
+	}
+}
+
+func TestAnalyticsLogsSpans(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("testdata", "analytics_logs", "http_requests.json"))
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write(response)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	r, err := newAnalyticsLogsReceiver(receivertest.NewNopSettings(metadata.Type), &AnalyticsLogsConfig{
+		APIConfig:    APIConfig{ClientConfig: clientConfig, APIToken: "abc123"},
+		Zones:        []string{testZoneID},
+		Datasets:     []string{"firewall_events", "http_requests"},
+		PollInterval: time.Hour,
+	}, nil)
+	require.NoError(t, err)
+	sink := &consumertest.TracesSink{}
+	r.traces = sink
+	require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(t.Context())) }()
+
+	// Only the requests are converted into spans, so the firewall events aren't polled without a logs pipeline.
+	require.False(t, r.emits(r.datasets["firewall_events"]))
+	require.True(t, r.emits(r.datasets["http_requests"]))
+
+	r.checkpoints["http_requests/"+testZoneID] = newEventCheckpoint(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	require.NoError(t, r.pollDataset(t.Context(), "http_requests", testZoneID, time.Date(2024, 5, 1, 10, 1, 0, 0, time.UTC)))
+	require.Len(t, sink.AllTraces(), 1)
+
+	expectedTraces, err := golden.ReadTraces(filepath.Join("testdata", "analytics_logs", "http_requests_spans_expected.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, ptracetest.CompareTraces(expectedTraces, sink.AllTraces()[0]))
+}
+
+// fakeAnalyticsLogsClient serves a fixed list of events of a zone ordered by time, emulating the
+// filtering and the limit of the API.
+type fakeAnalyticsLogsClient struct {
+	client
+	events []analyticsGroup
+	calls  int
+	// failing is the zone whose queries fail.
+	failing string
+}
+
+func (f *fakeAnalyticsLogsClient) QueryGraphQL(_ context.Context, _ string, variables map[string]any, data any) error {
+	f.calls++
+	if variables["tag"] == f.failing {
+		return errors.New("zone not found")
+	}
+	since, err := time.Parse(time.RFC3339, variables["since"].(string))
+	if err != nil {
+		return err
+	}
+	until, err := time.Parse(time.RFC3339, variables["until"].(string))
+	if err != nil {
+		return err
+	}
+	events := []analyticsGroup{}
+	for _, event := range f.events {
+		created, err := time.Parse(time.RFC3339, event.str("datetime"))
+		if err != nil {
+			return err
+		}
+		if created.Before(since) || !created.Before(until) {
+			continue
+		}
+		if len(events) == variables["limit"].(int) {
+			break
+		}
+		events = append(events, event)
+	}
+	payload, err := json.Marshal(map[string]any{"viewer": map[string]any{"zones": []any{map[string]any{"events": events}}}})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, data)
+}
+
+func newFirewallEvent(rayName string, created time.Time) analyticsGroup {
+	return analyticsGroup{"datetime": created.Format(time.RFC3339), "rayName": rayName, "action": "block"}
+}
+
+func emittedEventRayNames(sink *consumertest.LogsSink) []string {
+	var rayNames []string
+	for _, logs := range sink.AllLogs() {
+		records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < records.Len(); i++ {
+			rayName, _ := records.At(i).Attributes().Get(attrRayID)
+			rayNames = append(rayNames, rayName.Str())
+		}
+	}
+	return rayNames
+}
+
+func TestAnalyticsLogsRetention(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAnalyticsLogsClient{events: []analyticsGroup{
+		newFirewallEvent("expired", start.Add(time.Hour)),
+		newFirewallEvent("kept", start.Add(49*time.Hour)),
+	}}
+	sink := &consumertest.LogsSink{}
+	core, logs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.Logger = zap.New(core)
+	r, err := newAnalyticsLogsReceiver(settings, &AnalyticsLogsConfig{
+		Zones:        []string{testZoneID},
+		Datasets:     []string{"firewall_events"},
+		PollInterval: time.Minute,
+		Retention:    24 * time.Hour,
+	}, sink)
+	require.NoError(t, err)
+	r.client = fake
+	r.checkpoints["firewall_events/"+testZoneID] = newEventCheckpoint(start)
+
+	// Once polling resumes after longer than the retention, the events older than the retention are
+	// skipped rather than requested, which is only logged once.
+	require.NoError(t, r.pollDataset(t.Context(), "firewall_events", testZoneID, start.Add(50*time.Hour)))
+	require.Equal(t, []string{"kept"}, emittedEventRayNames(sink))
+	require.NoError(t, r.pollDataset(t.Context(), "firewall_events", testZoneID, start.Add(100*time.Hour)))
+	require.Equal(t, 1, logs.FilterMessage("Skipping the events older than the retention, which can't be collected anymore").Len())
+}
+
+func TestAnalyticsLogsPollDataset(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAnalyticsLogsClient{events: []analyticsGroup{
+		newFirewallEvent("ray1", start.Add(time.Second)),
+		newFirewallEvent("ray2", start.Add(2*time.Second)),
+		newFirewallEvent("ray3", start.Add(2*time.Second)),
+		newFirewallEvent("ray4", start.Add(3*time.Second)),
+	}}
+	sink := &consumertest.LogsSink{}
+	r, err := newAnalyticsLogsReceiver(receivertest.NewNopSettings(metadata.Type), &AnalyticsLogsConfig{
+		Zones:        []string{testZoneID},
+		Datasets:     []string{"firewall_events"},
+		PollInterval: time.Minute,
+	}, sink)
+	require.NoError(t, err)
+	r.client = fake
+	r.limit = 3
+	r.checkpoints["firewall_events/"+testZoneID] = newEventCheckpoint(start)
+
+	// The events are paged through from the time of the last event of every page, skipping the
+	// already emitted events of that time.
+	until := start.Add(time.Minute)
+	require.NoError(t, r.pollDataset(t.Context(), "firewall_events", testZoneID, until))
+	require.Equal(t, []string{"ray1", "ray2", "ray3", "ray4"}, emittedEventRayNames(sink))
+	require.Equal(t, 3, fake.calls)
+
+	// The next poll starts where the window of the previous one ended.
+	fake.events = append(fake.events, newFirewallEvent("ray5", until.Add(time.Second)))
+	require.NoError(t, r.pollDataset(t.Context(), "firewall_events", testZoneID, until.Add(time.Minute)))
+	require.Equal(t, []string{"ray1", "ray2", "ray3", "ray4", "ray5"}, emittedEventRayNames(sink))
+
+	// More events of the same time than the limit can't be paged through.
+	fake.events = []analyticsGroup{
+		newFirewallEvent("ray6", until.Add(2*time.Minute)),
+		newFirewallEvent("ray7", until.Add(2*time.Minute)),
+		newFirewallEvent("ray8", until.Add(2*time.Minute)),
+	}
+	r.limit = 2
+	err = r.pollDataset(t.Context(), "firewall_events", testZoneID, until.Add(3*time.Minute))
+	require.ErrorContains(t, err, "more than 2 events were created at")
+}
+
+func TestAnalyticsLogsPollIsolation(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAnalyticsLogsClient{
+		events:  []analyticsGroup{newFirewallEvent("ray1", start.Add(time.Second))},
+		failing: "failing",
+	}
+	sink := &consumertest.LogsSink{}
+	r, err := newAnalyticsLogsReceiver(receivertest.NewNopSettings(metadata.Type), &AnalyticsLogsConfig{
+		Zones:        []string{"failing", testZoneID},
+		Datasets:     []string{"firewall_events"},
+		PollInterval: time.Minute,
+		Delay:        time.Minute,
+	}, sink)
+	require.NoError(t, err)
+	r.client = fake
+	r.zoneIDs = r.cfg.Zones
+	r.started = start.Add(time.Minute)
+
+	// The failing zone doesn't prevent the events of the other zone from being emitted, and its window
+	// is polled again on the next poll.
+	r.poll(t.Context())
+	require.Equal(t, []string{"ray1"}, emittedEventRayNames(sink))
+	require.Equal(t, 2, fake.calls)
+	require.Equal(t, start, r.checkpoints["firewall_events/failing"].since)
+	require.True(t, r.checkpoints["firewall_events/"+testZoneID].since.After(start))
+}
+
+func TestAnalyticsLogsAttributes(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	r, err := newAnalyticsLogsReceiver(receivertest.NewNopSettings(metadata.Type), &AnalyticsLogsConfig{
+		Zones:        []string{testZoneID},
+		Datasets:     []string{"firewall_events"},
+		PollInterval: time.Minute,
+		Attributes:   map[string]string{"clientCountryName": "client.geo.country", "userAgent": "", "action": "cloudflare.action"},
+	}, sink)
+	require.NoError(t, err)
+
+	event := analyticsGroup{"datetime": "2024-05-01T10:00:01Z", "rayName": "ray1", "clientCountryName": "NL", "userAgent": "curl/8.5.0", "action": "block"}
+	logs := r.processEvents(0, "firewall_events", testZoneID, []analyticsEvent{{group: event, id: "ray1"}})
+
+	// The mapped fields are renamed or dropped, while the other fields keep the attributes of the dataset.
+	attrs := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	require.Equal(t, "NL", attrs["client.geo.country"])
+	require.Equal(t, "block", attrs["cloudflare.action"])
+	require.Equal(t, "ray1", attrs[attrRayID])
+	require.NotContains(t, attrs, "geo.country.iso_code")
+	require.NotContains(t, attrs, "user_agent.original")
+}
+
+func TestAnalyticsLogsCustomQueries(t *testing.T) {
+	fake := &fakeAnalyticsLogsClient{events: []analyticsGroup{
+		{"datetime": "2024-05-01T10:00:01Z", "queryName": "www.example.com", "responseCode": "NOERROR"},
+	}}
+	sink := &consumertest.LogsSink{}
+	r, err := newAnalyticsLogsReceiver(receivertest.NewNopSettings(metadata.Type), &AnalyticsLogsConfig{
+		Zones:        []string{testZoneID},
+		PollInterval: time.Minute,
+		CustomQueries: []AnalyticsEventQueryConfig{{
+			Name:       "dns",
+			Node:       "dnsAnalyticsAdaptive",
+			Fields:     "datetime queryName responseCode",
+			Attributes: map[string]string{"queryName": "dns.question.name"},
+		}},
+	}, sink)
+	require.NoError(t, err)
+	r.client = fake
+	r.checkpoints["dns/"+testZoneID] = newEventCheckpoint(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+
+	// The events of the custom query are emitted as a dataset named after the query.
+	r.poll(t.Context())
+	require.Equal(t, 1, sink.LogRecordCount())
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	dataset, ok := rl.Resource().Attributes().Get(attrDataset)
+	require.True(t, ok)
+	require.Equal(t, "dns", dataset.Str())
+	attrs := rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	require.Equal(t, map[string]any{"dns.question.name": "www.example.com"}, attrs)
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// dataset returns the custom query as a dataset made of its node.
+func (q AnalyticsQueryConfig) dataset() analyticsDataset {
+	return analyticsDataset{
+		account: q.Scope == "account",
+		nodes:   []analyticsNode{{name: q.Node, fields: q.Fields, filter: q.Filter}},
+	}
+}
+
+// queryCustom queries the custom queries of the zone, or of the account if account is true, over
+// [since, until), and appends their metrics to md under the resource, returning their failures.
+func (s *analyticsScraper) queryCustom(ctx context.Context, c client, tag string, account bool, res pcommon.Resource, since, until time.Time, md pmetric.Metrics) error {
+	kind := "zone"
+	if account {
+		kind = "account"
+	}
+	var errs error
+	rm := pmetric.NewResourceMetrics()
+	sm := rm.ScopeMetrics().AppendEmpty()
+	for _, q := range s.cfg.CustomQueries {
+		dataset := q.dataset().withOptions(s.cfg.DatasetOptions[q.Name])
+		if dataset.account != account {
+			continue
+		}
+		data, err := s.queryAnalytics(ctx, c, q.Name, dataset, tag, since, until)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to query the %s custom query of %s %s: %w", q.Name, kind, tag, err))
+			// The groups of a partial result are recorded as well.
+			var partialErr *partialResultError
+			if !errors.As(err, &partialErr) {
+				continue
+			}
+		}
+		groups := data.groups(account, "n0")
+		s.recordRows(ctx, q.Name, int64(len(groups)))
+		if len(groups) == 0 {
+			continue
+		}
+		for _, mc := range q.Metrics {
+			recordCustomMetric(sm.Metrics().AppendEmpty(), mc, q.Attributes, groups, since, until)
+		}
+	}
+	if sm.Metrics().Len() == 0 {
+		return errs
+	}
+	res.CopyTo(rm.Resource())
+	sm.Scope().SetName(metadata.ScopeName)
+	sm.Scope().SetVersion(s.buildInfo.Version)
+	rm.MoveTo(md.ResourceMetrics().AppendEmpty())
+	return errs
+}
+
+// recordCustomMetric records the metric with a data point for every group, carrying the fields of the
+// group mapped by attributes.
+func recordCustomMetric(m pmetric.Metric, mc AnalyticsQueryMetricConfig, attributes map[string]string, groups []analyticsGroup, since, until time.Time) {
+	m.SetName(mc.Name)
+	m.SetDescription(mc.Description)
+	m.SetUnit(mc.Unit)
+	var dps pmetric.NumberDataPointSlice
+	if mc.Type == "gauge" {
+		dps = m.SetEmptyGauge().DataPoints()
+	} else {
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dps = sum.DataPoints()
+	}
+	for _, group := range groups {
+		dp := dps.AppendEmpty()
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(since))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(until))
+		dp.SetDoubleValue(group.float(strings.Split(mc.Field, ".")...))
+		for path, attr := range attributes {
+			dp.Attributes().PutStr(attr, group.str(strings.Split(path, ".")...))
+		}
+	}
+}
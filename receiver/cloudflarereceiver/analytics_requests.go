@@ -0,0 +1,356 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// Dimensions of the requests and firewall events that are only added when configured, as they multiply
+// their series.
+const (
+	dimensionClientASN             = "client_asn"
+	dimensionClientASNOrganization = "client_asn_organization"
+	dimensionPath                  = "path"
+	dimensionJA3                   = "ja3"
+	dimensionJA4                   = "ja4"
+	dimensionWAFAttackScore        = "waf_attack_score"
+	dimensionWAFSQLiAttackScore    = "waf_sqli_attack_score"
+	dimensionWAFXSSAttackScore     = "waf_xss_attack_score"
+)
+
+// requestDimension is an optional dimension of the requests counted by the http_requests and
+// firewall_events datasets, added when listed in the dimensions of the analytics section.
+type requestDimension struct {
+	name string
+	// field is the dimension of the groups of the nodes holding the value of the dimension.
+	field string
+	// option returns the attribute holding the value, which isn't empty.
+	option func(value string) metadata.MetricAttributeOption
+	// class returns the class of the value of the field of the dimensions of a group, such as the
+	// class of a score, nil when the value is kept as is.
+	class func(dimensions map[string]any, field string) string
+}
+
+// requestDimensions lists the dimensions that can be added to the requests and firewall events
+// metrics.
+var requestDimensions = [...]requestDimension{
+	{name: dimensionClientASN, field: "clientAsn", option: func(value string) metadata.MetricAttributeOption {
+		asn, _ := strconv.ParseInt(value, 10, 64)
+		return metadata.WithClientAsnMetricAttribute(asn)
+	}},
+	{name: dimensionClientASNOrganization, field: "clientASNDescription", option: metadata.WithClientAsnOrganizationMetricAttribute},
+	{name: dimensionPath, field: "clientRequestPath", option: metadata.WithPathMetricAttribute},
+	{name: dimensionJA3, field: "ja3Hash", option: metadata.WithJa3MetricAttribute},
+	{name: dimensionJA4, field: "ja4", option: metadata.WithJa4MetricAttribute},
+	{
+		name: dimensionWAFAttackScore, field: "wafAttackScore", option: metadata.WithWafAttackScoreClassMetricAttribute,
+		class: wafScoreClass,
+	},
+	{
+		name: dimensionWAFSQLiAttackScore, field: "wafSqliAttackScore", option: metadata.WithWafSqliAttackScoreClassMetricAttribute,
+		class: wafScoreClass,
+	},
+	{
+		name: dimensionWAFXSSAttackScore, field: "wafXssAttackScore", option: metadata.WithWafXSSAttackScoreClassMetricAttribute,
+		class: wafScoreClass,
+	},
+}
+
+// analyticsDimensionNames lists the names of the requestDimensions.
+var analyticsDimensionNames = func() []string {
+	names := make([]string, 0, len(requestDimensions))
+	for _, dimension := range requestDimensions {
+		names = append(names, dimension.name)
+	}
+	return names
+}()
+
+// dimensionValues holds the values of the requestDimensions of a group, empty for the dimensions that
+// aren't enabled or that the group lacks.
+type dimensionValues [len(requestDimensions)]string
+
+// analyticsDimensions holds the requestDimensions enabled by the configuration, and how the hosts of
+// the requests are counted.
+type analyticsDimensions struct {
+	enabled [len(requestDimensions)]bool
+	// allowedHosts are the hosts with a series of their own, nil for all of them.
+	allowedHosts map[string]struct{}
+	// pathRules normalize the paths of the path dimension.
+	pathRules []pathRule
+}
+
+// newAnalyticsDimensions returns the dimensions of the requests and firewall events.
+func newAnalyticsDimensions(cfg *AnalyticsConfig) analyticsDimensions {
+	var d analyticsDimensions
+	for i, dimension := range requestDimensions {
+		d.enabled[i] = slices.Contains(cfg.Dimensions, dimension.name)
+	}
+	for _, rule := range cfg.PathRules {
+		// The patterns are checked when the config is validated.
+		d.pathRules = append(d.pathRules, pathRule{pattern: regexp.MustCompile(rule.Pattern), replacement: rule.Replacement})
+	}
+	if len(cfg.Hosts) > 0 {
+		d.allowedHosts = make(map[string]struct{}, len(cfg.Hosts))
+		for _, host := range cfg.Hosts {
+			d.allowedHosts[strings.ToLower(host)] = struct{}{}
+		}
+	}
+	return d
+}
+
+// host returns the host of the group, or otherValue for the hosts that aren't allowed, which
+// are summed into a single series.
+func (d analyticsDimensions) host(group analyticsGroup) string {
+	host := group.str("dimensions", "clientRequestHTTPHost")
+	if _, ok := d.allowedHosts[strings.ToLower(host)]; d.allowedHosts != nil && host != "" && !ok {
+		return otherValue
+	}
+	return host
+}
+
+// fields returns the dimensions of the nodes to query for the enabled dimensions, each preceded by a
+// space.
+func (d analyticsDimensions) fields() string {
+	var b strings.Builder
+	for i, dimension := range requestDimensions {
+		if d.enabled[i] {
+			b.WriteString(" " + dimension.field)
+		}
+	}
+	return b.String()
+}
+
+// values returns the values of the enabled dimensions of the group, the paths being normalized by the
+// path rules and the scores replaced by their class. An ASN of 0 stands for an unknown autonomous
+// system, and is left empty.
+func (d analyticsDimensions) values(group analyticsGroup) dimensionValues {
+	var values dimensionValues
+	dimensions, _ := group.value("dimensions").(map[string]any)
+	for i, dimension := range requestDimensions {
+		value := group.str("dimensions", dimension.field)
+		switch {
+		case !d.enabled[i] || (value == "0" && dimension.class == nil):
+			continue
+		case dimension.class != nil:
+			value = dimension.class(dimensions, dimension.field)
+		case dimension.name == dimensionPath:
+			value = normalizePath(d.pathRules, value)
+		}
+		values[i] = value
+	}
+	return values
+}
+
+// options returns the attributes of the values that aren't empty.
+func (v dimensionValues) options() []metadata.MetricAttributeOption {
+	var options []metadata.MetricAttributeOption
+	for i, value := range v {
+		if value != "" {
+			options = append(options, requestDimensions[i].option(value))
+		}
+	}
+	return options
+}
+
+// pathRule is a PathRule with its pattern compiled.
+type pathRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// normalizePath applies the path rules to the path, in order.
+func normalizePath(rules []pathRule, path string) string {
+	for _, rule := range rules {
+		path = rule.pattern.ReplaceAllString(path, rule.replacement)
+	}
+	return path
+}
+
+// httpRequestsKey identifies a series of the cloudflare.http.requests metric.
+type httpRequestsKey struct {
+	statusClass string
+	action      string
+	host        string
+	dimensions  dimensionValues
+}
+
+// firewallEventsKey identifies a series of the cloudflare.firewall.events metric.
+type firewallEventsKey struct {
+	action     string
+	source     string
+	host       string
+	dimensions dimensionValues
+}
+
+// httpRequestsDataset returns the http_requests dataset, counting the requests of the zones along with
+// their unique visitors when cloudflare.http.unique_visitors is enabled, and their ratios when
+// derive_ratios is enabled. Since the groups of the API are by status code rather than by class, the
+// groups of a class are summed.
+func httpRequestsDataset(cfg *AnalyticsConfig) analyticsDataset {
+	dimensions := newAnalyticsDimensions(cfg)
+	nodes := []analyticsNode{{
+		name:   "httpRequestsAdaptiveGroups",
+		fields: "count dimensions { edgeResponseStatus securityAction clientRequestHTTPHost" + dimensions.fields() + " }",
+	}}
+	if cfg.Metrics.CloudflareHTTPUniqueVisitors.Enabled {
+		nodes = append(nodes, analyticsNode{
+			// The adaptive nodes don't count the unique visitors, which are only counted by the node
+			// of the requests of every minute. Without dimensions, its only group covers the window.
+			name:   "httpRequests1mGroups",
+			fields: "uniq { uniques }",
+			record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, group analyticsGroup) {
+				mb.RecordCloudflareHTTPUniqueVisitorsDataPoint(ts, group.int("uniq", "uniques"))
+			},
+		})
+	}
+	ratios := -1
+	if cfg.DeriveRatios {
+		ratios = len(nodes)
+		nodes = append(nodes, analyticsNode{
+			name:   "httpRequestsAdaptiveGroups",
+			fields: "count dimensions { cacheStatus originResponseStatus securityAction }",
+		})
+	}
+	return analyticsDataset{
+		nodes: nodes,
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, groups [][]analyticsGroup) {
+			recordHTTPRequests(mb, ts, groups[0], dimensions)
+			if ratios >= 0 {
+				recordHTTPRatios(mb, ts, groups[ratios])
+			}
+		},
+	}
+}
+
+// recordHTTPRequests records the requests of the groups, by class of status code, action, host and
+// enabled dimensions.
+func recordHTTPRequests(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, groups []analyticsGroup, dimensions analyticsDimensions) {
+	counts := map[httpRequestsKey]int64{}
+	for _, group := range groups {
+		key := httpRequestsKey{
+			statusClass: statusClass(group.value("dimensions", "edgeResponseStatus")),
+			action:      group.str("dimensions", "securityAction"),
+			host:        dimensions.host(group),
+			dimensions:  dimensions.values(group),
+		}
+		counts[key] += group.int("count")
+	}
+	for key, count := range counts {
+		mb.RecordCloudflareHTTPRequestsDataPoint(ts, count, key.statusClass, key.action, key.host, key.dimensions.options()...)
+	}
+}
+
+// firewallEventsDataset returns the firewall_events dataset, counting the firewall events of the zones
+// by action, security product, host and enabled dimensions.
+func firewallEventsDataset(cfg *AnalyticsConfig) analyticsDataset {
+	dimensions := newAnalyticsDimensions(cfg)
+	return analyticsDataset{
+		nodes: []analyticsNode{{
+			name:   "firewallEventsAdaptiveGroups",
+			fields: "count dimensions { action source clientRequestHTTPHost" + dimensions.fields() + " }",
+		}},
+		record: func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, groups [][]analyticsGroup) {
+			counts := map[firewallEventsKey]int64{}
+			for _, group := range groups[0] {
+				key := firewallEventsKey{
+					action:     group.str("dimensions", "action"),
+					source:     group.str("dimensions", "source"),
+					host:       dimensions.host(group),
+					dimensions: dimensions.values(group),
+				}
+				counts[key] += group.int("count")
+			}
+			for key, count := range counts {
+				mb.RecordCloudflareFirewallEventsDataPoint(ts, count, key.action, key.source, key.host, key.dimensions.options()...)
+			}
+		},
+	}
+}
+
+// cacheHitStatuses are the cache statuses of the responses served from the cache.
+var cacheHitStatuses = map[string]struct{}{
+	"hit":         {},
+	"stale":       {},
+	"updating":    {},
+	"revalidated": {},
+}
+
+// ratioCounts counts the requests the ratio metrics are computed from.
+type ratioCounts struct {
+	requests       int64
+	cacheRequests  int64
+	cacheHits      int64
+	originRequests int64
+	originErrors   int64
+	blocked        int64
+}
+
+// add counts weight requests with the cache status, origin response status and security action. The
+// cache status is empty for the requests without one, and the origin response status isn't a status
+// code for the requests that weren't sent to the origin.
+func (c *ratioCounts) add(cacheStatus string, originStatus any, action string, weight int64) {
+	c.requests += weight
+	if cacheStatus != "" {
+		c.cacheRequests += weight
+		if _, ok := cacheHitStatuses[cacheStatus]; ok {
+			c.cacheHits += weight
+		}
+	}
+	if originClass := statusClass(originStatus); originClass != "" {
+		c.originRequests += weight
+		if originClass == "5xx" {
+			c.originErrors += weight
+		}
+	}
+	if action == "block" {
+		c.blocked += weight
+	}
+}
+
+// recordHTTPRatios records the cache hit, origin error and blocked ratios of the requests of the
+// groups. The ratios lacking any request to be computed from are not recorded.
+func recordHTTPRatios(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, groups []analyticsGroup) {
+	var counts ratioCounts
+	for _, group := range groups {
+		counts.add(group.str("dimensions", "cacheStatus"), group.value("dimensions", "originResponseStatus"),
+			group.str("dimensions", "securityAction"), group.int("count"))
+	}
+	if counts.cacheRequests > 0 {
+		mb.RecordCloudflareHTTPCacheHitRatioDataPoint(ts, float64(counts.cacheHits)/float64(counts.cacheRequests))
+	}
+	if counts.originRequests > 0 {
+		mb.RecordCloudflareHTTPOriginErrorRatioDataPoint(ts, float64(counts.originErrors)/float64(counts.originRequests))
+	}
+	if counts.requests > 0 {
+		mb.RecordCloudflareHTTPBlockedRatioDataPoint(ts, float64(counts.blocked)/float64(counts.requests))
+	}
+}
+
+// wafScoreClass returns the class of the WAF attack score held by the field of the dimensions of a
+// group, following the classes of the Cloudflare dashboard, or an empty string if the request wasn't
+// scored.
+func wafScoreClass(dimensions map[string]any, field string) string {
+	score, ok := intField(dimensions, field)
+	switch {
+	case !ok || score < 1 || score > 99:
+		// Requests that weren't scored have a score of 0, or 100 in some datasets.
+		return ""
+	case score <= 20:
+		return "attack"
+	case score <= 50:
+		return "likely_attack"
+	case score <= 80:
+		return "likely_clean"
+	default:
+		return "clean"
+	}
+}
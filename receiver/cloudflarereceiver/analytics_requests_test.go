@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// recordRequests records the groups of the first node of the dataset configured after cfg, and
+// returns the values of the data points of the metric by their attributes, joined with slashes in
+// the order of the attributes.
+func recordRequests(t *testing.T, name string, cfg *AnalyticsConfig, groups []analyticsGroup, metric string, attrs ...string) map[string]int64 {
+	dataset := analyticsDatasets[name].configure(cfg)
+	mb := metadata.NewMetricsBuilder(metadata.DefaultMetricsBuilderConfig(), receivertest.NewNopSettings(metadata.Type))
+	nodeGroups := make([][]analyticsGroup, len(dataset.nodes))
+	nodeGroups[0] = groups
+	dataset.record(mb, pcommon.NewTimestampFromTime(time.Now()), nodeGroups)
+
+	values := map[string]int64{}
+	for _, rm := range mb.Emit().ResourceMetrics().All() {
+		for _, m := range rm.ScopeMetrics().At(0).Metrics().All() {
+			if m.Name() != metric {
+				continue
+			}
+			for _, dp := range m.Sum().DataPoints().All() {
+				values[dataPointKey(dp, attrs)] = dp.IntValue()
+			}
+		}
+	}
+	return values
+}
+
+func dataPointKey(dp pmetric.NumberDataPoint, attrs []string) string {
+	var key string
+	for i, attr := range attrs {
+		if i > 0 {
+			key += "/"
+		}
+		if value, ok := dp.Attributes().Get(attr); ok {
+			key += value.AsString()
+		}
+	}
+	return key
+}
+
+func TestAnalyticsRequestsAllowedHosts(t *testing.T) {
+	groups := []analyticsGroup{
+		{"count": float64(10), "dimensions": map[string]any{"edgeResponseStatus": float64(200), "clientRequestHTTPHost": "Example.com"}},
+		{"count": float64(5), "dimensions": map[string]any{"edgeResponseStatus": float64(200), "clientRequestHTTPHost": "a.example.net"}},
+		{"count": float64(7), "dimensions": map[string]any{"edgeResponseStatus": float64(204), "clientRequestHTTPHost": "b.example.net"}},
+		{"count": float64(1), "dimensions": map[string]any{"edgeResponseStatus": float64(200), "clientRequestHTTPHost": ""}},
+	}
+	cfg := &AnalyticsConfig{Hosts: []string{"example.com"}}
+	require.Equal(t, map[string]int64{
+		"2xx/Example.com": 10,
+		"2xx/other":       12,
+		"2xx/":            1,
+	}, recordRequests(t, "http_requests", cfg, groups, "cloudflare.http.requests", "cloudflare.edge.response.status_class", "server.address"))
+
+	// Every host keeps a series of its own without an allowlist.
+	require.Len(t, recordRequests(t, "http_requests", &AnalyticsConfig{}, groups, "cloudflare.http.requests", "server.address"), 4)
+}
+
+func TestAnalyticsRequestsPathRules(t *testing.T) {
+	groups := []analyticsGroup{
+		{"count": float64(3), "dimensions": map[string]any{"action": "block", "clientRequestPath": "/users/42/orders"}},
+		{"count": float64(4), "dimensions": map[string]any{"action": "block", "clientRequestPath": "/users/7/orders"}},
+		{"count": float64(2), "dimensions": map[string]any{"action": "block", "clientRequestPath": "/login"}},
+	}
+	cfg := &AnalyticsConfig{
+		Dimensions: []string{dimensionPath},
+		PathRules:  []PathRule{{Pattern: `^/users/\d+`, Replacement: "/users/{id}"}},
+	}
+	require.Equal(t, map[string]int64{
+		"block//users/{id}/orders": 7,
+		"block//login":             2,
+	}, recordRequests(t, "firewall_events", cfg, groups, "cloudflare.firewall.events", "cloudflare.action", "url.path"))
+
+	// The paths are left out unless the dimension is enabled.
+	require.Equal(t, map[string]int64{"block/": 9},
+		recordRequests(t, "firewall_events", &AnalyticsConfig{PathRules: cfg.PathRules}, groups, "cloudflare.firewall.events", "cloudflare.action", "url.path"))
+}
+
+func TestAnalyticsTopFingerprints(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		Dimensions:        []string{dimensionJA3, dimensionJA4},
+		TopFingerprints:   1,
+		CardinalityLimits: map[string]int{"server.address": 5},
+	}
+	require.Equal(t, map[string]int{"server.address": 5, "tls.client.ja3": 1, "tls.client.ja4": 1}, cfg.cardinalityLimits())
+
+	dataset := analyticsDatasets["firewall_events"].configure(cfg)
+	require.Contains(t, dataset.query(), "dimensions { action source clientRequestHTTPHost ja3Hash ja4 }")
+	mb := metadata.NewMetricsBuilder(metadata.DefaultMetricsBuilderConfig(), receivertest.NewNopSettings(metadata.Type))
+	dataset.record(mb, pcommon.NewTimestampFromTime(time.Now()), [][]analyticsGroup{{
+		{"count": float64(30), "dimensions": map[string]any{"action": "block", "ja3Hash": "e7d705a3286e19ea42f587b344ee6865", "ja4": "t13d1516h2_8daaf6152771_02713d6af862"}},
+		{"count": float64(5), "dimensions": map[string]any{"action": "block", "ja3Hash": "6734f37431670b3ab4292b8f60f29984", "ja4": "t13d1516h2_8daaf6152771_02713d6af862"}},
+		{"count": float64(2), "dimensions": map[string]any{"action": "block", "ja3Hash": "cd08e31494f9531f560d64c695473da9", "ja4": "t12d1209h2_d34a8e72043a_b39be8c56a14"}},
+	}})
+	md := mb.Emit()
+	limitCardinality(md, cfg.cardinalityLimits())
+
+	counts := map[string]int64{}
+	for _, dp := range md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().All() {
+		counts[dataPointKey(dp, []string{"tls.client.ja3", "tls.client.ja4"})] = dp.IntValue()
+	}
+	require.Equal(t, map[string]int64{
+		"e7d705a3286e19ea42f587b344ee6865/t13d1516h2_8daaf6152771_02713d6af862": 30,
+		"other/t13d1516h2_8daaf6152771_02713d6af862":                            5,
+		"other/other": 2,
+	}, counts)
+
+	// The limits follow the renamed attributes, and skip the dropped ones.
+	cfg.Attributes = map[string]string{"tls.client.ja3": "ja3", "tls.client.ja4": ""}
+	require.Equal(t, map[string]int{"server.address": 5, "ja3": 1}, cfg.cardinalityLimits())
+}
+
+func TestWAFScoreClass(t *testing.T) {
+	require.Equal(t, "attack", wafScoreClass(map[string]any{"wafAttackScore": float64(1)}, "wafAttackScore"))
+	require.Equal(t, "attack", wafScoreClass(map[string]any{"wafAttackScore": float64(20)}, "wafAttackScore"))
+	require.Equal(t, "likely_attack", wafScoreClass(map[string]any{"wafAttackScore": float64(21)}, "wafAttackScore"))
+	require.Equal(t, "likely_clean", wafScoreClass(map[string]any{"wafAttackScore": "80"}, "wafAttackScore"))
+	require.Equal(t, "clean", wafScoreClass(map[string]any{"wafAttackScore": float64(99)}, "wafAttackScore"))
+	require.Empty(t, wafScoreClass(map[string]any{"wafAttackScore": float64(0)}, "wafAttackScore"))
+	require.Empty(t, wafScoreClass(map[string]any{"wafAttackScore": float64(100)}, "wafAttackScore"))
+	require.Empty(t, wafScoreClass(map[string]any{}, "wafAttackScore"))
+}
+
+func TestAnalyticsRequestsWAFScores(t *testing.T) {
+	cfg := &AnalyticsConfig{Dimensions: []string{dimensionWAFAttackScore, dimensionWAFSQLiAttackScore}}
+	groups := []analyticsGroup{
+		{"count": float64(6), "dimensions": map[string]any{"edgeResponseStatus": float64(403), "wafAttackScore": float64(8), "wafSqliAttackScore": float64(12)}},
+		{"count": float64(4), "dimensions": map[string]any{"edgeResponseStatus": float64(403), "wafAttackScore": float64(15), "wafSqliAttackScore": float64(3)}},
+		{"count": float64(90), "dimensions": map[string]any{"edgeResponseStatus": float64(200), "wafAttackScore": float64(95), "wafSqliAttackScore": float64(99)}},
+		// Requests that weren't scored have a score of 100.
+		{"count": float64(20), "dimensions": map[string]any{"edgeResponseStatus": float64(200), "wafAttackScore": float64(100), "wafSqliAttackScore": float64(100)}},
+	}
+	require.Equal(t, map[string]int64{
+		"4xx/attack/attack": 10,
+		"2xx/clean/clean":   90,
+		"2xx//":             20,
+	}, recordRequests(t, "http_requests", cfg, groups, "cloudflare.http.requests",
+		"cloudflare.edge.response.status_class", "cloudflare.waf.attack_score_class", "cloudflare.waf.sqli_attack_score_class"))
+
+	require.Contains(t, analyticsDatasets["http_requests"].configure(cfg).query(), "wafAttackScore wafSqliAttackScore }")
+
+	// The scores are dimensions of the firewall events as well.
+	require.Contains(t, analyticsDatasets["firewall_events"].configure(cfg).query(), "dimensions { action source clientRequestHTTPHost wafAttackScore wafSqliAttackScore }")
+	events := []analyticsGroup{
+		{"count": float64(8), "dimensions": map[string]any{"action": "block", "source": "waf", "wafAttackScore": float64(5), "wafSqliAttackScore": float64(9)}},
+		{"count": float64(2), "dimensions": map[string]any{"action": "block", "source": "waf", "wafAttackScore": float64(18), "wafSqliAttackScore": float64(2)}},
+		{"count": float64(3), "dimensions": map[string]any{"action": "log", "source": "waf", "wafAttackScore": float64(40), "wafSqliAttackScore": float64(100)}},
+	}
+	require.Equal(t, map[string]int64{
+		"block/attack/attack": 10,
+		"log/likely_attack/":  3,
+	}, recordRequests(t, "firewall_events", cfg, events, "cloudflare.firewall.events",
+		"cloudflare.action", "cloudflare.waf.attack_score_class", "cloudflare.waf.sqli_attack_score_class"))
+}
+
+func TestAnalyticsRequestsUniqueVisitorsDisabled(t *testing.T) {
+	cfg := &AnalyticsConfig{MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(), DeriveRatios: true}
+	require.Contains(t, analyticsDatasets["http_requests"].configure(cfg).query(), "httpRequests1mGroups")
+
+	cfg.Metrics.CloudflareHTTPUniqueVisitors.Enabled = false
+	dataset := analyticsDatasets["http_requests"].configure(cfg)
+	require.NotContains(t, dataset.query(), "httpRequests1mGroups")
+	require.Len(t, dataset.nodes, 2)
+
+	// The ratios are still computed from the groups of the last node.
+	mb := metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, receivertest.NewNopSettings(metadata.Type))
+	dataset.record(mb, pcommon.NewTimestampFromTime(time.Now()), [][]analyticsGroup{nil, {
+		{"count": float64(3), "dimensions": map[string]any{"cacheStatus": "hit", "originResponseStatus": float64(0), "securityAction": ""}},
+		{"count": float64(1), "dimensions": map[string]any{"cacheStatus": "miss", "originResponseStatus": float64(200), "securityAction": ""}},
+	}})
+	metrics := mb.Emit()
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	var ratio float64
+	for _, m := range metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().All() {
+		require.NotEqual(t, "cloudflare.http.unique_visitors", m.Name())
+		if m.Name() == "cloudflare.http.cache_hit_ratio" {
+			ratio = m.Gauge().DataPoints().At(0).DoubleValue()
+		}
+	}
+	require.Equal(t, 0.75, ratio)
+}
@@ -0,0 +1,891 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadatatest"
+)
+
+const (
+	testZoneID    = "023e105f4ecef8ad9ca31a8372d0c353"
+	testAccountID = "01a7362d577a6c3019a474fd6f485823"
+)
+
+func TestAnalyticsScraper(t *testing.T) {
+	for _, dataset := range slices.Sorted(maps.Keys(analyticsDatasets)) {
+		t.Run(dataset, func(t *testing.T) {
+			response, err := os.ReadFile(filepath.Join("testdata", "analytics", dataset+".json"))
+			require.NoError(t, err)
+
+			var s *analyticsScraper
+			mux := http.NewServeMux()
+			mux.HandleFunc("/graphql", func(rw http.ResponseWriter, req *http.Request) {
+				require.Equal(t, "Bearer abc123", req.Header.Get("Authorization"))
+				var body graphQLRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				require.Equal(t, s.datasets[dataset].query(), body.Query)
+				if s.datasets[dataset].account {
+					require.Equal(t, testAccountID, body.Variables["tag"])
+				} else {
+					require.Equal(t, testZoneID, body.Variables["tag"])
+				}
+				_, _ = rw.Write(response)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			clientConfig := confighttp.NewDefaultClientConfig()
+			clientConfig.Endpoint = server.URL
+			cfg := &AnalyticsConfig{
+				APIConfig:            APIConfig{ClientConfig: clientConfig, APIToken: "abc123"},
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Zones:                []string{testZoneID},
+				Accounts:             []string{testAccountID},
+				Datasets:             []string{dataset},
+				DeriveRatios:         true,
+				Dimensions:           analyticsDimensionNames,
+			}
+			cfg.CollectionInterval = time.Minute
+
+			s, err = newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+			require.NoError(t, err)
+			require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+			actualMetrics, err := s.scrape(t.Context())
+			require.NoError(t, err)
+
+			expectedMetrics, err := golden.ReadMetrics(filepath.Join("testdata", "analytics", dataset+"_expected.yaml"))
+			require.NoError(t, err)
+			require.NoError(t, pmetrictest.CompareMetrics(expectedMetrics, actualMetrics,
+				pmetrictest.IgnoreStartTimestamp(),
+				pmetrictest.IgnoreTimestamp(),
+				pmetrictest.IgnoreMetricDataPointsOrder(),
+			))
+		})
+	}
+}
+
+// fakeAnalyticsClient answers the queries of the zones and accounts with the groups and the events of
+// their first node, failing for the zones and accounts without groups, and the lookups of the known
+// zones.
+type fakeAnalyticsClient struct {
+	client
+	groups  map[string][]analyticsGroup
+	events  map[string][]analyticsGroup
+	queries []map[string]any
+	zones   map[string]zone
+	lookups int
+}
+
+func (f *fakeAnalyticsClient) GetZone(_ context.Context, zoneID string) (zone, error) {
+	f.lookups++
+	z, ok := f.zones[zoneID]
+	if !ok {
+		return zone{}, errors.New("zone lookup failed")
+	}
+	return z, nil
+}
+
+func (f *fakeAnalyticsClient) QueryGraphQL(_ context.Context, _ string, variables map[string]any, data any) error {
+	f.queries = append(f.queries, variables)
+	groups, ok := f.groups[variables["tag"].(string)]
+	if !ok {
+		return errors.New("not found")
+	}
+	nodes := []any{map[string]any{"n0": groups, "e0": f.events[variables["tag"].(string)]}}
+	payload, err := json.Marshal(map[string]any{"viewer": map[string]any{"zones": nodes, "accounts": nodes}})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, data)
+}
+
+func TestAnalyticsScraperWindows(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"healthy", "failing"},
+		Accounts:             []string{"account"},
+		Datasets:             []string{"waiting_room", "turnstile"},
+		Delay:                time.Minute,
+	}
+	cfg.CollectionInterval = 5 * time.Minute
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	fake := &fakeAnalyticsClient{groups: map[string][]analyticsGroup{
+		"healthy": {{"dimensions": map[string]any{"waitingRoomId": "room"}, "sum": map[string]any{"totalAcceptedUsers": 3.0}}},
+		"account": {{"count": 10.0, "dimensions": map[string]any{"siteKey": "widget", "eventType": "challenge_solved"}}},
+	}}
+	s.tenants[0].client = fake
+
+	metrics, err := s.scrape(t.Context())
+	// The failing zone is reported as a partial error, while the healthy zone and the account are still
+	// reported. The datasets of zones are only queried for zones, and those of accounts for accounts.
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.EqualError(t, err, "failed to query the waiting_room analytics of zone failing: not found")
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+	require.Len(t, fake.queries, 3)
+	accountID, ok := metrics.ResourceMetrics().At(1).Resource().Attributes().Get("cloudflare.account.id")
+	require.True(t, ok)
+	require.Equal(t, "account", accountID.Str())
+
+	// The first window covers the collection interval ending delay ago, and every following window
+	// starts where the previous one ended.
+	first := fake.queries[0]
+	since, err := time.Parse(time.RFC3339, first["since"].(string))
+	require.NoError(t, err)
+	until, err := time.Parse(time.RFC3339, first["until"].(string))
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Minute, until.Sub(since))
+	require.WithinDuration(t, time.Now().Add(-time.Minute), until, 5*time.Second)
+
+	_, _ = s.scrape(t.Context())
+	require.Equal(t, first["until"], fake.queries[3]["since"])
+}
+
+func TestAnalyticsScraperZoneDetails(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"known", "unknown"},
+		Datasets:             []string{"waiting_room"},
+		ZoneCacheTTL:         time.Hour,
+	}
+	cfg.CollectionInterval = time.Minute
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, s.shutdown(t.Context())) }()
+	known := zone{ID: "known", Name: "example.com"}
+	known.Plan.Name = "Enterprise"
+	known.Account.ID = "account"
+	groups := []analyticsGroup{{"dimensions": map[string]any{"waitingRoomId": "room"}, "sum": map[string]any{"totalAcceptedUsers": 3.0}}}
+	fake := &fakeAnalyticsClient{
+		groups: map[string][]analyticsGroup{"known": groups, "unknown": groups},
+		zones:  map[string]zone{"known": known},
+	}
+	s.tenants[0].client = fake
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+	require.Equal(t, map[string]any{
+		"cloudflare.zone.id":    "known",
+		"cloudflare.zone.name":  "example.com",
+		"cloudflare.zone.plan":  "Enterprise",
+		"cloudflare.account.id": "account",
+	}, metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	// A zone that can't be looked up is reported with its ID only.
+	require.Equal(t, map[string]any{"cloudflare.zone.id": "unknown"}, metrics.ResourceMetrics().At(1).Resource().Attributes().AsRaw())
+	require.Equal(t, 2, fake.lookups)
+
+	// The details of the known zone are cached, while the lookup of the unknown zone is retried.
+	_, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 3, fake.lookups)
+}
+
+func TestAnalyticsScraperAlignWindow(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"healthy"},
+		Datasets:             []string{"waiting_room"},
+		AlignWindow:          true,
+	}
+	cfg.CollectionInterval = time.Hour
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	fake := &fakeAnalyticsClient{groups: map[string][]analyticsGroup{"healthy": {}}}
+	s.tenants[0].client = fake
+
+	// The window ends on a multiple of the collection interval.
+	_, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Len(t, fake.queries, 1)
+	until, err := time.Parse(time.RFC3339, fake.queries[0]["until"].(string))
+	require.NoError(t, err)
+	require.Equal(t, until.Truncate(time.Hour), until)
+
+	// The window doesn't move until the next interval has elapsed.
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 0, metrics.ResourceMetrics().Len())
+	require.Len(t, fake.queries, 1)
+}
+
+func TestAnalyticsScraperRetention(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"healthy"},
+		Datasets:             []string{"waiting_room"},
+		Retention:            24 * time.Hour,
+	}
+	cfg.CollectionInterval = time.Minute
+	core, logs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.Logger = zap.New(core)
+	s, err := newAnalyticsScraper(settings, cfg)
+	require.NoError(t, err)
+	fake := &fakeAnalyticsClient{groups: map[string][]analyticsGroup{"healthy": {}}}
+	s.tenants[0].client = fake
+
+	// A window starting before the retention, e.g. once scraping resumes after days, is clamped to the
+	// retention, which is only logged once.
+	for range 2 {
+		s.windowEnd = time.Now().Add(-72 * time.Hour)
+		_, err = s.scrape(t.Context())
+		require.NoError(t, err)
+		query := fake.queries[len(fake.queries)-1]
+		since, err := time.Parse(time.RFC3339, query["since"].(string))
+		require.NoError(t, err)
+		until, err := time.Parse(time.RFC3339, query["until"].(string))
+		require.NoError(t, err)
+		require.Equal(t, 24*time.Hour, until.Sub(since))
+	}
+	require.Equal(t, 1, logs.FilterMessage("Skipping the analytics older than the retention, which can't be queried anymore").Len())
+}
+
+func TestAnalyticsScraperCircuitBreaker(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"healthy", "failing"},
+		Datasets:             []string{"waiting_room"},
+		CircuitBreaker:       CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Hour},
+	}
+	cfg.CollectionInterval = time.Minute
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	fake := &fakeAnalyticsClient{groups: map[string][]analyticsGroup{
+		"healthy": {{"dimensions": map[string]any{"waitingRoomId": "room"}, "sum": map[string]any{"totalAcceptedUsers": 3.0}}},
+	}}
+	s.tenants[0].client = fake
+
+	for range 2 {
+		_, err := s.scrape(t.Context())
+		require.EqualError(t, err, "failed to query the waiting_room analytics of zone failing: not found")
+	}
+	require.Len(t, fake.queries, 4)
+
+	// Once paused, the failing zone is no longer queried, but keeps being reported as a partial error.
+	metrics, err := s.scrape(t.Context())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.ErrorContains(t, err, "failing is paused until")
+	require.Len(t, fake.queries, 5)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+
+	// Once the cooldown has elapsed, the zone is probed again.
+	s.breaker.states["/failing"].openUntil = time.Now()
+	_, err = s.scrape(t.Context())
+	require.EqualError(t, err, "failed to query the waiting_room analytics of zone failing: not found")
+	require.Len(t, fake.queries, 7)
+}
+
+// slowAnalyticsClient never answers the queries of the slow zone before they're cancelled.
+type slowAnalyticsClient struct {
+	*fakeAnalyticsClient
+}
+
+func (f slowAnalyticsClient) QueryGraphQL(ctx context.Context, query string, variables map[string]any, data any) error {
+	if variables["tag"] == "slow" {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return f.fakeAnalyticsClient.QueryGraphQL(ctx, query, variables, data)
+}
+
+func TestAnalyticsScraperTimeouts(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"slow", "healthy"},
+		Datasets:             []string{"waiting_room"},
+		QueryTimeout:         10 * time.Millisecond,
+		CircuitBreaker:       CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour},
+	}
+	cfg.CollectionInterval = time.Minute
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	fake := &fakeAnalyticsClient{groups: map[string][]analyticsGroup{
+		"healthy": {{"dimensions": map[string]any{"waitingRoomId": "room"}, "sum": map[string]any{"totalAcceptedUsers": 3.0}}},
+	}}
+	s.tenants[0].client = slowAnalyticsClient{fake}
+
+	// The slow zone times out without preventing the healthy zone from being reported.
+	metrics, err := s.scrape(t.Context())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.EqualError(t, err, "failed to query the waiting_room analytics of zone slow: context deadline exceeded")
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+
+	// Once the scrape deadline is exceeded, the remaining queries are skipped, which doesn't count as a
+	// failure of the zones.
+	s.breaker.states = map[string]*circuitState{}
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	metrics, err = s.scrape(ctx)
+	require.ErrorContains(t, err, "failed to query the waiting_room analytics of zone healthy: skipped: context canceled")
+	require.Equal(t, 0, metrics.ResourceMetrics().Len())
+	require.Len(t, fake.queries, 1)
+	require.Empty(t, s.breaker.states)
+}
+
+// partialAnalyticsClient answers the queries of the partial zone with their groups alongside errors.
+type partialAnalyticsClient struct {
+	*fakeAnalyticsClient
+}
+
+func (f partialAnalyticsClient) QueryGraphQL(ctx context.Context, query string, variables map[string]any, data any) error {
+	if err := f.fakeAnalyticsClient.QueryGraphQL(ctx, query, variables, data); err != nil || variables["tag"] != "partial" {
+		return err
+	}
+	return &partialResultError{path: graphQLPath, errs: []error{graphQLError{Message: "node n1 unavailable"}}}
+}
+
+func TestAnalyticsScraperPartialResult(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"partial"},
+		Datasets:             []string{"waiting_room"},
+		CircuitBreaker:       CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour},
+		CustomQueries: []AnalyticsQueryConfig{{
+			Name:    "spectrum",
+			Node:    "spectrumNetworkAnalyticsAdaptiveGroups",
+			Fields:  "sum { bits }",
+			Metrics: []AnalyticsQueryMetricConfig{{Name: "cloudflare.spectrum.bits", Field: "sum.bits"}},
+		}},
+	}
+	cfg.CollectionInterval = time.Minute
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	s.tenants[0].client = partialAnalyticsClient{&fakeAnalyticsClient{groups: map[string][]analyticsGroup{
+		"partial": {{"dimensions": map[string]any{"waitingRoomId": "room"}, "sum": map[string]any{"totalAcceptedUsers": 3.0, "bits": 8.0}}},
+	}}}
+
+	// The groups of the partial results are recorded, while their errors are reported as a partial
+	// scrape error without counting as a failure of the zone.
+	for range 2 {
+		metrics, err := s.scrape(t.Context())
+		require.True(t, scrapererror.IsPartialScrapeError(err))
+		require.ErrorContains(t, err, "failed to query the waiting_room analytics of zone partial: response to /graphql holds a partial result: node n1 unavailable")
+		require.ErrorContains(t, err, "failed to query the spectrum custom query of zone partial: response to /graphql holds a partial result: node n1 unavailable")
+		require.Equal(t, 2, metrics.ResourceMetrics().Len())
+		require.Equal(t, 4, metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len())
+		require.Equal(t, "cloudflare.spectrum.bits", metrics.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics().At(0).Name())
+	}
+	require.Empty(t, s.breaker.states)
+}
+
+// limitedAnalyticsClient rejects the queries whose limit exceeds maxLimit, as Cloudflare does for
+// the queries that cost too much.
+type limitedAnalyticsClient struct {
+	*fakeAnalyticsClient
+	maxLimit int
+}
+
+func (f limitedAnalyticsClient) QueryGraphQL(ctx context.Context, query string, variables map[string]any, data any) error {
+	if limit := variables["limit"].(int); limit > f.maxLimit {
+		f.queries = append(f.queries, variables)
+		return errors.Join(&statusError{path: graphQLPath, statusCode: http.StatusOK, errorType: metadata.AttributeErrorTypeQueryLimit},
+			graphQLError{Message: fmt.Sprintf("limit must be less or equal to %d", f.maxLimit)})
+	}
+	return f.fakeAnalyticsClient.QueryGraphQL(ctx, query, variables, data)
+}
+
+func TestAnalyticsScraperLimit(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"healthy"},
+		Datasets:             []string{"waiting_room"},
+		DatasetOptions:       map[string]AnalyticsDatasetOptions{"waiting_room": {Limit: 1000}},
+	}
+	cfg.CollectionInterval = time.Minute
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	fake := &fakeAnalyticsClient{groups: map[string][]analyticsGroup{
+		"healthy": {{"dimensions": map[string]any{"waitingRoomId": "room"}, "sum": map[string]any{"totalAcceptedUsers": 3.0}}},
+	}}
+	s.tenants[0].client = limitedAnalyticsClient{fakeAnalyticsClient: fake, maxLimit: 300}
+
+	// The limit is halved until the query succeeds, and is kept for the following scrapes.
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	limits := func() []any {
+		var limits []any
+		for _, query := range fake.queries {
+			limits = append(limits, query["limit"])
+		}
+		return limits
+	}
+	require.Equal(t, []any{1000, 500, 250}, limits())
+
+	_, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []any{1000, 500, 250, 250}, limits())
+}
+
+// planAnalyticsClient rejects the queries of the workers dataset for the zones and accounts whose plan
+// lacks it.
+type planAnalyticsClient struct {
+	*fakeAnalyticsClient
+	lacking map[string]bool
+}
+
+func (f planAnalyticsClient) QueryGraphQL(ctx context.Context, query string, variables map[string]any, data any) error {
+	if tag := variables["tag"].(string); f.lacking[tag] && strings.Contains(query, "workersInvocationsAdaptive") {
+		f.queries = append(f.queries, variables)
+		return errors.Join(&statusError{path: graphQLPath, statusCode: http.StatusOK, errorType: metadata.AttributeErrorTypePlan},
+			graphQLError{Message: fmt.Sprintf("account '%s' does not have access to the path", tag)})
+	}
+	return f.fakeAnalyticsClient.QueryGraphQL(ctx, query, variables, data)
+}
+
+func TestAnalyticsScraperPlan(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Accounts:             []string{"free", "paid"},
+		Datasets:             []string{"workers"},
+		CircuitBreaker:       CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour},
+		PlanRecheckInterval:  time.Hour,
+	}
+	cfg.CollectionInterval = time.Minute
+	core, logs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.Logger = zap.New(core)
+	s, err := newAnalyticsScraper(settings, cfg)
+	require.NoError(t, err)
+	groups := []analyticsGroup{{"dimensions": map[string]any{"scriptName": "worker"}, "sum": map[string]any{"requests": 10.0}}}
+	fake := &fakeAnalyticsClient{groups: map[string][]analyticsGroup{"free": groups, "paid": groups}}
+	client := planAnalyticsClient{fakeAnalyticsClient: fake, lacking: map[string]bool{"free": true}}
+	s.tenants[0].client = client
+
+	// The account whose plan lacks the dataset is found by the probe on start, and logged once.
+	s.probe(t.Context())
+	require.Len(t, fake.queries, 2)
+	require.Equal(t, 1, logs.FilterMessage("The dataset isn't available on the plan, pausing its analytics queries").Len())
+
+	// It's then no longer queried, without counting as a failure of the account.
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	require.Len(t, fake.queries, 3)
+
+	// Once the recheck interval has elapsed, it's queried again and reported as a partial scrape error,
+	// without being logged again nor pausing the account.
+	s.unavailable["/workers/free"] = time.Now()
+	_, err = s.scrape(t.Context())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.ErrorContains(t, err, "the workers analytics aren't available for account free")
+	require.Len(t, fake.queries, 5)
+	require.Equal(t, 1, logs.FilterMessage("The dataset isn't available on the plan, pausing its analytics queries").Len())
+	require.Empty(t, s.breaker.states)
+
+	// Once the plan includes the dataset, it's queried on every scrape again.
+	client.lacking["free"] = false
+	s.unavailable["/workers/free"] = time.Now()
+	_, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, s.unavailable)
+}
+
+func TestAnalyticsScraperTelemetry(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	defer func() { require.NoError(t, tt.Shutdown(t.Context())) }()
+
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"healthy", "failing"},
+		Datasets:             []string{"waiting_room"},
+	}
+	cfg.CollectionInterval = time.Minute
+	s, err := newAnalyticsScraper(metadatatest.NewSettings(tt), cfg)
+	require.NoError(t, err)
+	s.tenants[0].client = &fakeAnalyticsClient{groups: map[string][]analyticsGroup{
+		"healthy": {
+			{"dimensions": map[string]any{"waitingRoomId": "room1"}, "sum": map[string]any{"totalAcceptedUsers": 3.0}},
+			{"dimensions": map[string]any{"waitingRoomId": "room2"}, "sum": map[string]any{"totalAcceptedUsers": 5.0}},
+		},
+	}}
+
+	// The groups returned are counted by dataset, the failing zone returning none.
+	_, err = s.scrape(t.Context())
+	require.Error(t, err)
+	metadatatest.AssertEqualCloudflareAPIGraphqlRows(t, tt, []metricdata.DataPoint[int64]{
+		{Value: 2, Attributes: attribute.NewSet(attribute.String(attrDataset, "waiting_room"))},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestAnalyticsScraperTenants(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("testdata", "analytics", "waiting_room.json"))
+	require.NoError(t, err)
+
+	// Every tenant is queried with its own token, the first tenant inheriting the token of the section.
+	var tokens []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(rw http.ResponseWriter, req *http.Request) {
+		tokens = append(tokens, req.Header.Get("Authorization"))
+		_, _ = rw.Write(response)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	cfg := &AnalyticsConfig{
+		APIConfig:            APIConfig{ClientConfig: clientConfig, APIToken: "abc123"},
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Datasets:             []string{"waiting_room"},
+		Tenants: []AnalyticsTenantConfig{
+			{Name: "acme", Zones: []string{testZoneID}, ResourceAttributes: map[string]string{"customer": "acme"}},
+			{Name: "globex", APIToken: "def456", Zones: []string{testZoneID}, ResourceAttributes: map[string]string{"customer": "globex"}},
+		},
+	}
+	cfg.CollectionInterval = time.Minute
+
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	// The datasets of the tenants are probed on start, and then queried by the scrape.
+	require.Equal(t, []string{"Bearer abc123", "Bearer def456", "Bearer abc123", "Bearer def456"}, tokens)
+
+	// The metrics of every tenant are reported under resources carrying its resource attributes.
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+	for i, customer := range []string{"acme", "globex"} {
+		attrs := metrics.ResourceMetrics().At(i).Resource().Attributes()
+		value, ok := attrs.Get("customer")
+		require.True(t, ok)
+		require.Equal(t, customer, value.Str())
+		zoneID, ok := attrs.Get("cloudflare.zone.id")
+		require.True(t, ok)
+		require.Equal(t, testZoneID, zoneID.Str())
+	}
+}
+
+func TestMapDataPointAttributes(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	sum := metrics.AppendEmpty().SetEmptySum().DataPoints()
+	gauge := metrics.AppendEmpty().SetEmptyGauge().DataPoints()
+	for _, point := range []struct {
+		country, colo string
+		value         int64
+	}{{"NL", "AMS", 2}, {"NL", "FRA", 3}, {"US", "IAD", 5}} {
+		for _, dps := range []pmetric.NumberDataPointSlice{sum, gauge} {
+			dp := dps.AppendEmpty()
+			dp.Attributes().PutStr("geo.country.iso_code", point.country)
+			dp.Attributes().PutStr("cloudflare.colo.code", point.colo)
+			dp.SetIntValue(point.value)
+		}
+	}
+
+	mapDataPointAttributes(md, map[string]string{"geo.country.iso_code": "client.geo.country", "cloudflare.colo.code": ""})
+
+	// The data points left with the same country are merged, summing sums and integer gauges.
+	for dps, values := range map[pmetric.NumberDataPointSlice][]int64{sum: {5, 5}, gauge: {5, 5}} {
+		require.Equal(t, len(values), dps.Len())
+		for i, value := range values {
+			require.Equal(t, value, dps.At(i).IntValue())
+			require.Equal(t, 1, dps.At(i).Attributes().Len())
+			_, ok := dps.At(i).Attributes().Get("client.geo.country")
+			require.True(t, ok)
+		}
+	}
+}
+
+func TestLimitCardinality(t *testing.T) {
+	md := pmetric.NewMetrics()
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum().DataPoints()
+	for _, point := range []struct {
+		email, action string
+		value         int64
+	}{
+		{"alice@example.com", "block", 10},
+		{"bob@example.com", "block", 1},
+		{"carol@example.com", "block", 2},
+		{"carol@example.com", "allow", 7},
+		{"dave@example.com", "allow", 4},
+	} {
+		dp := dps.AppendEmpty()
+		dp.Attributes().PutStr("user.email", point.email)
+		dp.Attributes().PutStr("cloudflare.action", point.action)
+		dp.SetIntValue(point.value)
+	}
+
+	limitCardinality(md, map[string]int{"user.email": 2, "cloudflare.action": 2})
+
+	// The users with the most requests are kept, the others being merged per action under other.
+	var points []string
+	for _, dp := range dps.All() {
+		email, _ := dp.Attributes().Get("user.email")
+		action, _ := dp.Attributes().Get("cloudflare.action")
+		points = append(points, fmt.Sprintf("%s %s %d", email.Str(), action.Str(), dp.IntValue()))
+	}
+	require.Equal(t, []string{
+		"alice@example.com block 10",
+		"other block 1",
+		"carol@example.com block 2",
+		"carol@example.com allow 7",
+		"other allow 4",
+	}, points)
+}
+
+func TestNonAdditiveGauges(t *testing.T) {
+	md := pmetric.NewMetrics()
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+	for _, point := range []struct {
+		colo  string
+		value float64
+	}{{"AMS", 0.5}, {"FRA", 0.25}, {"IAD", 1}} {
+		dp := dps.AppendEmpty()
+		dp.Attributes().PutStr("cloudflare.colo.code", point.colo)
+		dp.Attributes().PutStr("quantile", "p50")
+		dp.SetDoubleValue(point.value)
+	}
+
+	// The averages, quantiles and ratios can't be summed, and are neither merged nor put under other.
+	limitCardinality(md, map[string]int{"cloudflare.colo.code": 2})
+	require.Equal(t, 2, dps.Len())
+	require.Equal(t, 1.0, dps.At(1).DoubleValue())
+
+	mapDataPointAttributes(md, map[string]string{"cloudflare.colo.code": ""})
+	require.Equal(t, 2, dps.Len())
+	require.Equal(t, 0.5, dps.At(0).DoubleValue())
+	require.Equal(t, 1.0, dps.At(1).DoubleValue())
+}
+
+func TestAnalyticsScraperCustomQueries(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{testZoneID},
+		CustomQueries: []AnalyticsQueryConfig{{
+			Name:   "spectrum",
+			Node:   "spectrumNetworkAnalyticsAdaptiveGroups",
+			Fields: "dimensions { coloCode } sum { bits }",
+			Metrics: []AnalyticsQueryMetricConfig{
+				{Name: "cloudflare.spectrum.bits", Field: "sum.bits", Unit: "bit"},
+			},
+			Attributes: map[string]string{"dimensions.coloCode": "cloudflare.colo.code"},
+		}},
+	}
+	cfg.CollectionInterval = time.Minute
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	fake := &fakeAnalyticsClient{groups: map[string][]analyticsGroup{
+		testZoneID: {
+			{"dimensions": map[string]any{"coloCode": "AMS"}, "sum": map[string]any{"bits": 2048.0}},
+			{"dimensions": map[string]any{"coloCode": "FRA"}, "sum": map[string]any{"bits": 512.0}},
+		},
+	}}
+	s.tenants[0].client = fake
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Len(t, fake.queries, 1)
+
+	// The groups of the node are emitted as the configured metric under the resource of the zone.
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	rm := metrics.ResourceMetrics().At(0)
+	zoneID, ok := rm.Resource().Attributes().Get("cloudflare.zone.id")
+	require.True(t, ok)
+	require.Equal(t, testZoneID, zoneID.Str())
+	m := rm.ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, "cloudflare.spectrum.bits", m.Name())
+	require.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	require.Equal(t, 2, m.Sum().DataPoints().Len())
+	dp := m.Sum().DataPoints().At(0)
+	require.Equal(t, 2048.0, dp.DoubleValue())
+	require.Equal(t, map[string]any{"cloudflare.colo.code": "AMS"}, dp.Attributes().AsRaw())
+}
+
+func TestAnalyticsScraperDatasetOptions(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{testZoneID},
+		Datasets:             []string{"bot_management", "waiting_room"},
+		DatasetOptions: map[string]AnalyticsDatasetOptions{
+			"bot_management": {Limit: 100, OrderBy: []string{"count_DESC"}},
+		},
+	}
+	cfg.CollectionInterval = time.Minute
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	fake := &fakeAnalyticsClient{groups: map[string][]analyticsGroup{testZoneID: {}}}
+	s.tenants[0].client = fake
+
+	_, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Len(t, fake.queries, 2)
+	require.Equal(t, 100, fake.queries[0]["limit"])
+	require.Equal(t, analyticsLimit, fake.queries[1]["limit"])
+
+	// The groups of every node of the dataset are ordered.
+	query := analyticsDatasets["bot_management"].withOptions(cfg.DatasetOptions["bot_management"]).query()
+	require.Equal(t, len(analyticsDatasets["bot_management"].nodes), strings.Count(query, "orderBy: [count_DESC]"))
+	require.NotContains(t, analyticsDatasets["bot_management"].query(), "orderBy")
+}
+
+func TestAnalyticsScraperCumulative(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig:   metadata.DefaultMetricsBuilderConfig(),
+		Zones:                  []string{testZoneID},
+		Datasets:               []string{"waiting_room"},
+		AggregationTemporality: temporalityCumulative,
+	}
+	cfg.CollectionInterval = time.Minute
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	s.tenants[0].client = &fakeAnalyticsClient{groups: map[string][]analyticsGroup{
+		testZoneID: {{"dimensions": map[string]any{"waitingRoomId": "room"}, "sum": map[string]any{"totalAcceptedUsers": 3.0}, "max": map[string]any{"totalActiveUsers": 7.0}}},
+	}}
+
+	// The counts are emitted as the running totals since the first window, while gauges are unchanged.
+	var start pcommon.Timestamp
+	for _, total := range []int64{3, 6, 9} {
+		metrics, err := s.scrape(t.Context())
+		require.NoError(t, err)
+		for _, m := range metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().All() {
+			switch m.Name() {
+			case "cloudflare.waiting_room.accepted_users":
+				require.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+				dp := m.Sum().DataPoints().At(0)
+				require.Equal(t, total, dp.IntValue())
+				if start == 0 {
+					start = dp.StartTimestamp()
+				}
+				require.Equal(t, start, dp.StartTimestamp())
+			case "cloudflare.waiting_room.active_users":
+				require.Equal(t, int64(7), m.Gauge().DataPoints().At(0).IntValue())
+			}
+		}
+	}
+}
+
+func TestAnalyticsScraperCumulativeStorage(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	cfg := &AnalyticsConfig{
+		APIConfig:              APIConfig{ClientConfig: clientConfig, APIToken: "abc123"},
+		MetricsBuilderConfig:   metadata.DefaultMetricsBuilderConfig(),
+		Zones:                  []string{testZoneID},
+		Datasets:               []string{"waiting_room"},
+		AggregationTemporality: temporalityCumulative,
+		StorageID:              &storageID,
+	}
+	cfg.CollectionInterval = time.Minute
+	settings := receivertest.NewNopSettings(metadata.Type)
+	fake := &fakeAnalyticsClient{groups: map[string][]analyticsGroup{
+		testZoneID: {{"dimensions": map[string]any{"waitingRoomId": "room"}, "sum": map[string]any{"totalAcceptedUsers": 3.0}}},
+	}}
+	acceptedUsers := func(metrics pmetric.Metrics) int64 {
+		for _, m := range metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().All() {
+			if m.Name() == "cloudflare.waiting_room.accepted_users" {
+				return m.Sum().DataPoints().At(0).IntValue()
+			}
+		}
+		return 0
+	}
+
+	s, err := newAnalyticsScraper(settings, cfg)
+	require.NoError(t, err)
+	require.NoError(t, s.start(t.Context(), host))
+	s.tenants[0].client = fake
+	for range 2 {
+		_, err := s.scrape(t.Context())
+		require.NoError(t, err)
+	}
+	require.NoError(t, s.shutdown(t.Context()))
+
+	// The running totals are restored after a restart, rather than starting again from zero.
+	s, err = newAnalyticsScraper(settings, cfg)
+	require.NoError(t, err)
+	require.NoError(t, s.start(t.Context(), host))
+	defer func() { require.NoError(t, s.shutdown(t.Context())) }()
+	s.tenants[0].client = fake
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(9), acceptedUsers(metrics))
+}
+
+func TestAnalyticsScraperExemplars(t *testing.T) {
+	cfg := &AnalyticsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{testZoneID},
+		Datasets:             []string{"api_gateway"},
+		Exemplars:            true,
+	}
+	cfg.CollectionInterval = time.Minute
+	s, err := newAnalyticsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	s.tenants[0].client = &fakeAnalyticsClient{
+		groups: map[string][]analyticsGroup{testZoneID: {
+			{"count": 5.0, "dimensions": map[string]any{"apiGatewayMatchedHost": "api.example.com", "apiGatewayMatchedEndpoint": "/orders"}},
+			{"count": 2.0, "dimensions": map[string]any{"apiGatewayMatchedHost": "api.example.com", "apiGatewayMatchedEndpoint": "/users"}},
+		}},
+		events: map[string][]analyticsGroup{testZoneID: {
+			{"datetime": "2024-05-01T10:00:02Z", "rayName": "8a1f2b3c4d5e6f70", "apiGatewayMatchedHost": "api.example.com", "apiGatewayMatchedEndpoint": "/orders"},
+			{"datetime": "2024-05-01T10:00:01Z", "rayName": "8a1f2b3c4d5e6f71", "apiGatewayMatchedHost": "api.example.com", "apiGatewayMatchedEndpoint": "/orders"},
+		}},
+	}
+
+	// The events are queried along with the groups of the nodes with events.
+	dataset := analyticsDatasets["api_gateway"]
+	dataset.exemplars = true
+	require.Contains(t, dataset.query(), "e0: httpRequestsAdaptive(limit: 1000, "+
+		`filter: {datetime_geq: $since, datetime_lt: $until, apiGatewayMatchedEndpoint_neq: ""}, orderBy: [datetime_DESC]) {
+        datetime rayName apiGatewayMatchedHost apiGatewayMatchedEndpoint
+      }`)
+	require.NotContains(t, analyticsDatasets["api_gateway"].query(), "e0:")
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	var dps pmetric.NumberDataPointSlice
+	for _, m := range metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().All() {
+		if m.Name() == "cloudflare.api_gateway.requests" {
+			dps = m.Sum().DataPoints()
+		}
+	}
+	require.Equal(t, 2, dps.Len())
+
+	// The latest event of a group is its exemplar, while the groups without events get none.
+	exemplars := dps.At(0).Exemplars()
+	require.Equal(t, 1, exemplars.Len())
+	rayID := pcommon.SpanID{0x8a, 0x1f, 0x2b, 0x3c, 0x4d, 0x5e, 0x6f, 0x70}
+	require.Equal(t, rayID, exemplars.At(0).SpanID())
+	require.Equal(t, traceIDFromRayID(rayID), exemplars.At(0).TraceID())
+	require.Equal(t, pcommon.NewTimestampFromTime(time.Date(2024, 5, 1, 10, 0, 2, 0, time.UTC)), exemplars.At(0).Timestamp())
+	require.Equal(t, int64(1), exemplars.At(0).IntValue())
+	require.Equal(t, 0, dps.At(1).Exemplars().Len())
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// Codes of the errors reported by the Cloudflare API in the response envelope.
+const (
+	apiErrorCodeAuthentication = 10000 // Authentication error
+	apiErrorCodeUnauthorized   = 9109  // Unauthorized to access requested resource
+	apiErrorCodeInvalidToken   = 6111  // Invalid format for Authorization header
+	apiErrorCodeNoRoute        = 7003  // Could not route to the path, perhaps the identifier is invalid
+	apiErrorCodeNotFound       = 7000  // No route for that URI
+	apiErrorCodeThrottled      = 971   // Please wait and consider throttling your request speed
+	apiErrorCodeNotEntitled    = 1002  // The zone or account is not entitled to the feature
+)
+
+// errorTypeHints holds the actionable messages reported for the kinds of failures that have a
+// common cause.
+var errorTypeHints = map[metadata.AttributeErrorType]string{
+	metadata.AttributeErrorTypeAuthentication: "the API token is invalid, expired, or lacks the permission required by this request",
+	metadata.AttributeErrorTypeNotFound:       "the resource doesn't exist or the API token has no access to it, check the configured zones, accounts and queues",
+	metadata.AttributeErrorTypeRateLimited:    "the rate limit of the API token was exceeded, poll less often or use a separate token per section",
+	metadata.AttributeErrorTypePlan:           "the feature isn't available on the plan of the zone or account",
+	metadata.AttributeErrorTypeQueryLimit:     "the query exceeds the limits of the dataset, such as its maximum time range, retention or number of groups, poll more often, lower delay or set a smaller limit in dataset_options",
+}
+
+// graphQLErrorMessages maps the messages of the well-known failures of the GraphQL Analytics API to
+// their kind. The API reports them with a 200 status code and without documented error codes, so
+// they're matched by message, in order.
+var graphQLErrorMessages = []struct {
+	message   string
+	errorType metadata.AttributeErrorType
+}{
+	// Reported for the nodes missing from the plan of the zone or account, such as
+	// "zone '…' does not have access to the path".
+	{"does not have access to the path", metadata.AttributeErrorTypePlan},
+	{"not authorized", metadata.AttributeErrorTypeAuthentication},
+	{"not found", metadata.AttributeErrorTypeNotFound},
+	// The queries of every token share a budget of query cost over five minutes.
+	{"budget depleted", metadata.AttributeErrorTypeRateLimited},
+	{"cannot request data older than", metadata.AttributeErrorTypeQueryLimit},
+	{"time range", metadata.AttributeErrorTypeQueryLimit},
+	{"must be less or equal", metadata.AttributeErrorTypeQueryLimit},
+	{"exceeds", metadata.AttributeErrorTypeQueryLimit},
+}
+
+// graphQLLimitMessages holds the messages of the failures of the GraphQL queries that requested too
+// many groups, or cost too much, which a smaller limit may fix, unlike a window beyond the retention
+// of the dataset.
+var graphQLLimitMessages = []string{"must be less or equal", "exceeds"}
+
+// limitExceeded returns whether the query failed for requesting too many groups: its response exceeded
+// max_response_size, or Cloudflare rejected its limit or its cost.
+func limitExceeded(err error) bool {
+	if errors.Is(err, errResponseTooLarge) {
+		return true
+	}
+	var partialErr *partialResultError
+	var gqlErr graphQLError
+	if errors.As(err, &partialErr) || !errors.As(err, &gqlErr) {
+		return false
+	}
+	message := strings.ToLower(gqlErr.Message)
+	return slices.ContainsFunc(graphQLLimitMessages, func(limitMessage string) bool {
+		return strings.Contains(message, limitMessage)
+	})
+}
+
+// classifyAPIError returns the kind of failure of a request from its status code and the errors of
+// the response envelope. Apart from rate limiting and server errors, the error codes take precedence,
+// since Cloudflare doesn't use the other status codes consistently across its endpoints.
+func classifyAPIError(statusCode int, apiErrs []apiError) metadata.AttributeErrorType {
+	if errorType, ok := classifyStatusCode(statusCode); ok {
+		return errorType
+	}
+
+	for _, apiErr := range apiErrs {
+		switch apiErr.Code {
+		case apiErrorCodeNotEntitled:
+			return metadata.AttributeErrorTypePlan
+		case apiErrorCodeAuthentication, apiErrorCodeUnauthorized, apiErrorCodeInvalidToken:
+			return metadata.AttributeErrorTypeAuthentication
+		case apiErrorCodeNoRoute, apiErrorCodeNotFound:
+			return metadata.AttributeErrorTypeNotFound
+		case apiErrorCodeThrottled:
+			return metadata.AttributeErrorTypeRateLimited
+		}
+	}
+	return classifyFallbackStatusCode(statusCode)
+}
+
+// classifyGraphQLError returns the kind of failure of a query of the GraphQL Analytics API from its
+// status code and the errors of the response, the messages of the errors taking precedence as in
+// classifyAPIError.
+func classifyGraphQLError(statusCode int, gqlErrs []graphQLError) metadata.AttributeErrorType {
+	if errorType, ok := classifyStatusCode(statusCode); ok {
+		return errorType
+	}
+
+	for _, gqlErr := range gqlErrs {
+		message := strings.ToLower(gqlErr.Message)
+		for _, known := range graphQLErrorMessages {
+			if strings.Contains(message, known.message) {
+				return known.errorType
+			}
+		}
+	}
+	return classifyFallbackStatusCode(statusCode)
+}
+
+// classifyStatusCode returns the kind of failure of the status codes that take precedence over the
+// errors of the response.
+func classifyStatusCode(statusCode int) (metadata.AttributeErrorType, bool) {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return metadata.AttributeErrorTypeRateLimited, true
+	case statusCode >= http.StatusInternalServerError:
+		return metadata.AttributeErrorTypeOther, true
+	default:
+		return metadata.AttributeErrorType(0), false
+	}
+}
+
+// classifyFallbackStatusCode returns the kind of failure of a response whose errors are unknown.
+func classifyFallbackStatusCode(statusCode int) metadata.AttributeErrorType {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return metadata.AttributeErrorTypeAuthentication
+	case http.StatusNotFound:
+		return metadata.AttributeErrorTypeNotFound
+	default:
+		return metadata.AttributeErrorTypeOther
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+func TestClassifyAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		apiErrs    []apiError
+		expected   metadata.AttributeErrorType
+	}{
+		{
+			name:       "authentication error code",
+			statusCode: http.StatusBadRequest,
+			apiErrs:    []apiError{{Code: 10000, Message: "Authentication error"}},
+			expected:   metadata.AttributeErrorTypeAuthentication,
+		},
+		{
+			name:       "forbidden",
+			statusCode: http.StatusForbidden,
+			apiErrs:    []apiError{{Code: 1000, Message: "Invalid API Token"}},
+			expected:   metadata.AttributeErrorTypeAuthentication,
+		},
+		{
+			name:       "zone not found",
+			statusCode: http.StatusBadRequest,
+			apiErrs:    []apiError{{Code: 7003, Message: "Could not route to /zones/abc/logpush/jobs, perhaps your object identifier is invalid?"}},
+			expected:   metadata.AttributeErrorTypeNotFound,
+		},
+		{
+			name:       "not found",
+			statusCode: http.StatusNotFound,
+			expected:   metadata.AttributeErrorTypeNotFound,
+		},
+		{
+			name:       "rate limited",
+			statusCode: http.StatusTooManyRequests,
+			apiErrs:    []apiError{{Code: 10000, Message: "Rate limited"}},
+			expected:   metadata.AttributeErrorTypeRateLimited,
+		},
+		{
+			name:       "throttled",
+			statusCode: http.StatusBadRequest,
+			apiErrs:    []apiError{{Code: 971, Message: "Please wait and consider throttling your request speed"}},
+			expected:   metadata.AttributeErrorTypeRateLimited,
+		},
+		{
+			name:       "not entitled",
+			statusCode: http.StatusForbidden,
+			apiErrs:    []apiError{{Code: 1002, Message: "zone is not entitled to use Logpush"}},
+			expected:   metadata.AttributeErrorTypePlan,
+		},
+		{
+			name:       "message mentioning the plan",
+			statusCode: http.StatusBadRequest,
+			apiErrs:    []apiError{{Code: 1004, Message: "Invalid filter for the plan"}},
+			expected:   metadata.AttributeErrorTypeOther,
+		},
+		{
+			name:       "server error",
+			statusCode: http.StatusServiceUnavailable,
+			apiErrs:    []apiError{{Code: 10000, Message: "Internal error"}},
+			expected:   metadata.AttributeErrorTypeOther,
+		},
+		{
+			name:       "bad request",
+			statusCode: http.StatusBadRequest,
+			apiErrs:    []apiError{{Code: 1004, Message: "Invalid filter"}},
+			expected:   metadata.AttributeErrorTypeOther,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, classifyAPIError(tc.statusCode, tc.apiErrs))
+		})
+	}
+}
+
+func TestClassifyGraphQLError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		gqlErrs    []graphQLError
+		expected   metadata.AttributeErrorType
+	}{
+		{
+			name:       "node missing from the plan",
+			statusCode: http.StatusOK,
+			gqlErrs:    []graphQLError{{Message: "zone '023e105f4ecef8ad9ca31a8372d0c353' does not have access to the path"}},
+			expected:   metadata.AttributeErrorTypePlan,
+		},
+		{
+			name:       "not authorized",
+			statusCode: http.StatusOK,
+			gqlErrs:    []graphQLError{{Message: "not authorized for that account"}},
+			expected:   metadata.AttributeErrorTypeAuthentication,
+		},
+		{
+			name:       "budget depleted",
+			statusCode: http.StatusOK,
+			gqlErrs:    []graphQLError{{Message: "rate limiter budget depleted, try again after 5 minutes"}},
+			expected:   metadata.AttributeErrorTypeRateLimited,
+		},
+		{
+			name:       "retention",
+			statusCode: http.StatusOK,
+			gqlErrs:    []graphQLError{{Message: "cannot request data older than 691200s"}},
+			expected:   metadata.AttributeErrorTypeQueryLimit,
+		},
+		{
+			name:       "unknown message",
+			statusCode: http.StatusOK,
+			gqlErrs:    []graphQLError{{Message: "unknown field"}},
+			expected:   metadata.AttributeErrorTypeOther,
+		},
+		{
+			name:       "forbidden",
+			statusCode: http.StatusForbidden,
+			expected:   metadata.AttributeErrorTypeAuthentication,
+		},
+		{
+			name:       "server error",
+			statusCode: http.StatusBadGateway,
+			gqlErrs:    []graphQLError{{Message: "zone not found"}},
+			expected:   metadata.AttributeErrorTypeOther,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, classifyGraphQLError(tc.statusCode, tc.gqlErrs))
+		})
+	}
+}
+
+func TestLimitExceeded(t *testing.T) {
+	require.True(t, limitExceeded(fmt.Errorf("response exceeds max_response_size: %w", errResponseTooLarge)))
+	require.True(t, limitExceeded(errors.Join(errors.New("request failed"), graphQLError{Message: "limit must be less or equal to 2500"})))
+	require.False(t, limitExceeded(errors.Join(errors.New("request failed"), graphQLError{Message: "cannot request data older than 691200s"})))
+	require.False(t, limitExceeded(&partialResultError{path: graphQLPath, errs: []error{graphQLError{Message: "query exceeds the budget"}}}))
+	require.False(t, limitExceeded(nil))
+}
+
+func TestClientErrorHint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, err := rw.Write([]byte(`{"success":false,"errors":[{"code":7003,"message":"Could not route"}],"result":null}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(t, server.URL, newDefaultAPIConfig().BackOffConfig).GetZone(t.Context(), testZoneID)
+	require.EqualError(t, err, "request to /zones/"+testZoneID+" failed with status code 400: the resource doesn't exist or the API token "+
+		"has no access to it, check the configured zones, accounts and queues\n7003: Could not route")
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	rcvr "go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// Attributes set on audit log records.
+const (
+	attrAuditLogID        = "cloudflare.audit_log.id"
+	attrAuditActorID      = "cloudflare.audit_log.actor.id"
+	attrAuditActorType    = "cloudflare.audit_log.actor.type"
+	attrAuditActionType   = "cloudflare.audit_log.action.type"
+	attrAuditActionResult = "cloudflare.audit_log.action.result"
+	attrAuditInterface    = "cloudflare.audit_log.interface"
+	attrAuditResourceID   = "cloudflare.audit_log.resource.id"
+	attrAuditResourceType = "cloudflare.audit_log.resource.type"
+)
+
+// auditLogResultSuccess is the result of the actions that succeeded.
+const auditLogResultSuccess = "success"
+
+// auditLogsReceiver polls the Cloudflare API for the audit logs of accounts and emits them as
+// log records.
+type auditLogsReceiver struct {
+	*accountPoller
+	cfg *AuditLogsConfig
+
+	// windows holds the window of audit logs being collected, keyed by account ID.
+	windows map[string]*auditLogsWindow
+	// clamped holds the accounts whose window was clamped to the retention, which is only logged once.
+	clamped map[string]bool
+}
+
+// auditLogsWindow is the time range of the audit logs of an account being collected. The API pages
+// through the range with a cursor, so a poll failing part way resumes at the cursor of the page that
+// failed, and the next range starts where the collected one ends.
+type auditLogsWindow struct {
+	since  time.Time
+	before time.Time
+	// cursor is the cursor of the next page of the range, empty when no range is being collected.
+	cursor string
+}
+
+func newAuditLogsReceiver(params rcvr.Settings, cfg *AuditLogsConfig, consumer consumer.Logs) (*auditLogsReceiver, error) {
+	poller, err := newAccountPoller(params, consumer)
+	if err != nil {
+		return nil, err
+	}
+	poller.apiCfg = &cfg.APIConfig
+	poller.pollInterval = cfg.PollInterval
+	poller.alignWindow = cfg.AlignWindow
+	poller.section = "audit_logs"
+	poller.permission = auditLogsPermission
+	poller.events = "audit logs"
+	poller.accountIDs = cfg.Accounts
+	poller.pageSize = cfg.PageSize
+
+	r := &auditLogsReceiver{
+		accountPoller: poller,
+		cfg:           cfg,
+		windows:       map[string]*auditLogsWindow{},
+		clamped:       map[string]bool{},
+	}
+	poller.pollAccount = r.pollAccount
+	return r, nil
+}
+
+// pollAccount pages through the audit logs of the account written between the end of the last
+// collected range and before. The cursor only moves forward once a page has been consumed, so a
+// failed poll resumes at the page that failed, without emitting the consumed pages again.
+func (r *auditLogsReceiver) pollAccount(ctx context.Context, accountID string, before time.Time) error {
+	w, ok := r.windows[accountID]
+	if !ok {
+		w = &auditLogsWindow{since: r.started}
+		r.windows[accountID] = w
+	}
+	if w.cursor == "" {
+		oldest := before.Add(-r.cfg.Retention)
+		if r.cfg.Retention > 0 && w.since.Before(oldest) {
+			w.since = oldest
+			if !r.clamped[accountID] {
+				r.clamped[accountID] = true
+				r.logger.Warn("Skipping the audit logs older than the retention, which can't be collected anymore",
+					zap.String("account", accountID),
+					zap.Duration("retention", r.cfg.Retention))
+			}
+		}
+		// Aligned windows may not have moved since the last poll.
+		if !before.After(w.since) {
+			return nil
+		}
+		w.before = before
+	}
+
+	for {
+		page, err := r.client.ListAuditLogs(ctx, accountID, w.since, w.before, w.cursor, r.pageSize)
+		if shrinkPageSize(r.logger, &r.pageSize, err) {
+			// The cursor points at the next entry, so the page is requested again with the smaller size.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(page.Items) > 0 {
+			if err := r.consume(ctx, r.processEntries(pcommon.NewTimestampFromTime(time.Now()), accountID, page.Items)); err != nil {
+				return err
+			}
+		}
+
+		if page.Cursor == "" || len(page.Items) == 0 {
+			w.since, w.cursor = w.before, ""
+			return nil
+		}
+		w.cursor = page.Cursor
+	}
+}
+
+func (r *auditLogsReceiver) processEntries(now pcommon.Timestamp, accountID string, entries []auditLog) plog.Logs {
+	logs := plog.NewLogs()
+	resourceLogs, scopeLogs := appendResourceLogs(logs, r.buildInfo)
+	resourceLogs.Resource().Attributes().PutStr(attrAccountID, accountID)
+
+	for _, entry := range entries {
+		logRecord := scopeLogs.LogRecords().AppendEmpty()
+		logRecord.SetObservedTimestamp(now)
+		logRecord.SetTimestamp(pcommon.NewTimestampFromTime(entry.Action.Time))
+
+		// Failed actions are worth a closer look, e.g. repeated attempts to change settings
+		// without the necessary permissions.
+		sev := plog.SeverityNumberInfo
+		if entry.Action.Result != auditLogResultSuccess {
+			sev = plog.SeverityNumberWarn
+		}
+		logRecord.SetSeverityNumber(sev)
+		logRecord.SetSeverityText(sev.String())
+
+		attrs := logRecord.Attributes()
+		attrs.PutStr(attrAuditLogID, entry.ID)
+		attrs.PutStr(attrAuditActionType, entry.Action.Type)
+		attrs.PutBool(attrAuditActionResult, entry.Action.Result == auditLogResultSuccess)
+		attrs.PutStr(attrAuditActorID, entry.Actor.ID)
+		attrs.PutStr(attrAuditActorType, entry.Actor.Type)
+		if entry.Actor.Email != "" {
+			attrs.PutStr(attrUserEmail, entry.Actor.Email)
+		}
+		if entry.Actor.IPAddress != "" {
+			attrs.PutStr(attrClientAddress, entry.Actor.IPAddress)
+		}
+		if entry.Actor.Context != "" {
+			attrs.PutStr(attrAuditInterface, entry.Actor.Context)
+		}
+		attrs.PutStr(attrAuditResourceID, entry.Resource.ID)
+		attrs.PutStr(attrAuditResourceType, entry.Resource.Type)
+
+		if err := logRecord.Body().SetEmptyMap().FromRaw(entry.raw); err != nil {
+			r.logger.Warn("unable to set body", zap.Error(err))
+		}
+	}
+
+	return logs
+}
@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// fakeAuditLogsClient serves a fixed list of entries, emulating the filtering and cursor paging of the
+// API, and failing pages larger than maxLimit as too large if set, or every page if negative.
+type fakeAuditLogsClient struct {
+	client
+	entries  []auditLog
+	cursors  []string
+	maxLimit int
+}
+
+func (f *fakeAuditLogsClient) ListAuditLogs(_ context.Context, _ string, since, before time.Time, cursor string, limit int) (cursorPage[auditLog], error) {
+	f.cursors = append(f.cursors, cursor)
+	if f.maxLimit != 0 && limit > f.maxLimit {
+		return cursorPage[auditLog]{}, fmt.Errorf("response exceeds max_response_size: %w", errResponseTooLarge)
+	}
+	var matching []auditLog
+	for _, entry := range f.entries {
+		if !entry.Action.Time.Before(since) && entry.Action.Time.Before(before) {
+			matching = append(matching, entry)
+		}
+	}
+	// The cursor is the index of the first entry of the page.
+	start := 0
+	if cursor != "" {
+		start, _ = strconv.Atoi(cursor)
+	}
+	start = min(start, len(matching))
+	end := min(start+limit, len(matching))
+	page := cursorPage[auditLog]{Items: matching[start:end]}
+	if end < len(matching) {
+		page.Cursor = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+func newAuditLog(id string, when time.Time, result string) auditLog {
+	entry := auditLog{ID: id, raw: map[string]any{"id": id}}
+	entry.Action.Type = "zone_setting_update"
+	entry.Action.Result = result
+	entry.Action.Time = when
+	entry.Actor.ID = "f6b5de0326bb5182b8a4840ee01ec774"
+	entry.Actor.Email = "user@example.com"
+	entry.Actor.IPAddress = "198.41.129.166"
+	entry.Actor.Type = "user"
+	entry.Actor.Context = "dash"
+	entry.Resource.ID = "023e105f4ecef8ad9ca31a8372d0c353"
+	entry.Resource.Type = "zone"
+	return entry
+}
+
+func newTestAuditLogsReceiver(t *testing.T, next consumer.Logs, c client, pageSize int) *auditLogsReceiver {
+	r, err := newAuditLogsReceiver(receivertest.NewNopSettings(metadata.Type), &AuditLogsConfig{
+		Accounts:     []string{testAccountID},
+		PollInterval: time.Minute,
+		PageSize:     pageSize,
+	}, next)
+	require.NoError(t, err)
+	r.client = c
+	return r
+}
+
+func TestAuditLogsPollAccount(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAuditLogsClient{
+		entries: []auditLog{
+			newAuditLog("id1", start.Add(time.Second), "success"),
+			newAuditLog("id2", start.Add(time.Second), "success"),
+			newAuditLog("id3", start.Add(time.Second), "failure"),
+			newAuditLog("id4", start.Add(2*time.Second), "success"),
+		},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestAuditLogsReceiver(t, sink, fake, 2)
+	r.started = start
+
+	before := start.Add(time.Minute)
+	require.NoError(t, r.pollAccount(t.Context(), testAccountID, before))
+	require.Equal(t, []string{"id1", "id2", "id3", "id4"}, emittedAuditLogIDs(sink))
+	require.Equal(t, []string{"", "2"}, fake.cursors)
+
+	// The next poll starts where the collected range ended.
+	fake.entries = append(fake.entries,
+		newAuditLog("id5", start.Add(30*time.Second), "success"),
+		newAuditLog("id6", start.Add(90*time.Second), "success"))
+	require.NoError(t, r.pollAccount(t.Context(), testAccountID, start.Add(2*time.Minute)))
+	require.Equal(t, []string{"id1", "id2", "id3", "id4", "id6"}, emittedAuditLogIDs(sink))
+}
+
+func TestAuditLogsPollAccountShrinksPageSize(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAuditLogsClient{
+		entries: []auditLog{
+			newAuditLog("id1", start.Add(time.Second), "success"),
+			newAuditLog("id2", start.Add(2*time.Second), "success"),
+			newAuditLog("id3", start.Add(3*time.Second), "success"),
+		},
+		maxLimit: 2,
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestAuditLogsReceiver(t, sink, fake, 5)
+	r.started = start
+
+	require.NoError(t, r.pollAccount(t.Context(), testAccountID, start.Add(time.Minute)))
+	require.Equal(t, []string{"id1", "id2", "id3"}, emittedAuditLogIDs(sink))
+	require.Equal(t, []string{"", "", "2"}, fake.cursors)
+	// The reduced page size is kept for the next polls.
+	require.Equal(t, 2, r.pageSize)
+
+	// Once the page size is down to 1, it can't be reduced any further.
+	fake.maxLimit = -1
+	require.ErrorIs(t, r.pollAccount(t.Context(), testAccountID, start.Add(2*time.Minute)), errResponseTooLarge)
+}
+
+func TestAuditLogsPollAccountConsumerError(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAuditLogsClient{
+		entries: []auditLog{
+			newAuditLog("id1", start.Add(time.Second), "success"),
+			newAuditLog("id2", start.Add(2*time.Second), "success"),
+		},
+	}
+	next := &failingLogsConsumer{failAt: 2}
+	r := newTestAuditLogsReceiver(t, next, fake, 1)
+	r.started = start
+
+	before := start.Add(time.Minute)
+	require.ErrorContains(t, r.pollAccount(t.Context(), testAccountID, before), "consumer failed")
+	require.Equal(t, []string{"id1"}, emittedAuditLogIDs(&next.LogsSink))
+
+	// The next poll resumes at the page that failed, within the same range.
+	require.NoError(t, r.pollAccount(t.Context(), testAccountID, start.Add(2*time.Minute)))
+	require.Equal(t, []string{"id1", "id2"}, emittedAuditLogIDs(&next.LogsSink))
+	require.Equal(t, []string{"", "1", "1"}, fake.cursors)
+	require.Equal(t, before, r.windows[testAccountID].since)
+}
+
+func TestAuditLogsPollAccountRetention(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeAuditLogsClient{
+		entries: []auditLog{
+			newAuditLog("id1", start.Add(time.Hour), "success"),
+			newAuditLog("id2", start.Add(3*time.Hour), "success"),
+		},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestAuditLogsReceiver(t, sink, fake, 10)
+	r.cfg.Retention = 2 * time.Hour
+	r.started = start
+
+	// The audit logs older than the retention are no longer requested.
+	require.NoError(t, r.pollAccount(t.Context(), testAccountID, start.Add(4*time.Hour)))
+	require.Equal(t, []string{"id2"}, emittedAuditLogIDs(sink))
+	require.True(t, r.clamped[testAccountID])
+}
+
+// failingLogsConsumer fails the failAt-th call, and records the logs of the others.
+type failingLogsConsumer struct {
+	consumertest.LogsSink
+	calls  int
+	failAt int
+}
+
+func (f *failingLogsConsumer) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
+	f.calls++
+	if f.calls == f.failAt {
+		return errors.New("consumer failed")
+	}
+	return f.LogsSink.ConsumeLogs(ctx, logs)
+}
+
+func TestAuditLogsProcessEntries(t *testing.T) {
+	when := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	failed := newAuditLog("d5b0f326-1232-4452-8858-1089bd7168ef", when, "failure")
+
+	r := newTestAuditLogsReceiver(t, consumertest.NewNop(), nil, 2)
+	logs := r.processEntries(pcommon.NewTimestampFromTime(time.Now()), testAccountID, []auditLog{failed})
+
+	expected := plog.NewLogs()
+	rl := expected.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl(conventions.SchemaURL)
+	rl.Resource().Attributes().PutStr("cloudflare.account.id", testAccountID)
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(metadata.ScopeName)
+	sl.Scope().SetVersion("latest")
+	sl.SetSchemaUrl(conventions.SchemaURL)
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(when))
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText(plog.SeverityNumberWarn.String())
+	require.NoError(t, lr.Attributes().FromRaw(map[string]any{
+		"cloudflare.audit_log.id":            "d5b0f326-1232-4452-8858-1089bd7168ef",
+		"cloudflare.audit_log.action.type":   "zone_setting_update",
+		"cloudflare.audit_log.action.result": false,
+		"cloudflare.audit_log.actor.id":      "f6b5de0326bb5182b8a4840ee01ec774",
+		"cloudflare.audit_log.actor.type":    "user",
+		"user.email":                         "user@example.com",
+		"client.address":                     "198.41.129.166",
+		"cloudflare.audit_log.interface":     "dash",
+		"cloudflare.audit_log.resource.id":   "023e105f4ecef8ad9ca31a8372d0c353",
+		"cloudflare.audit_log.resource.type": "zone",
+	}))
+	require.NoError(t, lr.Body().SetEmptyMap().FromRaw(map[string]any{"id": "d5b0f326-1232-4452-8858-1089bd7168ef"}))
+
+	require.NoError(t, plogtest.CompareLogs(expected, logs, plogtest.IgnoreObservedTimestamp()))
+}
+
+func TestListAuditLogs(t *testing.T) {
+	since := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	before := since.Add(time.Minute)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/accounts/"+testAccountID+"/logs/audit", req.URL.Path)
+		require.Equal(t, "2024-05-01T10:00:00Z", req.URL.Query().Get("since"))
+		require.Equal(t, "2024-05-01T10:01:00Z", req.URL.Query().Get("before"))
+		require.Equal(t, "Mzk1MjM2NTY3OTU", req.URL.Query().Get("cursor"))
+		require.Equal(t, "50", req.URL.Query().Get("limit"))
+		require.Equal(t, "asc", req.URL.Query().Get("direction"))
+		require.NoError(t, json.NewEncoder(rw).Encode(map[string]any{
+			"success": true,
+			"result": []map[string]any{{
+				"id":       "d5b0f326-1232-4452-8858-1089bd7168ef",
+				"action":   map[string]any{"type": "create", "result": "success", "time": "2024-05-01T10:00:30Z"},
+				"actor":    map[string]any{"email": "user@example.com", "type": "user", "ip_address": "198.41.129.166", "context": "api_token"},
+				"resource": map[string]any{"id": "4e8cd1e2", "type": "account"},
+			}},
+			"result_info": map[string]any{"count": 1, "cursor": "ASqdKd7dKgxh-aZ8bm0mZos1BtW4BdEqifCzNkEeGRzi"},
+		}))
+	}))
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	c, err := newClient(t.Context(), &APIConfig{ClientConfig: clientConfig, APIToken: "abc123"}, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	page, err := c.ListAuditLogs(t.Context(), testAccountID, since, before, "Mzk1MjM2NTY3OTU", 50)
+	require.NoError(t, err)
+	require.Equal(t, "ASqdKd7dKgxh-aZ8bm0mZos1BtW4BdEqifCzNkEeGRzi", page.Cursor)
+	require.Len(t, page.Items, 1)
+	entry := page.Items[0]
+	require.Equal(t, "d5b0f326-1232-4452-8858-1089bd7168ef", entry.ID)
+	require.Equal(t, "create", entry.Action.Type)
+	require.Equal(t, "success", entry.Action.Result)
+	require.Equal(t, since.Add(30*time.Second), entry.Action.Time)
+	require.Equal(t, "user@example.com", entry.Actor.Email)
+	require.Equal(t, "198.41.129.166", entry.Actor.IPAddress)
+	require.Equal(t, "api_token", entry.Actor.Context)
+	require.Equal(t, "account", entry.raw["resource"].(map[string]any)["type"])
+}
+
+func emittedAuditLogIDs(sink *consumertest.LogsSink) []string {
+	var ids []string
+	for _, logs := range sink.AllLogs() {
+		records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < records.Len(); i++ {
+			id, _ := records.At(i).Attributes().Get("cloudflare.audit_log.id")
+			ids = append(ids, id.Str())
+		}
+	}
+	return ids
+}
@@ -0,0 +1,338 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	rcvr "go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// bucketCheckpointKey is the storage key of the processed files of a bucket.
+const bucketCheckpointKey = "checkpoint"
+
+// bucketCheckpoint is the persisted state of a bucket poller.
+type bucketCheckpoint struct {
+	Processed []string `json:"processed"`
+}
+
+// bucketObject is a Logpush output file stored in a bucket.
+type bucketObject struct {
+	Key          string
+	LastModified time.Time
+}
+
+// bucketClient is a minimal client for the S3 API of a bucket.
+type bucketClient interface {
+	// ListObjects lists the objects whose key starts with prefix and sorts after startAfter, in key order.
+	ListObjects(ctx context.Context, prefix, startAfter string) ([]bucketObject, error)
+	// GetObject returns the content of the object.
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+var _ bucketClient = (*s3BucketClient)(nil)
+
+type s3BucketClient struct {
+	client *s3.Client
+	bucket string
+}
+
+// newS3BucketClient returns a client of the bucket served by the S3 API at endpoint, or by AWS S3 if
+// endpoint is empty. Without access keys, the credentials are loaded from the default AWS chain.
+func newS3BucketClient(ctx context.Context, cfg *BucketConfig, endpoint, region string) (*s3BucketClient, error) {
+	opts := s3.Options{Region: region}
+	if endpoint != "" {
+		opts.BaseEndpoint = aws.String(endpoint)
+		opts.UsePathStyle = true
+	}
+
+	if cfg.AccessKeyID != "" {
+		opts.Credentials = credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, string(cfg.SecretAccessKey), "")
+	} else {
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS credentials: %w", err)
+		}
+		opts.Credentials = awsCfg.Credentials
+	}
+
+	return &s3BucketClient{client: s3.New(opts), bucket: cfg.Bucket}, nil
+}
+
+func (c *s3BucketClient) ListObjects(ctx context.Context, prefix, startAfter string) ([]bucketObject, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucket),
+		Prefix: aws.String(prefix),
+	}
+	if startAfter != "" {
+		input.StartAfter = aws.String(startAfter)
+	}
+
+	var objects []bucketObject
+	paginator := s3.NewListObjectsV2Paginator(c.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range page.Contents {
+			objects = append(objects, bucketObject{
+				Key:          aws.ToString(object.Key),
+				LastModified: aws.ToTime(object.LastModified),
+			})
+		}
+	}
+	return objects, nil
+}
+
+func (c *s3BucketClient) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+// bucketReceiver polls a bucket for the files written by a Logpush job and emits their logs.
+type bucketReceiver struct {
+	id        component.ID
+	name      string
+	cfg       *BucketConfig
+	logger    *zap.Logger
+	consumer  consumer.Logs
+	obsrecv   *receiverhelper.ObsReport
+	client    bucketClient
+	newClient func(context.Context) (bucketClient, error)
+	storage   storage.Client
+
+	// converter converts the logs of the files like the Logpush endpoint does.
+	converter *logsConverter
+	dataset   *DatasetConfig
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+
+	// since is the time the receiver started. Files written before are not collected, unless a
+	// checkpoint was restored from storage.
+	since time.Time
+	// processed holds the keys of the processed files that are still listed, which are the ones of
+	// the lookback window, and of the files collected after a restart until a poll completes.
+	processed map[string]struct{}
+}
+
+// newBucketReceiver returns a receiver polling the bucket. The name identifies the bucket among the
+// sources of the receiver, such as in its storage.
+func newBucketReceiver(params rcvr.Settings, cfg *Config, name string, bucketCfg *BucketConfig, newClient func(context.Context) (bucketClient, error), consumer consumer.Logs) (*bucketReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             params.ID,
+		Transport:              "http",
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := newLogsConverter(params, &cfg.Logs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bucketReceiver{
+		id:        params.ID,
+		name:      name,
+		cfg:       bucketCfg,
+		logger:    params.Logger,
+		consumer:  consumer,
+		obsrecv:   obsrecv,
+		newClient: newClient,
+		processed: map[string]struct{}{},
+		converter: converter,
+		dataset:   converter.defaultDataset.merge(DatasetConfig{Dataset: bucketCfg.Dataset}),
+	}, nil
+}
+
+func (r *bucketReceiver) Start(ctx context.Context, host component.Host) error {
+	client, err := r.newClient(ctx)
+	if err != nil {
+		return err
+	}
+	r.client = client
+	r.since = time.Now()
+
+	r.storage, err = getStorageClient(ctx, host, r.cfg.StorageID, r.id, r.name)
+	if err != nil {
+		return err
+	}
+	data, err := r.storage.Get(ctx, bucketCheckpointKey)
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if data != nil {
+		var checkpoint bucketCheckpoint
+		if err := json.Unmarshal(data, &checkpoint); err != nil {
+			return fmt.Errorf("failed to decode checkpoint: %w", err)
+		}
+		for _, key := range checkpoint.Processed {
+			r.processed[key] = struct{}{}
+		}
+		// Files written while the collector was down are collected too.
+		r.since = time.Time{}
+	}
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.startPolling(pollCtx)
+	return nil
+}
+
+func (r *bucketReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	r.converter.shutdown()
+	if r.storage != nil {
+		return r.storage.Close(ctx)
+	}
+	return nil
+}
+
+func (r *bucketReceiver) startPolling(ctx context.Context) {
+	defer r.wg.Done()
+
+	t := time.NewTicker(r.cfg.PollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := r.poll(ctx, time.Now()); err != nil {
+				r.logger.Error("Failed to collect Logpush files", zap.String("source", r.name), zap.String("bucket", r.cfg.Bucket), zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll emits the logs of the files of the lookback window that weren't processed yet, including the
+// ones written late with a key sorting before already processed files. A file is only recorded as
+// processed once it has been consumed, so failed files are retried on the next poll.
+func (r *bucketReceiver) poll(ctx context.Context, now time.Time) error {
+	objects, err := r.client.ListObjects(ctx, r.prefix(), r.startAfter(now))
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	for _, object := range objects {
+		if _, ok := r.processed[object.Key]; ok || object.LastModified.Before(r.since) {
+			continue
+		}
+		if err := r.processObject(ctx, object.Key); err != nil {
+			return fmt.Errorf("failed to process file %q: %w", object.Key, err)
+		}
+		r.processed[object.Key] = struct{}{}
+		if err := r.saveCheckpoint(ctx); err != nil {
+			return err
+		}
+	}
+
+	// The files before the lookback window are no longer listed, so they can be forgotten.
+	windowStart := r.windowStart(now)
+	for key := range r.processed {
+		if key <= windowStart {
+			delete(r.processed, key)
+		}
+	}
+	return r.saveCheckpoint(ctx)
+}
+
+// prefix returns the prefix of the keys of the Logpush job's files, which is a folder of the bucket.
+func (r *bucketReceiver) prefix() string {
+	if r.cfg.Prefix != "" && !strings.HasSuffix(r.cfg.Prefix, "/") {
+		return r.cfg.Prefix + "/"
+	}
+	return r.cfg.Prefix
+}
+
+// windowStart returns the key after which the files of the lookback window are listed. Logpush
+// prefixes the name of its files, or of the folders holding them, with their date, so the window
+// starts at the beginning of the day of now minus the lookback.
+func (r *bucketReceiver) windowStart(now time.Time) string {
+	return r.prefix() + now.UTC().Add(-r.cfg.Lookback).Format("20060102")
+}
+
+// startAfter returns the key after which files are listed. It's the start of the lookback window,
+// unless files older than the window were processed before a restart, in which case listing resumes
+// at the oldest of them so that the files written while the collector was down are collected.
+func (r *bucketReceiver) startAfter(now time.Time) string {
+	startAfter := r.windowStart(now)
+	for key := range r.processed {
+		startAfter = min(startAfter, key)
+	}
+	return startAfter
+}
+
+func (r *bucketReceiver) saveCheckpoint(ctx context.Context) error {
+	checkpoint := bucketCheckpoint{Processed: slices.Sorted(maps.Keys(r.processed))}
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := r.storage.Set(ctx, bucketCheckpointKey, data); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (r *bucketReceiver) processObject(ctx context.Context, key string) error {
+	body, err := r.client.GetObject(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// Logpush files are gzip-compressed, which is detected from the magic bytes.
+	logs, rejected, _, err := r.converter.readLogs(body, "", nil)
+	if err != nil {
+		return err
+	}
+
+	pLogs := r.converter.processDatasetLogs(ctx, pcommon.NewTimestampFromTime(time.Now()), logs, rejected, r.dataset)
+	if pLogs.LogRecordCount() == 0 {
+		return nil
+	}
+
+	obsCtx := r.obsrecv.StartLogsOp(ctx)
+	err = r.consumer.ConsumeLogs(obsCtx, pLogs)
+	r.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), pLogs.LogRecordCount(), err)
+	if err != nil {
+		return errors.Join(errors.New("failed to consume logs"), err)
+	}
+	return nil
+}
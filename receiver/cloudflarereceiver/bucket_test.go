@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// fakeBucketClient serves fixed objects, emulating the ordering of the S3 API.
+type fakeBucketClient struct {
+	objects  []bucketObject
+	contents map[string]string
+}
+
+func (f *fakeBucketClient) ListObjects(_ context.Context, prefix, startAfter string) ([]bucketObject, error) {
+	var objects []bucketObject
+	for _, object := range f.objects {
+		if strings.HasPrefix(object.Key, prefix) && object.Key > startAfter {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+func (f *fakeBucketClient) GetObject(_ context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(f.contents[key])), nil
+}
+
+func newTestBucketReceiver(t *testing.T, fake *fakeBucketClient, next consumer.Logs) *bucketReceiver {
+	cfg := createDefaultConfig().(*Config)
+	bucketCfg := &BucketConfig{Bucket: "logpush", Prefix: "http_requests/", Dataset: "http_requests", PollInterval: time.Minute, Lookback: 24 * time.Hour}
+	r, err := newBucketReceiver(receivertest.NewNopSettings(metadata.Type), cfg, "r2", bucketCfg, func(context.Context) (bucketClient, error) { return fake, nil }, next)
+	require.NoError(t, err)
+	r.client = fake
+	r.storage = storage.NewNopClient()
+	return r
+}
+
+func TestBucketPoll(t *testing.T) {
+	since := time.Date(2023, 3, 3, 5, 0, 0, 0, time.UTC)
+	fake := &fakeBucketClient{
+		objects: []bucketObject{
+			{Key: "http_requests/20230303/20230303T045900Z_20230303T050000Z_a.log.gz", LastModified: since.Add(-time.Minute)},
+			{Key: "http_requests/20230303/20230303T050000Z_20230303T050100Z_b.log.gz", LastModified: since.Add(time.Minute)},
+		},
+		contents: map[string]string{
+			"http_requests/20230303/20230303T050000Z_20230303T050100Z_b.log.gz": gzippedMessage(
+				`{"EdgeStartTimestamp":"2023-03-03T05:00:05Z","RayID":"1"}` + "\n" + `{"EdgeStartTimestamp":"2023-03-03T05:00:06Z","RayID":"2"}`),
+		},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestBucketReceiver(t, fake, sink)
+	r.since = since
+
+	require.NoError(t, r.poll(t.Context(), since.Add(time.Minute)))
+	// The file written before the receiver started is skipped.
+	require.Equal(t, 2, sink.LogRecordCount())
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	dataset, _ := rl.Resource().Attributes().Get(attrDataset)
+	require.Equal(t, "http_requests", dataset.Str())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	require.Equal(t, time.Date(2023, 3, 3, 5, 0, 5, 0, time.UTC), lr.Timestamp().AsTime())
+	require.Contains(t, r.processed, "http_requests/20230303/20230303T050000Z_20230303T050100Z_b.log.gz")
+
+	// Polling again skips the processed files.
+	require.NoError(t, r.poll(t.Context(), since.Add(2*time.Minute)))
+	require.Equal(t, 2, sink.LogRecordCount())
+
+	key := "http_requests/20230303/20230303T050100Z_20230303T050200Z_c.log.gz"
+	fake.objects = append(fake.objects, bucketObject{Key: key, LastModified: since.Add(2 * time.Minute)})
+	fake.contents[key] = gzippedMessage(`{"EdgeStartTimestamp":"2023-03-03T05:01:05Z","RayID":"3"}`)
+	require.NoError(t, r.poll(t.Context(), since.Add(3*time.Minute)))
+	require.Equal(t, 3, sink.LogRecordCount())
+
+	// A file written late, whose key sorts before the processed files, is collected too.
+	late := "http_requests/20230303/20230303T045900Z_20230303T050000Z_d.log.gz"
+	fake.objects = append(fake.objects, bucketObject{Key: late, LastModified: since.Add(4 * time.Minute)})
+	fake.contents[late] = gzippedMessage(`{"EdgeStartTimestamp":"2023-03-03T04:59:05Z","RayID":"4"}`)
+	require.NoError(t, r.poll(t.Context(), since.Add(5*time.Minute)))
+	require.Equal(t, 4, sink.LogRecordCount())
+
+	// The files before the lookback window are forgotten.
+	require.NoError(t, r.poll(t.Context(), since.Add(48*time.Hour)))
+	require.Equal(t, 4, sink.LogRecordCount())
+	require.Empty(t, r.processed)
+}
+
+func TestBucketPollConsumerError(t *testing.T) {
+	key := "http_requests/20230303/20230303T050000Z_20230303T050100Z_b.log.gz"
+	fake := &fakeBucketClient{
+		objects:  []bucketObject{{Key: key, LastModified: time.Date(2023, 3, 3, 5, 1, 0, 0, time.UTC)}},
+		contents: map[string]string{key: gzippedMessage(`{"EdgeStartTimestamp":"2023-03-03T05:00:05Z"}`)},
+	}
+	r := newTestBucketReceiver(t, fake, consumertest.NewErr(errors.New("consumer failed")))
+	r.since = time.Date(2023, 3, 3, 5, 0, 0, 0, time.UTC)
+
+	require.ErrorContains(t, r.poll(t.Context(), time.Date(2023, 3, 3, 5, 2, 0, 0, time.UTC)), "consumer failed")
+	// The file is not recorded as processed, so it's retried on the next poll.
+	require.Empty(t, r.processed)
+}
+
+func TestBucketStartAfter(t *testing.T) {
+	r := newTestBucketReceiver(t, &fakeBucketClient{}, consumertest.NewNop())
+	r.cfg.Prefix = "logs/http_requests"
+	now := time.Date(2023, 3, 3, 0, 30, 0, 0, time.UTC)
+	require.Equal(t, "logs/http_requests/", r.prefix())
+	// The files of the previous day may still be written.
+	require.Equal(t, "logs/http_requests/20230302", r.startAfter(now))
+
+	r.processed["logs/http_requests/20230303/20230303T003000Z_20230303T003100Z_a.log.gz"] = struct{}{}
+	require.Equal(t, "logs/http_requests/20230302", r.startAfter(now))
+
+	// Listing resumes at the oldest file processed before a restart.
+	older := "logs/http_requests/20230228/20230228T003000Z_20230228T003100Z_a.log.gz"
+	r.processed[older] = struct{}{}
+	require.Equal(t, older, r.startAfter(now))
+}
+
+func TestBucketCheckpointStorage(t *testing.T) {
+	key := "http_requests/20230303/20230303T050000Z_20230303T050100Z_b.log.gz"
+	fake := &fakeBucketClient{
+		objects:  []bucketObject{{Key: key, LastModified: time.Date(2023, 3, 3, 5, 1, 0, 0, time.UTC)}},
+		contents: map[string]string{key: gzippedMessage(`{"EdgeStartTimestamp":"2023-03-03T05:00:05Z"}`)},
+	}
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+
+	sink := &consumertest.LogsSink{}
+	r := newTestBucketReceiver(t, fake, sink)
+	r.cfg.StorageID = &storageID
+	require.NoError(t, r.Start(t.Context(), host))
+	r.since = time.Date(2023, 3, 3, 5, 0, 0, 0, time.UTC)
+	require.NoError(t, r.poll(t.Context(), time.Date(2023, 3, 3, 5, 2, 0, 0, time.UTC)))
+	require.Equal(t, 1, sink.LogRecordCount())
+	require.NoError(t, r.Shutdown(t.Context()))
+
+	// A file written while the collector was down is collected after the restart.
+	next := "http_requests/20230303/20230303T050100Z_20230303T050200Z_c.log.gz"
+	fake.objects = append(fake.objects, bucketObject{Key: next, LastModified: time.Date(2023, 3, 3, 5, 2, 0, 0, time.UTC)})
+	fake.contents[next] = gzippedMessage(`{"EdgeStartTimestamp":"2023-03-03T05:01:05Z"}`)
+
+	id := r.id
+	r = newTestBucketReceiver(t, fake, sink)
+	r.id = id
+	r.cfg.StorageID = &storageID
+	require.NoError(t, r.Start(t.Context(), host))
+	defer func() { require.NoError(t, r.Shutdown(t.Context())) }()
+	require.Contains(t, r.processed, key)
+	// The collector was down for days, longer than the lookback window.
+	require.NoError(t, r.poll(t.Context(), time.Date(2023, 3, 6, 5, 0, 0, 0, time.UTC)))
+	require.Equal(t, 2, sink.LogRecordCount())
+}
+
+func TestS3BucketClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Contains(t, req.Header.Get("Authorization"), "Credential=abcdef123456/")
+		switch req.URL.Path {
+		case "/logpush":
+			require.Equal(t, "2", req.URL.Query().Get("list-type"))
+			require.Equal(t, "http_requests/", req.URL.Query().Get("prefix"))
+			require.Equal(t, "http_requests/a.log.gz", req.URL.Query().Get("start-after"))
+			rw.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(rw, `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Name>logpush</Name>
+  <Prefix>http_requests/</Prefix>
+  <IsTruncated>false</IsTruncated>
+  <Contents>
+    <Key>http_requests/b.log.gz</Key>
+    <LastModified>2023-03-03T05:01:00.000Z</LastModified>
+    <Size>10</Size>
+  </Contents>
+</ListBucketResult>`)
+		case "/logpush/http_requests/b.log.gz":
+			fmt.Fprint(rw, `{"RayID":"1"}`)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := newS3BucketClient(t.Context(), &BucketConfig{Bucket: "logpush", AccessKeyID: "abcdef123456", SecretAccessKey: "1234567890abcdef"}, server.URL, "auto")
+	require.NoError(t, err)
+
+	objects, err := c.ListObjects(t.Context(), "http_requests/", "http_requests/a.log.gz")
+	require.NoError(t, err)
+	require.Equal(t, []bucketObject{{Key: "http_requests/b.log.gz", LastModified: time.Date(2023, 3, 3, 5, 1, 0, 0, time.UTC)}}, objects)
+
+	body, err := c.GetObject(t.Context(), "http_requests/b.log.gz")
+	require.NoError(t, err)
+	defer body.Close()
+	content, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"RayID":"1"}`, string(content))
+}
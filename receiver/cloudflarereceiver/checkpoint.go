@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import "time"
+
+// eventCheckpoint tracks the position of an API poller in a stream of events ordered by creation time.
+type eventCheckpoint struct {
+	since time.Time
+	// seen holds the IDs of the already emitted events created at since, which the next query
+	// returns again.
+	seen map[string]struct{}
+}
+
+func newEventCheckpoint(since time.Time) *eventCheckpoint {
+	return &eventCheckpoint{since: since, seen: map[string]struct{}{}}
+}
+
+// emitted returns true if the event with the given ID and creation time was already emitted.
+func (c *eventCheckpoint) emitted(id string, createdAt time.Time) bool {
+	if createdAt.Before(c.since) {
+		return true
+	}
+	_, ok := c.seen[id]
+	return ok && createdAt.Equal(c.since)
+}
+
+// advance records that the event with the given ID and creation time was emitted.
+func (c *eventCheckpoint) advance(id string, createdAt time.Time) {
+	if createdAt.After(c.since) {
+		c.since = createdAt
+		clear(c.seen)
+	}
+	if createdAt.Equal(c.since) {
+		c.seen[id] = struct{}{}
+	}
+}
+
+// reset moves the checkpoint to since and forgets the emitted events.
+func (c *eventCheckpoint) reset(since time.Time) {
+	c.since = since
+	clear(c.seen)
+}
+
+// clamp moves the checkpoint forward to oldest if it's older, returning true if it moved. This skips
+// the events that Cloudflare no longer retains.
+func (c *eventCheckpoint) clamp(oldest time.Time) bool {
+	if !c.since.Before(oldest) {
+		return false
+	}
+	c.reset(oldest)
+	return true
+}
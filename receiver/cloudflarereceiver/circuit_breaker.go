@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import "time"
+
+// circuitBreaker stops querying a target, such as a zone, after consecutive failures for a cooldown
+// period, so that a misconfigured target doesn't use up the rate limit of the API token on every
+// scrape. Once the cooldown has elapsed, the target is probed again: a success resumes querying it,
+// while a failure pauses it for another cooldown.
+type circuitBreaker struct {
+	cfg    CircuitBreakerConfig
+	states map[string]*circuitState
+}
+
+type circuitState struct {
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker(cfg CircuitBreakerConfig) *circuitBreaker {
+	return &circuitBreaker{cfg: cfg, states: map[string]*circuitState{}}
+}
+
+// allow returns true if the target may be queried, and otherwise the time it will be probed again.
+func (c *circuitBreaker) allow(target string, now time.Time) (bool, time.Time) {
+	state, ok := c.states[target]
+	if !ok || !now.Before(state.openUntil) {
+		return true, time.Time{}
+	}
+	return false, state.openUntil
+}
+
+// recordSuccess resets the failures of the target.
+func (c *circuitBreaker) recordSuccess(target string) {
+	delete(c.states, target)
+}
+
+// recordFailure counts a failure of the target, returning true if the target is paused as a result.
+func (c *circuitBreaker) recordFailure(target string, now time.Time) bool {
+	if c.cfg.FailureThreshold == 0 {
+		return false
+	}
+
+	state, ok := c.states[target]
+	if !ok {
+		state = &circuitState{}
+		c.states[target] = state
+	}
+	state.failures++
+	if state.failures < c.cfg.FailureThreshold {
+		return false
+	}
+	state.openUntil = now.Add(c.cfg.Cooldown)
+	return true
+}
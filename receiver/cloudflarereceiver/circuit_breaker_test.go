@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	c := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Minute})
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	require.False(t, c.recordFailure("zone a", now))
+	ok, _ := c.allow("zone a", now)
+	require.True(t, ok)
+
+	// The second consecutive failure pauses the zone for the cooldown, without affecting other zones.
+	require.True(t, c.recordFailure("zone a", now))
+	ok, probeAt := c.allow("zone a", now.Add(30*time.Second))
+	require.False(t, ok)
+	require.Equal(t, now.Add(time.Minute), probeAt)
+	ok, _ = c.allow("zone b", now)
+	require.True(t, ok)
+
+	// A failed probe pauses the zone for another cooldown.
+	ok, _ = c.allow("zone a", now.Add(time.Minute))
+	require.True(t, ok)
+	require.True(t, c.recordFailure("zone a", now.Add(time.Minute)))
+	ok, _ = c.allow("zone a", now.Add(90*time.Second))
+	require.False(t, ok)
+
+	// A successful probe resumes querying the zone.
+	c.recordSuccess("zone a")
+	ok, _ = c.allow("zone a", now.Add(90*time.Second))
+	require.True(t, ok)
+	require.False(t, c.recordFailure("zone a", now.Add(90*time.Second)))
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	c := newCircuitBreaker(CircuitBreakerConfig{})
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	for range 10 {
+		require.False(t, c.recordFailure("zone a", now))
+	}
+	ok, _ := c.allow("zone a", now)
+	require.True(t, ok)
+}
@@ -0,0 +1,819 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// client is a minimal client for the Cloudflare REST API. A result is returned alongside an error only
+// when the error is a *partialResultError.
+type client interface {
+	// ListZoneLogpushJobs calls "/zones/{zone_id}/logpush/jobs" to list the Logpush jobs of a zone.
+	ListZoneLogpushJobs(ctx context.Context, zoneID string) ([]logpushJob, error)
+	// GetZone calls "/zones/{zone_id}" to get the details of a zone.
+	GetZone(ctx context.Context, zoneID string) (zone, error)
+	// VerifyToken calls "/user/tokens/verify" to get the status of the API token.
+	VerifyToken(ctx context.Context) (tokenStatus, error)
+	// ListZones calls "/zones" to list the zones named name the token has access to.
+	ListZones(ctx context.Context, name string) ([]zone, error)
+	// ListAccounts calls "/accounts" to list the accounts named name the token has access to.
+	ListAccounts(ctx context.Context, name string) ([]account, error)
+	// ListAccountLogpushJobs calls "/accounts/{account_id}/logpush/jobs" to list the Logpush jobs of an account.
+	ListAccountLogpushJobs(ctx context.Context, accountID string) ([]logpushJob, error)
+	// CreateZoneLogpushJob calls "/zones/{zone_id}/logpush/jobs" to create a Logpush job of a zone.
+	CreateZoneLogpushJob(ctx context.Context, zoneID string, request logpushJobRequest) (logpushJob, error)
+	// UpdateZoneLogpushJob calls "/zones/{zone_id}/logpush/jobs/{job_id}" to update a Logpush job of a zone.
+	UpdateZoneLogpushJob(ctx context.Context, zoneID string, jobID int64, request logpushJobRequest) (logpushJob, error)
+	// ListAccessRequests calls "/accounts/{account_id}/access/logs/access_requests" to list the Access
+	// authentication events of an account created in [since, until), oldest first.
+	ListAccessRequests(ctx context.Context, accountID string, since, until time.Time, limit int) ([]accessRequest, error)
+	// ListAuditLogs calls "/accounts/{account_id}/logs/audit" to list one page of the audit logs of an
+	// account created in [since, before), oldest first, starting at the cursor of the page, or at the
+	// first page if empty.
+	ListAuditLogs(ctx context.Context, accountID string, since, before time.Time, cursor string, limit int) (cursorPage[auditLog], error)
+	// QueryGraphQL calls "/graphql" to run a query of the GraphQL Analytics API with its variables, and
+	// decodes the data of the response into data.
+	QueryGraphQL(ctx context.Context, query string, variables map[string]any, data any) error
+	// CreateInstantLogsSession calls "/zones/{zone_id}/logpush/edge/jobs" to create an Instant Logs
+	// session streaming the HTTP requests of a zone.
+	CreateInstantLogsSession(ctx context.Context, zoneID string, request instantLogsRequest) (instantLogsSession, error)
+	// PullQueueMessages calls "/accounts/{account_id}/queues/{queue_id}/messages/pull" to lease a batch
+	// of messages of a queue.
+	PullQueueMessages(ctx context.Context, accountID, queueID string, batchSize int, visibilityTimeout time.Duration) ([]queueMessage, error)
+	// AckQueueMessages calls "/accounts/{account_id}/queues/{queue_id}/messages/ack" to acknowledge
+	// leased messages, which removes them from the queue.
+	AckQueueMessages(ctx context.Context, accountID, queueID string, leaseIDs []string) error
+}
+
+var _ client = (*cloudflareClient)(nil)
+
+var errResponseTooLarge = errors.New("response too large")
+
+// graphQLPath is the path of the GraphQL Analytics API.
+const graphQLPath = "/graphql"
+
+// partialResultError reports the errors of a successful response that still holds a result, in which
+// case the result is returned alongside the error, since it holds the data that could be collected.
+type partialResultError struct {
+	path string
+	errs []error
+}
+
+func (e *partialResultError) Error() string {
+	return fmt.Sprintf("response to %s holds a partial result: %v", e.path, errors.Join(e.errs...))
+}
+
+func (e *partialResultError) Unwrap() []error {
+	return e.errs
+}
+
+type cloudflareClient struct {
+	client   *http.Client
+	endpoint string
+	token    string
+	backOff  configretry.BackOffConfig
+	maxSize  int64
+	logger   *zap.Logger
+
+	telemetryBuilder *metadata.TelemetryBuilder
+}
+
+// apiResponse is the envelope every Cloudflare v4 API response is wrapped in.
+type apiResponse[T any] struct {
+	Success    bool       `json:"success"`
+	Errors     []apiError `json:"errors"`
+	Result     T          `json:"result"`
+	ResultInfo resultInfo `json:"result_info"`
+}
+
+// resultInfo holds the pagination details of a list result.
+type resultInfo struct {
+	// Cursor is the cursor of the next page of lists paginated by cursor, empty on the last page.
+	Cursor string `json:"cursor"`
+}
+
+// cursorPage is a page of a list paginated by cursor, along with the cursor of the next page.
+type cursorPage[T any] struct {
+	Items  []T
+	Cursor string
+}
+
+func (p *cursorPage[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &p.Items)
+}
+
+func (p *cursorPage[T]) setResultInfo(info resultInfo) {
+	p.Cursor = info.Cursor
+}
+
+// resultInfoSetter is implemented by the results that need the pagination details of the response.
+type resultInfoSetter interface {
+	setResultInfo(info resultInfo)
+}
+
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e apiError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// graphQLRequest is the body of a request to the GraphQL Analytics API.
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// graphQLResponse is the envelope of the responses of the GraphQL Analytics API. Unlike the v4 API,
+// errors are reported alongside the data, which is null unless the query could be run at least in part.
+type graphQLResponse struct {
+	Data   graphQLData    `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+func (r *graphQLResponse) decodeStream(dec *json.Decoder) error {
+	_, err := decodeObject(dec, func(key string) error {
+		switch key {
+		case "data":
+			return r.Data.decodeStream(dec)
+		case "errors":
+			return dec.Decode(&r.Errors)
+		default:
+			return skipValue(dec)
+		}
+	})
+	return err
+}
+
+// graphQLData decodes the data of a response into the value of the caller, recording whether the
+// response held any.
+type graphQLData struct {
+	value any
+	set   bool
+}
+
+func (d *graphQLData) decodeStream(dec *json.Decoder) error {
+	// The members of the data are decoded as they're read when the value of the caller supports it,
+	// rather than buffering the whole data, which can hold tens of thousands of events.
+	if m, ok := d.value.(memberDecoder); ok {
+		set, err := decodeObject(dec, func(key string) error {
+			return m.decodeMember(dec, key)
+		})
+		d.set = set
+		return err
+	}
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	if string(raw) == "null" {
+		return nil
+	}
+	d.set = true
+	return json.Unmarshal(raw, d.value)
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+	// Path is the path of the field of the query the error relates to, such as viewer, zones, 0.
+	Path       []any `json:"path"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
+}
+
+func (e graphQLError) Error() string {
+	if e.Extensions.Code == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Extensions.Code, e.Message)
+}
+
+// statusError reports the status code of a failed request, and what to do about it for the kinds of
+// failures that have a common cause.
+type statusError struct {
+	path       string
+	statusCode int
+	errorType  metadata.AttributeErrorType
+}
+
+func (e *statusError) Error() string {
+	if hint, ok := errorTypeHints[e.errorType]; ok {
+		return fmt.Sprintf("request to %s failed with status code %d: %s", e.path, e.statusCode, hint)
+	}
+	return fmt.Sprintf("request to %s failed with status code %d", e.path, e.statusCode)
+}
+
+type zone struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Account struct {
+		ID string `json:"id"`
+	} `json:"account"`
+	Plan struct {
+		Name string `json:"name"`
+	} `json:"plan"`
+}
+
+// tokenStatus is the status of an API token, such as active, disabled or expired.
+type tokenStatus struct {
+	ID        string     `json:"id"`
+	Status    string     `json:"status"`
+	ExpiresOn *time.Time `json:"expires_on"`
+}
+
+type account struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type logpushJob struct {
+	ID           int64      `json:"id"`
+	Name         string     `json:"name"`
+	Dataset      string     `json:"dataset"`
+	Enabled      bool       `json:"enabled"`
+	LastComplete *time.Time `json:"last_complete"`
+	LastError    *time.Time `json:"last_error"`
+	ErrorMessage string     `json:"error_message"`
+}
+
+// logpushJobRequest holds the settings of a Logpush job that is created or updated. The name and
+// dataset of a job can't be updated.
+type logpushJobRequest struct {
+	Name            string               `json:"name,omitempty"`
+	Dataset         string               `json:"dataset,omitempty"`
+	DestinationConf string               `json:"destination_conf"`
+	Enabled         bool                 `json:"enabled"`
+	Filter          string               `json:"filter,omitempty"`
+	OutputOptions   logpushOutputOptions `json:"output_options"`
+}
+
+// logpushOutputOptions configures the fields and format of the logs sent by a Logpush job.
+type logpushOutputOptions struct {
+	FieldNames      []string `json:"field_names"`
+	OutputType      string   `json:"output_type"`
+	TimestampFormat string   `json:"timestamp_format,omitempty"`
+}
+
+// accessRequest is a Zero Trust Access authentication event. The raw event is kept alongside
+// the decoded fields so it can be used as the log body.
+type accessRequest struct {
+	Action     string    `json:"action"`
+	Allowed    bool      `json:"allowed"`
+	AppDomain  string    `json:"app_domain"`
+	AppUID     string    `json:"app_uid"`
+	Connection string    `json:"connection"`
+	Country    string    `json:"country"`
+	CreatedAt  time.Time `json:"created_at"`
+	IPAddress  string    `json:"ip_address"`
+	RayID      string    `json:"ray_id"`
+	UserEmail  string    `json:"user_email"`
+
+	raw map[string]any
+}
+
+func (r *accessRequest) UnmarshalJSON(data []byte) error {
+	// The alias type drops this method so the fields can be decoded without recursing.
+	type fields accessRequest
+	if err := json.Unmarshal(data, (*fields)(r)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &r.raw)
+}
+
+// auditLog is an entry of the account audit log. The raw entry is kept alongside the decoded
+// fields so it can be used as the log body.
+type auditLog struct {
+	ID     string `json:"id"`
+	Action struct {
+		Type   string    `json:"type"`
+		Result string    `json:"result"`
+		Time   time.Time `json:"time"`
+	} `json:"action"`
+	Actor struct {
+		ID        string `json:"id"`
+		Email     string `json:"email"`
+		IPAddress string `json:"ip_address"`
+		Type      string `json:"type"`
+		Context   string `json:"context"`
+	} `json:"actor"`
+	Resource struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	} `json:"resource"`
+
+	raw map[string]any
+}
+
+func (l *auditLog) UnmarshalJSON(data []byte) error {
+	type fields auditLog
+	if err := json.Unmarshal(data, (*fields)(l)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &l.raw)
+}
+
+// instantLogsRequest configures the HTTP request logs streamed by an Instant Logs session.
+type instantLogsRequest struct {
+	Fields string `json:"fields"`
+	Sample int    `json:"sample"`
+	Filter string `json:"filter,omitempty"`
+	Kind   string `json:"kind"`
+}
+
+// instantLogsSession is an Instant Logs session, whose logs are streamed by the WebSocket at
+// DestinationConf.
+type instantLogsSession struct {
+	SessionID       string `json:"session_id"`
+	DestinationConf string `json:"destination_conf"`
+}
+
+// queueMessage is a message leased from a queue.
+type queueMessage struct {
+	ID       string            `json:"id"`
+	LeaseID  string            `json:"lease_id"`
+	Body     string            `json:"body"`
+	Attempts int               `json:"attempts"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+type queuePullRequest struct {
+	BatchSize           int   `json:"batch_size"`
+	VisibilityTimeoutMs int64 `json:"visibility_timeout_ms"`
+}
+
+type queuePullResult struct {
+	Messages []queueMessage `json:"messages"`
+}
+
+type queueAck struct {
+	LeaseID string `json:"lease_id"`
+}
+
+type queueAckRequest struct {
+	Acks []queueAck `json:"acks"`
+}
+
+func newClient(ctx context.Context, cfg *APIConfig, host component.Host, settings component.TelemetrySettings) (client, error) {
+	httpClient, err := cfg.ToClient(ctx, host, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP Client: %w", err)
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telemetry builder: %w", err)
+	}
+
+	return &cloudflareClient{
+		client:   httpClient,
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		token:    string(cfg.APIToken),
+		backOff:  cfg.BackOffConfig,
+		maxSize:  cfg.MaxResponseSize,
+		logger:   settings.Logger,
+
+		telemetryBuilder: telemetryBuilder,
+	}, nil
+}
+
+func (c *cloudflareClient) GetZone(ctx context.Context, zoneID string) (zone, error) {
+	return getResult[zone](ctx, c, "/zones/"+url.PathEscape(zoneID), nil)
+}
+
+// VerifyToken isn't retried, so that an unreachable API doesn't delay the start of the receiver.
+func (c *cloudflareClient) VerifyToken(ctx context.Context) (tokenStatus, error) {
+	const path = "/user/tokens/verify"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+path, http.NoBody)
+	if err != nil {
+		return tokenStatus{}, fmt.Errorf("failed to create get request for path %s: %w", path, err)
+	}
+	status, _, err := attemptRequest[tokenStatus](c, req, path)
+	return status, err
+}
+
+func (c *cloudflareClient) ListZones(ctx context.Context, name string) ([]zone, error) {
+	query := url.Values{}
+	query.Set("name", name)
+	return getResult[[]zone](ctx, c, "/zones", query)
+}
+
+func (c *cloudflareClient) ListAccounts(ctx context.Context, name string) ([]account, error) {
+	query := url.Values{}
+	query.Set("name", name)
+	return getResult[[]account](ctx, c, "/accounts", query)
+}
+
+func (c *cloudflareClient) ListZoneLogpushJobs(ctx context.Context, zoneID string) ([]logpushJob, error) {
+	return getResult[[]logpushJob](ctx, c, "/zones/"+url.PathEscape(zoneID)+"/logpush/jobs", nil)
+}
+
+func (c *cloudflareClient) ListAccountLogpushJobs(ctx context.Context, accountID string) ([]logpushJob, error) {
+	return getResult[[]logpushJob](ctx, c, "/accounts/"+url.PathEscape(accountID)+"/logpush/jobs", nil)
+}
+
+func (c *cloudflareClient) CreateZoneLogpushJob(ctx context.Context, zoneID string, request logpushJobRequest) (logpushJob, error) {
+	return sendResult[logpushJob](ctx, c, http.MethodPost, "/zones/"+url.PathEscape(zoneID)+"/logpush/jobs", request)
+}
+
+func (c *cloudflareClient) UpdateZoneLogpushJob(ctx context.Context, zoneID string, jobID int64, request logpushJobRequest) (logpushJob, error) {
+	path := "/zones/" + url.PathEscape(zoneID) + "/logpush/jobs/" + strconv.FormatInt(jobID, 10)
+	return sendResult[logpushJob](ctx, c, http.MethodPut, path, request)
+}
+
+func (c *cloudflareClient) ListAccessRequests(ctx context.Context, accountID string, since, until time.Time, limit int) ([]accessRequest, error) {
+	query := url.Values{}
+	query.Set("since", since.UTC().Format(time.RFC3339Nano))
+	query.Set("until", until.UTC().Format(time.RFC3339Nano))
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("direction", "asc")
+	return getResult[[]accessRequest](ctx, c, "/accounts/"+url.PathEscape(accountID)+"/access/logs/access_requests", query)
+}
+
+func (c *cloudflareClient) ListAuditLogs(ctx context.Context, accountID string, since, before time.Time, cursor string, limit int) (cursorPage[auditLog], error) {
+	query := url.Values{}
+	query.Set("since", since.UTC().Format(time.RFC3339Nano))
+	query.Set("before", before.UTC().Format(time.RFC3339Nano))
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("direction", "asc")
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	return getResult[cursorPage[auditLog]](ctx, c, "/accounts/"+url.PathEscape(accountID)+"/logs/audit", query)
+}
+
+func (c *cloudflareClient) CreateInstantLogsSession(ctx context.Context, zoneID string, request instantLogsRequest) (instantLogsSession, error) {
+	return sendResult[instantLogsSession](ctx, c, http.MethodPost, "/zones/"+url.PathEscape(zoneID)+"/logpush/edge/jobs", request)
+}
+
+func (c *cloudflareClient) PullQueueMessages(ctx context.Context, accountID, queueID string, batchSize int, visibilityTimeout time.Duration) ([]queueMessage, error) {
+	result, err := sendResult[queuePullResult](ctx, c, http.MethodPost, queuePath(accountID, queueID)+"/messages/pull", queuePullRequest{
+		BatchSize:           batchSize,
+		VisibilityTimeoutMs: visibilityTimeout.Milliseconds(),
+	})
+	return result.Messages, err
+}
+
+func (c *cloudflareClient) AckQueueMessages(ctx context.Context, accountID, queueID string, leaseIDs []string) error {
+	acks := make([]queueAck, 0, len(leaseIDs))
+	for _, leaseID := range leaseIDs {
+		acks = append(acks, queueAck{LeaseID: leaseID})
+	}
+	_, err := sendResult[json.RawMessage](ctx, c, http.MethodPost, queuePath(accountID, queueID)+"/messages/ack", queueAckRequest{Acks: acks})
+	return err
+}
+
+func (c *cloudflareClient) QueryGraphQL(ctx context.Context, query string, variables map[string]any, data any) error {
+	const path = graphQLPath
+	payload, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode request payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create post request for path %s: %w", path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	_, err = retryRequest(c, req, path, func(c *cloudflareClient, req *http.Request, path string) (struct{}, bool, error) {
+		retryable, err := attemptGraphQL(c, req, path, data)
+		return struct{}{}, retryable, err
+	})
+	return err
+}
+
+func queuePath(accountID, queueID string) string {
+	return "/accounts/" + url.PathEscape(accountID) + "/queues/" + url.PathEscape(queueID)
+}
+
+// getResult issues an authenticated GET request and returns the result from the response envelope.
+func getResult[T any](ctx context.Context, c *cloudflareClient, path string, query url.Values) (T, error) {
+	reqURL := c.endpoint + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		var result T
+		return result, fmt.Errorf("failed to create get request for path %s: %w", path, err)
+	}
+	return doRequest[T](c, req, path)
+}
+
+// sendResult issues an authenticated request with a JSON body and returns the result from the
+// response envelope.
+func sendResult[T any](ctx context.Context, c *cloudflareClient, method, path string, body any) (T, error) {
+	var result T
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return result, fmt.Errorf("failed to encode request payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(payload))
+	if err != nil {
+		return result, fmt.Errorf("failed to create %s request for path %s: %w", strings.ToLower(method), path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return doRequest[T](c, req, path)
+}
+
+// noRetriesKey is the context key disabling the retries of the requests made with the context.
+type noRetriesKey struct{}
+
+// withoutRetries returns a context whose requests aren't retried, e.g. for requests made on start,
+// so that an unreachable API doesn't delay the start of the receiver.
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetriesKey{}, true)
+}
+
+// doRequest authenticates and issues the request, and returns the result from the response envelope.
+// Requests failing with a 5xx status or a network error are retried with an exponential backoff,
+// until the retry settings or the deadline of the context give up, unless the context is
+// withoutRetries.
+func doRequest[T any](c *cloudflareClient, req *http.Request, path string) (T, error) {
+	return retryRequest(c, req, path, attemptRequest[T])
+}
+
+// retryRequest issues the request with attempt, retrying it while attempt reports a transient error.
+func retryRequest[T any](c *cloudflareClient, req *http.Request, path string, attempt func(*cloudflareClient, *http.Request, string) (T, bool, error)) (T, error) {
+	result, retryable, err := attempt(c, req, path)
+	if err == nil || !retryable || !c.backOff.Enabled || req.Context().Value(noRetriesKey{}) != nil {
+		return result, err
+	}
+
+	expBackOff := backoff.ExponentialBackOff{
+		InitialInterval:     c.backOff.InitialInterval,
+		RandomizationFactor: c.backOff.RandomizationFactor,
+		Multiplier:          c.backOff.Multiplier,
+		MaxInterval:         c.backOff.MaxInterval,
+	}
+	expBackOff.Reset()
+	start := time.Now()
+	for err != nil && retryable {
+		delay := expBackOff.NextBackOff()
+		if c.backOff.MaxElapsedTime > 0 && time.Since(start)+delay > c.backOff.MaxElapsedTime {
+			break
+		}
+		// A retry that can't be attempted before the deadline of the request, such as the deadline of the
+		// scrape, is given up right away.
+		if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			break
+		}
+		c.logger.Debug("Retrying failed request", zap.String("path", path), zap.Duration("delay", delay), zap.Error(err))
+		c.telemetryBuilder.CloudflareAPIRetries.Add(req.Context(), 1)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return result, fmt.Errorf("failed to reset request body: %w", err)
+			}
+		}
+		result, retryable, err = attempt(c, req, path)
+	}
+	return result, err
+}
+
+// attemptRequest issues the request once, and returns the result from the response envelope, or an
+// error and whether it's transient.
+func attemptRequest[T any](c *cloudflareClient, req *http.Request, path string) (T, bool, error) {
+	var respObj apiResponse[T]
+	// Cloudflare reports failures in the response envelope, so decode it regardless of the status code.
+	statusCode, retryable, err := send(c, req, path, &respObj)
+	if err != nil {
+		return respObj.Result, retryable, err
+	}
+	if setter, ok := any(&respObj.Result).(resultInfoSetter); ok {
+		setter.setResultInfo(respObj.ResultInfo)
+	}
+
+	if statusCode == http.StatusOK && len(respObj.Errors) > 0 && hasResult(respObj.Result) {
+		errs := make([]error, 0, len(respObj.Errors))
+		for _, apiErr := range respObj.Errors {
+			errs = append(errs, apiErr)
+		}
+		return respObj.Result, false, &partialResultError{path: path, errs: errs}
+	}
+
+	if !respObj.Success || statusCode != http.StatusOK {
+		errorType := classifyAPIError(statusCode, respObj.Errors)
+		c.telemetryBuilder.CloudflareAPIErrors.Add(req.Context(), 1, metric.WithAttributes(
+			attribute.String("error.type", errorType.String()),
+		))
+		errs := make([]error, 0, len(respObj.Errors)+1)
+		errs = append(errs, &statusError{path: path, statusCode: statusCode, errorType: errorType})
+		for _, apiErr := range respObj.Errors {
+			errs = append(errs, apiErr)
+		}
+		return respObj.Result, retryable, errors.Join(errs...)
+	}
+
+	return respObj.Result, false, nil
+}
+
+// attemptGraphQL issues the GraphQL request once, and decodes the data of the response into data, or
+// returns an error and whether it's transient. The data of a response that also holds errors is
+// decoded alongside a *partialResultError.
+func attemptGraphQL(c *cloudflareClient, req *http.Request, path string, data any) (bool, error) {
+	respObj := graphQLResponse{Data: graphQLData{value: data}}
+	statusCode, retryable, err := send(c, req, path, &respObj)
+	if err != nil {
+		return retryable, err
+	}
+
+	errs := make([]error, 0, len(respObj.Errors)+1)
+	for _, gqlErr := range respObj.Errors {
+		errs = append(errs, gqlErr)
+	}
+	if statusCode == http.StatusOK && len(errs) > 0 && respObj.Data.set {
+		return false, &partialResultError{path: path, errs: errs}
+	}
+
+	if statusCode != http.StatusOK || len(errs) > 0 {
+		errorType := classifyGraphQLError(statusCode, respObj.Errors)
+		c.telemetryBuilder.CloudflareAPIErrors.Add(req.Context(), 1, metric.WithAttributes(
+			attribute.String("error.type", errorType.String()),
+		))
+		errs = append([]error{&statusError{path: path, statusCode: statusCode, errorType: errorType}}, errs...)
+		return retryable, errors.Join(errs...)
+	}
+
+	return false, nil
+}
+
+// send issues the request once and decodes the response into respObj, regardless of its status code.
+// It returns the status code of the response, or an error and whether it's transient.
+func send(c *cloudflareClient, req *http.Request, path string, respObj any) (int, bool, error) {
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.telemetryBuilder.CloudflareAPIRequests.Add(req.Context(), 1)
+		return 0, req.Context().Err() == nil, fmt.Errorf("failed to make http request: %w", err)
+	}
+	body := &countingReader{r: resp.Body}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			c.logger.Warn("failed to close response body", zap.Error(closeErr))
+		}
+		c.telemetryBuilder.CloudflareAPIResponseSize.Add(req.Context(), body.n)
+	}()
+	c.telemetryBuilder.CloudflareAPIRequests.Add(req.Context(), 1, metric.WithAttributes(
+		attribute.Int("http.response.status_code", resp.StatusCode),
+	))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		c.telemetryBuilder.CloudflareAPIRateLimited.Add(req.Context(), 1)
+	}
+	retryable := resp.StatusCode >= http.StatusInternalServerError
+
+	var reader io.Reader = body
+	if c.maxSize > 0 {
+		reader = &maxSizeReader{reader: body, remaining: c.maxSize, err: errResponseTooLarge}
+	}
+
+	if err := decodeResponse(reader, respObj); err != nil {
+		if errors.Is(err, errResponseTooLarge) {
+			narrow := "narrow the request, e.g. with a smaller page_size"
+			if path == graphQLPath {
+				// The queries return many groups when they are split by dimensions of many values.
+				narrow = "narrow the query, e.g. with a smaller limit in dataset_options, fewer dimensions or a filter"
+			}
+			return resp.StatusCode, false, fmt.Errorf("response to %s exceeds max_response_size of %d bytes, "+
+				"%s, or raise the limit: %w", path, c.maxSize, narrow, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, retryable, fmt.Errorf("non 200 code returned %d", resp.StatusCode)
+		}
+		return resp.StatusCode, false, fmt.Errorf("failed to decode response payload: %w", err)
+	}
+	return resp.StatusCode, retryable, nil
+}
+
+// hasResult returns whether the result holds data, empty lists and maps holding none.
+func hasResult[T any](result T) bool {
+	v := reflect.ValueOf(result)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() > 0
+	default:
+		return !v.IsZero()
+	}
+}
+
+// decodeResponse decodes the response envelope from the stream. Unknown fields of the envelope are
+// skipped.
+func decodeResponse(r io.Reader, respObj any) error {
+	dec := json.NewDecoder(r)
+	if s, ok := respObj.(streamDecoder); ok {
+		return s.decodeStream(dec)
+	}
+	return dec.Decode(respObj)
+}
+
+// streamDecoder is implemented by the responses decoded token by token as they're read, so that their
+// large values aren't buffered before being decoded.
+type streamDecoder interface {
+	decodeStream(dec *json.Decoder) error
+}
+
+// memberDecoder is implemented by the values of JSON objects whose members are decoded one by one.
+type memberDecoder interface {
+	// decodeMember decodes the value of the member with the key, which it must consume.
+	decodeMember(dec *json.Decoder, key string) error
+}
+
+// decodeObject decodes the next value of the decoder, which must be an object or null, calling
+// decodeMember for every member, which must consume its value. It returns false if the value is null.
+func decodeObject(dec *json.Decoder, decodeMember func(key string) error) (bool, error) {
+	if ok, err := openValue(dec, '{'); !ok || err != nil {
+		return false, err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return false, err
+		}
+		if err := decodeMember(tok.(string)); err != nil {
+			return false, err
+		}
+	}
+	_, err := dec.Token()
+	return true, err
+}
+
+// decodeArray decodes the next value of the decoder, which must be an array or null, calling
+// decodeElement for every element, which must consume it.
+func decodeArray(dec *json.Decoder, decodeElement func() error) error {
+	if ok, err := openValue(dec, '['); !ok || err != nil {
+		return err
+	}
+	for dec.More() {
+		if err := decodeElement(); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// openValue reads the opening delimiter of the next value of the decoder, returning false if the value
+// is null.
+func openValue(dec *json.Decoder, delim json.Delim) (bool, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+	if tok == nil {
+		return false, nil
+	}
+	if tok != delim {
+		return false, fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+	return true, nil
+}
+
+// skipValue consumes the next value of the decoder.
+func skipValue(dec *json.Decoder) error {
+	var raw json.RawMessage
+	return dec.Decode(&raw)
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,426 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadatatest"
+)
+
+func newTestClient(t *testing.T, endpoint string, backOff configretry.BackOffConfig) client {
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = endpoint
+	c, err := newClient(t.Context(), &APIConfig{ClientConfig: clientConfig, APIToken: "abc123", BackOffConfig: backOff}, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return c
+}
+
+// failingServer fails the first requests with the status, and then succeeds.
+func failingServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, "https://logs.example.com", body["destination_conf"])
+
+		if calls.Add(1) <= failures {
+			rw.WriteHeader(status)
+			require.NoError(t, json.NewEncoder(rw).Encode(map[string]any{
+				"success": false,
+				"errors":  []map[string]any{{"code": 10000, "message": "Internal error"}},
+			}))
+			return
+		}
+		require.NoError(t, json.NewEncoder(rw).Encode(map[string]any{
+			"success": true,
+			"result":  map[string]any{"id": 1},
+		}))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClientRetry(t *testing.T) {
+	backOff := configretry.NewDefaultBackOffConfig()
+	backOff.InitialInterval = time.Millisecond
+	backOff.MaxInterval = time.Millisecond
+	request := logpushJobRequest{DestinationConf: "https://logs.example.com"}
+
+	t.Run("server error", func(t *testing.T) {
+		server, calls := failingServer(t, 2, http.StatusServiceUnavailable)
+		// The body of the request is sent again on every attempt.
+		job, err := newTestClient(t, server.URL, backOff).CreateZoneLogpushJob(t.Context(), testZoneID, request)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), job.ID)
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		server, calls := failingServer(t, 1, http.StatusBadRequest)
+		_, err := newTestClient(t, server.URL, backOff).CreateZoneLogpushJob(t.Context(), testZoneID, request)
+		require.ErrorContains(t, err, "failed with status code 400")
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		server, calls := failingServer(t, 1, http.StatusBadGateway)
+		_, err := newTestClient(t, server.URL, configretry.BackOffConfig{}).CreateZoneLogpushJob(t.Context(), testZoneID, request)
+		require.ErrorContains(t, err, "failed with status code 502")
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("max elapsed time", func(t *testing.T) {
+		server, calls := failingServer(t, 100, http.StatusInternalServerError)
+		limited := backOff
+		limited.InitialInterval = 30 * time.Millisecond
+		limited.MaxInterval = 30 * time.Millisecond
+		limited.RandomizationFactor = 0
+		limited.MaxElapsedTime = 45 * time.Millisecond
+		_, err := newTestClient(t, server.URL, limited).CreateZoneLogpushJob(t.Context(), testZoneID, request)
+		require.ErrorContains(t, err, "failed with status code 500")
+		// The second retry would exceed the max elapsed time, so the request is given up.
+		require.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		server, calls := failingServer(t, 100, http.StatusInternalServerError)
+		delayed := backOff
+		delayed.InitialInterval = time.Minute
+		delayed.MaxInterval = time.Minute
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		defer cancel()
+		_, err := newTestClient(t, server.URL, delayed).CreateZoneLogpushJob(ctx, testZoneID, request)
+		require.ErrorContains(t, err, "failed with status code 500")
+		// The retry would happen after the deadline, so the request is given up without waiting for it.
+		require.NoError(t, ctx.Err())
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("graphql", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) == 1 {
+				rw.WriteHeader(http.StatusBadGateway)
+				_, _ = rw.Write([]byte(`{"data":null,"errors":[{"message":"bad gateway"}]}`))
+				return
+			}
+			_, _ = rw.Write([]byte(`{"data":{"viewer":{"zones":[{"n0":[{"count":3}]}]}},"errors":null}`))
+		}))
+		defer server.Close()
+
+		// The body of the query is sent again on every attempt.
+		var data analyticsData
+		require.NoError(t, newTestClient(t, server.URL, backOff).QueryGraphQL(t.Context(),
+			"query { viewer { zones { n0 } } }", map[string]any{"tag": testZoneID}, &data))
+		require.Equal(t, int32(2), calls.Load())
+		require.Equal(t, []map[string][]analyticsGroup{{"n0": {{"count": 3.0}}}}, data.Viewer.Zones)
+	})
+}
+
+func TestClientTelemetry(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	defer func() { require.NoError(t, tt.Shutdown(t.Context())) }()
+
+	const (
+		unavailable = `{"success":false,"errors":[{"code":10000,"message":"Internal error"}]}`
+		rateLimited = `{"success":false,"errors":[{"code":10000,"message":"Rate limited"}]}`
+		ok          = `{"success":true,"result":[]}`
+	)
+	responses := []struct {
+		status int
+		body   string
+	}{
+		{http.StatusServiceUnavailable, unavailable},
+		{http.StatusOK, ok},
+		{http.StatusTooManyRequests, rateLimited},
+	}
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		response := responses[calls.Add(1)-1]
+		rw.WriteHeader(response.status)
+		_, err := rw.Write([]byte(response.body))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	backOff := configretry.NewDefaultBackOffConfig()
+	backOff.InitialInterval = time.Millisecond
+	c, err := newClient(t.Context(), &APIConfig{ClientConfig: clientConfig, APIToken: "abc123", BackOffConfig: backOff}, componenttest.NewNopHost(), tt.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	_, err = c.ListZoneLogpushJobs(t.Context(), testZoneID)
+	require.NoError(t, err)
+	_, err = c.ListZoneLogpushJobs(t.Context(), testZoneID)
+	require.ErrorContains(t, err, "failed with status code 429")
+
+	metadatatest.AssertEqualCloudflareAPIRequests(t, tt, []metricdata.DataPoint[int64]{
+		{Value: 1, Attributes: attribute.NewSet(attribute.Int("http.response.status_code", http.StatusServiceUnavailable))},
+		{Value: 1, Attributes: attribute.NewSet(attribute.Int("http.response.status_code", http.StatusOK))},
+		{Value: 1, Attributes: attribute.NewSet(attribute.Int("http.response.status_code", http.StatusTooManyRequests))},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualCloudflareAPIRetries(t, tt, []metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualCloudflareAPIRateLimited(t, tt, []metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualCloudflareAPIErrors(t, tt, []metricdata.DataPoint[int64]{
+		{Value: 1, Attributes: attribute.NewSet(attribute.String("error.type", "other"))},
+		{Value: 1, Attributes: attribute.NewSet(attribute.String("error.type", "rate_limited"))},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualCloudflareAPIResponseSize(t, tt, []metricdata.DataPoint[int64]{
+		{Value: int64(len(unavailable) + len(ok) + len(rateLimited))},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestDecodeResponse(t *testing.T) {
+	var jobs apiResponse[[]logpushJob]
+	require.NoError(t, decodeResponse(strings.NewReader(`{
+		"result": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}],
+		"result_info": {"page": 1, "count": 2},
+		"success": true,
+		"errors": [],
+		"messages": []
+	}`), &jobs))
+	require.True(t, jobs.Success)
+	require.Equal(t, []logpushJob{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, jobs.Result)
+
+	var missing apiResponse[[]logpushJob]
+	require.NoError(t, decodeResponse(strings.NewReader(`{"success":false,"errors":[{"code":7003,"message":"Could not route"}],"result":null}`), &missing))
+	require.Nil(t, missing.Result)
+	require.Equal(t, []apiError{{Code: 7003, Message: "Could not route"}}, missing.Errors)
+
+	var pulled apiResponse[queuePullResult]
+	require.NoError(t, decodeResponse(strings.NewReader(`{"success":true,"result":{"messages":[{"lease_id":"1"}]}}`), &pulled))
+	require.Equal(t, "1", pulled.Result.Messages[0].LeaseID)
+
+	var raw apiResponse[json.RawMessage]
+	require.NoError(t, decodeResponse(strings.NewReader(`{"success":true,"result":{"ackCount":1}}`), &raw))
+	require.JSONEq(t, `{"ackCount":1}`, string(raw.Result))
+
+	require.Error(t, decodeResponse(strings.NewReader(`{"success":true,"result":{"id":1}}`), &jobs))
+	require.Error(t, decodeResponse(strings.NewReader(`{"success":true,"result":[{"id":1}`), &jobs))
+	require.Error(t, decodeResponse(strings.NewReader(`<html>Bad Gateway</html>`), &jobs))
+}
+
+func TestClientMaxGraphQLResponseSize(t *testing.T) {
+	response := `{"data":{"viewer":{"zones":[{"n0":[{"count":3},{"count":1}]}]}},"errors":null}`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, err := rw.Write([]byte(response))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	c, err := newClient(t.Context(), &APIConfig{ClientConfig: clientConfig, APIToken: "abc123", MaxResponseSize: int64(len(response)) - 1}, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	// The response is given up as soon as it exceeds the limit, suggesting how to narrow the query.
+	var data analyticsData
+	err = c.QueryGraphQL(t.Context(), "query { viewer { zones { n0 } } }", map[string]any{"tag": testZoneID}, &data)
+	require.ErrorIs(t, err, errResponseTooLarge)
+	require.ErrorContains(t, err, "response to /graphql exceeds max_response_size of 77 bytes, "+
+		"narrow the query, e.g. with a smaller limit in dataset_options, fewer dimensions or a filter, or raise the limit")
+}
+
+func TestDecodeGraphQLResponse(t *testing.T) {
+	// The groups of the zones and accounts are decoded one by one, skipping unknown members.
+	var data analyticsData
+	resp := graphQLResponse{Data: graphQLData{value: &data}}
+	require.NoError(t, decodeResponse(strings.NewReader(`{
+		"data": {"viewer": {
+			"budget": 1000,
+			"zones": [{"n0": [{"count": 3, "dimensions": {"action": "block"}}, {"count": 1}], "n1": null}],
+			"accounts": null
+		}},
+		"errors": null,
+		"extensions": {"cost": 1}
+	}`), &resp))
+	require.True(t, resp.Data.set)
+	require.Empty(t, resp.Errors)
+	require.Equal(t, []analyticsGroup{{"count": 3.0, "dimensions": map[string]any{"action": "block"}}, {"count": 1.0}}, data.groups(false, "n0"))
+	require.Empty(t, data.groups(false, "n1"))
+	require.Empty(t, data.groups(true, "n0"))
+
+	// Null data is told apart from empty data.
+	resp = graphQLResponse{Data: graphQLData{value: &analyticsData{}}}
+	require.NoError(t, decodeResponse(strings.NewReader(`{"data":null,"errors":[{"message":"not authorized"}]}`), &resp))
+	require.False(t, resp.Data.set)
+	require.Len(t, resp.Errors, 1)
+
+	// Values of the caller that aren't decoded by members are decoded at once.
+	var raw map[string]any
+	resp = graphQLResponse{Data: graphQLData{value: &raw}}
+	require.NoError(t, decodeResponse(strings.NewReader(`{"data":{"viewer":{}}}`), &resp))
+	require.True(t, resp.Data.set)
+	require.Equal(t, map[string]any{"viewer": map[string]any{}}, raw)
+
+	for _, malformed := range []string{
+		`{"data":{"viewer":{"zones":[{"n0":[{"count":3}`,
+		`{"data":{"viewer":{"zones":{"n0":[]}}}}`,
+		`<html>Bad Gateway</html>`,
+	} {
+		resp = graphQLResponse{Data: graphQLData{value: &analyticsData{}}}
+		require.Error(t, decodeResponse(strings.NewReader(malformed), &resp), malformed)
+	}
+}
+
+func TestClientMaxResponseSize(t *testing.T) {
+	response := `{"success":true,"errors":[],"result":[{"id":1,"name":"a"},{"id":2,"name":"b"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, err := rw.Write([]byte(response))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name        string
+		maxSize     int64
+		expectedErr string
+	}{
+		{name: "unlimited"},
+		{name: "exact", maxSize: int64(len(response))},
+		{
+			name:        "exceeded",
+			maxSize:     int64(len(response)) - 1,
+			expectedErr: "response to /zones/" + testZoneID + "/logpush/jobs exceeds max_response_size of 78 bytes",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clientConfig := confighttp.NewDefaultClientConfig()
+			clientConfig.Endpoint = server.URL
+			c, err := newClient(t.Context(), &APIConfig{ClientConfig: clientConfig, APIToken: "abc123", MaxResponseSize: tc.maxSize}, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			jobs, err := c.ListZoneLogpushJobs(t.Context(), testZoneID)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				require.ErrorIs(t, err, errResponseTooLarge)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, jobs, 2)
+		})
+	}
+}
+
+func TestClientPartialResult(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		response     string
+		expectedJobs []logpushJob
+		partial      bool
+	}{
+		{
+			name:         "partial result",
+			response:     `{"success":false,"errors":[{"code":1000,"message":"job 2 unavailable"}],"result":[{"id":1,"name":"a"}]}`,
+			expectedJobs: []logpushJob{{ID: 1, Name: "a"}},
+			partial:      true,
+		},
+		{
+			name:         "empty result",
+			response:     `{"success":false,"errors":[{"code":1000,"message":"job 2 unavailable"}],"result":[]}`,
+			expectedJobs: []logpushJob{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				_, err := rw.Write([]byte(tc.response))
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			jobs, err := newTestClient(t, server.URL, newDefaultAPIConfig().BackOffConfig).ListZoneLogpushJobs(t.Context(), testZoneID)
+			var partialErr *partialResultError
+			require.Equal(t, tc.partial, errors.As(err, &partialErr))
+			require.ErrorIs(t, err, apiError{Code: 1000, Message: "job 2 unavailable"})
+			require.Equal(t, tc.expectedJobs, jobs)
+		})
+	}
+}
+
+func TestClientQueryGraphQL(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		status      int
+		response    string
+		expectedErr string
+		partial     bool
+	}{
+		{
+			name:     "data",
+			status:   http.StatusOK,
+			response: `{"data":{"viewer":{"zones":[{"n0":[{"count":3}]}]}},"errors":null}`,
+		},
+		{
+			name:        "partial data",
+			status:      http.StatusOK,
+			response:    `{"data":{"viewer":{"zones":[{"n0":[{"count":3}]}]}},"errors":[{"message":"node n1 unavailable","path":["viewer","zones","0","n1"]}]}`,
+			expectedErr: "response to /graphql holds a partial result: node n1 unavailable",
+			partial:     true,
+		},
+		{
+			name:        "errors without data",
+			status:      http.StatusOK,
+			response:    `{"data":null,"errors":[{"message":"unknown field","extensions":{"code":"unknown"}}]}`,
+			expectedErr: "request to /graphql failed with status code 200\nunknown: unknown field",
+		},
+		{
+			name:     "known error without data",
+			status:   http.StatusOK,
+			response: `{"data":null,"errors":[{"message":"zone '023e105f4ecef8ad9ca31a8372d0c353' does not have access to the path","path":["viewer","zones","0","n0"]}]}`,
+			expectedErr: "request to /graphql failed with status code 200: the feature isn't available on the plan of the zone or account\n" +
+				"zone '023e105f4ecef8ad9ca31a8372d0c353' does not have access to the path",
+		},
+		{
+			name:        "status error",
+			status:      http.StatusForbidden,
+			response:    `{"data":null,"errors":[{"message":"not authorized"}]}`,
+			expectedErr: "request to /graphql failed with status code 403: the API token is invalid, expired, or lacks the permission required by this request\nnot authorized",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				var body graphQLRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				require.Equal(t, "query { viewer { zones { n0 } } }", body.Query)
+				require.Equal(t, map[string]any{"tag": testZoneID}, body.Variables)
+				rw.WriteHeader(tc.status)
+				_, err := rw.Write([]byte(tc.response))
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			var data analyticsData
+			err := newTestClient(t, server.URL, newDefaultAPIConfig().BackOffConfig).QueryGraphQL(t.Context(),
+				"query { viewer { zones { n0 } } }", map[string]any{"tag": testZoneID}, &data)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedErr)
+			}
+			var partialErr *partialResultError
+			require.Equal(t, tc.partial, errors.As(err, &partialErr))
+			if tc.status == http.StatusOK && (tc.expectedErr == "" || tc.partial) {
+				require.Equal(t, []map[string][]analyticsGroup{{"n0": {{"count": 3.0}}}}, data.Viewer.Zones)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// Attributes shared by the log records of several sources.
+const (
+	attrAccountID = "cloudflare.account.id"
+	attrZoneID    = "cloudflare.zone.id"
+	attrZoneName  = "cloudflare.zone.name"
+	// attrZoneNameDeprecated is the name of attrZoneName on Logpush logs unless the
+	// zoneNameAttributeFeatureGate is enabled.
+	attrZoneNameDeprecated = "cloudflare.zone"
+	attrUserEmail          = "user.email"
+	attrClientAddress      = "client.address"
+)
+
+// combinedLogsReceiver wraps the Logpush and Notifications endpoints, the API, GraphQL and bucket pollers and
+// the Instant Logs stream that emit logs in a single log receiver to be consumed by the factory.
+type combinedLogsReceiver struct {
+	logs           *sharedcomponent.SharedComponent
+	analyticsLogs  *sharedcomponent.SharedComponent
+	accessRequests *accessRequestsReceiver
+	auditLogs      *auditLogsReceiver
+	notifications  *notificationsReceiver
+	r2             *bucketReceiver
+	s3             *bucketReceiver
+	gcs            *bucketReceiver
+	instantLogs    *instantLogsReceiver
+	queues         *queuesReceiver
+}
+
+func (c *combinedLogsReceiver) Start(ctx context.Context, host component.Host) error {
+	var errs error
+
+	if c.logs != nil {
+		errs = multierr.Append(errs, c.logs.Start(ctx, host))
+	}
+
+	if c.analyticsLogs != nil {
+		errs = multierr.Append(errs, c.analyticsLogs.Start(ctx, host))
+	}
+
+	if c.accessRequests != nil {
+		errs = multierr.Append(errs, c.accessRequests.Start(ctx, host))
+	}
+
+	if c.auditLogs != nil {
+		errs = multierr.Append(errs, c.auditLogs.Start(ctx, host))
+	}
+
+	if c.notifications != nil {
+		errs = multierr.Append(errs, c.notifications.Start(ctx, host))
+	}
+
+	if c.r2 != nil {
+		errs = multierr.Append(errs, c.r2.Start(ctx, host))
+	}
+
+	if c.s3 != nil {
+		errs = multierr.Append(errs, c.s3.Start(ctx, host))
+	}
+
+	if c.gcs != nil {
+		errs = multierr.Append(errs, c.gcs.Start(ctx, host))
+	}
+
+	if c.instantLogs != nil {
+		errs = multierr.Append(errs, c.instantLogs.Start(ctx, host))
+	}
+
+	if c.queues != nil {
+		errs = multierr.Append(errs, c.queues.Start(ctx, host))
+	}
+
+	return errs
+}
+
+func (c *combinedLogsReceiver) Shutdown(ctx context.Context) error {
+	var errs error
+
+	if c.logs != nil {
+		errs = multierr.Append(errs, c.logs.Shutdown(ctx))
+	}
+
+	if c.analyticsLogs != nil {
+		errs = multierr.Append(errs, c.analyticsLogs.Shutdown(ctx))
+	}
+
+	if c.accessRequests != nil {
+		errs = multierr.Append(errs, c.accessRequests.Shutdown(ctx))
+	}
+
+	if c.auditLogs != nil {
+		errs = multierr.Append(errs, c.auditLogs.Shutdown(ctx))
+	}
+
+	if c.notifications != nil {
+		errs = multierr.Append(errs, c.notifications.Shutdown(ctx))
+	}
+
+	if c.r2 != nil {
+		errs = multierr.Append(errs, c.r2.Shutdown(ctx))
+	}
+
+	if c.s3 != nil {
+		errs = multierr.Append(errs, c.s3.Shutdown(ctx))
+	}
+
+	if c.gcs != nil {
+		errs = multierr.Append(errs, c.gcs.Shutdown(ctx))
+	}
+
+	if c.instantLogs != nil {
+		errs = multierr.Append(errs, c.instantLogs.Shutdown(ctx))
+	}
+
+	if c.queues != nil {
+		errs = multierr.Append(errs, c.queues.Shutdown(ctx))
+	}
+
+	return errs
+}
+
+// appendResourceLogs appends resource logs with a single scope identifying the receiver. Both are
+// stamped with the schema URL of the semantic conventions the emitted attributes follow.
+func appendResourceLogs(logs plog.Logs, buildInfo component.BuildInfo) (plog.ResourceLogs, plog.ScopeLogs) {
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.SetSchemaUrl(conventions.SchemaURL)
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.SetSchemaUrl(conventions.SchemaURL)
+	scopeLogs.Scope().SetName(metadata.ScopeName)
+	scopeLogs.Scope().SetVersion(buildInfo.Version)
+	return resourceLogs, scopeLogs
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+)
+
+// combinedMetricsReceiver wraps the Logpush jobs and analytics scrapers and the Logpush endpoint
+// deriving metrics from the received logs in a single metrics receiver to be consumed by the factory.
+type combinedMetricsReceiver struct {
+	logpushJobs receiver.Metrics
+	analytics   receiver.Metrics
+	logs        *sharedcomponent.SharedComponent
+}
+
+func (c *combinedMetricsReceiver) Start(ctx context.Context, host component.Host) error {
+	var errs error
+
+	if c.logpushJobs != nil {
+		errs = multierr.Append(errs, c.logpushJobs.Start(ctx, host))
+	}
+
+	if c.analytics != nil {
+		errs = multierr.Append(errs, c.analytics.Start(ctx, host))
+	}
+
+	if c.logs != nil {
+		errs = multierr.Append(errs, c.logs.Start(ctx, host))
+	}
+
+	return errs
+}
+
+func (c *combinedMetricsReceiver) Shutdown(ctx context.Context) error {
+	var errs error
+
+	if c.logpushJobs != nil {
+		errs = multierr.Append(errs, c.logpushJobs.Shutdown(ctx))
+	}
+
+	if c.analytics != nil {
+		errs = multierr.Append(errs, c.analytics.Shutdown(ctx))
+	}
+
+	if c.logs != nil {
+		errs = multierr.Append(errs, c.logs.Shutdown(ctx))
+	}
+
+	return errs
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+)
+
+// combinedTracesReceiver wraps the Logpush endpoint deriving spans from the Workers trace events and
+// the GraphQL poller deriving spans from the sampled requests in a single traces receiver to be
+// consumed by the factory.
+type combinedTracesReceiver struct {
+	logs          *sharedcomponent.SharedComponent
+	analyticsLogs *sharedcomponent.SharedComponent
+}
+
+func (c *combinedTracesReceiver) Start(ctx context.Context, host component.Host) error {
+	var errs error
+
+	if c.logs != nil {
+		errs = multierr.Append(errs, c.logs.Start(ctx, host))
+	}
+
+	if c.analyticsLogs != nil {
+		errs = multierr.Append(errs, c.analyticsLogs.Start(ctx, host))
+	}
+
+	return errs
+}
+
+func (c *combinedTracesReceiver) Shutdown(ctx context.Context) error {
+	var errs error
+
+	if c.logs != nil {
+		errs = multierr.Append(errs, c.logs.Shutdown(ctx))
+	}
+
+	if c.analyticsLogs != nil {
+		errs = multierr.Append(errs, c.analyticsLogs.Shutdown(ctx))
+	}
+
+	return errs
+}
@@ -4,76 +4,1628 @@
 package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
 	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
 )
 
 // Config holds all the parameters to start an HTTP server that can be sent logs from CloudFlare
 type Config struct {
-	Logs LogsConfig `mapstructure:"logs"`
+	Logs           LogsConfig                                    `mapstructure:"logs"`
+	LogpushJobs    configoptional.Optional[LogpushJobsConfig]    `mapstructure:"logpush_jobs"`
+	Analytics      configoptional.Optional[AnalyticsConfig]      `mapstructure:"analytics"`
+	AnalyticsLogs  configoptional.Optional[AnalyticsLogsConfig]  `mapstructure:"analytics_logs"`
+	AccessRequests configoptional.Optional[AccessRequestsConfig] `mapstructure:"access_requests"`
+	AuditLogs      configoptional.Optional[AuditLogsConfig]      `mapstructure:"audit_logs"`
+	Notifications  configoptional.Optional[NotificationsConfig]  `mapstructure:"notifications"`
+	R2             configoptional.Optional[R2Config]             `mapstructure:"r2"`
+	S3             configoptional.Optional[S3Config]             `mapstructure:"s3"`
+	GCS            configoptional.Optional[GCSConfig]            `mapstructure:"gcs"`
+	InstantLogs    configoptional.Optional[InstantLogsConfig]    `mapstructure:"instant_logs"`
+	Queues         configoptional.Optional[QueuesConfig]         `mapstructure:"queues"`
+	// ManageJobs creates or updates the Logpush jobs sending logs to the Logpush endpoint at startup.
+	ManageJobs configoptional.Optional[ManageJobsConfig] `mapstructure:"manage_jobs"`
 
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
 
 type LogsConfig struct {
-	Secret          string                  `mapstructure:"secret"`
+	Secret          configopaque.String     `mapstructure:"secret"`
 	Endpoint        string                  `mapstructure:"endpoint"`
 	TLS             *configtls.ServerConfig `mapstructure:"tls"`
 	Attributes      map[string]string       `mapstructure:"attributes"`
 	TimestampField  string                  `mapstructure:"timestamp_field"`
 	TimestampFormat string                  `mapstructure:"timestamp_format"`
 	Separator       string                  `mapstructure:"separator"`
+	// Auth configures an authenticator extension that authenticates requests, in addition to the secret.
+	Auth configoptional.Optional[configauth.Config] `mapstructure:"auth"`
+	// Datasets configures how the logs received on specific paths are processed.
+	Datasets []DatasetConfig `mapstructure:"datasets"`
+	// SeverityRules sets the severity of the logs matching them, the first matching rule winning.
+	SeverityRules []SeverityRule `mapstructure:"severity_rules"`
+	// DropFields lists the fields removed from logs before they are processed, e.g. to avoid storing
+	// personal data.
+	DropFields []string `mapstructure:"drop_fields"`
+	// HashFields lists the fields whose value is replaced with its SHA-256 hash before logs are processed.
+	HashFields []string `mapstructure:"hash_fields"`
+	// Secrets lists additional accepted secrets, so that the secret can be rotated without downtime.
+	Secrets []configopaque.String `mapstructure:"secrets"`
+	// SemanticConventions renames the fields of automatically ingested logs that have an
+	// OpenTelemetry semantic convention equivalent.
+	SemanticConventions bool `mapstructure:"semantic_conventions"`
+	// ParseUserAgent sets the browser and operating system parsed from the ClientRequestUserAgent field.
+	ParseUserAgent bool `mapstructure:"parse_user_agent"`
+	// OCSF sets the OCSF representation of firewall and Gateway HTTP events in the ocsf attribute.
+	OCSF bool `mapstructure:"ocsf"`
+	// MaxDecompressedSize is the maximum size in bytes of a decompressed payload, 0 meaning no limit.
+	MaxDecompressedSize int64 `mapstructure:"max_decompressed_size"`
+	// MaxInFlightSize is the maximum total size in bytes of the payloads being processed at once, 0
+	// meaning no limit. Payloads received beyond it are refused, so that Cloudflare retries them later.
+	MaxInFlightSize int64 `mapstructure:"max_in_flight_size"`
+	// MaxRequestBodySize is the maximum size in bytes of a request body, before decompression, 0
+	// meaning no limit. Larger requests are refused.
+	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
+	// MaxConcurrentRequests is the maximum number of requests handled at once, 0 meaning no limit.
+	// Requests received beyond it are refused, so that Cloudflare retries them later.
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests"`
+	// ReadTimeout is the maximum duration for reading a request, including its body, 0 meaning no timeout.
+	ReadTimeout time.Duration `mapstructure:"read_timeout"`
+	// IdleTimeout is how long keep-alive connections are kept open while idle, 0 meaning read_timeout.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+	// ConsumeTimeout bounds how long a payload waits for the next consumers, 0 meaning no timeout.
+	// Payloads timing out are answered with a 503 status, so that Cloudflare retries them later.
+	ConsumeTimeout time.Duration `mapstructure:"consume_timeout"`
+	// TraceContextFromRayID sets the trace and span IDs of log records from the RayID field.
+	TraceContextFromRayID bool `mapstructure:"trace_context_from_ray_id"`
+	// ForwardUnparseable forwards the records that are rejected as log records with the raw line as
+	// body, instead of dropping them.
+	ForwardUnparseable bool `mapstructure:"forward_unparseable"`
+	// HealthCheckPath is the path answering with the health of the receiver, without authentication.
+	// Empty disables the health check.
+	HealthCheckPath string `mapstructure:"health_check_path"`
+	// HealthCheckFailureTimeout is how long a payload that failed to be delivered makes the health check
+	// fail, unless a later payload is delivered. 0 keeps it failing until then.
+	HealthCheckFailureTimeout time.Duration `mapstructure:"health_check_failure_timeout"`
+	// DeriveMetrics counts the received records in the cloudflare.logpush.records metric when the
+	// receiver is part of a metrics pipeline.
+	DeriveMetrics bool `mapstructure:"derive_metrics"`
+	// DetectDataset detects the dataset of the logs received on paths without a dataset from their
+	// fields, so that they are processed with the settings of the dataset.
+	DetectDataset bool `mapstructure:"detect_dataset"`
+	// ExtrapolateSamples counts every record of a sampled dataset as SampleInterval requests in the
+	// cloudflare.logpush.records metric, rather than as one.
+	ExtrapolateSamples bool `mapstructure:"extrapolate_samples"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// DatasetConfig holds the settings used to process the logs of a Logpush dataset received on a path.
+// Unset settings default to the ones of the logs configuration.
+type DatasetConfig struct {
+	// Path is the URL path the Logpush job of the dataset sends logs to.
+	Path string `mapstructure:"path"`
+	// Dataset is the name of the Logpush dataset, e.g. firewall_events, which sets the default
+	// timestamp field and the cloudflare.dataset resource attribute.
+	Dataset         string            `mapstructure:"dataset"`
+	TimestampField  string            `mapstructure:"timestamp_field"`
+	TimestampFormat string            `mapstructure:"timestamp_format"`
+	Attributes      map[string]string `mapstructure:"attributes"`
+	// ResourceAttributes are set on the resource of all logs of the dataset.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+	// DropFields and HashFields list the fields removed or hashed before the logs are processed.
+	DropFields []string `mapstructure:"drop_fields"`
+	HashFields []string `mapstructure:"hash_fields"`
+	// SampleInterval is the number of requests every log stands for when the Logpush job samples
+	// them, i.e. one over its sample_rate. Logs sampled with an interval above 1 get the
+	// cloudflare.sample_interval attribute.
+	SampleInterval int `mapstructure:"sample_interval"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// merge returns the dataset settings, defaulting unset ones to the settings of d.
+func (d *DatasetConfig) merge(ds DatasetConfig) *DatasetConfig {
+	if ds.Dataset != "" {
+		if ds.TimestampField == "" {
+			ds.TimestampField = datasetTimestampFields[ds.Dataset]
+			if ds.TimestampFormat == "" {
+				ds.TimestampFormat = datasetTimestampFormats[ds.Dataset]
+			}
+		}
+		if _, ok := ds.ResourceAttributes[attrDataset]; !ok {
+			resourceAttributes := map[string]string{attrDataset: ds.Dataset}
+			maps.Copy(resourceAttributes, ds.ResourceAttributes)
+			ds.ResourceAttributes = resourceAttributes
+		}
+	}
+	if ds.TimestampField == "" {
+		ds.TimestampField = d.TimestampField
+	}
+	if ds.TimestampFormat == "" {
+		ds.TimestampFormat = d.TimestampFormat
+	}
+	if ds.Attributes == nil {
+		ds.Attributes = d.Attributes
+	}
+	if ds.DropFields == nil {
+		ds.DropFields = d.DropFields
+	}
+	if ds.HashFields == nil {
+		ds.HashFields = d.HashFields
+	}
+	if ds.SampleInterval == 0 {
+		ds.SampleInterval = d.SampleInterval
+	}
+	return &ds
+}
+
+// SeverityRule sets the severity of logs whose field equals a value, or lies within a numeric range.
+type SeverityRule struct {
+	Field string `mapstructure:"field"`
+	// Equals matches the field when its value, formatted as a string, is equal.
+	Equals *string `mapstructure:"equals"`
+	// Min and Max are the inclusive bounds of the numeric range matching the field.
+	Min *float64 `mapstructure:"min"`
+	Max *float64 `mapstructure:"max"`
+	// Severity is one of trace, debug, info, warn, error or fatal.
+	Severity string `mapstructure:"severity"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// PathRule normalizes paths by replacing the matches of a regular expression.
+type PathRule struct {
+	// Pattern is the regular expression, such as ^/users/\d+.
+	Pattern string `mapstructure:"pattern"`
+	// Replacement replaces the matches of Pattern, and may refer to its submatches, such as ${1}.
+	Replacement string `mapstructure:"replacement"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// APIConfig holds the settings used to connect to the Cloudflare API.
+type APIConfig struct {
+	confighttp.ClientConfig `mapstructure:",squash"`
+
+	// APIToken is a Cloudflare API token with read access to the polled resources.
+	APIToken configopaque.String `mapstructure:"api_token"`
+	// BackOffConfig configures how requests failing with a 5xx status or a network error are retried.
+	BackOffConfig configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+	// MaxResponseSize is the maximum size in bytes of a response of the API, 0 meaning no limit.
+	MaxResponseSize int64 `mapstructure:"max_response_size"`
+}
+
+// LogpushJobsConfig configures polling of the Cloudflare API for the health of Logpush jobs.
+type LogpushJobsConfig struct {
+	scraperhelper.ControllerConfig `mapstructure:",squash"`
+	APIConfig                      `mapstructure:",squash"`
+	metadata.MetricsBuilderConfig  `mapstructure:",squash"`
+
+	// Zones lists the IDs or names of the zones whose Logpush jobs are monitored. Names are resolved
+	// to IDs on start.
+	Zones []string `mapstructure:"zones"`
+	// Accounts lists the IDs or names of the accounts whose Logpush jobs are monitored.
+	Accounts []string `mapstructure:"accounts"`
+	// QueryTimeout bounds every request to the API, including its retries, 0 meaning no timeout. The
+	// whole scrape is bounded by the timeout of the controller.
+	QueryTimeout time.Duration `mapstructure:"query_timeout"`
+	// ZoneCacheTTL is how long the details of a zone are cached before they're refreshed, 0 meaning
+	// they're never refreshed.
+	ZoneCacheTTL time.Duration `mapstructure:"zone_cache_ttl"`
+	// CircuitBreaker pauses the polling of zones and accounts whose jobs repeatedly fail to be listed.
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	// PlanRecheckInterval is how often the zones and accounts whose plan doesn't include Logpush are
+	// checked again, 0 meaning they're no longer polled until the next start.
+	PlanRecheckInterval time.Duration `mapstructure:"plan_recheck_interval"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// CircuitBreakerConfig configures when the polling of a zone or account is paused after failures.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures after which a zone or account is paused,
+	// 0 disabling the circuit breaker.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// Cooldown is how long a zone or account is paused before it's probed again.
+	Cooldown time.Duration `mapstructure:"cooldown"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AnalyticsConfig configures polling of the GraphQL Analytics API for the analytics of zones and
+// accounts.
+type AnalyticsConfig struct {
+	scraperhelper.ControllerConfig `mapstructure:",squash"`
+	APIConfig                      `mapstructure:",squash"`
+	metadata.MetricsBuilderConfig  `mapstructure:",squash"`
+
+	// Zones lists the IDs or names of the zones whose analytics are collected. Names are resolved to
+	// IDs on start.
+	Zones []string `mapstructure:"zones"`
+	// Accounts lists the IDs or names of the accounts whose analytics are collected.
+	Accounts []string `mapstructure:"accounts"`
+	// Datasets lists the analytics datasets collected, such as waiting_room. The datasets of zones are
+	// collected for every zone, and the datasets of accounts for every account.
+	Datasets []string `mapstructure:"datasets"`
+	// Delay is how long the end of every polled window lags behind the time of the scrape, so that the
+	// events of the window were processed by Cloudflare by the time it's polled.
+	Delay time.Duration `mapstructure:"delay"`
+	// Tenants lists further accounts whose analytics are collected with their own API token, such as
+	// the accounts of customers.
+	Tenants []AnalyticsTenantConfig `mapstructure:"tenants"`
+	// Attributes renames the attributes of the data points, such as geo.country.iso_code, or drops
+	// those mapped to an empty name. The data points left with the same attributes are merged.
+	Attributes map[string]string `mapstructure:"attributes"`
+	// CardinalityLimits limits the number of values of attributes of every metric, such as user.email,
+	// the data points of the values beyond the top values being merged under the value other.
+	CardinalityLimits map[string]int `mapstructure:"cardinality_limits"`
+	// CustomQueries lists the nodes of the GraphQL Analytics API queried in addition to the datasets,
+	// whose groups are emitted as the configured metrics.
+	CustomQueries []AnalyticsQueryConfig `mapstructure:"custom_queries"`
+	// DatasetOptions overrides how the nodes of the datasets and custom queries are queried, by name.
+	DatasetOptions map[string]AnalyticsDatasetOptions `mapstructure:"dataset_options"`
+	// AggregationTemporality is delta to emit the counts of every polled window as delta sums, or
+	// cumulative to emit the running totals of the counts since the receiver started.
+	AggregationTemporality string `mapstructure:"aggregation_temporality"`
+	// StorageID is the storage extension used to persist the running totals of the counts across
+	// restarts, when they are emitted as cumulative sums.
+	StorageID *component.ID `mapstructure:"storage"`
+	// Exemplars attaches the Ray ID of the latest event counted by the data points of the metrics of
+	// HTTP requests and firewall events as an exemplar, so that metrics link to example events.
+	Exemplars bool `mapstructure:"exemplars"`
+	// QueryTimeout bounds every query of the GraphQL Analytics API, including its retries, 0 meaning no
+	// timeout. The whole scrape is bounded by the timeout of the controller.
+	QueryTimeout time.Duration `mapstructure:"query_timeout"`
+	// ZoneCacheTTL is how long the details of a zone are cached before they're refreshed, 0 meaning
+	// they're never refreshed.
+	ZoneCacheTTL time.Duration `mapstructure:"zone_cache_ttl"`
+	// CircuitBreaker pauses the queries of zones and accounts whose analytics repeatedly fail to be
+	// queried.
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	// PlanRecheckInterval is how often the datasets missing from the plan of a zone or account are
+	// queried again, 0 meaning they're no longer queried until the next start.
+	PlanRecheckInterval time.Duration `mapstructure:"plan_recheck_interval"`
+	// Retention is how long Cloudflare keeps the analytics of the datasets, depending on the dataset
+	// and the plan. Windows older than the retention are no longer queried. 0 queries every window
+	// since the last scrape.
+	Retention time.Duration `mapstructure:"retention"`
+	// AlignWindow truncates the end of every queried window to a multiple of CollectionInterval, so
+	// that every scrape covers whole intervals, such as whole minutes.
+	AlignWindow bool `mapstructure:"align_window"`
+	// DeriveRatios computes the cache hit, origin error and blocked ratios of every zone whose
+	// http_requests dataset is collected.
+	DeriveRatios bool `mapstructure:"derive_ratios"`
+	// Dimensions adds optional dimensions, such as client_asn, to the requests and firewall events
+	// counted by the http_requests and firewall_events datasets.
+	Dimensions []string `mapstructure:"dimensions"`
+	// Hosts limits the hosts of the requests and firewall events counted by the http_requests and
+	// firewall_events datasets to the listed ones, the other hosts being counted under the "other"
+	// host. Empty keeps every host.
+	Hosts []string `mapstructure:"hosts"`
+	// PathRules normalize the paths of the path dimension, every rule being applied in order, so
+	// that paths holding IDs don't start a series per ID.
+	PathRules []PathRule `mapstructure:"path_rules"`
+	// TopFingerprints limits the values of the ja3 and ja4 dimensions of every metric to the ones
+	// with the most requests or events, the other fingerprints being merged under the "other"
+	// fingerprint. 0 keeps every fingerprint.
+	TopFingerprints int `mapstructure:"top_fingerprints"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AnalyticsTenantConfig configures the analytics collected for a tenant, an account monitored with
+// its own API token.
+type AnalyticsTenantConfig struct {
+	// Name identifies the tenant in the configuration errors.
+	Name string `mapstructure:"name"`
+	// APIToken is a Cloudflare API token with read access to the analytics of the tenant. It defaults
+	// to the api_token of the analytics section.
+	APIToken configopaque.String `mapstructure:"api_token"`
+	// Zones lists the IDs or names of the zones of the tenant whose analytics are collected.
+	Zones []string `mapstructure:"zones"`
+	// Accounts lists the IDs or names of the accounts of the tenant whose analytics are collected.
+	Accounts []string `mapstructure:"accounts"`
+	// Datasets lists the analytics datasets collected for the tenant. It defaults to the datasets of
+	// the analytics section.
+	Datasets []string `mapstructure:"datasets"`
+	// ResourceAttributes are set on the resources of all metrics of the tenant.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// tenants returns the tenants whose analytics are collected, with their defaults applied. The zones,
+// accounts and datasets of the analytics section make up an unnamed tenant, unless only tenants are
+// configured.
+func (a *AnalyticsConfig) tenants() []AnalyticsTenantConfig {
+	var tenants []AnalyticsTenantConfig
+	if len(a.Zones) > 0 || len(a.Accounts) > 0 || len(a.Tenants) == 0 {
+		tenants = append(tenants, AnalyticsTenantConfig{
+			APIToken: a.APIToken,
+			Zones:    a.Zones,
+			Accounts: a.Accounts,
+			Datasets: a.Datasets,
+		})
+	}
+	for _, tenant := range a.Tenants {
+		if tenant.APIToken == "" {
+			tenant.APIToken = a.APIToken
+		}
+		if len(tenant.Datasets) == 0 {
+			tenant.Datasets = a.Datasets
+		}
+		tenants = append(tenants, tenant)
+	}
+	return tenants
+}
+
+// collects returns whether the dataset is collected for any tenant.
+func (a *AnalyticsConfig) collects(dataset string) bool {
+	for _, tenant := range a.tenants() {
+		if slices.Contains(tenant.Datasets, dataset) {
+			return true
+		}
+	}
+	return false
+}
+
+// cardinalityLimits returns the cardinality limits of the attributes, to which top_fingerprints adds
+// the limits of the fingerprint attributes, as renamed by the attributes, unless they're limited
+// already or dropped.
+func (a *AnalyticsConfig) cardinalityLimits() map[string]int {
+	if a.TopFingerprints <= 0 {
+		return a.CardinalityLimits
+	}
+	limits := maps.Clone(a.CardinalityLimits)
+	if limits == nil {
+		limits = map[string]int{}
+	}
+	for _, attr := range []string{"tls.client.ja3", "tls.client.ja4"} {
+		if renamed, ok := a.Attributes[attr]; ok {
+			attr = renamed
+		}
+		if _, ok := limits[attr]; !ok && attr != "" {
+			limits[attr] = a.TopFingerprints
+		}
+	}
+	return limits
+}
+
+// AnalyticsDatasetOptions overrides how the nodes of a dataset of the GraphQL Analytics API are
+// queried.
+type AnalyticsDatasetOptions struct {
+	// Limit is the maximum number of groups returned for every node, at most 10000, the default.
+	Limit int `mapstructure:"limit"`
+	// OrderBy orders the groups of every node, such as count_DESC, so that the groups kept by the
+	// limit are the top ones.
+	OrderBy []string `mapstructure:"order_by"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AnalyticsQueryConfig configures a custom query of a node of the GraphQL Analytics API, such as
+// spectrumNetworkAnalyticsAdaptiveGroups, for the datasets the receiver doesn't support.
+type AnalyticsQueryConfig struct {
+	// Name identifies the query in the configuration errors and the logs.
+	Name string `mapstructure:"name"`
+	// Scope is zone, the default, when the node is queried for every zone, or account when it's
+	// queried for every account.
+	Scope string `mapstructure:"scope"`
+	// Node is the name of the node in the GraphQL schema.
+	Node string `mapstructure:"node"`
+	// Fields is the selection of the groups of the node, such as count dimensions { coloCode }.
+	Fields string `mapstructure:"fields"`
+	// Filter holds the conditions added to the filter of the node on the polled window.
+	Filter string `mapstructure:"filter"`
+	// Metrics lists the metrics emitted for every group of the node.
+	Metrics []AnalyticsQueryMetricConfig `mapstructure:"metrics"`
+	// Attributes maps the dot-separated paths of the fields of the groups, such as
+	// dimensions.coloCode, to the attributes of the data points they are copied to.
+	Attributes map[string]string `mapstructure:"attributes"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AnalyticsQueryMetricConfig configures a metric emitted for every group of a custom query.
+type AnalyticsQueryMetricConfig struct {
+	// Name is the name of the metric.
+	Name string `mapstructure:"name"`
+	// Field is the dot-separated path of the numeric field of the groups holding the value of the
+	// metric, such as sum.bits.
+	Field string `mapstructure:"field"`
+	// Type is sum, the default, for the counts of the events of the polled window, emitted as delta
+	// sums, or gauge.
+	Type string `mapstructure:"type"`
+	// Description is the description of the metric.
+	Description string `mapstructure:"description"`
+	// Unit is the unit of the metric.
+	Unit string `mapstructure:"unit"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AnalyticsEventQueryConfig configures a custom query of a node of the GraphQL Analytics API whose
+// events are emitted as log records, such as dnsAnalyticsAdaptive.
+type AnalyticsEventQueryConfig struct {
+	// Name is the name of the dataset of the events, set in the cloudflare.dataset resource attribute.
+	Name string `mapstructure:"name"`
+	// Scope is zone, the default, when the node is queried for every zone, or account when it's
+	// queried for every account.
+	Scope string `mapstructure:"scope"`
+	// Node is the name of the node in the GraphQL schema.
+	Node string `mapstructure:"node"`
+	// Fields is the selection of the events, which must include their datetime.
+	Fields string `mapstructure:"fields"`
+	// Filter holds the conditions added to the filter of the node on the polled window.
+	Filter string `mapstructure:"filter"`
+	// ID is the field identifying an event among the events of the same time, such as rayName. The
+	// events are identified by their content when empty.
+	ID string `mapstructure:"id"`
+	// Attributes maps the fields of the events to the attributes of their log records.
+	Attributes map[string]string `mapstructure:"attributes"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AnalyticsLogsConfig configures polling of the GraphQL Analytics API for the events of zones and
+// accounts, which are emitted as log records.
+type AnalyticsLogsConfig struct {
+	APIConfig `mapstructure:",squash"`
+
+	// Zones lists the IDs or names of the zones whose events are collected. Names are resolved to IDs
+	// on start.
+	Zones []string `mapstructure:"zones"`
+	// Accounts lists the IDs or names of the accounts whose events are collected.
+	Accounts []string `mapstructure:"accounts"`
+	// Datasets lists the event datasets collected, such as firewall_events. The datasets of zones are
+	// collected for every zone, and the datasets of accounts for every account.
+	Datasets []string `mapstructure:"datasets"`
+	// PollInterval is how often the events are polled.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// Delay is how long the end of every polled window lags behind the time of the poll, so that the
+	// events of the window were processed by Cloudflare by the time it's polled.
+	Delay time.Duration `mapstructure:"delay"`
+	// Retention is how long Cloudflare keeps the events of the datasets, depending on the dataset and
+	// the plan. Events older than the retention are no longer requested. 0 requests every event since
+	// the last poll.
+	Retention time.Duration `mapstructure:"retention"`
+	// Attributes maps the fields of the events, such as clientCountryName, to the names of the
+	// attributes they are copied to, overriding the attributes of the datasets. Fields mapped to an
+	// empty name are not copied.
+	Attributes map[string]string `mapstructure:"attributes"`
+	// CustomQueries lists the nodes of the GraphQL Analytics API whose events are collected in
+	// addition to the datasets, as datasets named after the queries.
+	CustomQueries []AnalyticsEventQueryConfig `mapstructure:"custom_queries"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AccessRequestsConfig configures polling of Zero Trust Access authentication events.
+type AccessRequestsConfig struct {
+	APIConfig `mapstructure:",squash"`
+
+	// Accounts lists the IDs or names of the accounts whose Access authentication events are collected.
+	Accounts []string `mapstructure:"accounts"`
+	// PollInterval is how often new authentication events are fetched.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// PageSize is the maximum number of events requested at once.
+	PageSize int `mapstructure:"page_size"`
+	// Retention is how long Cloudflare keeps the events of the account, depending on its plan. Events
+	// older than the retention are no longer requested. 0 requests every event since the last poll.
+	Retention time.Duration `mapstructure:"retention"`
+	// AlignWindow truncates the end of every polled window to a multiple of PollInterval, so that every
+	// poll covers whole intervals, such as whole minutes.
+	AlignWindow bool `mapstructure:"align_window"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AuditLogsConfig configures polling of the account audit logs.
+type AuditLogsConfig struct {
+	APIConfig `mapstructure:",squash"`
+
+	// Accounts lists the IDs or names of the accounts whose audit logs are collected.
+	Accounts []string `mapstructure:"accounts"`
+	// PollInterval is how often new audit logs are fetched.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// PageSize is the number of audit logs requested per page.
+	PageSize int `mapstructure:"page_size"`
+	// Retention is how long Cloudflare keeps the audit logs of the account. Audit logs older than the
+	// retention are no longer requested. 0 requests every audit log since the last poll.
+	Retention time.Duration `mapstructure:"retention"`
+	// AlignWindow truncates the end of every polled window to a multiple of PollInterval, so that every
+	// poll covers whole intervals, such as whole minutes.
+	AlignWindow bool `mapstructure:"align_window"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// NotificationsConfig configures the HTTP server that receives Cloudflare Notifications webhooks.
+type NotificationsConfig struct {
+	// Secret is the secret of the webhook destination, which Cloudflare sends in the cf-webhook-auth header.
+	Secret   configopaque.String     `mapstructure:"secret"`
+	Endpoint string                  `mapstructure:"endpoint"`
+	TLS      *configtls.ServerConfig `mapstructure:"tls"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// InstantLogsConfig configures the streaming of the HTTP requests of a zone through Instant Logs.
+type InstantLogsConfig struct {
+	APIConfig `mapstructure:",squash"`
+
+	// Zone is the ID or name of the zone whose HTTP requests are streamed.
+	Zone string `mapstructure:"zone"`
+	// Fields lists the fields of the http_requests dataset included in the logs.
+	Fields []string `mapstructure:"fields"`
+	// Sample streams one of every Sample requests.
+	Sample int `mapstructure:"sample"`
+	// Filter is the filter of the session, in the JSON format of Logpush job filters.
+	Filter string `mapstructure:"filter"`
+	// ReconnectDelay is how long to wait before creating a new session when the stream ends.
+	ReconnectDelay time.Duration `mapstructure:"reconnect_delay"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// QueuesConfig configures the pulling of log events from a Cloudflare Queue.
+type QueuesConfig struct {
+	APIConfig `mapstructure:",squash"`
+
+	// Account is the ID or name of the account owning the queue.
+	Account string `mapstructure:"account"`
+	// Queue is the ID of the queue, which must have a pull consumer.
+	Queue string `mapstructure:"queue"`
+	// Dataset is the name of the Logpush dataset the log events belong to, if any.
+	Dataset string `mapstructure:"dataset"`
+	// PollInterval is how often the queue is pulled once it was emptied.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// BatchSize is the maximum number of messages pulled at once.
+	BatchSize int `mapstructure:"batch_size"`
+	// VisibilityTimeout is how long pulled messages are hidden from other consumers before they are
+	// delivered again, unless they were acknowledged.
+	VisibilityTimeout time.Duration `mapstructure:"visibility_timeout"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// ManageJobsConfig configures the Logpush jobs of zones the receiver creates or updates, so that
+// they send the logs of datasets to the Logpush endpoint.
+type ManageJobsConfig struct {
+	APIConfig `mapstructure:",squash"`
+
+	// DestinationURL is the public HTTPS URL at which Cloudflare reaches the Logpush endpoint. The path
+	// of the dataset, if configured in logs, is appended to it.
+	DestinationURL string `mapstructure:"destination_url"`
+	// Zones lists the IDs or names of the zones whose Logpush jobs are managed.
+	Zones []string `mapstructure:"zones"`
+	// Datasets lists the datasets a Logpush job is managed for in every zone.
+	Datasets []ManagedJobConfig `mapstructure:"datasets"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// ManagedJobConfig configures the Logpush job of a dataset.
+type ManagedJobConfig struct {
+	// Dataset is the name of the Logpush dataset, e.g. http_requests.
+	Dataset string `mapstructure:"dataset"`
+	// Fields lists the fields of the dataset included in the logs.
+	Fields []string `mapstructure:"fields"`
+	// Filter is the filter of the job, in the JSON format of Logpush job filters.
+	Filter string `mapstructure:"filter"`
+	// Name is the name of the job, which identifies the job to update. It defaults to otelcol- followed
+	// by the dataset.
+	Name string `mapstructure:"name"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// BucketConfig configures polling of the Logpush output files written to an S3-compatible bucket.
+type BucketConfig struct {
+	// Bucket is the name of the bucket the Logpush job writes to.
+	Bucket string `mapstructure:"bucket"`
+	// Prefix is the path of the Logpush job destination within the bucket.
+	Prefix string `mapstructure:"prefix"`
+	// Dataset is the name of the dataset the Logpush job exports, used to process its logs like the
+	// dataset of the same name received on the Logpush endpoint.
+	Dataset string `mapstructure:"dataset"`
+	// AccessKeyID and SecretAccessKey are the credentials used to access the bucket. They are required
+	// for R2, while S3 falls back to the default AWS credential chain.
+	AccessKeyID     string              `mapstructure:"access_key_id"`
+	SecretAccessKey configopaque.String `mapstructure:"secret_access_key"`
+	// PollInterval is how often new files are listed.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// Lookback is how far back files are listed again on every poll, so that files written late, with
+	// a key sorting before already processed files, are still collected.
+	Lookback time.Duration `mapstructure:"lookback"`
+	// StorageID is the storage extension used to persist the processed files across restarts.
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// R2Config configures polling of the Logpush output files written to an R2 bucket.
+type R2Config struct {
+	BucketConfig `mapstructure:",squash"`
+
+	// AccountID is the ID of the account owning the bucket.
+	AccountID string `mapstructure:"account_id"`
+	// Endpoint is the S3 API endpoint of R2, which defaults to the one of the account.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// S3Config configures polling of the Logpush output files written to an AWS S3 bucket.
+type S3Config struct {
+	BucketConfig `mapstructure:",squash"`
+
+	// Region is the AWS region of the bucket.
+	Region string `mapstructure:"region"`
+	// Endpoint overrides the S3 API endpoint, such as for S3-compatible storage.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// GCSConfig configures polling of the Logpush output files written to a Google Cloud Storage bucket,
+// through its XML API, which is compatible with the S3 API when authenticating with HMAC keys.
+type GCSConfig struct {
+	BucketConfig `mapstructure:",squash"`
+
+	// Endpoint is the XML API endpoint of Cloud Storage.
+	Endpoint string `mapstructure:"endpoint"`
 
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
 
 var (
-	errNoEndpoint = errors.New("an endpoint must be specified")
-	errNoCert     = errors.New("tls was configured, but no cert file was specified")
-	errNoKey      = errors.New("tls was configured, but no key file was specified")
+	errNoEndpoint               = errors.New("an endpoint must be specified")
+	errNoCert                   = errors.New("tls was configured, but no cert file was specified")
+	errNoKey                    = errors.New("tls was configured, but no key file was specified")
+	errNoAPIToken               = errors.New("an api_token must be specified")
+	errNoTargets                = errors.New("at least one of 'zones' or 'accounts' must be specified")
+	errNoDatasets               = errors.New("at least one dataset must be specified")
+	errNoDataset                = errors.New("a dataset must be specified")
+	errInvalidFilter            = errors.New("filter must be valid JSON")
+	errInvalidDestinationURL    = errors.New("destination_url must be an absolute https URL")
+	errNoJobsEndpoint           = errors.New("manage_jobs requires logs.endpoint to be specified")
+	errNoDeriveMetricsEndpoint  = errors.New("derive_metrics requires logs.endpoint to be specified")
+	errNoTenantName             = errors.New("every tenant must have a name")
+	errInvalidDelay             = errors.New("delay must not be negative")
+	errInvalidAnalyticsInterval = errors.New("collection_interval must be at least 1m, the granularity of the GraphQL Analytics API")
+	errUnalignedInterval        = errors.New("collection_interval must be a multiple of 1m when align_window is enabled")
+	errRatiosWithoutRequests    = errors.New("derive_ratios requires the http_requests dataset to be collected")
+	errEmptyAnalyticsHost       = errors.New("hosts must not contain empty values")
+	errInvalidFingerprintLimit  = errors.New("top_fingerprints must not be negative")
+	errInvalidCardinality       = errors.New("cardinality_limits must be positive")
+	errNoQueryName              = errors.New("every custom query must have a name")
+	errInvalidTemporality       = errors.New("aggregation_temporality must be delta or cumulative")
+	errStorageWithoutCumulative = errors.New("storage is only used when aggregation_temporality is cumulative")
+	errNoQueryNode              = errors.New("node must be specified")
+	errNoQueryFields            = errors.New("fields must be specified")
+	errNoAccounts               = errors.New("at least one account must be specified")
+	errNoBucket                 = errors.New("a bucket must be specified")
+	errNoAccessKey              = errors.New("access_key_id and secret_access_key must be specified")
+	errNoAccountIDOrEndpoint    = errors.New("either account_id or endpoint must be specified")
+	errIncompleteAccessKey      = errors.New("access_key_id and secret_access_key must be specified together")
+	errNoRegion                 = errors.New("a region must be specified")
+	errNoZone                   = errors.New("a zone must be specified")
+	errNoFields                 = errors.New("at least one field must be specified")
+	errInvalidSample            = errors.New("sample must be at least 1")
+	errInvalidReconnectDelay    = errors.New("reconnect_delay must be positive")
+	errNoAccount                = errors.New("an account must be specified")
+	errNoQueue                  = errors.New("a queue must be specified")
+	errNoZones                  = errors.New("at least one zone must be specified")
+	errInvalidQueryTimeout      = errors.New("query_timeout must not be negative")
+	errInvalidZoneCacheTTL      = errors.New("zone_cache_ttl must not be negative")
+	errInvalidPlanRecheck       = errors.New("plan_recheck_interval must not be negative")
+	errInvalidThreshold         = errors.New("circuit_breaker::failure_threshold must not be negative")
+	errInvalidCooldown          = errors.New("circuit_breaker::cooldown must be positive")
+
+	errInvalidBatchSize         = fmt.Errorf("batch_size must be between 1 and %d", maxQueuesBatchSize)
+	errInvalidVisibilityTimeout = errors.New("visibility_timeout must be positive")
+
+	errInvalidPollInterval = errors.New("poll_interval must be positive")
+	errInvalidLookback     = errors.New("lookback must be positive")
+	errInvalidPageSize     = errors.New("page_size must be positive")
+	errInvalidRetention    = errors.New("retention must not be negative")
+
+	errInvalidMaxDecompressedSize = errors.New("max_decompressed_size must not be negative")
+	errInvalidMaxInFlightSize     = errors.New("max_in_flight_size must not be negative")
+	errInvalidHealthCheckPath     = errors.New("health_check_path must start with '/'")
+	errInvalidHealthCheckTimeout  = errors.New("health_check_failure_timeout must not be negative")
+	errMaxInFlightSizeTooSmall    = errors.New("max_in_flight_size must not be smaller than max_decompressed_size")
+	errInvalidMaxRequestBodySize  = errors.New("max_request_body_size must not be negative")
+	errInvalidMaxResponseSize     = errors.New("max_response_size must not be negative")
+	errInvalidMaxConcurrency      = errors.New("max_concurrent_requests must not be negative")
+	errInvalidServerTimeout       = errors.New("read_timeout, idle_timeout and consume_timeout must not be negative")
+	errEmptySecret                = errors.New("secrets must not contain empty values")
+	errInvalidDatasetPath         = errors.New("path must start with '/'")
+	errInvalidSampleInterval      = errors.New("sample_interval must not be negative")
+	errNoSeverityRuleField        = errors.New("field must be specified")
+	errNoPathRulePattern          = errors.New("pattern must be specified")
+	errInvalidSeverityRuleMatch   = errors.New("either equals, or min and/or max, must be specified")
 
 	defaultTimestampField  = "EdgeStartTimestamp"
 	defaultTimestampFormat = "rfc3339"
 	defaultSeparator       = "."
+	defaultAPIEndpoint     = "https://api.cloudflare.com/client/v4"
+)
+
+const (
+	defaultAnalyticsDelay          = 3 * time.Minute
+	defaultPollInterval            = time.Minute
+	defaultAccessRequestsPageSize  = 100
+	defaultAuditLogsPageSize       = 100
+	maxAuditLogsPageSize           = 1000
+	defaultAccessRequestsRetention = 24 * time.Hour           // Zero Trust Free plan
+	defaultAuditLogsRetention      = 18 * 30 * 24 * time.Hour // 18 months
+	defaultAnalyticsRetention      = 24 * time.Hour
+	defaultMaxDecompressedSize     = 100 << 20
+	defaultMaxInFlightSize         = 500 << 20
+	defaultMaxRequestBodySize      = 100 << 20
+	defaultMaxResponseSize         = 100 << 20
+	defaultIdleTimeout             = 90 * time.Second
+	defaultConsumeTimeout          = 30 * time.Second
+	defaultHealthCheckTimeout      = time.Minute
+	defaultBucketLookback          = 24 * time.Hour
+	defaultGCSEndpoint             = "https://storage.googleapis.com"
+	defaultInstantLogsSample       = 1
+	defaultReconnectDelay          = 5 * time.Second
+	defaultQueuesBatchSize         = 50
+	maxQueuesBatchSize             = 100
+	defaultVisibilityTimeout       = 30 * time.Second
+	defaultFailureThreshold        = 5
+	defaultCooldown                = 10 * time.Minute
+	defaultQueryTimeout            = 30 * time.Second
+	defaultZoneCacheTTL            = time.Hour
+	defaultPlanRecheckInterval     = time.Hour
+	defaultRetryInitialInterval    = time.Second
+	defaultRetryMaxInterval        = 10 * time.Second
+	defaultRetryMaxElapsedTime     = 30 * time.Second
+)
+
+// The aggregation temporalities of the counts of the analytics section.
+const (
+	temporalityDelta      = "delta"
+	temporalityCumulative = "cumulative"
 )
 
 func (c *Config) Validate() error {
-	if c.Logs.Endpoint == "" {
+	var errs error
+	if c.LogpushJobs.HasValue() {
+		errs = multierr.Append(errs, c.LogpushJobs.Get().validate())
+	}
+
+	if c.Analytics.HasValue() {
+		errs = multierr.Append(errs, c.Analytics.Get().validate())
+	}
+
+	if c.AnalyticsLogs.HasValue() {
+		errs = multierr.Append(errs, c.AnalyticsLogs.Get().validate())
+	}
+
+	if c.AccessRequests.HasValue() {
+		errs = multierr.Append(errs, c.AccessRequests.Get().validate())
+	}
+	if c.AuditLogs.HasValue() {
+		errs = multierr.Append(errs, c.AuditLogs.Get().validate())
+	}
+	if c.Notifications.HasValue() {
+		errs = multierr.Append(errs, c.Notifications.Get().validate())
+	}
+	if c.R2.HasValue() {
+		errs = multierr.Append(errs, c.R2.Get().validate())
+	}
+	if c.S3.HasValue() {
+		errs = multierr.Append(errs, c.S3.Get().validate())
+	}
+	if c.GCS.HasValue() {
+		errs = multierr.Append(errs, c.GCS.Get().validate())
+	}
+	if c.InstantLogs.HasValue() {
+		errs = multierr.Append(errs, c.InstantLogs.Get().validate())
+	}
+	if c.Queues.HasValue() {
+		errs = multierr.Append(errs, c.Queues.Get().validate())
+	}
+	if c.ManageJobs.HasValue() {
+		errs = multierr.Append(errs, c.ManageJobs.Get().validate())
+	}
+
+	// The Logpush endpoint is optional when the receiver collects data from other sources.
+	if c.Logs.Endpoint != "" || !c.hasOtherSources() {
+		errs = multierr.Append(errs, c.Logs.validate())
+	} else {
+		if c.Logs.DeriveMetrics {
+			// The metrics are derived from the records received on the endpoint, which isn't started.
+			errs = multierr.Append(errs, errNoDeriveMetricsEndpoint)
+		}
+		if c.ManageJobs.HasValue() {
+			errs = multierr.Append(errs, errNoJobsEndpoint)
+		}
+	}
+
+	return errs
+}
+
+// hasOtherSources returns true if the receiver collects data from sources other than Logpush.
+func (c *Config) hasOtherSources() bool {
+	return c.LogpushJobs.HasValue() || c.Analytics.HasValue() || c.AnalyticsLogs.HasValue() || c.AccessRequests.HasValue() || c.AuditLogs.HasValue() || c.Notifications.HasValue() || c.R2.HasValue() || c.S3.HasValue() || c.GCS.HasValue() || c.InstantLogs.HasValue() || c.Queues.HasValue()
+}
+
+func (l *LogsConfig) validate() error {
+	if l.Endpoint == "" {
 		return errNoEndpoint
 	}
 
-	var errs error
-	// Validate timestamp_format if provided
-	if c.Logs.TimestampFormat != "" {
-		switch c.Logs.TimestampFormat {
-		case "unix", "unixnano", "rfc3339":
-		default:
-			errs = multierr.Append(errs, fmt.Errorf("invalid timestamp_format %q, must be one of: unix, unixnano, rfc3339", c.Logs.TimestampFormat))
+	errs := validateTimestampFormat(l.TimestampFormat)
+
+	for i, rule := range l.SeverityRules {
+		if err := rule.validate(); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid severity rule %d: %w", i, err))
+		}
+	}
+
+	paths := make(map[string]bool, len(l.Datasets))
+	for _, ds := range l.Datasets {
+		if err := ds.validate(); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid dataset for path %q: %w", ds.Path, err))
+		}
+		if paths[ds.Path] {
+			errs = multierr.Append(errs, fmt.Errorf("duplicate dataset path %q", ds.Path))
 		}
+		paths[ds.Path] = true
+	}
+
+	if l.HealthCheckPath != "" {
+		if !strings.HasPrefix(l.HealthCheckPath, "/") {
+			errs = multierr.Append(errs, errInvalidHealthCheckPath)
+		} else if paths[l.HealthCheckPath] {
+			errs = multierr.Append(errs, fmt.Errorf("health_check_path %q is also a dataset path", l.HealthCheckPath))
+		}
+	}
+	if l.HealthCheckFailureTimeout < 0 {
+		errs = multierr.Append(errs, errInvalidHealthCheckTimeout)
+	}
+
+	for _, secret := range l.Secrets {
+		if secret == "" {
+			errs = multierr.Append(errs, errEmptySecret)
+			break
+		}
+	}
+
+	if l.MaxDecompressedSize < 0 {
+		errs = multierr.Append(errs, errInvalidMaxDecompressedSize)
+	}
+
+	if l.MaxInFlightSize < 0 {
+		errs = multierr.Append(errs, errInvalidMaxInFlightSize)
+	} else if l.MaxInFlightSize > 0 && l.MaxInFlightSize < l.MaxDecompressedSize {
+		// Larger payloads would be refused on every retry.
+		errs = multierr.Append(errs, errMaxInFlightSizeTooSmall)
+	}
+
+	if l.MaxRequestBodySize < 0 {
+		errs = multierr.Append(errs, errInvalidMaxRequestBodySize)
+	}
+
+	if l.MaxConcurrentRequests < 0 {
+		errs = multierr.Append(errs, errInvalidMaxConcurrency)
 	}
 
-	if c.Logs.TLS != nil {
+	if l.ReadTimeout < 0 || l.IdleTimeout < 0 || l.ConsumeTimeout < 0 {
+		errs = multierr.Append(errs, errInvalidServerTimeout)
+	}
+
+	return multierr.Append(errs, validateServer(l.Endpoint, l.TLS))
+}
+
+func (d *DatasetConfig) validate() error {
+	var errs error
+	if !strings.HasPrefix(d.Path, "/") {
+		errs = errInvalidDatasetPath
+	}
+	if d.Dataset != "" {
+		errs = multierr.Append(errs, validateDataset(d.Dataset))
+	}
+	if d.SampleInterval < 0 {
+		errs = multierr.Append(errs, errInvalidSampleInterval)
+	}
+	return multierr.Append(errs, validateTimestampFormat(d.TimestampFormat))
+}
+
+func (r *SeverityRule) validate() error {
+	var errs error
+	if r.Field == "" {
+		errs = errNoSeverityRuleField
+	}
+	if (r.Equals != nil) == (r.Min != nil || r.Max != nil) {
+		errs = multierr.Append(errs, errInvalidSeverityRuleMatch)
+	}
+	if _, ok := severityNumbers[r.Severity]; !ok {
+		errs = multierr.Append(errs, fmt.Errorf("invalid severity %q, must be one of: trace, debug, info, warn, error, fatal", r.Severity))
+	}
+	return errs
+}
+
+func (r *PathRule) validate() error {
+	if r.Pattern == "" {
+		return errNoPathRulePattern
+	}
+	if _, err := regexp.Compile(r.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", r.Pattern, err)
+	}
+	return nil
+}
+
+// validateTimestampFormat validates timestamp_format if provided.
+func validateTimestampFormat(format string) error {
+	switch format {
+	case "", "unix", "unixmilli", "unixnano", "rfc3339":
+		return nil
+	default:
+		return fmt.Errorf("invalid timestamp_format %q, must be one of: unix, unixmilli, unixnano, rfc3339", format)
+	}
+}
+
+// acceptedSecrets returns all secrets accepted in the X-CF-Secret header.
+func (l *LogsConfig) acceptedSecrets() []configopaque.String {
+	if l.Secret == "" {
+		return l.Secrets
+	}
+	return append([]configopaque.String{l.Secret}, l.Secrets...)
+}
+
+// validateServer validates the settings of an HTTP server Cloudflare pushes data to.
+func validateServer(endpoint string, tls *configtls.ServerConfig) error {
+	var errs error
+	if tls != nil {
 		// Missing key
-		if c.Logs.TLS.KeyFile == "" {
+		if tls.KeyFile == "" {
 			errs = multierr.Append(errs, errNoKey)
 		}
 
 		// Missing cert
-		if c.Logs.TLS.CertFile == "" {
+		if tls.CertFile == "" {
 			errs = multierr.Append(errs, errNoCert)
 		}
 	}
 
-	_, _, err := net.SplitHostPort(c.Logs.Endpoint)
+	_, _, err := net.SplitHostPort(endpoint)
 	if err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to split endpoint into 'host:port' pair: %w", err))
 	}
 
 	return errs
 }
+
+func (a *APIConfig) validate() error {
+	var errs error
+	if a.APIToken == "" {
+		errs = multierr.Append(errs, errNoAPIToken)
+	}
+	return multierr.Append(errs, a.validateClient())
+}
+
+// validateClient validates the settings of the client, leaving out the API token for the sections
+// whose token can be configured elsewhere.
+func (a *APIConfig) validateClient() error {
+	var errs error
+	if _, err := url.ParseRequestURI(a.Endpoint); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("invalid endpoint %q: %w", a.Endpoint, err))
+	}
+
+	if a.MaxResponseSize < 0 {
+		errs = multierr.Append(errs, errInvalidMaxResponseSize)
+	}
+
+	if err := a.BackOffConfig.Validate(); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("invalid retry_on_failure: %w", err))
+	}
+
+	return errs
+}
+
+func (j *LogpushJobsConfig) validate() error {
+	errs := j.APIConfig.validate()
+	if len(j.Zones) == 0 && len(j.Accounts) == 0 {
+		errs = multierr.Append(errs, errNoTargets)
+	}
+
+	if j.QueryTimeout < 0 {
+		errs = multierr.Append(errs, errInvalidQueryTimeout)
+	}
+
+	if j.ZoneCacheTTL < 0 {
+		errs = multierr.Append(errs, errInvalidZoneCacheTTL)
+	}
+
+	if j.PlanRecheckInterval < 0 {
+		errs = multierr.Append(errs, errInvalidPlanRecheck)
+	}
+
+	if j.CircuitBreaker.FailureThreshold < 0 {
+		errs = multierr.Append(errs, errInvalidThreshold)
+	} else if j.CircuitBreaker.FailureThreshold > 0 && j.CircuitBreaker.Cooldown <= 0 {
+		errs = multierr.Append(errs, errInvalidCooldown)
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid logpush_jobs config: %w", errs)
+	}
+	return nil
+}
+
+func (a *AnalyticsConfig) validate() error {
+	errs := a.APIConfig.validateClient()
+	for _, tenant := range a.Tenants {
+		if tenant.Name == "" {
+			errs = multierr.Append(errs, errNoTenantName)
+			break
+		}
+	}
+	for _, tenant := range a.tenants() {
+		if err := tenant.validate(len(a.CustomQueries) > 0); err != nil {
+			if tenant.Name != "" {
+				err = fmt.Errorf("tenant %q: %w", tenant.Name, err)
+			}
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	// Shorter windows would be queried before their minute is complete, returning partial counts. A
+	// collection_interval that isn't positive is rejected by the controller.
+	if a.CollectionInterval > 0 && a.CollectionInterval < analyticsGranularity {
+		errs = multierr.Append(errs, errInvalidAnalyticsInterval)
+	} else if a.AlignWindow && a.CollectionInterval%analyticsGranularity != 0 {
+		// The windows would end in the middle of the minutes, splitting their groups across scrapes.
+		errs = multierr.Append(errs, errUnalignedInterval)
+	}
+
+	if a.Delay < 0 {
+		errs = multierr.Append(errs, errInvalidDelay)
+	}
+
+	if a.QueryTimeout < 0 {
+		errs = multierr.Append(errs, errInvalidQueryTimeout)
+	}
+
+	if a.ZoneCacheTTL < 0 {
+		errs = multierr.Append(errs, errInvalidZoneCacheTTL)
+	}
+
+	if a.CircuitBreaker.FailureThreshold < 0 {
+		errs = multierr.Append(errs, errInvalidThreshold)
+	} else if a.CircuitBreaker.FailureThreshold > 0 && a.CircuitBreaker.Cooldown <= 0 {
+		errs = multierr.Append(errs, errInvalidCooldown)
+	}
+
+	if a.PlanRecheckInterval < 0 {
+		errs = multierr.Append(errs, errInvalidPlanRecheck)
+	}
+
+	if a.Retention < 0 {
+		errs = multierr.Append(errs, errInvalidRetention)
+	} else if a.Retention > 0 && a.Delay+a.CollectionInterval > a.Retention {
+		// Every window would start before the oldest analytics kept by Cloudflare, and be clamped.
+		errs = multierr.Append(errs, fmt.Errorf(
+			"delay (%s) plus collection_interval (%s) exceeds retention (%s), so no window could be queried in full: lower delay or collection_interval, or raise retention to the one of the plan",
+			a.Delay, a.CollectionInterval, a.Retention))
+	}
+
+	if a.DeriveRatios && !a.collects("http_requests") {
+		errs = multierr.Append(errs, errRatiosWithoutRequests)
+	}
+
+	if slices.Contains(a.Hosts, "") {
+		errs = multierr.Append(errs, errEmptyAnalyticsHost)
+	}
+
+	for i, rule := range a.PathRules {
+		if err := rule.validate(); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid path rule %d: %w", i, err))
+		}
+	}
+
+	if a.TopFingerprints < 0 {
+		errs = multierr.Append(errs, errInvalidFingerprintLimit)
+	}
+
+	for _, dimension := range a.Dimensions {
+		if !slices.Contains(analyticsDimensionNames, dimension) {
+			errs = multierr.Append(errs, fmt.Errorf("invalid dimensions %q, must be one of: %s",
+				dimension, strings.Join(analyticsDimensionNames, ", ")))
+		}
+	}
+
+	for _, limit := range a.CardinalityLimits {
+		if limit <= 0 {
+			errs = multierr.Append(errs, errInvalidCardinality)
+			break
+		}
+	}
+
+	names := map[string]bool{}
+	for _, query := range a.CustomQueries {
+		if names[query.Name] {
+			errs = multierr.Append(errs, fmt.Errorf("custom query %q is defined more than once", query.Name))
+		}
+		names[query.Name] = true
+		errs = multierr.Append(errs, query.validate())
+	}
+
+	switch a.AggregationTemporality {
+	case "", temporalityDelta:
+		if a.StorageID != nil {
+			errs = multierr.Append(errs, errStorageWithoutCumulative)
+		}
+	case temporalityCumulative:
+	default:
+		errs = multierr.Append(errs, errInvalidTemporality)
+	}
+
+	for name, options := range a.DatasetOptions {
+		if _, ok := analyticsDatasets[name]; !ok && !names[name] {
+			errs = multierr.Append(errs, fmt.Errorf("dataset_options: %w", validateName(name, slices.Collect(maps.Keys(analyticsDatasets)))))
+		}
+		errs = multierr.Append(errs, options.validate(name))
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid analytics config: %w", errs)
+	}
+	return nil
+}
+
+// validate validates the tenant, whose datasets may be left out when custom queries are configured.
+func (t *AnalyticsTenantConfig) validate(customQueries bool) error {
+	var errs error
+	if t.APIToken == "" {
+		errs = multierr.Append(errs, errNoAPIToken)
+	}
+
+	if len(t.Zones) == 0 && len(t.Accounts) == 0 {
+		errs = multierr.Append(errs, errNoTargets)
+	}
+
+	if len(t.Datasets) == 0 && !customQueries {
+		errs = multierr.Append(errs, errNoDatasets)
+	}
+	for i, name := range t.Datasets {
+		dataset, ok := analyticsDatasets[name]
+		switch {
+		case !ok:
+			errs = multierr.Append(errs, validateName(name, slices.Collect(maps.Keys(analyticsDatasets))))
+		case slices.Contains(t.Datasets[:i], name):
+			errs = multierr.Append(errs, fmt.Errorf("dataset %q is listed more than once", name))
+		case dataset.account && len(t.Accounts) == 0:
+			errs = multierr.Append(errs, fmt.Errorf("dataset %q is collected for accounts, but no accounts are specified", name))
+		case !dataset.account && len(t.Zones) == 0:
+			errs = multierr.Append(errs, fmt.Errorf("dataset %q is collected for zones, but no zones are specified", name))
+		}
+	}
+	return errs
+}
+
+// orderingPattern matches the orderings of the groups of the GraphQL Analytics API, such as count_DESC.
+var orderingPattern = regexp.MustCompile(`^\w+_(ASC|DESC)$`)
+
+func (o *AnalyticsDatasetOptions) validate(name string) error {
+	var errs error
+	if o.Limit < 0 || o.Limit > analyticsLimit {
+		errs = multierr.Append(errs, fmt.Errorf("limit must not be negative nor exceed %d", analyticsLimit))
+	}
+	for _, ordering := range o.OrderBy {
+		if !orderingPattern.MatchString(ordering) {
+			errs = multierr.Append(errs, fmt.Errorf("invalid order_by %q, expected a field followed by _ASC or _DESC", ordering))
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("dataset_options %q: %w", name, errs)
+	}
+	return nil
+}
+
+func (q *AnalyticsQueryConfig) validate() error {
+	if q.Name == "" {
+		return errNoQueryName
+	}
+	var errs error
+	if q.Scope != "" && q.Scope != "zone" && q.Scope != "account" {
+		errs = multierr.Append(errs, errors.New("scope must be zone or account"))
+	}
+	if q.Node == "" {
+		errs = multierr.Append(errs, errNoQueryNode)
+	}
+	if q.Fields == "" {
+		errs = multierr.Append(errs, errNoQueryFields)
+	}
+	if len(q.Metrics) == 0 {
+		errs = multierr.Append(errs, errors.New("at least one metric must be specified"))
+	}
+	for _, metric := range q.Metrics {
+		if metric.Name == "" || metric.Field == "" {
+			errs = multierr.Append(errs, errors.New("every metric must have a name and a field"))
+		}
+		if metric.Type != "" && metric.Type != "sum" && metric.Type != "gauge" {
+			errs = multierr.Append(errs, fmt.Errorf("metric %q: type must be sum or gauge", metric.Name))
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("custom query %q: %w", q.Name, errs)
+	}
+	return nil
+}
+
+func (q *AnalyticsEventQueryConfig) validate(a *AnalyticsLogsConfig) error {
+	if q.Name == "" {
+		return errNoQueryName
+	}
+	var errs error
+	switch q.Scope {
+	case "", "zone":
+		if len(a.Zones) == 0 {
+			errs = multierr.Append(errs, errors.New("the query is made for zones, but no zones are specified"))
+		}
+	case "account":
+		if len(a.Accounts) == 0 {
+			errs = multierr.Append(errs, errors.New("the query is made for accounts, but no accounts are specified"))
+		}
+	default:
+		errs = multierr.Append(errs, errors.New("scope must be zone or account"))
+	}
+	if q.Node == "" {
+		errs = multierr.Append(errs, errNoQueryNode)
+	}
+	if !slices.Contains(strings.Fields(q.Fields), "datetime") {
+		errs = multierr.Append(errs, errors.New("fields must include datetime"))
+	}
+	if errs != nil {
+		return fmt.Errorf("custom query %q: %w", q.Name, errs)
+	}
+	return nil
+}
+
+func (a *AnalyticsLogsConfig) validate() error {
+	errs := a.APIConfig.validate()
+	if len(a.Zones) == 0 && len(a.Accounts) == 0 {
+		errs = multierr.Append(errs, errNoTargets)
+	}
+
+	if len(a.Datasets) == 0 && len(a.CustomQueries) == 0 {
+		errs = multierr.Append(errs, errNoDatasets)
+	}
+	for _, name := range a.Datasets {
+		dataset, ok := analyticsLogDatasets[name]
+		switch {
+		case !ok:
+			errs = multierr.Append(errs, validateName(name, slices.Collect(maps.Keys(analyticsLogDatasets))))
+		case dataset.account && len(a.Accounts) == 0:
+			errs = multierr.Append(errs, fmt.Errorf("dataset %q is collected for accounts, but no accounts are specified", name))
+		case !dataset.account && len(a.Zones) == 0:
+			errs = multierr.Append(errs, fmt.Errorf("dataset %q is collected for zones, but no zones are specified", name))
+		}
+	}
+
+	names := map[string]bool{}
+	for _, query := range a.CustomQueries {
+		if names[query.Name] || slices.Contains(a.Datasets, query.Name) {
+			errs = multierr.Append(errs, fmt.Errorf("custom query %q is defined more than once", query.Name))
+		}
+		names[query.Name] = true
+		errs = multierr.Append(errs, query.validate(a))
+	}
+
+	if a.PollInterval <= 0 {
+		errs = multierr.Append(errs, errInvalidPollInterval)
+	}
+
+	if a.Delay < 0 {
+		errs = multierr.Append(errs, errInvalidDelay)
+	}
+
+	if a.Retention < 0 {
+		errs = multierr.Append(errs, errInvalidRetention)
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid analytics_logs config: %w", errs)
+	}
+	return nil
+}
+
+func (a *AccessRequestsConfig) validate() error {
+	errs := a.APIConfig.validate()
+	if len(a.Accounts) == 0 {
+		errs = multierr.Append(errs, errNoAccounts)
+	}
+
+	if a.PollInterval <= 0 {
+		errs = multierr.Append(errs, errInvalidPollInterval)
+	}
+
+	if a.PageSize <= 0 {
+		errs = multierr.Append(errs, errInvalidPageSize)
+	}
+
+	if a.Retention < 0 {
+		errs = multierr.Append(errs, errInvalidRetention)
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid access_requests config: %w", errs)
+	}
+	return nil
+}
+
+func (a *AuditLogsConfig) validate() error {
+	errs := a.APIConfig.validate()
+	if len(a.Accounts) == 0 {
+		errs = multierr.Append(errs, errNoAccounts)
+	}
+
+	if a.PollInterval <= 0 {
+		errs = multierr.Append(errs, errInvalidPollInterval)
+	}
+
+	if a.PageSize <= 0 || a.PageSize > maxAuditLogsPageSize {
+		errs = multierr.Append(errs, fmt.Errorf("page_size must be between 1 and %d", maxAuditLogsPageSize))
+	}
+
+	if a.Retention < 0 {
+		errs = multierr.Append(errs, errInvalidRetention)
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid audit_logs config: %w", errs)
+	}
+	return nil
+}
+
+func (i *InstantLogsConfig) validate() error {
+	errs := i.APIConfig.validate()
+	if i.Zone == "" {
+		errs = multierr.Append(errs, errNoZone)
+	}
+
+	if len(i.Fields) == 0 {
+		errs = multierr.Append(errs, errNoFields)
+	}
+
+	if i.Sample < 1 {
+		errs = multierr.Append(errs, errInvalidSample)
+	}
+
+	if i.ReconnectDelay <= 0 {
+		errs = multierr.Append(errs, errInvalidReconnectDelay)
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid instant_logs config: %w", errs)
+	}
+	return nil
+}
+
+func (q *QueuesConfig) validate() error {
+	errs := q.APIConfig.validate()
+	if q.Account == "" {
+		errs = multierr.Append(errs, errNoAccount)
+	}
+
+	if q.Queue == "" {
+		errs = multierr.Append(errs, errNoQueue)
+	}
+
+	if q.Dataset != "" {
+		errs = multierr.Append(errs, validateDataset(q.Dataset))
+	}
+
+	if q.PollInterval <= 0 {
+		errs = multierr.Append(errs, errInvalidPollInterval)
+	}
+
+	if q.BatchSize < 1 || q.BatchSize > maxQueuesBatchSize {
+		errs = multierr.Append(errs, errInvalidBatchSize)
+	}
+
+	if q.VisibilityTimeout <= 0 {
+		errs = multierr.Append(errs, errInvalidVisibilityTimeout)
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid queues config: %w", errs)
+	}
+	return nil
+}
+
+func (m *ManageJobsConfig) validate() error {
+	errs := m.APIConfig.validate()
+	if u, err := url.ParseRequestURI(m.DestinationURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		errs = multierr.Append(errs, errInvalidDestinationURL)
+	}
+
+	if len(m.Zones) == 0 {
+		errs = multierr.Append(errs, errNoZones)
+	}
+
+	if len(m.Datasets) == 0 {
+		errs = multierr.Append(errs, errNoDatasets)
+	}
+	names := make(map[string]bool, len(m.Datasets))
+	for _, job := range m.Datasets {
+		if err := job.validate(); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid job for dataset %q: %w", job.Dataset, err))
+		}
+		if names[job.name()] {
+			errs = multierr.Append(errs, fmt.Errorf("duplicate job name %q", job.name()))
+		}
+		names[job.name()] = true
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid manage_jobs config: %w", errs)
+	}
+	return nil
+}
+
+func (j *ManagedJobConfig) validate() error {
+	var errs error
+	if j.Dataset == "" {
+		errs = errNoDataset
+	} else {
+		errs = validateDataset(j.Dataset)
+	}
+
+	if len(j.Fields) == 0 {
+		errs = multierr.Append(errs, errNoFields)
+	}
+
+	if j.Filter != "" && !json.Valid([]byte(j.Filter)) {
+		errs = multierr.Append(errs, errInvalidFilter)
+	}
+	return errs
+}
+
+// name returns the name of the job.
+func (j *ManagedJobConfig) name() string {
+	if j.Name != "" {
+		return j.Name
+	}
+	return "otelcol-" + strings.ReplaceAll(j.Dataset, "_", "-")
+}
+
+func (b *BucketConfig) validate() error {
+	var errs error
+	if b.Bucket == "" {
+		errs = multierr.Append(errs, errNoBucket)
+	}
+
+	if b.Dataset != "" {
+		errs = multierr.Append(errs, validateDataset(b.Dataset))
+	}
+
+	if b.PollInterval <= 0 {
+		errs = multierr.Append(errs, errInvalidPollInterval)
+	}
+	if b.Lookback <= 0 {
+		errs = multierr.Append(errs, errInvalidLookback)
+	}
+	return errs
+}
+
+func (r *R2Config) validate() error {
+	errs := r.BucketConfig.validate()
+	if r.AccessKeyID == "" || r.SecretAccessKey == "" {
+		errs = multierr.Append(errs, errNoAccessKey)
+	}
+
+	if r.AccountID == "" && r.Endpoint == "" {
+		errs = multierr.Append(errs, errNoAccountIDOrEndpoint)
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid r2 config: %w", errs)
+	}
+	return nil
+}
+
+// endpoint returns the S3 API endpoint of the bucket.
+func (r *R2Config) endpoint() string {
+	if r.Endpoint != "" {
+		return r.Endpoint
+	}
+	return fmt.Sprintf("https://%s.r2.cloudflarestorage.com", r.AccountID)
+}
+
+func (s *S3Config) validate() error {
+	errs := s.BucketConfig.validate()
+	if (s.AccessKeyID == "") != (s.SecretAccessKey == "") {
+		errs = multierr.Append(errs, errIncompleteAccessKey)
+	}
+
+	if s.Region == "" {
+		errs = multierr.Append(errs, errNoRegion)
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid s3 config: %w", errs)
+	}
+	return nil
+}
+
+func (g *GCSConfig) validate() error {
+	errs := g.BucketConfig.validate()
+	if g.AccessKeyID == "" || g.SecretAccessKey == "" {
+		errs = multierr.Append(errs, errNoAccessKey)
+	}
+
+	if g.Endpoint == "" {
+		errs = multierr.Append(errs, errNoEndpoint)
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid gcs config: %w", errs)
+	}
+	return nil
+}
+
+func (n *NotificationsConfig) validate() error {
+	var errs error
+	if n.Endpoint == "" {
+		errs = errNoEndpoint
+	} else {
+		errs = validateServer(n.Endpoint, n.TLS)
+	}
+
+	if errs != nil {
+		return fmt.Errorf("invalid notifications config: %w", errs)
+	}
+	return nil
+}
@@ -6,17 +6,26 @@ package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-co
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
 )
 
 func TestValidate(t *testing.T) {
+	block := "block"
+	minStatus := 500.0
+
 	cases := []struct {
 		name        string
 		config      Config
@@ -88,6 +97,735 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: errNoCert.Error(),
 		},
+		{
+			name: "Valid logpush_jobs config without logs endpoint",
+			config: Config{
+				LogpushJobs: configoptional.Some(LogpushJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones: []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+				}),
+			},
+		},
+		{
+			name: "Valid analytics config without logs endpoint",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:    []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets: []string{"waiting_room"},
+				}),
+			},
+		},
+		{
+			name: "logpush_jobs missing api_token",
+			config: Config{
+				LogpushJobs: configoptional.Some(LogpushJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+					},
+					Zones: []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+				}),
+			},
+			expectedErr: "invalid logpush_jobs config: " + errNoAPIToken.Error(),
+		},
+		{
+			name: "logpush_jobs missing zones and accounts",
+			config: Config{
+				LogpushJobs: configoptional.Some(LogpushJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+				}),
+			},
+			expectedErr: "invalid logpush_jobs config: " + errNoTargets.Error(),
+		},
+		{
+			name: "logpush_jobs invalid endpoint",
+			config: Config{
+				LogpushJobs: configoptional.Some(LogpushJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: "not a url"},
+						APIToken:     "abc123",
+					},
+					Accounts: []string{"01a7362d577a6c3019a474fd6f485823"},
+				}),
+			},
+			expectedErr: `invalid logpush_jobs config: invalid endpoint "not a url"`,
+		},
+		{
+			name: "analytics missing api_token",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+					},
+					Zones:    []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets: []string{"waiting_room"},
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errNoAPIToken.Error(),
+		},
+		{
+			name: "analytics missing zones, accounts and datasets",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errNoTargets.Error() + "; " + errNoDatasets.Error(),
+		},
+		{
+			name: "analytics account dataset without accounts",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:    []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets: []string{"waiting_room", "turnstile"},
+				}),
+			},
+			expectedErr: `invalid analytics config: dataset "turnstile" is collected for accounts, but no accounts are specified`,
+		},
+		{
+			name: "analytics interval shorter than the granularity and duplicate dataset",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					ControllerConfig: scraperhelper.ControllerConfig{CollectionInterval: 30 * time.Second},
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:    []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets: []string{"waiting_room", "waiting_room"},
+				}),
+			},
+			expectedErr: `invalid analytics config: dataset "waiting_room" is listed more than once; ` + errInvalidAnalyticsInterval.Error(),
+		},
+		{
+			name: "analytics aligned window on an interval that isn't whole minutes",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					ControllerConfig: scraperhelper.ControllerConfig{CollectionInterval: 90 * time.Second},
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:       []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:    []string{"waiting_room"},
+					AlignWindow: true,
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errUnalignedInterval.Error(),
+		},
+		{
+			name: "analytics ratios without the http_requests dataset",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					ControllerConfig: scraperhelper.ControllerConfig{CollectionInterval: time.Minute},
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:        []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:     []string{"waiting_room"},
+					DeriveRatios: true,
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errRatiosWithoutRequests.Error(),
+		},
+		{
+			name: "analytics invalid dimension, empty host, invalid path rules and negative top fingerprints",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					ControllerConfig: scraperhelper.ControllerConfig{CollectionInterval: time.Minute},
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:           []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:        []string{"firewall_events"},
+					Dimensions:      []string{"client_asn", "country"},
+					Hosts:           []string{"example.com", ""},
+					PathRules:       []PathRule{{Replacement: "/users/{id}"}, {Pattern: "/(", Replacement: "/"}},
+					TopFingerprints: -1,
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errEmptyAnalyticsHost.Error() + "; invalid path rule 0: " + errNoPathRulePattern.Error() +
+				"; invalid path rule 1: invalid pattern \"/(\": error parsing regexp: missing closing ): `/(`; " + errInvalidFingerprintLimit.Error() + `; invalid dimensions "country", must be one of: client_asn, client_asn_organization, path, ja3, ja4, waf_attack_score, waf_sqli_attack_score, waf_xss_attack_score`,
+		},
+		{
+			name: "analytics unknown dataset",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:    []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets: []string{"waiting_rooms"},
+				}),
+			},
+			expectedErr: `invalid analytics config: unknown dataset "waiting_rooms", did you mean "waiting_room"?`,
+		},
+		{
+			name: "Valid analytics config with tenants only",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+					},
+					Datasets: []string{"workers"},
+					Tenants: []AnalyticsTenantConfig{
+						{Name: "acme", APIToken: "abc123", Accounts: []string{"01a7362d577a6c3019a474fd6f485823"}},
+					},
+				}),
+			},
+		},
+		{
+			name: "analytics invalid tenants",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+					},
+					Datasets: []string{"workers"},
+					Tenants: []AnalyticsTenantConfig{
+						{Name: "acme", Zones: []string{"023e105f4ecef8ad9ca31a8372d0c353"}},
+						{APIToken: "abc123", Accounts: []string{"01a7362d577a6c3019a474fd6f485823"}},
+					},
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errNoTenantName.Error() + `; tenant "acme": ` + errNoAPIToken.Error() +
+				`; dataset "workers" is collected for accounts, but no accounts are specified`,
+		},
+		{
+			name: "analytics negative delay",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:    []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets: []string{"waiting_room"},
+					Delay:    -time.Minute,
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errInvalidDelay.Error(),
+		},
+		{
+			name: "analytics invalid query timeout and circuit breaker",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:               []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:            []string{"waiting_room"},
+					QueryTimeout:        -time.Second,
+					ZoneCacheTTL:        -time.Minute,
+					CircuitBreaker:      CircuitBreakerConfig{FailureThreshold: -1},
+					PlanRecheckInterval: -time.Minute,
+					Retention:           -time.Hour,
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errInvalidQueryTimeout.Error() + "; " + errInvalidZoneCacheTTL.Error() + "; " +
+				errInvalidThreshold.Error() + "; " + errInvalidPlanRecheck.Error() + "; " + errInvalidRetention.Error(),
+		},
+		{
+			name: "analytics window beyond the retention",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					ControllerConfig: scraperhelper.ControllerConfig{CollectionInterval: time.Hour},
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:     []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:  []string{"waiting_room"},
+					Delay:     10 * time.Minute,
+					Retention: time.Hour,
+				}),
+			},
+			expectedErr: "invalid analytics config: delay (10m0s) plus collection_interval (1h0m0s) exceeds retention (1h0m0s)",
+		},
+		{
+			name: "analytics invalid cardinality_limits",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:             []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:          []string{"waiting_room"},
+					CardinalityLimits: map[string]int{"user.email": 0},
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errInvalidCardinality.Error(),
+		},
+		{
+			name: "analytics invalid dataset_options",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:    []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets: []string{"bot_management"},
+					DatasetOptions: map[string]AnalyticsDatasetOptions{
+						"bot_management": {Limit: 20000, OrderBy: []string{"count DESC"}},
+					},
+				}),
+			},
+			expectedErr: `invalid analytics config: dataset_options "bot_management": limit must not be negative nor exceed 10000; ` +
+				`invalid order_by "count DESC", expected a field followed by _ASC or _DESC`,
+		},
+		{
+			name: "analytics invalid aggregation_temporality",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:                  []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:               []string{"waiting_room"},
+					AggregationTemporality: "monotonic",
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errInvalidTemporality.Error(),
+		},
+		{
+			name: "analytics storage without cumulative sums",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:     []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:  []string{"waiting_room"},
+					StorageID: &component.ID{},
+				}),
+			},
+			expectedErr: "invalid analytics config: " + errStorageWithoutCumulative.Error(),
+		},
+		{
+			name: "analytics invalid custom_queries",
+			config: Config{
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones: []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					CustomQueries: []AnalyticsQueryConfig{
+						{Name: "spectrum", Scope: "colo", Node: "spectrumNetworkAnalyticsAdaptiveGroups", Metrics: []AnalyticsQueryMetricConfig{{Name: "bits", Field: "sum.bits", Type: "histogram"}}},
+						{},
+					},
+				}),
+			},
+			expectedErr: `invalid analytics config: custom query "spectrum": scope must be zone or account; ` + errNoQueryFields.Error() +
+				`; metric "bits": type must be sum or gauge; ` + errNoQueryName.Error(),
+		},
+		{
+			name: "Valid analytics_logs config without logs endpoint",
+			config: Config{
+				AnalyticsLogs: configoptional.Some(AnalyticsLogsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:        []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:     []string{"firewall_events"},
+					PollInterval: time.Minute,
+				}),
+			},
+		},
+		{
+			name: "analytics_logs invalid",
+			config: Config{
+				AnalyticsLogs: configoptional.Some(AnalyticsLogsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Accounts:  []string{"01a7362d577a6c3019a474fd6f485823"},
+					Datasets:  []string{"firewall_event", "firewall_events"},
+					Delay:     -time.Minute,
+					Retention: -time.Hour,
+				}),
+			},
+			expectedErr: `invalid analytics_logs config: unknown dataset "firewall_event", did you mean "firewall_events"?; ` +
+				`dataset "firewall_events" is collected for zones, but no zones are specified; ` +
+				errInvalidPollInterval.Error() + "; " + errInvalidDelay.Error() + "; " + errInvalidRetention.Error(),
+		},
+		{
+			name: "analytics_logs invalid custom_queries",
+			config: Config{
+				AnalyticsLogs: configoptional.Some(AnalyticsLogsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:         []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					CustomQueries: []AnalyticsEventQueryConfig{{Name: "dns", Scope: "account", Node: "dnsAnalyticsAdaptive", Fields: "queryName"}},
+					PollInterval:  time.Minute,
+				}),
+			},
+			expectedErr: `invalid analytics_logs config: custom query "dns": the query is made for accounts, but no accounts are specified; fields must include datetime`,
+		},
+		{
+			name: "logpush_jobs invalid circuit breaker",
+			config: Config{
+				LogpushJobs: configoptional.Some(LogpushJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Accounts:       []string{"01a7362d577a6c3019a474fd6f485823"},
+					CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 3},
+				}),
+			},
+			expectedErr: "invalid logpush_jobs config: " + errInvalidCooldown.Error(),
+		},
+		{
+			name: "logpush_jobs negative query_timeout",
+			config: Config{
+				LogpushJobs: configoptional.Some(LogpushJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Accounts:     []string{"01a7362d577a6c3019a474fd6f485823"},
+					QueryTimeout: -time.Second,
+				}),
+			},
+			expectedErr: "invalid logpush_jobs config: " + errInvalidQueryTimeout.Error(),
+		},
+		{
+			name: "logpush_jobs negative zone_cache_ttl",
+			config: Config{
+				LogpushJobs: configoptional.Some(LogpushJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:        []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					ZoneCacheTTL: -time.Minute,
+				}),
+			},
+			expectedErr: "invalid logpush_jobs config: " + errInvalidZoneCacheTTL.Error(),
+		},
+		{
+			name: "logpush_jobs negative plan_recheck_interval",
+			config: Config{
+				LogpushJobs: configoptional.Some(LogpushJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:               []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					PlanRecheckInterval: -time.Minute,
+				}),
+			},
+			expectedErr: "invalid logpush_jobs config: " + errInvalidPlanRecheck.Error(),
+		},
+		{
+			name: "access_requests negative max_response_size",
+			config: Config{
+				AccessRequests: configoptional.Some(AccessRequestsConfig{
+					APIConfig: APIConfig{
+						ClientConfig:    confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:        "abc123",
+						MaxResponseSize: -1,
+					},
+					Accounts:     []string{"01a7362d577a6c3019a474fd6f485823"},
+					PollInterval: time.Minute,
+					PageSize:     100,
+				}),
+			},
+			expectedErr: "invalid access_requests config: " + errInvalidMaxResponseSize.Error(),
+		},
+		{
+			name: "logpush_jobs invalid retry_on_failure",
+			config: Config{
+				LogpushJobs: configoptional.Some(LogpushJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig:  confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:      "abc123",
+						BackOffConfig: configretry.BackOffConfig{Enabled: true, InitialInterval: time.Minute, MaxElapsedTime: time.Second},
+					},
+					Accounts: []string{"01a7362d577a6c3019a474fd6f485823"},
+				}),
+			},
+			expectedErr: "invalid logpush_jobs config: invalid retry_on_failure: 'max_elapsed_time' must not be less than 'initial_interval'",
+		},
+		{
+			name: "Valid access_requests config without logs endpoint",
+			config: Config{
+				AccessRequests: configoptional.Some(AccessRequestsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Accounts:     []string{"01a7362d577a6c3019a474fd6f485823"},
+					PollInterval: time.Minute,
+					PageSize:     100,
+				}),
+			},
+		},
+		{
+			name: "invalid s3 config",
+			config: Config{
+				S3: configoptional.Some(S3Config{
+					BucketConfig: BucketConfig{
+						Bucket:       "logpush",
+						AccessKeyID:  "abcdef123456",
+						PollInterval: time.Minute,
+						Lookback:     time.Hour,
+					},
+				}),
+			},
+			expectedErr: "invalid s3 config: " + errIncompleteAccessKey.Error() + "; " + errNoRegion.Error(),
+		},
+		{
+			name: "s3 with default credentials",
+			config: Config{
+				S3: configoptional.Some(S3Config{
+					BucketConfig: BucketConfig{Bucket: "logpush", PollInterval: time.Minute, Lookback: time.Hour},
+					Region:       "eu-west-1",
+				}),
+			},
+		},
+		{
+			name: "invalid bucket lookback",
+			config: Config{
+				S3: configoptional.Some(S3Config{
+					BucketConfig: BucketConfig{Bucket: "logpush", PollInterval: time.Minute},
+					Region:       "eu-west-1",
+				}),
+			},
+			expectedErr: "invalid s3 config: " + errInvalidLookback.Error(),
+		},
+		{
+			name: "invalid gcs config",
+			config: Config{
+				GCS: configoptional.Some(GCSConfig{
+					BucketConfig: BucketConfig{PollInterval: time.Minute, Lookback: time.Hour},
+				}),
+			},
+			expectedErr: "invalid gcs config: " + errNoBucket.Error() + "; " + errNoAccessKey.Error() + "; " + errNoEndpoint.Error(),
+		},
+		{
+			name: "invalid instant_logs config",
+			config: Config{
+				InstantLogs: configoptional.Some(InstantLogsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+				}),
+			},
+			expectedErr: "invalid instant_logs config: " + errNoZone.Error() + "; " + errNoFields.Error() + "; " + errInvalidSample.Error() + "; " + errInvalidReconnectDelay.Error(),
+		},
+		{
+			name: "invalid queues config",
+			config: Config{
+				Queues: configoptional.Some(QueuesConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					PollInterval:      time.Minute,
+					BatchSize:         500,
+					VisibilityTimeout: time.Minute,
+				}),
+			},
+			expectedErr: "invalid queues config: " + errNoAccount.Error() + "; " + errNoQueue.Error() + "; " + errInvalidBatchSize.Error(),
+		},
+		{
+			name: "invalid manage_jobs config",
+			config: Config{
+				Logs: LogsConfig{Endpoint: "localhost:0"},
+				ManageJobs: configoptional.Some(ManageJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					DestinationURL: "http://logs.example.com",
+					Datasets: []ManagedJobConfig{
+						{Dataset: "http_requests", Filter: "{"},
+						{Dataset: "http_requests", Fields: []string{"RayID"}},
+					},
+				}),
+			},
+			expectedErr: "invalid manage_jobs config: " + errInvalidDestinationURL.Error() + "; " + errNoZones.Error() + "; " +
+				`invalid job for dataset "http_requests": ` + errNoFields.Error() + "; " + errInvalidFilter.Error() + "; " +
+				`duplicate job name "otelcol-http-requests"`,
+		},
+		{
+			name: "manage_jobs with misspelled dataset",
+			config: Config{
+				Logs: LogsConfig{Endpoint: "localhost:0"},
+				ManageJobs: configoptional.Some(ManageJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					DestinationURL: "https://logs.example.com",
+					Zones:          []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:       []ManagedJobConfig{{Dataset: "http_request", Fields: []string{"RayID"}}},
+				}),
+			},
+			expectedErr: `invalid manage_jobs config: invalid job for dataset "http_request": unknown dataset "http_request", did you mean "http_requests"?`,
+		},
+		{
+			name: "queues with unknown dataset",
+			config: Config{
+				Queues: configoptional.Some(QueuesConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Account:           "01a7362d577a6c3019a474fd6f485823",
+					Queue:             "023e105f4ecef8ad9ca31a8372d0c353",
+					Dataset:           "custom",
+					PollInterval:      time.Minute,
+					BatchSize:         10,
+					VisibilityTimeout: time.Minute,
+				}),
+			},
+			expectedErr: `invalid queues config: unknown dataset "custom", expected one of access_requests, audit_logs,`,
+		},
+		{
+			name: "manage_jobs without logs endpoint",
+			config: Config{
+				Queues: configoptional.Some(QueuesConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Account:           "01a7362d577a6c3019a474fd6f485823",
+					Queue:             "023e105f4ecef8ad9ca31a8372d0c353",
+					PollInterval:      time.Minute,
+					BatchSize:         10,
+					VisibilityTimeout: time.Minute,
+				}),
+				ManageJobs: configoptional.Some(ManageJobsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					DestinationURL: "https://logs.example.com",
+					Zones:          []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets:       []ManagedJobConfig{{Dataset: "http_requests", Fields: []string{"RayID"}}},
+				}),
+			},
+			expectedErr: errNoJobsEndpoint.Error(),
+		},
+		{
+			name: "derive_metrics without logs endpoint",
+			config: Config{
+				Logs: LogsConfig{DeriveMetrics: true},
+				Analytics: configoptional.Some(AnalyticsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Zones:    []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+					Datasets: []string{"waiting_room"},
+				}),
+			},
+			expectedErr: errNoDeriveMetricsEndpoint.Error(),
+		},
+		{
+			name: "invalid access_requests config",
+			config: Config{
+				AccessRequests: configoptional.Some(AccessRequestsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+				}),
+			},
+			expectedErr: "invalid access_requests config: " + errNoAccounts.Error() + "; " + errInvalidPollInterval.Error() + "; " + errInvalidPageSize.Error(),
+		},
+		{
+			name: "Valid audit_logs config without logs endpoint",
+			config: Config{
+				AuditLogs: configoptional.Some(AuditLogsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Accounts:     []string{"01a7362d577a6c3019a474fd6f485823"},
+					PollInterval: time.Minute,
+					PageSize:     100,
+				}),
+			},
+		},
+		{
+			name: "audit_logs page_size too large",
+			config: Config{
+				AuditLogs: configoptional.Some(AuditLogsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Accounts:     []string{"01a7362d577a6c3019a474fd6f485823"},
+					PollInterval: time.Minute,
+					PageSize:     5000,
+				}),
+			},
+			expectedErr: "invalid audit_logs config: page_size must be between 1 and 1000",
+		},
+		{
+			name: "audit_logs negative retention",
+			config: Config{
+				AuditLogs: configoptional.Some(AuditLogsConfig{
+					APIConfig: APIConfig{
+						ClientConfig: confighttp.ClientConfig{Endpoint: defaultAPIEndpoint},
+						APIToken:     "abc123",
+					},
+					Accounts:     []string{"01a7362d577a6c3019a474fd6f485823"},
+					PollInterval: time.Minute,
+					PageSize:     100,
+					Retention:    -time.Hour,
+				}),
+			},
+			expectedErr: "invalid audit_logs config: " + errInvalidRetention.Error(),
+		},
+		{
+			name: "Valid notifications config without logs endpoint",
+			config: Config{
+				Notifications: configoptional.Some(NotificationsConfig{
+					Endpoint: "0.0.0.0:9999",
+				}),
+			},
+		},
+		{
+			name: "notifications config without endpoint",
+			config: Config{
+				Notifications: configoptional.Some(NotificationsConfig{}),
+			},
+			expectedErr: "invalid notifications config: " + errNoEndpoint.Error(),
+		},
 		{
 			name: "invalid timestamp_format",
 			config: Config{
@@ -98,6 +836,171 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: "invalid timestamp_format \"bad\"",
 		},
+		{
+			name: "empty secret in secrets",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint: "0.0.0.0:9999",
+					Secrets:  []configopaque.String{"abc123", ""},
+				},
+			},
+			expectedErr: errEmptySecret.Error(),
+		},
+		{
+			name: "dataset path without leading slash",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint: "0.0.0.0:9999",
+					Datasets: []DatasetConfig{{Path: "http_requests"}},
+				},
+			},
+			expectedErr: "invalid dataset for path \"http_requests\": " + errInvalidDatasetPath.Error(),
+		},
+		{
+			name: "dataset with invalid timestamp_format",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint: "0.0.0.0:9999",
+					Datasets: []DatasetConfig{{Path: "/http_requests", TimestampFormat: "bad"}},
+				},
+			},
+			expectedErr: "invalid dataset for path \"/http_requests\": invalid timestamp_format \"bad\"",
+		},
+		{
+			name: "unknown dataset",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint: "0.0.0.0:9999",
+					Datasets: []DatasetConfig{{Path: "/custom", Dataset: "custom", TimestampField: "Time"}},
+				},
+			},
+			expectedErr: `unknown dataset "custom", expected one of access_requests, audit_logs,`,
+		},
+		{
+			name: "misspelled dataset",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint: "0.0.0.0:9999",
+					Datasets: []DatasetConfig{{Path: "/firewall_events", Dataset: "firewall_event"}},
+				},
+			},
+			expectedErr: `unknown dataset "firewall_event", did you mean "firewall_events"?`,
+		},
+		{
+			name: "duplicate dataset path",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint: "0.0.0.0:9999",
+					Datasets: []DatasetConfig{{Path: "/http_requests"}, {Path: "/http_requests"}},
+				},
+			},
+			expectedErr: "duplicate dataset path \"/http_requests\"",
+		},
+		{
+			name: "severity rule without field",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint:      "0.0.0.0:9999",
+					SeverityRules: []SeverityRule{{Min: &minStatus, Severity: "error"}},
+				},
+			},
+			expectedErr: "invalid severity rule 0: " + errNoSeverityRuleField.Error(),
+		},
+		{
+			name: "severity rule with both equals and range",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint:      "0.0.0.0:9999",
+					SeverityRules: []SeverityRule{{Field: "OriginResponseStatus", Equals: &block, Min: &minStatus, Severity: "error"}},
+				},
+			},
+			expectedErr: errInvalidSeverityRuleMatch.Error(),
+		},
+		{
+			name: "severity rule with invalid severity",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint:      "0.0.0.0:9999",
+					SeverityRules: []SeverityRule{{Field: "SecurityAction", Equals: &block, Severity: "critical"}},
+				},
+			},
+			expectedErr: "invalid severity \"critical\"",
+		},
+		{
+			name: "negative max_decompressed_size",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint:            "0.0.0.0:9999",
+					MaxDecompressedSize: -1,
+				},
+			},
+			expectedErr: errInvalidMaxDecompressedSize.Error(),
+		},
+		{
+			name: "health_check_path without leading slash",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint:        "0.0.0.0:9999",
+					HealthCheckPath: "healthz",
+				},
+			},
+			expectedErr: errInvalidHealthCheckPath.Error(),
+		},
+		{
+			name: "health_check_path used by a dataset",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint:        "0.0.0.0:9999",
+					HealthCheckPath: "/healthz",
+					Datasets:        []DatasetConfig{{Path: "/healthz", TimestampField: "Datetime"}},
+				},
+			},
+			expectedErr: "health_check_path \"/healthz\" is also a dataset path",
+		},
+		{
+			name: "negative health_check_failure_timeout",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint:                  "0.0.0.0:9999",
+					HealthCheckPath:           "/healthz",
+					HealthCheckFailureTimeout: -time.Minute,
+				},
+			},
+			expectedErr: errInvalidHealthCheckTimeout.Error(),
+		},
+		{
+			name: "negative max_in_flight_size",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint:        "0.0.0.0:9999",
+					MaxInFlightSize: -1,
+				},
+			},
+			expectedErr: errInvalidMaxInFlightSize.Error(),
+		},
+		{
+			name: "max_in_flight_size smaller than max_decompressed_size",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint:            "0.0.0.0:9999",
+					MaxDecompressedSize: 2048,
+					MaxInFlightSize:     1024,
+				},
+			},
+			expectedErr: errMaxInFlightSizeTooSmall.Error(),
+		},
+		{
+			name: "negative server limits",
+			config: Config{
+				Logs: LogsConfig{
+					Endpoint:              "0.0.0.0:9999",
+					MaxRequestBodySize:    -1,
+					MaxConcurrentRequests: -1,
+					ReadTimeout:           -time.Second,
+				},
+			},
+			expectedErr: errInvalidMaxRequestBodySize.Error() + "; " + errInvalidMaxConcurrency.Error() + "; " + errInvalidServerTimeout.Error(),
+		},
 	}
 
 	for _, tc := range cases {
@@ -116,6 +1019,94 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
+	defaultCfg := createDefaultConfig().(*Config)
+	logpushJobsCfg := *createDefaultConfig().(*Config).LogpushJobs.GetOrInsertDefault()
+	logpushJobsCfg.CollectionInterval = 5 * time.Minute
+	logpushJobsCfg.APIToken = "abcdef123456"
+	logpushJobsCfg.Zones = []string{"023e105f4ecef8ad9ca31a8372d0c353"}
+	logpushJobsCfg.Accounts = []string{"01a7362d577a6c3019a474fd6f485823"}
+	analyticsCfg := *createDefaultConfig().(*Config).Analytics.GetOrInsertDefault()
+	analyticsCfg.APIToken = "abcdef123456"
+	analyticsCfg.Zones = []string{"023e105f4ecef8ad9ca31a8372d0c353"}
+	analyticsCfg.Accounts = []string{"01a7362d577a6c3019a474fd6f485823"}
+	analyticsCfg.Datasets = []string{"waiting_room", "turnstile"}
+	analyticsCfg.Delay = 5 * time.Minute
+	analyticsCfg.Tenants = []AnalyticsTenantConfig{{
+		Name:               "acme",
+		APIToken:           "123456abcdef",
+		Accounts:           []string{"372e67954025e0ba6aaa6d586b9e0b59"},
+		Datasets:           []string{"workers"},
+		ResourceAttributes: map[string]string{"customer": "acme"},
+	}}
+	analyticsLogsCfg := *createDefaultConfig().(*Config).AnalyticsLogs.GetOrInsertDefault()
+	analyticsLogsCfg.APIToken = "abcdef123456"
+	analyticsLogsCfg.Zones = []string{"023e105f4ecef8ad9ca31a8372d0c353"}
+	analyticsLogsCfg.Datasets = []string{"firewall_events"}
+	analyticsLogsCfg.PollInterval = 30 * time.Second
+	analyticsLogsCfg.Attributes = map[string]string{"clientCountryName": "client.geo.country", "userAgent": ""}
+
+	accessRequestsCfg := *createDefaultConfig().(*Config).AccessRequests.GetOrInsertDefault()
+	accessRequestsCfg.APIToken = "abcdef123456"
+	accessRequestsCfg.Accounts = []string{"01a7362d577a6c3019a474fd6f485823"}
+	accessRequestsCfg.PollInterval = 30 * time.Second
+	accessRequestsCfg.BackOffConfig.MaxElapsedTime = time.Minute
+
+	auditLogsCfg := *createDefaultConfig().(*Config).AuditLogs.GetOrInsertDefault()
+	auditLogsCfg.APIToken = "abcdef123456"
+	auditLogsCfg.Accounts = []string{"01a7362d577a6c3019a474fd6f485823"}
+	auditLogsCfg.PageSize = 500
+
+	storageID := component.MustNewID("file_storage")
+
+	queuesCfg := *createDefaultConfig().(*Config).Queues.GetOrInsertDefault()
+	queuesCfg.APIToken = "abcdef123456"
+	queuesCfg.Account = "01a7362d577a6c3019a474fd6f485823"
+	queuesCfg.Queue = "023e105f4ecef8ad9ca31a8372d0c353"
+	queuesCfg.Dataset = "http_requests"
+	queuesCfg.BatchSize = 100
+
+	manageJobsLogsCfg := createDefaultConfig().(*Config).Logs
+	manageJobsLogsCfg.Endpoint = "0.0.0.0:12345"
+	manageJobsLogsCfg.Secret = "1234567890abcdef1234567890abcdef"
+
+	manageJobsCfg := *createDefaultConfig().(*Config).ManageJobs.GetOrInsertDefault()
+	manageJobsCfg.APIToken = "abcdef123456"
+	manageJobsCfg.DestinationURL = "https://logs.example.com"
+	manageJobsCfg.Zones = []string{"023e105f4ecef8ad9ca31a8372d0c353"}
+	manageJobsCfg.Datasets = []ManagedJobConfig{
+		{
+			Dataset: "http_requests",
+			Fields:  []string{"EdgeStartTimestamp", "RayID", "ClientRequestHost"},
+			Filter:  `{"where":{"key":"ClientRequestHost","operator":"eq","value":"example.com"}}`,
+		},
+		{
+			Dataset: "firewall_events",
+			Fields:  []string{"Datetime", "RayID", "Action"},
+			Name:    "collector-firewall-events",
+		},
+	}
+
+	instantLogsCfg := *createDefaultConfig().(*Config).InstantLogs.GetOrInsertDefault()
+	instantLogsCfg.APIToken = "abcdef123456"
+	instantLogsCfg.Zone = "023e105f4ecef8ad9ca31a8372d0c353"
+	instantLogsCfg.Sample = 100
+	instantLogsCfg.Filter = `{"where":{"key":"EdgeResponseStatus","operator":"geq","value":500}}`
+
+	datasetsLogsCfg := createDefaultConfig().(*Config).Logs
+	datasetsLogsCfg.Endpoint = "0.0.0.0:12345"
+	datasetsLogsCfg.Datasets = []DatasetConfig{
+		{
+			Path:               "/http_requests",
+			ResourceAttributes: map[string]string{"cloudflare.dataset": "http_requests"},
+		},
+		{
+			Path:               "/firewall_events",
+			TimestampField:     "Datetime",
+			Attributes:         map[string]string{"RayID": "cloudflare.ray_id", "Action": "cloudflare.action"},
+			ResourceAttributes: map[string]string{"cloudflare.dataset": "firewall_events"},
+		},
+	}
+
 	cases := []struct {
 		name           string
 		expectedConfig component.Config
@@ -131,15 +1122,290 @@ func TestLoadConfig(t *testing.T) {
 							KeyFile:  "some_key_file",
 						},
 					},
-					Secret:          "1234567890abcdef1234567890abcdef",
-					TimestampField:  "EdgeStartTimestamp",
-					TimestampFormat: "rfc3339",
-					Separator:       ".",
+					Secret:              "1234567890abcdef1234567890abcdef",
+					TimestampField:      "EdgeStartTimestamp",
+					TimestampFormat:     "rfc3339",
+					Separator:           ".",
+					MaxDecompressedSize: defaultMaxDecompressedSize,
+					MaxInFlightSize:     defaultMaxInFlightSize,
+					MaxRequestBodySize:  defaultMaxRequestBodySize,
+					IdleTimeout:         defaultIdleTimeout,
+					ConsumeTimeout:      defaultConsumeTimeout,
+
+					HealthCheckFailureTimeout: defaultHealthCheckTimeout,
 					Attributes: map[string]string{
 						"ClientIP":         "http_request.client_ip",
 						"ClientRequestURI": "http_request.uri",
 					},
 				},
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    defaultCfg.InstantLogs,
+				Queues:         defaultCfg.Queues,
+				ManageJobs:     defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "logpush_jobs",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    configoptional.Some(logpushJobsCfg),
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    defaultCfg.InstantLogs,
+				Queues:         defaultCfg.Queues,
+				ManageJobs:     defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "analytics",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      configoptional.Some(analyticsCfg),
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    defaultCfg.InstantLogs,
+				Queues:         defaultCfg.Queues,
+				ManageJobs:     defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "analytics_logs",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  configoptional.Some(analyticsLogsCfg),
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    defaultCfg.InstantLogs,
+				Queues:         defaultCfg.Queues,
+				ManageJobs:     defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "access_requests",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: configoptional.Some(accessRequestsCfg),
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    defaultCfg.InstantLogs,
+				Queues:         defaultCfg.Queues,
+				ManageJobs:     defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "audit_logs",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      configoptional.Some(auditLogsCfg),
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    defaultCfg.InstantLogs,
+				Queues:         defaultCfg.Queues,
+				ManageJobs:     defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "datasets",
+			expectedConfig: &Config{
+				Logs:           datasetsLogsCfg,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    defaultCfg.InstantLogs,
+				Queues:         defaultCfg.Queues,
+				ManageJobs:     defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "notifications",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    defaultCfg.InstantLogs,
+				Queues:         defaultCfg.Queues,
+				ManageJobs:     defaultCfg.ManageJobs,
+				Notifications: configoptional.Some(NotificationsConfig{
+					Endpoint: "0.0.0.0:12346",
+					Secret:   "1234567890abcdef1234567890abcdef",
+				}),
+			},
+		},
+		{
+			name: "r2",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2: configoptional.Some(R2Config{
+					BucketConfig: BucketConfig{
+						Bucket:          "logpush",
+						Prefix:          "http_requests",
+						Dataset:         "http_requests",
+						AccessKeyID:     "abcdef123456",
+						SecretAccessKey: "1234567890abcdef",
+						PollInterval:    defaultPollInterval,
+						Lookback:        defaultBucketLookback,
+					},
+					AccountID: "01a7362d577a6c3019a474fd6f485823",
+				}),
+				S3:          defaultCfg.S3,
+				GCS:         defaultCfg.GCS,
+				InstantLogs: defaultCfg.InstantLogs,
+				Queues:      defaultCfg.Queues,
+				ManageJobs:  defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "s3",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3: configoptional.Some(S3Config{
+					BucketConfig: BucketConfig{
+						Bucket:       "logpush",
+						Prefix:       "logs/firewall_events",
+						Dataset:      "firewall_events",
+						PollInterval: 5 * time.Minute,
+						Lookback:     48 * time.Hour,
+						StorageID:    &storageID,
+					},
+					Region: "eu-west-1",
+				}),
+				GCS:         defaultCfg.GCS,
+				InstantLogs: defaultCfg.InstantLogs,
+				Queues:      defaultCfg.Queues,
+				ManageJobs:  defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "gcs",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS: configoptional.Some(GCSConfig{
+					BucketConfig: BucketConfig{
+						Bucket:          "logpush",
+						Prefix:          "dns_logs",
+						Dataset:         "dns_logs",
+						AccessKeyID:     "GOOG1EABCDEF",
+						SecretAccessKey: "1234567890abcdef",
+						PollInterval:    defaultPollInterval,
+						Lookback:        defaultBucketLookback,
+					},
+					Endpoint: defaultGCSEndpoint,
+				}),
+				InstantLogs: defaultCfg.InstantLogs,
+				Queues:      defaultCfg.Queues,
+				ManageJobs:  defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "instant_logs",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    configoptional.Some(instantLogsCfg),
+				Queues:         defaultCfg.Queues,
+				ManageJobs:     defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "queues",
+			expectedConfig: &Config{
+				Logs:           defaultCfg.Logs,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    defaultCfg.InstantLogs,
+				Queues:         configoptional.Some(queuesCfg),
+				ManageJobs:     defaultCfg.ManageJobs,
+			},
+		},
+		{
+			name: "manage_jobs",
+			expectedConfig: &Config{
+				Logs:           manageJobsLogsCfg,
+				LogpushJobs:    defaultCfg.LogpushJobs,
+				Analytics:      defaultCfg.Analytics,
+				AnalyticsLogs:  defaultCfg.AnalyticsLogs,
+				AccessRequests: defaultCfg.AccessRequests,
+				AuditLogs:      defaultCfg.AuditLogs,
+				R2:             defaultCfg.R2,
+				S3:             defaultCfg.S3,
+				GCS:            defaultCfg.GCS,
+				InstantLogs:    defaultCfg.InstantLogs,
+				Queues:         defaultCfg.Queues,
+				ManageJobs:     configoptional.Some(manageJobsCfg),
 			},
 		},
 	}
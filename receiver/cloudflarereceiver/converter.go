@@ -0,0 +1,324 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ua-parser/uap-go/uaparser"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	rcvr "go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// logsConverter converts Logpush records to log records with the settings of the logs section. It's
+// used by the Logpush endpoint and by the receivers collecting Logpush records from other sources.
+type logsConverter struct {
+	logger    *zap.Logger
+	cfg       *LogsConfig
+	uaParser  *uaparser.Parser
+	buildInfo component.BuildInfo
+
+	telemetryBuilder *metadata.TelemetryBuilder
+
+	// defaultDataset holds the settings of logs received on paths without a dataset of their own.
+	defaultDataset *DatasetConfig
+	datasets       map[string]*DatasetConfig
+	// detectedDatasets holds the settings of the datasets detected from the fields of logs, when
+	// detect_dataset is enabled.
+	detectedDatasets map[string]*DatasetConfig
+}
+
+func newLogsConverter(params rcvr.Settings, cfg *LogsConfig) (*logsConverter, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(params.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &logsConverter{
+		logger:           params.Logger,
+		cfg:              cfg,
+		buildInfo:        params.BuildInfo,
+		telemetryBuilder: telemetryBuilder,
+	}
+
+	c.defaultDataset = &DatasetConfig{
+		TimestampField:  cfg.TimestampField,
+		TimestampFormat: cfg.TimestampFormat,
+		Attributes:      cfg.Attributes,
+		DropFields:      cfg.DropFields,
+		HashFields:      cfg.HashFields,
+	}
+	c.datasets = make(map[string]*DatasetConfig, len(cfg.Datasets))
+	for _, ds := range cfg.Datasets {
+		c.datasets[ds.Path] = c.defaultDataset.merge(ds)
+	}
+	if cfg.DetectDataset {
+		c.detectedDatasets = make(map[string]*DatasetConfig, len(datasetSignatures))
+		for _, signature := range datasetSignatures {
+			c.detectedDatasets[signature.dataset] = c.defaultDataset.merge(DatasetConfig{Dataset: signature.dataset})
+		}
+	}
+
+	if cfg.ParseUserAgent {
+		c.uaParser = uaparser.NewFromSaved()
+	}
+	return c, nil
+}
+
+func (c *logsConverter) shutdown() {
+	c.telemetryBuilder.Shutdown()
+}
+
+// datasetFor returns the settings of the dataset received on the path. The dataset of logs received
+// on other paths is detected from their fields when detect_dataset is enabled.
+func (c *logsConverter) datasetFor(path string, logs []map[string]any) *DatasetConfig {
+	if ds, ok := c.datasets[path]; ok {
+		return ds
+	}
+	if c.detectedDatasets != nil && len(logs) != 0 {
+		// A Logpush batch holds the logs of a single dataset.
+		if ds, ok := c.detectedDatasets[detectDataset(logs[0])]; ok {
+			return ds
+		}
+	}
+	return c.defaultDataset
+}
+
+// readLogs decodes the lines of a payload with the given content encoding as they are read, so that
+// the payload is never held in memory as a whole. The compression is detected from the magic bytes
+// when the encoding is unknown. reserve is called with the size of every line before it's decoded,
+// and reading stops with errInFlightExceeded when it returns false. The total size reserved is
+// returned even when reading fails.
+func (c *logsConverter) readLogs(r io.Reader, encoding string, reserve func(int64) bool) ([]map[string]any, []rejectedRecord, int64, error) {
+	body := bufio.NewReader(r)
+	magic, _ := body.Peek(len(zstdMagic))
+
+	var decompressed io.Reader
+	switch {
+	case encoding == "gzip" || bytes.HasPrefix(magic, gzipMagic):
+		encoding = "gzip"
+		reader, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to read gzip payload: %w", err)
+		}
+		defer reader.Close()
+		decompressed = reader
+	case encoding == "zstd" || bytes.Equal(magic, zstdMagic):
+		encoding = "zstd"
+		reader, err := zstd.NewReader(body, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to read zstd payload: %w", err)
+		}
+		defer reader.Close()
+		decompressed = reader
+	default:
+		return readLines(body, reserve)
+	}
+
+	if c.cfg.MaxDecompressedSize > 0 {
+		decompressed = &maxSizeReader{reader: decompressed, remaining: c.cfg.MaxDecompressedSize, err: errPayloadTooLarge}
+	}
+	logs, rejected, size, err := readLines(decompressed, reserve)
+	if err != nil && !errors.Is(err, errPayloadTooLarge) && !errors.Is(err, errInFlightExceeded) {
+		err = fmt.Errorf("failed to read %s payload: %w", encoding, err)
+	}
+	return logs, rejected, size, err
+}
+
+func (c *logsConverter) processLogs(now pcommon.Timestamp, logs []map[string]any) plog.Logs {
+	return c.processDatasetLogs(context.Background(), now, logs, nil, c.defaultDataset)
+}
+
+// processDatasetLogs converts the logs to log records using the settings of the dataset they belong to.
+// Logs that don't match the schema of the dataset are rejected along with the lines that couldn't be parsed.
+func (c *logsConverter) processDatasetLogs(ctx context.Context, now pcommon.Timestamp, logs []map[string]any, rejected []rejectedRecord, ds *DatasetConfig) plog.Logs {
+	pLogs := plog.NewLogs()
+
+	if len(ds.DropFields) != 0 || len(ds.HashFields) != 0 {
+		for _, log := range logs {
+			redactFields(log, ds.DropFields, ds.HashFields)
+		}
+	}
+
+	// Group logs by zone, so that logs of several zones received on one endpoint have a resource each.
+	groupedLogs := make(map[zoneKey][]map[string]any)
+	groupedTimestamps := make(map[zoneKey][]pcommon.Timestamp)
+	for _, log := range logs {
+		var timestamp pcommon.Timestamp
+		if v, ok := log[ds.TimestampField]; ok {
+			ts, err := parseTimestamp(v, ds.TimestampFormat)
+			if err != nil {
+				c.logger.Warn("unable to parse "+ds.TimestampField, zap.Error(err))
+				rejected = append(rejected, newRejectedRecord(log, metadata.AttributeReasonInvalidTimestamp, err))
+				continue
+			}
+			timestamp = ts
+		} else if ds.Dataset != "" {
+			// The timestamp field is part of the schema of every known dataset.
+			rejected = append(rejected, newRejectedRecord(log, metadata.AttributeReasonMissingTimestamp, fmt.Errorf("missing %s field", ds.TimestampField)))
+			continue
+		} else {
+			c.logger.Warn("unable to parse "+ds.TimestampField, zap.Any("value", v))
+		}
+
+		zone := logZone(log)
+		groupedLogs[zone] = append(groupedLogs[zone], log)
+		groupedTimestamps[zone] = append(groupedTimestamps[zone], timestamp)
+	}
+
+	for zone, logGroup := range groupedLogs {
+		resourceLogs, scopeLogs := appendResourceLogs(pLogs, c.buildInfo)
+		resource := resourceLogs.Resource()
+		for k, v := range ds.ResourceAttributes {
+			resource.Attributes().PutStr(k, v)
+		}
+		putStrIfNotEmpty(resource.Attributes(), attrZoneID, zone.id)
+		if zoneNameAttributeFeatureGate.IsEnabled() {
+			putStrIfNotEmpty(resource.Attributes(), attrZoneName, zone.name)
+		} else {
+			putStrIfNotEmpty(resource.Attributes(), attrZoneNameDeprecated, zone.name)
+		}
+
+		for i, log := range logGroup {
+			logRecord := scopeLogs.LogRecords().AppendEmpty()
+			logRecord.SetObservedTimestamp(now)
+			logRecord.SetTimestamp(groupedTimestamps[zone][i])
+
+			if v, ok := log["EdgeResponseStatus"]; ok {
+				sev := plog.SeverityNumberUnspecified
+				switch v := v.(type) {
+				case string:
+					intV, err := strconv.ParseInt(v, 10, 64)
+					if err != nil {
+						c.logger.Warn("unable to parse EdgeResponseStatus", zap.Error(err), zap.String("value", v))
+					} else {
+						sev = severityFromStatusCode(intV)
+					}
+				case int64:
+					sev = severityFromStatusCode(v)
+				case float64:
+					sev = severityFromStatusCode(int64(v))
+				}
+				if sev != plog.SeverityNumberUnspecified {
+					logRecord.SetSeverityNumber(sev)
+					logRecord.SetSeverityText(sev.String())
+				}
+			}
+
+			if sev, ok := severityFromRules(c.cfg.SeverityRules, log); ok {
+				logRecord.SetSeverityNumber(sev)
+				logRecord.SetSeverityText(sev.String())
+			}
+
+			attrs := logRecord.Attributes()
+			for field, v := range log {
+				attrName := field
+				if len(ds.Attributes) != 0 {
+					// Only process fields that are in the config mapping
+					mappedAttr, ok := ds.Attributes[field]
+					if !ok {
+						// Skip fields not in mapping when we have a config
+						continue
+					}
+					attrName = mappedAttr
+				} else if c.cfg.SemanticConventions {
+					// Fields with a semantic convention equivalent are renamed, all others are kept as is.
+					if semconvAttr, ok := semconvAttributes[field]; ok {
+						if putSemconvAttribute(attrs, semconvAttr, v) {
+							continue
+						}
+						attrName = semconvAttr
+					}
+				}
+				// else default to processing all fields with no renaming
+
+				switch v := v.(type) {
+				case string:
+					attrs.PutStr(attrName, v)
+				case int:
+					attrs.PutInt(attrName, int64(v))
+				case int64:
+					attrs.PutInt(attrName, v)
+				case float64:
+					attrs.PutDouble(attrName, v)
+				case bool:
+					attrs.PutBool(attrName, v)
+				case map[string]any:
+					// Flatten the map and add each field with a prefixed key
+					flattened := make(map[string]any)
+					flattenMap(v, attrName+c.cfg.Separator, c.cfg.Separator, flattened)
+					for k, val := range flattened {
+						switch v := val.(type) {
+						case string:
+							attrs.PutStr(k, v)
+						case int:
+							attrs.PutInt(k, int64(v))
+						case int64:
+							attrs.PutInt(k, v)
+						case float64:
+							attrs.PutDouble(k, v)
+						case bool:
+							attrs.PutBool(k, v)
+						default:
+							c.logger.Warn("unable to translate flattened field to attribute, unsupported type",
+								zap.String("field", k),
+								zap.Any("value", v),
+								zap.String("type", fmt.Sprintf("%T", v)))
+						}
+					}
+				default:
+					c.logger.Warn("unable to translate field to attribute, unsupported type",
+						zap.String("field", field),
+						zap.Any("value", v),
+						zap.String("type", fmt.Sprintf("%T", v)))
+				}
+			}
+
+			if ds.SampleInterval > 1 {
+				attrs.PutInt(attrSampleInterval, int64(ds.SampleInterval))
+			}
+
+			if c.uaParser != nil {
+				if userAgent, ok := log["ClientRequestUserAgent"].(string); ok && userAgent != "" {
+					addUserAgentAttributes(attrs, c.uaParser, userAgent)
+				}
+			}
+
+			if isFirewallEvent(log) {
+				addFirewallEventAttributes(attrs, log)
+			}
+
+			if c.cfg.TraceContextFromRayID {
+				setTraceContextFromRayID(logRecord, log)
+			}
+
+			if c.cfg.OCSF {
+				if err := addOCSFAttributes(attrs, log, logRecord.Timestamp()); err != nil {
+					c.logger.Warn("unable to set OCSF attributes", zap.Error(err))
+				}
+			}
+
+			err := logRecord.Body().SetEmptyMap().FromRaw(log)
+			if err != nil {
+				c.logger.Warn("unable to set body", zap.Error(err))
+			}
+		}
+	}
+
+	c.reportRejected(ctx, now, pLogs, rejected, ds)
+	return pLogs
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// attrDataset is the resource attribute holding the name of the Logpush dataset.
+const attrDataset = "cloudflare.dataset"
+
+// attrSampleInterval is the log attribute holding the number of requests a sampled log stands for.
+const attrSampleInterval = "cloudflare.sample_interval"
+
+// datasetTimestampFields maps Logpush datasets to the field holding the time of their logs.
+var datasetTimestampFields = map[string]string{
+	"access_requests":             "CreatedAt",
+	"audit_logs":                  "When",
+	"casb_findings":               "DetectedTimestamp",
+	"device_posture_results":      "Timestamp",
+	"dlp_forensic_copies":         "Datetime",
+	"dns_firewall_logs":           "Timestamp",
+	"dns_logs":                    "Timestamp",
+	"firewall_events":             "Datetime",
+	"gateway_dns":                 "Datetime",
+	"gateway_http":                "Datetime",
+	"gateway_network":             "Datetime",
+	"http_requests":               "EdgeStartTimestamp",
+	"magic_ids_detections":        "Timestamp",
+	"nel_reports":                 "Timestamp",
+	"network_analytics_logs":      "Datetime",
+	"page_shield_events":          "Timestamp",
+	"sinkhole_http_logs":          "Timestamp",
+	"spectrum_events":             "Timestamp",
+	"workers_trace_events":        "EventTimestampMs",
+	"zero_trust_network_sessions": "SessionStartTime",
+}
+
+// datasetTimestampFormats maps Logpush datasets whose timestamp field ignores the timestamp_format of
+// the Logpush job to the format of the field.
+var datasetTimestampFormats = map[string]string{
+	"workers_trace_events": "unixmilli",
+}
+
+// datasetSignatures lists the fields characteristic of the logs of datasets, used to detect the
+// dataset of logs received on paths without one. The most specific signatures come first, since the
+// logs of a dataset may hold only some of its fields.
+var datasetSignatures = []struct {
+	dataset string
+	fields  []string
+}{
+	{dataset: "workers_trace_events", fields: []string{"EventTimestampMs", "ScriptName"}},
+	{dataset: "firewall_events", fields: []string{"Datetime", "Action", "RayName"}},
+	{dataset: "gateway_dns", fields: []string{"Datetime", "QueryName", "ResolverDecision"}},
+	{dataset: "gateway_http", fields: []string{"Datetime", "HTTPHost", "HTTPMethod"}},
+	{dataset: "gateway_network", fields: []string{"Datetime", "DestinationIP", "Transport"}},
+	{dataset: "dns_firewall_logs", fields: []string{"Timestamp", "QueryName", "ClusterID"}},
+	{dataset: "dns_logs", fields: []string{"Timestamp", "QueryName", "ResponseCode"}},
+	{dataset: "spectrum_events", fields: []string{"Timestamp", "Application", "Event"}},
+	{dataset: "nel_reports", fields: []string{"Timestamp", "Phase", "LastKnownGoodColoCode"}},
+	{dataset: "audit_logs", fields: []string{"When", "ActionType"}},
+	{dataset: "access_requests", fields: []string{"CreatedAt", "AppDomain"}},
+	{dataset: "casb_findings", fields: []string{"DetectedTimestamp"}},
+	{dataset: "zero_trust_network_sessions", fields: []string{"SessionStartTime"}},
+	{dataset: "http_requests", fields: []string{"EdgeStartTimestamp"}},
+}
+
+// detectDataset returns the dataset whose characteristic fields the log holds, or an empty string.
+func detectDataset(log map[string]any) string {
+	for _, signature := range datasetSignatures {
+		matches := true
+		for _, field := range signature.fields {
+			if _, ok := log[field]; !ok {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return signature.dataset
+		}
+	}
+	return ""
+}
+
+// validateDataset returns an error if the dataset isn't a known Logpush dataset, suggesting the known
+// dataset closest to it, so that typos are reported at config load time rather than by the API.
+func validateDataset(dataset string) error {
+	return validateName(dataset, slices.Collect(maps.Keys(datasetTimestampFields)))
+}
+
+// validateName returns an error if the dataset isn't one of the known datasets, suggesting the known
+// dataset closest to it.
+func validateName(dataset string, known []string) error {
+	if slices.Contains(known, dataset) {
+		return nil
+	}
+
+	slices.Sort(known)
+	closest, closestDistance := "", 0
+	for _, name := range known {
+		if d := editDistance(dataset, name); closest == "" || d < closestDistance {
+			closest, closestDistance = name, d
+		}
+	}
+	if closestDistance <= len(closest)/3 {
+		return fmt.Errorf("unknown dataset %q, did you mean %q?", dataset, closest)
+	}
+	return fmt.Errorf("unknown dataset %q, expected one of %s", dataset, strings.Join(known, ", "))
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	rcvr "go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// derivedMetricsKey identifies a series of the records metric.
+type derivedMetricsKey struct {
+	dataset     string
+	statusClass string
+	action      string
+	host        string
+}
+
+// derivedMetrics counts the records received by the Logpush endpoint. The counts are cumulative,
+// so they are kept for the lifetime of the receiver.
+type derivedMetrics struct {
+	consumer consumer.Metrics
+	// extrapolate counts the records of sampled datasets as the number of requests they stand for.
+	extrapolate bool
+
+	mu     sync.Mutex
+	mb     *metadata.MetricsBuilder
+	counts map[derivedMetricsKey]int64
+}
+
+func newDerivedMetrics(params rcvr.Settings, consumer consumer.Metrics, cfg *LogsConfig) *derivedMetrics {
+	return &derivedMetrics{
+		consumer:    consumer,
+		extrapolate: cfg.ExtrapolateSamples,
+		mb:          metadata.NewMetricsBuilder(metadata.DefaultMetricsBuilderConfig(), params),
+		counts:      make(map[derivedMetricsKey]int64),
+	}
+}
+
+// record counts the logs of the dataset, returning the cumulative counts of the series they belong to.
+func (d *derivedMetrics) record(now pcommon.Timestamp, logs []map[string]any, ds *DatasetConfig) pmetric.Metrics {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	weight := int64(1)
+	if d.extrapolate && ds.SampleInterval > 1 {
+		weight = int64(ds.SampleInterval)
+	}
+
+	updated := make(map[derivedMetricsKey]struct{})
+	for _, log := range logs {
+		key := derivedMetricsKey{
+			dataset:     datasetLabel(ds),
+			statusClass: statusClass(log["EdgeResponseStatus"]),
+			action:      stringField(log, "Action"),
+			host:        stringField(log, "ClientRequestHost", "HTTPHost"),
+		}
+		d.counts[key] += weight
+		updated[key] = struct{}{}
+	}
+
+	for key := range updated {
+		d.mb.RecordCloudflareLogpushRecordsDataPoint(now, d.counts[key], key.dataset, key.statusClass, key.action, key.host)
+	}
+
+	return d.mb.Emit()
+}
+
+// consume counts the logs of the dataset and sends the updated series to the metrics pipeline.
+func (d *derivedMetrics) consume(ctx context.Context, now pcommon.Timestamp, logs []map[string]any, ds *DatasetConfig) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return d.consumer.ConsumeMetrics(ctx, d.record(now, logs, ds))
+}
+
+// statusClass returns the class of a status code, such as 2xx.
+func statusClass(v any) string {
+	var code int64
+	switch v := v.(type) {
+	case string:
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return ""
+		}
+		code = parsed
+	case int64:
+		code = v
+	case float64:
+		code = int64(v)
+	default:
+		return ""
+	}
+	if code < 100 || code > 599 {
+		return ""
+	}
+	return strconv.FormatInt(code/100, 10) + "xx"
+}
+
+// intField returns the value of the field if it's an integer, which may be sent as a string.
+func intField(log map[string]any, field string) (int64, bool) {
+	switch v := log[field].(type) {
+	case float64:
+		return int64(v), true
+	case int64:
+		return v, true
+	case string:
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		return parsed, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// stringField returns the value of the first of the fields that is a string.
+func stringField(log map[string]any, fields ...string) string {
+	for _, field := range fields {
+		if v, ok := log[field].(string); ok {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+func TestStatusClass(t *testing.T) {
+	require.Equal(t, "2xx", statusClass(float64(200)))
+	require.Equal(t, "4xx", statusClass("403"))
+	require.Equal(t, "5xx", statusClass(int64(599)))
+	require.Empty(t, statusClass(float64(0)))
+	require.Empty(t, statusClass("unknown"))
+	require.Empty(t, statusClass(nil))
+}
+
+func TestDerivedMetrics(t *testing.T) {
+	sink := &consumertest.MetricsSink{}
+	r := newReceiver(t, &Config{Logs: LogsConfig{Endpoint: "localhost:0", TimestampField: "EdgeStartTimestamp"}}, nil)
+	r.metrics = newDerivedMetrics(receivertest.NewNopSettings(metadata.Type), sink, &LogsConfig{})
+
+	payload := strings.Join([]string{
+		`{"EdgeStartTimestamp":"2023-03-03T05:29:05Z","EdgeResponseStatus":200,"ClientRequestHost":"example.com"}`,
+		`{"EdgeStartTimestamp":"2023-03-03T05:29:06Z","EdgeResponseStatus":201,"ClientRequestHost":"example.com"}`,
+		`{"EdgeStartTimestamp":"2023-03-03T05:29:07Z","EdgeResponseStatus":403,"ClientRequestHost":"example.com","Action":"block"}`,
+	}, "\n")
+	for range 2 {
+		rec := httptest.NewRecorder()
+		r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	require.Len(t, sink.AllMetrics(), 2)
+	// The counts are cumulative over the payloads.
+	counts := derivedCounts(t, sink.AllMetrics()[1])
+	require.Equal(t, map[string]int64{
+		"2xx//example.com":      4,
+		"4xx/block/example.com": 2,
+	}, counts)
+}
+
+func TestDerivedMetricsExtrapolated(t *testing.T) {
+	payload := `{"EdgeStartTimestamp":"2023-03-03T05:29:05Z","EdgeResponseStatus":200,"ClientRequestHost":"example.com"}`
+	for _, tc := range []struct {
+		name        string
+		extrapolate bool
+		expected    int64
+	}{
+		{name: "raw", expected: 1},
+		{name: "extrapolated", extrapolate: true, expected: 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := &consumertest.MetricsSink{}
+			r := newReceiver(t, &Config{Logs: LogsConfig{
+				Endpoint: "localhost:0",
+				Datasets: []DatasetConfig{{Path: "/http_requests", Dataset: "http_requests", SampleInterval: 100}},
+			}}, nil)
+			r.metrics = newDerivedMetrics(receivertest.NewNopSettings(metadata.Type), sink, &LogsConfig{ExtrapolateSamples: tc.extrapolate})
+
+			rec := httptest.NewRecorder()
+			r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/http_requests", strings.NewReader(payload)))
+			require.Equal(t, http.StatusOK, rec.Code)
+			dps := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+			require.Equal(t, 1, dps.Len())
+			require.Equal(t, tc.expected, dps.At(0).IntValue())
+		})
+	}
+}
+
+// derivedRatios returns the ratios of the metrics by zone, dataset and metric name.
+func derivedRatios(md pmetric.Metrics) map[string]float64 {
+	ratios := make(map[string]float64)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		zone, ok := rms.At(i).Resource().Attributes().Get("cloudflare.zone.name")
+		if !ok {
+			continue
+		}
+		ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			dp := ms.At(j).Gauge().DataPoints().At(0)
+			dataset, _ := dp.Attributes().Get("cloudflare.logpush.dataset")
+			ratios[zone.Str()+"/"+dataset.Str()+"/"+ms.At(j).Name()] = dp.DoubleValue()
+		}
+	}
+	return ratios
+}
+
+func TestDerivedMetricsConsumerError(t *testing.T) {
+	r := newReceiver(t, &Config{Logs: LogsConfig{Endpoint: "localhost:0"}}, nil)
+	r.metrics = newDerivedMetrics(receivertest.NewNopSettings(metadata.Type), consumertest.NewErr(errors.New("consumer failed")), &LogsConfig{})
+
+	rec := httptest.NewRecorder()
+	r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"ClientRequestHost":"example.com"}`)))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+// derivedCounts returns the value of each series of the records metric, keyed by status class, action and host.
+func derivedCounts(t *testing.T, metrics pmetric.Metrics) map[string]int64 {
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.Len())
+	require.Equal(t, "cloudflare.logpush.records", ms.At(0).Name())
+
+	counts := make(map[string]int64)
+	dps := ms.At(0).Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		dataset, _ := attrs.Get("cloudflare.logpush.dataset")
+		require.Equal(t, "default", dataset.Str())
+		class, _ := attrs.Get("cloudflare.edge.response.status_class")
+		action, _ := attrs.Get("cloudflare.action")
+		host, _ := attrs.Get("server.address")
+		counts[class.Str()+"/"+action.Str()+"/"+host.Str()] = dps.At(i).IntValue()
+	}
+	return counts
+}
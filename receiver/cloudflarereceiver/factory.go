@@ -5,20 +5,43 @@ package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
 )
 
+var (
+	errNoMetricsSources = errors.New("'logpush_jobs', 'analytics' or 'logs.derive_metrics' must be configured to collect metrics")
+	errNoTracesSources  = errors.New("'logs.endpoint' or the 'http_requests' dataset of 'analytics_logs' must be configured to collect traces")
+)
+
+// receivers holds the Logpush endpoints, shared by the logs, metrics and traces receivers of a configuration
+// so that they listen only once.
+var receivers = sharedcomponent.NewSharedComponents()
+
+// analyticsLogsReceivers holds the GraphQL event pollers, shared by the logs and traces receivers of a
+// configuration so that they poll the events only once.
+var analyticsLogsReceivers = sharedcomponent.NewSharedComponents()
+
 // NewFactory returns the component factory for the cloudflarereceiver
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
 		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
 	)
 }
 
@@ -29,7 +52,227 @@ func createLogsReceiver(
 	consumer consumer.Logs,
 ) (receiver.Logs, error) {
 	cfg := rConf.(*Config)
-	return newLogsReceiver(params, cfg, consumer)
+
+	var err error
+	recv := &combinedLogsReceiver{}
+	// The Logpush endpoint is always started unless the receiver collects data from other sources.
+	if cfg.Logs.Endpoint != "" || !cfg.hasOtherSources() {
+		recv.logs, err = getLogsReceiver(params, cfg)
+		if err != nil {
+			return nil, err
+		}
+		recv.logs.Unwrap().(*logsReceiver).consumer = consumer
+	}
+
+	if cfg.AnalyticsLogs.HasValue() {
+		recv.analyticsLogs, err = getAnalyticsLogsReceiver(params, cfg)
+		if err != nil {
+			return nil, err
+		}
+		recv.analyticsLogs.Unwrap().(*analyticsLogsReceiver).consumer = consumer
+	}
+
+	if cfg.AccessRequests.HasValue() {
+		recv.accessRequests, err = newAccessRequestsReceiver(params, cfg.AccessRequests.Get(), consumer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.AuditLogs.HasValue() {
+		recv.auditLogs, err = newAuditLogsReceiver(params, cfg.AuditLogs.Get(), consumer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Notifications.HasValue() {
+		recv.notifications, err = newNotificationsReceiver(params, cfg.Notifications.Get(), consumer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.R2.HasValue() {
+		r2Cfg := cfg.R2.Get()
+		newClient := func(ctx context.Context) (bucketClient, error) {
+			// R2 ignores the region, which must be set to auto.
+			return newS3BucketClient(ctx, &r2Cfg.BucketConfig, r2Cfg.endpoint(), "auto")
+		}
+		recv.r2, err = newBucketReceiver(params, cfg, "r2", &r2Cfg.BucketConfig, newClient, consumer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.S3.HasValue() {
+		s3Cfg := cfg.S3.Get()
+		newClient := func(ctx context.Context) (bucketClient, error) {
+			return newS3BucketClient(ctx, &s3Cfg.BucketConfig, s3Cfg.Endpoint, s3Cfg.Region)
+		}
+		recv.s3, err = newBucketReceiver(params, cfg, "s3", &s3Cfg.BucketConfig, newClient, consumer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.GCS.HasValue() {
+		gcsCfg := cfg.GCS.Get()
+		newClient := func(ctx context.Context) (bucketClient, error) {
+			// Cloud Storage ignores the region of the signature, which must be set to auto.
+			return newS3BucketClient(ctx, &gcsCfg.BucketConfig, gcsCfg.Endpoint, "auto")
+		}
+		recv.gcs, err = newBucketReceiver(params, cfg, "gcs", &gcsCfg.BucketConfig, newClient, consumer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.InstantLogs.HasValue() {
+		recv.instantLogs, err = newInstantLogsReceiver(params, cfg, consumer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Queues.HasValue() {
+		recv.queues, err = newQueuesReceiver(params, cfg, consumer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return recv, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	rConf component.Config,
+	consumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	cfg := rConf.(*Config)
+	if !cfg.LogpushJobs.HasValue() && !cfg.Analytics.HasValue() && !cfg.Logs.DeriveMetrics {
+		return nil, errNoMetricsSources
+	}
+
+	recv := &combinedMetricsReceiver{}
+	if cfg.LogpushJobs.HasValue() {
+		jobsCfg := cfg.LogpushJobs.Get()
+		jobsScraper := newLogpushJobsScraper(params, jobsCfg)
+		s, err := scraper.NewMetrics(jobsScraper.scrape,
+			scraper.WithStart(jobsScraper.start),
+			scraper.WithShutdown(jobsScraper.shutdown))
+		if err != nil {
+			return nil, err
+		}
+
+		recv.logpushJobs, err = scraperhelper.NewMetricsController(&jobsCfg.ControllerConfig, params, consumer, scraperhelper.AddScraper(metadata.Type, s))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Analytics.HasValue() {
+		analyticsCfg := cfg.Analytics.Get()
+		analyticsScraper, err := newAnalyticsScraper(params, analyticsCfg)
+		if err != nil {
+			return nil, err
+		}
+		s, err := scraper.NewMetrics(analyticsScraper.scrape,
+			scraper.WithStart(analyticsScraper.start),
+			scraper.WithShutdown(analyticsScraper.shutdown))
+		if err != nil {
+			return nil, err
+		}
+
+		recv.analytics, err = scraperhelper.NewMetricsController(&analyticsCfg.ControllerConfig, params, consumer, scraperhelper.AddScraper(metadata.Type, s))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Like in the logs pipeline, the Logpush endpoint is only started when configured or when the
+	// receiver has no other sources.
+	if cfg.Logs.DeriveMetrics && (cfg.Logs.Endpoint != "" || !cfg.hasOtherSources()) {
+		var err error
+		recv.logs, err = getLogsReceiver(params, cfg)
+		if err != nil {
+			return nil, err
+		}
+		recv.logs.Unwrap().(*logsReceiver).metrics = newDerivedMetrics(params, consumer, &cfg.Logs)
+	}
+
+	return recv, nil
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	rConf component.Config,
+	consumer consumer.Traces,
+) (receiver.Traces, error) {
+	cfg := rConf.(*Config)
+	// Spans are derived from the Workers trace events received on the Logpush endpoint and from the
+	// requests sampled by the GraphQL Analytics API.
+	logpush := cfg.Logs.Endpoint != "" || !cfg.hasOtherSources()
+	requests := cfg.AnalyticsLogs.HasValue() && slices.Contains(cfg.AnalyticsLogs.Get().Datasets, "http_requests")
+	if !logpush && !requests {
+		return nil, errNoTracesSources
+	}
+
+	var err error
+	recv := &combinedTracesReceiver{}
+	if logpush {
+		recv.logs, err = getLogsReceiver(params, cfg)
+		if err != nil {
+			return nil, err
+		}
+		recv.logs.Unwrap().(*logsReceiver).traces = consumer
+	}
+
+	if requests {
+		recv.analyticsLogs, err = getAnalyticsLogsReceiver(params, cfg)
+		if err != nil {
+			return nil, err
+		}
+		recv.analyticsLogs.Unwrap().(*analyticsLogsReceiver).traces = consumer
+	}
+
+	return recv, nil
+}
+
+// getLogsReceiver returns the Logpush endpoint of the configuration, creating it if no other
+// receiver of the configuration did.
+func getLogsReceiver(params receiver.Settings, cfg *Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	recv := receivers.GetOrAdd(cfg, func() component.Component {
+		var logs *logsReceiver
+		logs, err = newLogsReceiver(params, cfg, nil)
+		if err == nil && cfg.ManageJobs.HasValue() {
+			logs.jobs = newJobsManager(params.TelemetrySettings, cfg)
+		}
+		return logs
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recv, nil
+}
+
+// getAnalyticsLogsReceiver returns the GraphQL event poller of the configuration, creating it if no
+// other receiver of the configuration did.
+func getAnalyticsLogsReceiver(params receiver.Settings, cfg *Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	recv := analyticsLogsReceivers.GetOrAdd(cfg, func() component.Component {
+		var analyticsLogs *analyticsLogsReceiver
+		analyticsLogs, err = newAnalyticsLogsReceiver(params, cfg.AnalyticsLogs.Get(), nil)
+		return analyticsLogs
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recv, nil
 }
 
 func createDefaultConfig() component.Config {
@@ -38,6 +281,102 @@ func createDefaultConfig() component.Config {
 			TimestampField:  defaultTimestampField,
 			TimestampFormat: defaultTimestampFormat,
 			Separator:       defaultSeparator,
+
+			MaxDecompressedSize: defaultMaxDecompressedSize,
+			MaxInFlightSize:     defaultMaxInFlightSize,
+			MaxRequestBodySize:  defaultMaxRequestBodySize,
+			IdleTimeout:         defaultIdleTimeout,
+			ConsumeTimeout:      defaultConsumeTimeout,
+
+			HealthCheckFailureTimeout: defaultHealthCheckTimeout,
 		},
+		LogpushJobs: configoptional.Default(LogpushJobsConfig{
+			ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+			APIConfig:            newDefaultAPIConfig(),
+			MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+			QueryTimeout:         defaultQueryTimeout,
+			ZoneCacheTTL:         defaultZoneCacheTTL,
+			PlanRecheckInterval:  defaultPlanRecheckInterval,
+			CircuitBreaker: CircuitBreakerConfig{
+				FailureThreshold: defaultFailureThreshold,
+				Cooldown:         defaultCooldown,
+			},
+		}),
+		Analytics: configoptional.Default(AnalyticsConfig{
+			ControllerConfig:       scraperhelper.NewDefaultControllerConfig(),
+			APIConfig:              newDefaultAPIConfig(),
+			MetricsBuilderConfig:   metadata.DefaultMetricsBuilderConfig(),
+			Delay:                  defaultAnalyticsDelay,
+			AggregationTemporality: temporalityDelta,
+			QueryTimeout:           defaultQueryTimeout,
+			ZoneCacheTTL:           defaultZoneCacheTTL,
+			CircuitBreaker: CircuitBreakerConfig{
+				FailureThreshold: defaultFailureThreshold,
+				Cooldown:         defaultCooldown,
+			},
+			PlanRecheckInterval: defaultPlanRecheckInterval,
+			Retention:           defaultAnalyticsRetention,
+		}),
+		AnalyticsLogs: configoptional.Default(AnalyticsLogsConfig{
+			APIConfig:    newDefaultAPIConfig(),
+			PollInterval: defaultPollInterval,
+			Delay:        defaultAnalyticsDelay,
+			Retention:    defaultAnalyticsRetention,
+		}),
+		AccessRequests: configoptional.Default(AccessRequestsConfig{
+			APIConfig:    newDefaultAPIConfig(),
+			PollInterval: defaultPollInterval,
+			PageSize:     defaultAccessRequestsPageSize,
+			Retention:    defaultAccessRequestsRetention,
+		}),
+		AuditLogs: configoptional.Default(AuditLogsConfig{
+			APIConfig:    newDefaultAPIConfig(),
+			PollInterval: defaultPollInterval,
+			PageSize:     defaultAuditLogsPageSize,
+			Retention:    defaultAuditLogsRetention,
+		}),
+		R2: configoptional.Default(R2Config{
+			BucketConfig: BucketConfig{PollInterval: defaultPollInterval, Lookback: defaultBucketLookback},
+		}),
+		S3: configoptional.Default(S3Config{
+			BucketConfig: BucketConfig{PollInterval: defaultPollInterval, Lookback: defaultBucketLookback},
+		}),
+		GCS: configoptional.Default(GCSConfig{
+			BucketConfig: BucketConfig{PollInterval: defaultPollInterval, Lookback: defaultBucketLookback},
+			Endpoint:     defaultGCSEndpoint,
+		}),
+		InstantLogs: configoptional.Default(InstantLogsConfig{
+			APIConfig:      newDefaultAPIConfig(),
+			Fields:         defaultInstantLogsFields,
+			Sample:         defaultInstantLogsSample,
+			ReconnectDelay: defaultReconnectDelay,
+		}),
+		Queues: configoptional.Default(QueuesConfig{
+			APIConfig:         newDefaultAPIConfig(),
+			PollInterval:      defaultPollInterval,
+			BatchSize:         defaultQueuesBatchSize,
+			VisibilityTimeout: defaultVisibilityTimeout,
+		}),
+		ManageJobs: configoptional.Default(ManageJobsConfig{
+			APIConfig: newDefaultAPIConfig(),
+		}),
+	}
+}
+
+// newDefaultAPIConfig returns the default settings of a client of the Cloudflare API. Failed requests
+// are retried for a shorter time than the default of exporters, so that they don't outlast a poll.
+func newDefaultAPIConfig() APIConfig {
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = defaultAPIEndpoint
+
+	backOffConfig := configretry.NewDefaultBackOffConfig()
+	backOffConfig.InitialInterval = defaultRetryInitialInterval
+	backOffConfig.MaxInterval = defaultRetryMaxInterval
+	backOffConfig.MaxElapsedTime = defaultRetryMaxElapsedTime
+
+	return APIConfig{
+		ClientConfig:    clientConfig,
+		BackOffConfig:   backOffConfig,
+		MaxResponseSize: defaultMaxResponseSize,
 	}
 }
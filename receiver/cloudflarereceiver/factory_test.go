@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
@@ -29,3 +31,107 @@ func TestCreateLogs(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestCreateMetricsWithoutSources(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	_, err := NewFactory().CreateMetrics(
+		t.Context(),
+		receivertest.NewNopSettings(metadata.Type),
+		cfg,
+		consumertest.NewNop(),
+	)
+	require.ErrorIs(t, err, errNoMetricsSources)
+}
+
+func TestCreateDerivedMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Endpoint = "localhost:0"
+	cfg.Logs.DeriveMetrics = true
+
+	factory := NewFactory()
+	logs, err := factory.CreateLogs(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	metrics, err := factory.CreateMetrics(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	// Both receivers share the Logpush endpoint.
+	shared := logs.(*combinedLogsReceiver).logs
+	require.Same(t, shared, metrics.(*combinedMetricsReceiver).logs)
+	recv := shared.Unwrap().(*logsReceiver)
+	require.NotNil(t, recv.consumer)
+	require.NotNil(t, recv.metrics)
+
+	require.NoError(t, logs.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, metrics.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, logs.Shutdown(t.Context()))
+	require.NoError(t, metrics.Shutdown(t.Context()))
+}
+
+func TestCreateDerivedMetricsWithoutEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.DeriveMetrics = true
+	cfg.LogpushJobs.GetOrInsertDefault()
+
+	// The Logpush endpoint isn't started when the receiver has other sources and no endpoint.
+	metrics, err := NewFactory().CreateMetrics(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.Nil(t, metrics.(*combinedMetricsReceiver).logs)
+	require.ErrorIs(t, cfg.Validate(), errNoDeriveMetricsEndpoint)
+}
+
+func TestCreateTracesWithoutEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Queues.GetOrInsertDefault()
+
+	_, err := NewFactory().CreateTraces(
+		t.Context(),
+		receivertest.NewNopSettings(metadata.Type),
+		cfg,
+		consumertest.NewNop(),
+	)
+	require.ErrorIs(t, err, errNoTracesSources)
+}
+
+func TestCreateTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Endpoint = "localhost:0"
+
+	factory := NewFactory()
+	logs, err := factory.CreateLogs(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	traces, err := factory.CreateTraces(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	// Both receivers share the Logpush endpoint.
+	shared := logs.(*combinedLogsReceiver).logs
+	require.Same(t, shared, traces.(*combinedTracesReceiver).logs)
+	recv := shared.Unwrap().(*logsReceiver)
+	require.NotNil(t, recv.consumer)
+	require.NotNil(t, recv.traces)
+
+	require.NoError(t, logs.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, traces.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, logs.Shutdown(t.Context()))
+	require.NoError(t, traces.Shutdown(t.Context()))
+}
+
+func TestCreateAnalyticsTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	analyticsLogsCfg := cfg.AnalyticsLogs.GetOrInsertDefault()
+	analyticsLogsCfg.Datasets = []string{"http_requests"}
+
+	factory := NewFactory()
+	logs, err := factory.CreateLogs(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	traces, err := factory.CreateTraces(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	// Both receivers share the GraphQL poller, and only the logs receiver starts the Logpush endpoint.
+	shared := logs.(*combinedLogsReceiver).analyticsLogs
+	require.Same(t, shared, traces.(*combinedTracesReceiver).analyticsLogs)
+	require.Nil(t, traces.(*combinedTracesReceiver).logs)
+	recv := shared.Unwrap().(*analyticsLogsReceiver)
+	require.NotNil(t, recv.consumer)
+	require.NotNil(t, recv.traces)
+}
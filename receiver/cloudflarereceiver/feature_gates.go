@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import "go.opentelemetry.io/collector/featuregate"
+
+// zoneNameAttributeFeatureGateName is the name of the feature gate renaming the cloudflare.zone
+// resource attribute of Logpush logs.
+const zoneNameAttributeFeatureGateName = "receiver.cloudflare.zoneNameAttribute"
+
+// zoneNameAttributeFeatureGate controls whether the zone name of Logpush logs is set in the
+// cloudflare.zone.name resource attribute, matching the other telemetry of the receiver, rather than
+// in the deprecated cloudflare.zone attribute.
+var zoneNameAttributeFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	zoneNameAttributeFeatureGateName, featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("When enabled, the zone name of Logpush logs is set in the cloudflare.zone.name resource attribute instead of cloudflare.zone."),
+	featuregate.WithRegisterFromVersion("v0.137.0"),
+)
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Security attributes set on log records of the Logpush firewall_events dataset.
+const (
+	attrEventName       = "event.name"
+	attrEventOutcome    = "event.outcome"
+	attrRuleDescription = "rule.description"
+	attrRuleCategory    = "rule.category"
+
+	firewallEventName = "cloudflare.firewall_event"
+)
+
+// firewallEventOutcomes maps the actions of firewall events to the outcome of the request:
+// "failure" when the request was stopped, "success" when it was let through.
+var firewallEventOutcomes = map[string]string{
+	"block":             "failure",
+	"challenge":         "failure",
+	"jschallenge":       "failure",
+	"managedChallenge":  "failure",
+	"connectionClose":   "failure",
+	"challengeFailed":   "failure",
+	"jschallengeFailed": "failure",
+
+	"allow":                                "success",
+	"log":                                  "success",
+	"bypass":                               "success",
+	"skip":                                 "success",
+	"challengeSolved":                      "success",
+	"challengeBypassed":                    "success",
+	"jschallengeSolved":                    "success",
+	"jschallengeBypassed":                  "success",
+	"managedChallengeSkipped":              "success",
+	"managedChallengeNonInteractiveSolved": "success",
+	"managedChallengeInteractiveSolved":    "success",
+	"managedChallengeBypassed":             "success",
+}
+
+// isFirewallEvent returns true if the log belongs to the firewall_events dataset. The Kind field
+// identifies it, but may not be among the exported fields, in which case the Action and Source
+// fields, which the other datasets don't have, are used instead.
+func isFirewallEvent(log map[string]any) bool {
+	if kind, ok := log["Kind"].(string); ok {
+		return kind == "firewall"
+	}
+	_, hasAction := log["Action"]
+	_, hasSource := log["Source"]
+	return hasAction && hasSource
+}
+
+// addFirewallEventAttributes sets security attributes derived from a firewall event, so that
+// SIEM-oriented backends can interpret it without a custom mapping.
+func addFirewallEventAttributes(attrs pcommon.Map, log map[string]any) {
+	attrs.PutStr(attrEventName, firewallEventName)
+
+	if action, ok := log["Action"].(string); ok {
+		attrs.PutStr(attrEventAction, action)
+		outcome, ok := firewallEventOutcomes[action]
+		if !ok {
+			outcome = "unknown"
+		}
+		attrs.PutStr(attrEventOutcome, outcome)
+	}
+
+	if ruleID, ok := log["RuleID"].(string); ok && ruleID != "" {
+		attrs.PutStr(attrRuleID, ruleID)
+	}
+	if description, ok := log["Description"].(string); ok && description != "" {
+		attrs.PutStr(attrRuleDescription, description)
+	}
+	if source, ok := log["Source"].(string); ok && source != "" {
+		attrs.PutStr(attrRuleCategory, source)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestIsFirewallEvent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		log      map[string]any
+		expected bool
+	}{
+		{
+			name:     "firewall kind",
+			log:      map[string]any{"Kind": "firewall", "RayID": "3a6050bcbe121a87"},
+			expected: true,
+		},
+		{
+			name:     "action and source without kind",
+			log:      map[string]any{"Action": "block", "Source": "waf"},
+			expected: true,
+		},
+		{
+			name:     "http request",
+			log:      map[string]any{"ClientRequestMethod": "GET", "EdgeResponseStatus": 200},
+			expected: false,
+		},
+		{
+			name:     "action without source",
+			log:      map[string]any{"Action": "allow", "PolicyID": "f174e90a-fafe-4643-bbbc-4a0ed4fc8415"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isFirewallEvent(tc.log))
+		})
+	}
+}
+
+func TestFirewallEventAttributes(t *testing.T) {
+	payload := `{"Action":"managedChallenge","ClientIP":"89.163.253.200","Datetime":"2023-03-03T05:29:05Z","Description":"Block bad bots","Kind":"firewall","RayID":"3a6050bcbe121a87","RuleID":"8c0ff4e7a1b64dd4b4d8f3b0e5ee0a21","Source":"firewallCustom"}
+{"Action":"rewrite","Kind":"firewall","RuleID":"","Source":"transform"}`
+
+	recv := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint:        "localhost:0",
+			TimestampField:  "Datetime",
+			TimestampFormat: "rfc3339",
+			Attributes:      map[string]string{"RayID": "cloudflare.ray_id"},
+		},
+	}, &consumertest.LogsSink{})
+
+	rawLogs, err := parsePayload([]byte(payload))
+	require.NoError(t, err)
+	logs := recv.converter.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+
+	require.Equal(t, map[string]any{
+		"cloudflare.ray_id": "3a6050bcbe121a87",
+		"event.name":        "cloudflare.firewall_event",
+		"event.action":      "managedChallenge",
+		"event.outcome":     "failure",
+		"rule.id":           "8c0ff4e7a1b64dd4b4d8f3b0e5ee0a21",
+		"rule.description":  "Block bad bots",
+		"rule.category":     "firewallCustom",
+	}, records.At(0).Attributes().AsRaw())
+
+	require.Equal(t, map[string]any{
+		"event.name":    "cloudflare.firewall_event",
+		"event.action":  "rewrite",
+		"event.outcome": "unknown",
+		"rule.category": "transform",
+	}, records.At(1).Attributes().AsRaw())
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// healthStatus is the body of the health check responses.
+type healthStatus struct {
+	Status      string     `json:"status"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LastFailure *time.Time `json:"last_failure,omitempty"`
+}
+
+// health tracks whether the receiver is serving and whether the latest payloads were delivered.
+type health struct {
+	// failureTimeout is how long a failure is reported for, 0 reporting it until the next success.
+	failureTimeout time.Duration
+
+	ready       atomic.Bool
+	lastSuccess atomic.Int64
+	lastFailure atomic.Int64
+}
+
+func (h *health) recordSuccess(t time.Time) {
+	h.lastSuccess.Store(t.UnixNano())
+}
+
+func (h *health) recordFailure(t time.Time) {
+	h.lastFailure.Store(t.UnixNano())
+}
+
+// status returns the health of the receiver at the time. It is unhealthy when it is not serving, or
+// when the latest payload failed to be delivered to the pipeline less than failureTimeout ago, so that
+// a receiver taken out of rotation by a load balancer recovers once the failure is old enough, even
+// though it receives no payload to succeed with.
+func (h *health) status(now time.Time) (int, healthStatus) {
+	status := healthStatus{Status: "ok"}
+	lastSuccess, lastFailure := h.lastSuccess.Load(), h.lastFailure.Load()
+	if lastSuccess != 0 {
+		t := time.Unix(0, lastSuccess).UTC()
+		status.LastSuccess = &t
+	}
+	if lastFailure != 0 {
+		t := time.Unix(0, lastFailure).UTC()
+		status.LastFailure = &t
+	}
+
+	switch {
+	case !h.ready.Load():
+		status.Status = "unavailable"
+		return http.StatusServiceUnavailable, status
+	case lastFailure > lastSuccess && (h.failureTimeout == 0 || now.Sub(time.Unix(0, lastFailure)) < h.failureTimeout):
+		status.Status = "failing"
+		return http.StatusServiceUnavailable, status
+	default:
+		return http.StatusOK, status
+	}
+}
+
+func (h *health) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	code, status := h.status(time.Now())
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	_ = json.NewEncoder(rw).Encode(status)
+}
+
+// withHealthCheck wraps the handler of the server so that requests to the path are answered with the
+// health of the receiver. They are not authenticated, so that load balancers can check it.
+func withHealthCheck(server *http.Server, path string, h *health) {
+	next := server.Handler
+	server.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == path {
+			h.ServeHTTP(rw, req)
+			return
+		}
+		next.ServeHTTP(rw, req)
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestHealthCheck(t *testing.T) {
+	var consumeErr error
+	next, err := consumer.NewLogs(func(_ context.Context, _ plog.Logs) error { return consumeErr })
+	require.NoError(t, err)
+	r := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint:        "localhost:0",
+			Secret:          "abc123",
+			TimestampField:  "EdgeStartTimestamp",
+			HealthCheckPath: "/healthz",
+		},
+	}, next)
+
+	checkHealth := func(expectedCode int, expectedStatus string) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		r.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody))
+		require.Equal(t, expectedCode, rec.Code)
+		var status healthStatus
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+		require.Equal(t, expectedStatus, status.Status)
+	}
+	push := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`))
+		req.Header.Set(secretHeaderName, "abc123")
+		rec := httptest.NewRecorder()
+		r.server.Handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+	// The health check is not subject to the secret.
+	checkHealth(http.StatusOK, "ok")
+
+	consumeErr = errors.New("consumer failed")
+	require.Equal(t, http.StatusServiceUnavailable, push())
+	checkHealth(http.StatusServiceUnavailable, "failing")
+
+	consumeErr = nil
+	require.Equal(t, http.StatusOK, push())
+	checkHealth(http.StatusOK, "ok")
+
+	require.NoError(t, r.Shutdown(t.Context()))
+	checkHealth(http.StatusServiceUnavailable, "unavailable")
+}
+
+func TestHealthStatusRecovery(t *testing.T) {
+	h := &health{failureTimeout: time.Minute}
+	h.ready.Store(true)
+	start := time.Date(2023, 3, 3, 5, 29, 0, 0, time.UTC)
+	h.recordSuccess(start)
+	h.recordFailure(start.Add(time.Second))
+
+	code, status := h.status(start.Add(30 * time.Second))
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "failing", status.Status)
+
+	// The receiver recovers without receiving any payload once the failure is old enough.
+	code, status = h.status(start.Add(time.Second + time.Minute))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "ok", status.Status)
+	require.Equal(t, start.Add(time.Second), *status.LastFailure)
+
+	// Without a timeout, the failure is reported until the next success.
+	h.failureTimeout = 0
+	code, _ = h.status(start.Add(time.Hour))
+	require.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestHealthCheckDisabled(t *testing.T) {
+	r := newReceiver(t, &Config{Logs: LogsConfig{Endpoint: "localhost:0", Secret: "abc123"}}, consumertest.NewNop())
+	require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(t.Context())) }()
+
+	rec := httptest.NewRecorder()
+	r.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
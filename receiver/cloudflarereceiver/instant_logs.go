@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	rcvr "go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// defaultInstantLogsFields are the fields of the http_requests dataset streamed by default.
+var defaultInstantLogsFields = []string{
+	"ClientIP",
+	"ClientRequestHost",
+	"ClientRequestMethod",
+	"ClientRequestURI",
+	"EdgeResponseBytes",
+	"EdgeResponseStatus",
+	"EdgeStartTimestamp",
+	"RayID",
+}
+
+// instantLogsReceiver creates an Instant Logs session for a zone and emits the HTTP request logs
+// streamed by its WebSocket. A new session is created whenever the stream ends.
+type instantLogsReceiver struct {
+	cfg      *InstantLogsConfig
+	settings component.TelemetrySettings
+	logger   *zap.Logger
+	consumer consumer.Logs
+	obsrecv  *receiverhelper.ObsReport
+	client   client
+	dialer   *websocket.Dialer
+	// zoneID is the ID of the zone, the configured name being resolved on start.
+	zoneID string
+
+	// converter converts the streamed logs like the Logpush endpoint does.
+	converter *logsConverter
+	dataset   *DatasetConfig
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+func newInstantLogsReceiver(params rcvr.Settings, cfg *Config, consumer consumer.Logs) (*instantLogsReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             params.ID,
+		Transport:              "websocket",
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := newLogsConverter(params, &cfg.Logs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instantLogsReceiver{
+		cfg:       cfg.InstantLogs.Get(),
+		settings:  params.TelemetrySettings,
+		logger:    params.Logger,
+		consumer:  consumer,
+		obsrecv:   obsrecv,
+		dialer:    websocket.DefaultDialer,
+		converter: converter,
+		dataset:   converter.defaultDataset.merge(DatasetConfig{Dataset: "http_requests", SampleInterval: cfg.InstantLogs.Get().Sample}),
+		zoneID:    cfg.InstantLogs.Get().Zone,
+	}, nil
+}
+
+func (r *instantLogsReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.client, err = newClient(ctx, &r.cfg.APIConfig, host, r.settings)
+	if err != nil {
+		return err
+	}
+	verifyToken(ctx, r.client, r.logger, "instant_logs", instantLogsPermission)
+	zoneIDs, err := resolveZoneIDs(ctx, r.client, []string{r.cfg.Zone})
+	if err != nil {
+		return err
+	}
+	r.zoneID = zoneIDs[0]
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.startStreaming(streamCtx)
+	return nil
+}
+
+func (r *instantLogsReceiver) Shutdown(_ context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	r.converter.shutdown()
+	return nil
+}
+
+func (r *instantLogsReceiver) startStreaming(ctx context.Context) {
+	defer r.wg.Done()
+
+	for {
+		if err := r.stream(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error("Instant Logs stream ended", zap.String("zone", r.zoneID), zap.Error(err))
+		}
+
+		select {
+		case <-time.After(r.cfg.ReconnectDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// stream creates a session and emits its logs until the WebSocket is closed.
+func (r *instantLogsReceiver) stream(ctx context.Context) error {
+	session, err := r.client.CreateInstantLogsSession(ctx, r.zoneID, instantLogsRequest{
+		Fields: strings.Join(r.cfg.Fields, ","),
+		Sample: r.cfg.Sample,
+		Filter: r.cfg.Filter,
+		Kind:   "instant-logs",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	conn, _, err := r.dialer.DialContext(ctx, session.DestinationConf, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to session %s: %w", session.SessionID, err)
+	}
+	defer conn.Close()
+	// Closing the connection unblocks the read when the receiver shuts down.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	r.logger.Debug("Connected to Instant Logs session", zap.String("zone", r.zoneID), zap.String("session", session.SessionID))
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if err := r.processMessage(ctx, message); err != nil {
+			// The stream can't be replayed, so the logs are dropped.
+			r.logger.Error("Failed to process Instant Logs", zap.String("zone", r.zoneID), zap.Error(err))
+		}
+	}
+}
+
+// processMessage emits the logs of a WebSocket message, which holds one or more JSON lines.
+func (r *instantLogsReceiver) processMessage(ctx context.Context, message []byte) error {
+	logs, rejected := parseLines(message)
+	pLogs := r.converter.processDatasetLogs(ctx, pcommon.NewTimestampFromTime(time.Now()), logs, rejected, r.dataset)
+	if pLogs.LogRecordCount() == 0 {
+		return nil
+	}
+
+	obsCtx := r.obsrecv.StartLogsOp(ctx)
+	err := r.consumer.ConsumeLogs(obsCtx, pLogs)
+	r.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), pLogs.LogRecordCount(), err)
+	if err != nil {
+		return errors.Join(errors.New("failed to consume logs"), err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+func TestInstantLogsStream(t *testing.T) {
+	var sessions atomic.Int32
+	upgrader := websocket.Upgrader{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/zones/023e105f4ecef8ad9ca31a8372d0c353/logpush/edge/jobs":
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, "Bearer abcdef123456", req.Header.Get("Authorization"))
+			var request instantLogsRequest
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+			assert.Equal(t, instantLogsRequest{Fields: "EdgeStartTimestamp,RayID", Sample: 10, Kind: "instant-logs"}, request)
+
+			session := sessions.Add(1)
+			destination := "ws" + strings.TrimPrefix(server.URL, "http") + "/sessions/" + strconv.Itoa(int(session))
+			_ = json.NewEncoder(rw).Encode(map[string]any{
+				"success": true,
+				"result":  map[string]any{"session_id": "session", "destination_conf": destination},
+			})
+		case strings.HasPrefix(req.URL.Path, "/sessions/"):
+			conn, err := upgrader.Upgrade(rw, req, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer conn.Close()
+			message := `{"EdgeStartTimestamp":"2023-03-03T05:00:05Z","RayID":"1"}` + "\n" + `{"EdgeStartTimestamp":"2023-03-03T05:00:06Z","RayID":"2"}`
+			assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(message)))
+			// Closing the connection ends the session.
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	instantLogsCfg := cfg.InstantLogs.GetOrInsertDefault()
+	instantLogsCfg.Endpoint = server.URL
+	instantLogsCfg.APIToken = "abcdef123456"
+	instantLogsCfg.Zone = "023e105f4ecef8ad9ca31a8372d0c353"
+	instantLogsCfg.Fields = []string{"EdgeStartTimestamp", "RayID"}
+	instantLogsCfg.Sample = 10
+	instantLogsCfg.ReconnectDelay = 10 * time.Millisecond
+	cfg.InstantLogs = configoptional.Some(*instantLogsCfg)
+
+	sink := &consumertest.LogsSink{}
+	r, err := newInstantLogsReceiver(receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(t.Context())) }()
+
+	// A new session is created once the first one ends.
+	require.Eventually(t, func() bool { return sink.LogRecordCount() >= 4 }, 5*time.Second, 10*time.Millisecond)
+	require.GreaterOrEqual(t, sessions.Load(), int32(2))
+
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	dataset, _ := rl.Resource().Attributes().Get(attrDataset)
+	require.Equal(t, "http_requests", dataset.Str())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	require.Equal(t, time.Date(2023, 3, 3, 5, 0, 5, 0, time.UTC), lr.Timestamp().AsTime())
+	sampleInterval, ok := lr.Attributes().Get(attrSampleInterval)
+	require.True(t, ok)
+	require.Equal(t, int64(10), sampleInterval.Int())
+}
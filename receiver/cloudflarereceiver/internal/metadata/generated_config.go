@@ -0,0 +1,340 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/filter"
+)
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for cloudflare metrics.
+type MetricsConfig struct {
+	CloudflareAccessLogins                       MetricConfig `mapstructure:"cloudflare.access.logins"`
+	CloudflareAiGatewayCachedRequests            MetricConfig `mapstructure:"cloudflare.ai_gateway.cached_requests"`
+	CloudflareAiGatewayCost                      MetricConfig `mapstructure:"cloudflare.ai_gateway.cost"`
+	CloudflareAiGatewayErrors                    MetricConfig `mapstructure:"cloudflare.ai_gateway.errors"`
+	CloudflareAiGatewayRequests                  MetricConfig `mapstructure:"cloudflare.ai_gateway.requests"`
+	CloudflareAiGatewayTokens                    MetricConfig `mapstructure:"cloudflare.ai_gateway.tokens"`
+	CloudflareAPIGatewayAbuseAnomalies           MetricConfig `mapstructure:"cloudflare.api_gateway.abuse_anomalies"`
+	CloudflareAPIGatewayRequests                 MetricConfig `mapstructure:"cloudflare.api_gateway.requests"`
+	CloudflareAPIGatewaySchemaValidationFailures MetricConfig `mapstructure:"cloudflare.api_gateway.schema_validation_failures"`
+	CloudflareArgoOriginResponseTime             MetricConfig `mapstructure:"cloudflare.argo.origin_response_time"`
+	CloudflareArgoRequests                       MetricConfig `mapstructure:"cloudflare.argo.requests"`
+	CloudflareBotManagementRequests              MetricConfig `mapstructure:"cloudflare.bot_management.requests"`
+	CloudflareCacheReserveOperations             MetricConfig `mapstructure:"cloudflare.cache_reserve.operations"`
+	CloudflareCacheReserveStorage                MetricConfig `mapstructure:"cloudflare.cache_reserve.storage"`
+	CloudflareDdosAttackEvents                   MetricConfig `mapstructure:"cloudflare.ddos.attack_events"`
+	CloudflareDdosMitigatedBitRate               MetricConfig `mapstructure:"cloudflare.ddos.mitigated_bit_rate"`
+	CloudflareDdosMitigatedPacketRate            MetricConfig `mapstructure:"cloudflare.ddos.mitigated_packet_rate"`
+	CloudflareDexTestAvailability                MetricConfig `mapstructure:"cloudflare.dex.test.availability"`
+	CloudflareDexTestDuration                    MetricConfig `mapstructure:"cloudflare.dex.test.duration"`
+	CloudflareEmailSecurityMessages              MetricConfig `mapstructure:"cloudflare.email_security.messages"`
+	CloudflareFirewallEvents                     MetricConfig `mapstructure:"cloudflare.firewall.events"`
+	CloudflareGatewayDNSQueries                  MetricConfig `mapstructure:"cloudflare.gateway.dns.queries"`
+	CloudflareGatewayHTTPRequests                MetricConfig `mapstructure:"cloudflare.gateway.http.requests"`
+	CloudflareGatewayNetworkIo                   MetricConfig `mapstructure:"cloudflare.gateway.network.io"`
+	CloudflareGatewayNetworkSessions             MetricConfig `mapstructure:"cloudflare.gateway.network.sessions"`
+	CloudflareHTTPBlockedRatio                   MetricConfig `mapstructure:"cloudflare.http.blocked_ratio"`
+	CloudflareHTTPCacheHitRatio                  MetricConfig `mapstructure:"cloudflare.http.cache_hit_ratio"`
+	CloudflareHTTPOriginErrorRatio               MetricConfig `mapstructure:"cloudflare.http.origin_error_ratio"`
+	CloudflareHTTPRequests                       MetricConfig `mapstructure:"cloudflare.http.requests"`
+	CloudflareHTTPUniqueVisitors                 MetricConfig `mapstructure:"cloudflare.http.unique_visitors"`
+	CloudflareHyperdriveOriginLatency            MetricConfig `mapstructure:"cloudflare.hyperdrive.origin_latency"`
+	CloudflareHyperdriveQueries                  MetricConfig `mapstructure:"cloudflare.hyperdrive.queries"`
+	CloudflareImagesRequests                     MetricConfig `mapstructure:"cloudflare.images.requests"`
+	CloudflareImagesStored                       MetricConfig `mapstructure:"cloudflare.images.stored"`
+	CloudflareImagesTransformations              MetricConfig `mapstructure:"cloudflare.images.transformations"`
+	CloudflareLogpushJobEnabled                  MetricConfig `mapstructure:"cloudflare.logpush.job.enabled"`
+	CloudflareLogpushJobErrors                   MetricConfig `mapstructure:"cloudflare.logpush.job.errors"`
+	CloudflareLogpushJobLastComplete             MetricConfig `mapstructure:"cloudflare.logpush.job.last_complete"`
+	CloudflareLogpushJobLastError                MetricConfig `mapstructure:"cloudflare.logpush.job.last_error"`
+	CloudflareLogpushRecords                     MetricConfig `mapstructure:"cloudflare.logpush.records"`
+	CloudflarePageShieldViolations               MetricConfig `mapstructure:"cloudflare.page_shield.violations"`
+	CloudflarePagesFunctionsCPUTime              MetricConfig `mapstructure:"cloudflare.pages.functions.cpu_time"`
+	CloudflarePagesFunctionsErrors               MetricConfig `mapstructure:"cloudflare.pages.functions.errors"`
+	CloudflarePagesFunctionsRequests             MetricConfig `mapstructure:"cloudflare.pages.functions.requests"`
+	CloudflareRateLimitingRequests               MetricConfig `mapstructure:"cloudflare.rate_limiting.requests"`
+	CloudflareStreamMinutesViewed                MetricConfig `mapstructure:"cloudflare.stream.minutes_viewed"`
+	CloudflareStreamStorage                      MetricConfig `mapstructure:"cloudflare.stream.storage"`
+	CloudflareStreamViewers                      MetricConfig `mapstructure:"cloudflare.stream.viewers"`
+	CloudflareTurnstileChallenges                MetricConfig `mapstructure:"cloudflare.turnstile.challenges"`
+	CloudflareWaitingRoomAcceptedUsers           MetricConfig `mapstructure:"cloudflare.waiting_room.accepted_users"`
+	CloudflareWaitingRoomActiveUsers             MetricConfig `mapstructure:"cloudflare.waiting_room.active_users"`
+	CloudflareWaitingRoomEstimatedWaitTime       MetricConfig `mapstructure:"cloudflare.waiting_room.estimated_wait_time"`
+	CloudflareWaitingRoomQueuedUsers             MetricConfig `mapstructure:"cloudflare.waiting_room.queued_users"`
+	CloudflareWarpDevices                        MetricConfig `mapstructure:"cloudflare.warp.devices"`
+	CloudflareWorkersCPUTime                     MetricConfig `mapstructure:"cloudflare.workers.cpu_time"`
+	CloudflareWorkersErrors                      MetricConfig `mapstructure:"cloudflare.workers.errors"`
+	CloudflareWorkersRequests                    MetricConfig `mapstructure:"cloudflare.workers.requests"`
+	CloudflareWorkersAiInferenceTime             MetricConfig `mapstructure:"cloudflare.workers_ai.inference_time"`
+	CloudflareWorkersAiNeurons                   MetricConfig `mapstructure:"cloudflare.workers_ai.neurons"`
+	CloudflareWorkersAiRequests                  MetricConfig `mapstructure:"cloudflare.workers_ai.requests"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		CloudflareAccessLogins: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareAiGatewayCachedRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareAiGatewayCost: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareAiGatewayErrors: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareAiGatewayRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareAiGatewayTokens: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareAPIGatewayAbuseAnomalies: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareAPIGatewayRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareAPIGatewaySchemaValidationFailures: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareArgoOriginResponseTime: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareArgoRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareBotManagementRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareCacheReserveOperations: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareCacheReserveStorage: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareDdosAttackEvents: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareDdosMitigatedBitRate: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareDdosMitigatedPacketRate: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareDexTestAvailability: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareDexTestDuration: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareEmailSecurityMessages: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareFirewallEvents: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareGatewayDNSQueries: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareGatewayHTTPRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareGatewayNetworkIo: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareGatewayNetworkSessions: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareHTTPBlockedRatio: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareHTTPCacheHitRatio: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareHTTPOriginErrorRatio: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareHTTPRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareHTTPUniqueVisitors: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareHyperdriveOriginLatency: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareHyperdriveQueries: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareImagesRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareImagesStored: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareImagesTransformations: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareLogpushJobEnabled: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareLogpushJobErrors: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareLogpushJobLastComplete: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareLogpushJobLastError: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareLogpushRecords: MetricConfig{
+			Enabled: true,
+		},
+		CloudflarePageShieldViolations: MetricConfig{
+			Enabled: true,
+		},
+		CloudflarePagesFunctionsCPUTime: MetricConfig{
+			Enabled: true,
+		},
+		CloudflarePagesFunctionsErrors: MetricConfig{
+			Enabled: true,
+		},
+		CloudflarePagesFunctionsRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareRateLimitingRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareStreamMinutesViewed: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareStreamStorage: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareStreamViewers: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareTurnstileChallenges: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWaitingRoomAcceptedUsers: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWaitingRoomActiveUsers: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWaitingRoomEstimatedWaitTime: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWaitingRoomQueuedUsers: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWarpDevices: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWorkersCPUTime: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWorkersErrors: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWorkersRequests: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWorkersAiInferenceTime: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWorkersAiNeurons: MetricConfig{
+			Enabled: true,
+		},
+		CloudflareWorkersAiRequests: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Experimental: MetricsInclude defines a list of filters for attribute values.
+	// If the list is not empty, only metrics with matching resource attribute values will be emitted.
+	MetricsInclude []filter.Config `mapstructure:"metrics_include"`
+	// Experimental: MetricsExclude defines a list of filters for attribute values.
+	// If the list is not empty, metrics with matching resource attribute values will not be emitted.
+	// MetricsInclude has higher priority than MetricsExclude.
+	MetricsExclude []filter.Config `mapstructure:"metrics_exclude"`
+
+	enabledSetByUser bool
+}
+
+func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(rac)
+	if err != nil {
+		return err
+	}
+	rac.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// ResourceAttributesConfig provides config for cloudflare resource attributes.
+type ResourceAttributesConfig struct {
+	CloudflareAccountID ResourceAttributeConfig `mapstructure:"cloudflare.account.id"`
+	CloudflareZoneID    ResourceAttributeConfig `mapstructure:"cloudflare.zone.id"`
+	CloudflareZoneName  ResourceAttributeConfig `mapstructure:"cloudflare.zone.name"`
+	CloudflareZonePlan  ResourceAttributeConfig `mapstructure:"cloudflare.zone.plan"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		CloudflareAccountID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		CloudflareZoneID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		CloudflareZoneName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		CloudflareZonePlan: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for cloudflare metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
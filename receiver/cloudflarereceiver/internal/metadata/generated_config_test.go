@@ -0,0 +1,239 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestMetricsBuilderConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		want MetricsBuilderConfig
+	}{
+		{
+			name: "default",
+			want: DefaultMetricsBuilderConfig(),
+		},
+		{
+			name: "all_set",
+			want: MetricsBuilderConfig{
+				Metrics: MetricsConfig{
+					CloudflareAccessLogins:                       MetricConfig{Enabled: true},
+					CloudflareAiGatewayCachedRequests:            MetricConfig{Enabled: true},
+					CloudflareAiGatewayCost:                      MetricConfig{Enabled: true},
+					CloudflareAiGatewayErrors:                    MetricConfig{Enabled: true},
+					CloudflareAiGatewayRequests:                  MetricConfig{Enabled: true},
+					CloudflareAiGatewayTokens:                    MetricConfig{Enabled: true},
+					CloudflareAPIGatewayAbuseAnomalies:           MetricConfig{Enabled: true},
+					CloudflareAPIGatewayRequests:                 MetricConfig{Enabled: true},
+					CloudflareAPIGatewaySchemaValidationFailures: MetricConfig{Enabled: true},
+					CloudflareArgoOriginResponseTime:             MetricConfig{Enabled: true},
+					CloudflareArgoRequests:                       MetricConfig{Enabled: true},
+					CloudflareBotManagementRequests:              MetricConfig{Enabled: true},
+					CloudflareCacheReserveOperations:             MetricConfig{Enabled: true},
+					CloudflareCacheReserveStorage:                MetricConfig{Enabled: true},
+					CloudflareDdosAttackEvents:                   MetricConfig{Enabled: true},
+					CloudflareDdosMitigatedBitRate:               MetricConfig{Enabled: true},
+					CloudflareDdosMitigatedPacketRate:            MetricConfig{Enabled: true},
+					CloudflareDexTestAvailability:                MetricConfig{Enabled: true},
+					CloudflareDexTestDuration:                    MetricConfig{Enabled: true},
+					CloudflareEmailSecurityMessages:              MetricConfig{Enabled: true},
+					CloudflareFirewallEvents:                     MetricConfig{Enabled: true},
+					CloudflareGatewayDNSQueries:                  MetricConfig{Enabled: true},
+					CloudflareGatewayHTTPRequests:                MetricConfig{Enabled: true},
+					CloudflareGatewayNetworkIo:                   MetricConfig{Enabled: true},
+					CloudflareGatewayNetworkSessions:             MetricConfig{Enabled: true},
+					CloudflareHTTPBlockedRatio:                   MetricConfig{Enabled: true},
+					CloudflareHTTPCacheHitRatio:                  MetricConfig{Enabled: true},
+					CloudflareHTTPOriginErrorRatio:               MetricConfig{Enabled: true},
+					CloudflareHTTPRequests:                       MetricConfig{Enabled: true},
+					CloudflareHTTPUniqueVisitors:                 MetricConfig{Enabled: true},
+					CloudflareHyperdriveOriginLatency:            MetricConfig{Enabled: true},
+					CloudflareHyperdriveQueries:                  MetricConfig{Enabled: true},
+					CloudflareImagesRequests:                     MetricConfig{Enabled: true},
+					CloudflareImagesStored:                       MetricConfig{Enabled: true},
+					CloudflareImagesTransformations:              MetricConfig{Enabled: true},
+					CloudflareLogpushJobEnabled:                  MetricConfig{Enabled: true},
+					CloudflareLogpushJobErrors:                   MetricConfig{Enabled: true},
+					CloudflareLogpushJobLastComplete:             MetricConfig{Enabled: true},
+					CloudflareLogpushJobLastError:                MetricConfig{Enabled: true},
+					CloudflareLogpushRecords:                     MetricConfig{Enabled: true},
+					CloudflarePageShieldViolations:               MetricConfig{Enabled: true},
+					CloudflarePagesFunctionsCPUTime:              MetricConfig{Enabled: true},
+					CloudflarePagesFunctionsErrors:               MetricConfig{Enabled: true},
+					CloudflarePagesFunctionsRequests:             MetricConfig{Enabled: true},
+					CloudflareRateLimitingRequests:               MetricConfig{Enabled: true},
+					CloudflareStreamMinutesViewed:                MetricConfig{Enabled: true},
+					CloudflareStreamStorage:                      MetricConfig{Enabled: true},
+					CloudflareStreamViewers:                      MetricConfig{Enabled: true},
+					CloudflareTurnstileChallenges:                MetricConfig{Enabled: true},
+					CloudflareWaitingRoomAcceptedUsers:           MetricConfig{Enabled: true},
+					CloudflareWaitingRoomActiveUsers:             MetricConfig{Enabled: true},
+					CloudflareWaitingRoomEstimatedWaitTime:       MetricConfig{Enabled: true},
+					CloudflareWaitingRoomQueuedUsers:             MetricConfig{Enabled: true},
+					CloudflareWarpDevices:                        MetricConfig{Enabled: true},
+					CloudflareWorkersCPUTime:                     MetricConfig{Enabled: true},
+					CloudflareWorkersErrors:                      MetricConfig{Enabled: true},
+					CloudflareWorkersRequests:                    MetricConfig{Enabled: true},
+					CloudflareWorkersAiInferenceTime:             MetricConfig{Enabled: true},
+					CloudflareWorkersAiNeurons:                   MetricConfig{Enabled: true},
+					CloudflareWorkersAiRequests:                  MetricConfig{Enabled: true},
+				},
+				ResourceAttributes: ResourceAttributesConfig{
+					CloudflareAccountID: ResourceAttributeConfig{Enabled: true},
+					CloudflareZoneID:    ResourceAttributeConfig{Enabled: true},
+					CloudflareZoneName:  ResourceAttributeConfig{Enabled: true},
+					CloudflareZonePlan:  ResourceAttributeConfig{Enabled: true},
+				},
+			},
+		},
+		{
+			name: "none_set",
+			want: MetricsBuilderConfig{
+				Metrics: MetricsConfig{
+					CloudflareAccessLogins:                       MetricConfig{Enabled: false},
+					CloudflareAiGatewayCachedRequests:            MetricConfig{Enabled: false},
+					CloudflareAiGatewayCost:                      MetricConfig{Enabled: false},
+					CloudflareAiGatewayErrors:                    MetricConfig{Enabled: false},
+					CloudflareAiGatewayRequests:                  MetricConfig{Enabled: false},
+					CloudflareAiGatewayTokens:                    MetricConfig{Enabled: false},
+					CloudflareAPIGatewayAbuseAnomalies:           MetricConfig{Enabled: false},
+					CloudflareAPIGatewayRequests:                 MetricConfig{Enabled: false},
+					CloudflareAPIGatewaySchemaValidationFailures: MetricConfig{Enabled: false},
+					CloudflareArgoOriginResponseTime:             MetricConfig{Enabled: false},
+					CloudflareArgoRequests:                       MetricConfig{Enabled: false},
+					CloudflareBotManagementRequests:              MetricConfig{Enabled: false},
+					CloudflareCacheReserveOperations:             MetricConfig{Enabled: false},
+					CloudflareCacheReserveStorage:                MetricConfig{Enabled: false},
+					CloudflareDdosAttackEvents:                   MetricConfig{Enabled: false},
+					CloudflareDdosMitigatedBitRate:               MetricConfig{Enabled: false},
+					CloudflareDdosMitigatedPacketRate:            MetricConfig{Enabled: false},
+					CloudflareDexTestAvailability:                MetricConfig{Enabled: false},
+					CloudflareDexTestDuration:                    MetricConfig{Enabled: false},
+					CloudflareEmailSecurityMessages:              MetricConfig{Enabled: false},
+					CloudflareFirewallEvents:                     MetricConfig{Enabled: false},
+					CloudflareGatewayDNSQueries:                  MetricConfig{Enabled: false},
+					CloudflareGatewayHTTPRequests:                MetricConfig{Enabled: false},
+					CloudflareGatewayNetworkIo:                   MetricConfig{Enabled: false},
+					CloudflareGatewayNetworkSessions:             MetricConfig{Enabled: false},
+					CloudflareHTTPBlockedRatio:                   MetricConfig{Enabled: false},
+					CloudflareHTTPCacheHitRatio:                  MetricConfig{Enabled: false},
+					CloudflareHTTPOriginErrorRatio:               MetricConfig{Enabled: false},
+					CloudflareHTTPRequests:                       MetricConfig{Enabled: false},
+					CloudflareHTTPUniqueVisitors:                 MetricConfig{Enabled: false},
+					CloudflareHyperdriveOriginLatency:            MetricConfig{Enabled: false},
+					CloudflareHyperdriveQueries:                  MetricConfig{Enabled: false},
+					CloudflareImagesRequests:                     MetricConfig{Enabled: false},
+					CloudflareImagesStored:                       MetricConfig{Enabled: false},
+					CloudflareImagesTransformations:              MetricConfig{Enabled: false},
+					CloudflareLogpushJobEnabled:                  MetricConfig{Enabled: false},
+					CloudflareLogpushJobErrors:                   MetricConfig{Enabled: false},
+					CloudflareLogpushJobLastComplete:             MetricConfig{Enabled: false},
+					CloudflareLogpushJobLastError:                MetricConfig{Enabled: false},
+					CloudflareLogpushRecords:                     MetricConfig{Enabled: false},
+					CloudflarePageShieldViolations:               MetricConfig{Enabled: false},
+					CloudflarePagesFunctionsCPUTime:              MetricConfig{Enabled: false},
+					CloudflarePagesFunctionsErrors:               MetricConfig{Enabled: false},
+					CloudflarePagesFunctionsRequests:             MetricConfig{Enabled: false},
+					CloudflareRateLimitingRequests:               MetricConfig{Enabled: false},
+					CloudflareStreamMinutesViewed:                MetricConfig{Enabled: false},
+					CloudflareStreamStorage:                      MetricConfig{Enabled: false},
+					CloudflareStreamViewers:                      MetricConfig{Enabled: false},
+					CloudflareTurnstileChallenges:                MetricConfig{Enabled: false},
+					CloudflareWaitingRoomAcceptedUsers:           MetricConfig{Enabled: false},
+					CloudflareWaitingRoomActiveUsers:             MetricConfig{Enabled: false},
+					CloudflareWaitingRoomEstimatedWaitTime:       MetricConfig{Enabled: false},
+					CloudflareWaitingRoomQueuedUsers:             MetricConfig{Enabled: false},
+					CloudflareWarpDevices:                        MetricConfig{Enabled: false},
+					CloudflareWorkersCPUTime:                     MetricConfig{Enabled: false},
+					CloudflareWorkersErrors:                      MetricConfig{Enabled: false},
+					CloudflareWorkersRequests:                    MetricConfig{Enabled: false},
+					CloudflareWorkersAiInferenceTime:             MetricConfig{Enabled: false},
+					CloudflareWorkersAiNeurons:                   MetricConfig{Enabled: false},
+					CloudflareWorkersAiRequests:                  MetricConfig{Enabled: false},
+				},
+				ResourceAttributes: ResourceAttributesConfig{
+					CloudflareAccountID: ResourceAttributeConfig{Enabled: false},
+					CloudflareZoneID:    ResourceAttributeConfig{Enabled: false},
+					CloudflareZoneName:  ResourceAttributeConfig{Enabled: false},
+					CloudflareZonePlan:  ResourceAttributeConfig{Enabled: false},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadMetricsBuilderConfig(t, tt.name)
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(MetricConfig{}, ResourceAttributeConfig{}))
+			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
+		})
+	}
+}
+
+func loadMetricsBuilderConfig(t *testing.T, name string) MetricsBuilderConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	cfg := DefaultMetricsBuilderConfig()
+	require.NoError(t, sub.Unmarshal(&cfg, confmap.WithIgnoreUnused()))
+	return cfg
+}
+
+func TestResourceAttributesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		want ResourceAttributesConfig
+	}{
+		{
+			name: "default",
+			want: DefaultResourceAttributesConfig(),
+		},
+		{
+			name: "all_set",
+			want: ResourceAttributesConfig{
+				CloudflareAccountID: ResourceAttributeConfig{Enabled: true},
+				CloudflareZoneID:    ResourceAttributeConfig{Enabled: true},
+				CloudflareZoneName:  ResourceAttributeConfig{Enabled: true},
+				CloudflareZonePlan:  ResourceAttributeConfig{Enabled: true},
+			},
+		},
+		{
+			name: "none_set",
+			want: ResourceAttributesConfig{
+				CloudflareAccountID: ResourceAttributeConfig{Enabled: false},
+				CloudflareZoneID:    ResourceAttributeConfig{Enabled: false},
+				CloudflareZoneName:  ResourceAttributeConfig{Enabled: false},
+				CloudflareZonePlan:  ResourceAttributeConfig{Enabled: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadResourceAttributesConfig(t, tt.name)
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(ResourceAttributeConfig{}))
+			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
+		})
+	}
+}
+
+func loadResourceAttributesConfig(t *testing.T, name string) ResourceAttributesConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	sub, err = sub.Sub("resource_attributes")
+	require.NoError(t, err)
+	cfg := DefaultResourceAttributesConfig()
+	require.NoError(t, sub.Unmarshal(&cfg))
+	return cfg
+}
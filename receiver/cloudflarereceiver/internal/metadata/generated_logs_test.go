@@ -20,7 +20,12 @@ func TestLogsBuilderAppendLogRecord(t *testing.T) {
 	settings.Logger = zap.New(observedZapCore)
 	lb := NewLogsBuilder(settings)
 
-	res := pcommon.NewResource()
+	rb := lb.NewResourceBuilder()
+	rb.SetCloudflareAccountID("cloudflare.account.id-val")
+	rb.SetCloudflareZoneID("cloudflare.zone.id-val")
+	rb.SetCloudflareZoneName("cloudflare.zone.name-val")
+	rb.SetCloudflareZonePlan("cloudflare.zone.plan-val")
+	res := rb.Emit()
 
 	// append the first log record
 	lr := plog.NewLogRecord()
@@ -0,0 +1,4356 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/filter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	conventions "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// AttributeBotScoreClass specifies the value bot_score_class attribute.
+type AttributeBotScoreClass int
+
+const (
+	_ AttributeBotScoreClass = iota
+	AttributeBotScoreClassAutomated
+	AttributeBotScoreClassLikelyAutomated
+	AttributeBotScoreClassLikelyHuman
+)
+
+// String returns the string representation of the AttributeBotScoreClass.
+func (av AttributeBotScoreClass) String() string {
+	switch av {
+	case AttributeBotScoreClassAutomated:
+		return "automated"
+	case AttributeBotScoreClassLikelyAutomated:
+		return "likely_automated"
+	case AttributeBotScoreClassLikelyHuman:
+		return "likely_human"
+	}
+	return ""
+}
+
+// MapAttributeBotScoreClass is a helper map of string to AttributeBotScoreClass attribute value.
+var MapAttributeBotScoreClass = map[string]AttributeBotScoreClass{
+	"automated":        AttributeBotScoreClassAutomated,
+	"likely_automated": AttributeBotScoreClassLikelyAutomated,
+	"likely_human":     AttributeBotScoreClassLikelyHuman,
+}
+
+// AttributeDexTestType specifies the value dex_test_type attribute.
+type AttributeDexTestType int
+
+const (
+	_ AttributeDexTestType = iota
+	AttributeDexTestTypeHttp
+	AttributeDexTestTypeTraceroute
+)
+
+// String returns the string representation of the AttributeDexTestType.
+func (av AttributeDexTestType) String() string {
+	switch av {
+	case AttributeDexTestTypeHttp:
+		return "http"
+	case AttributeDexTestTypeTraceroute:
+		return "traceroute"
+	}
+	return ""
+}
+
+// MapAttributeDexTestType is a helper map of string to AttributeDexTestType attribute value.
+var MapAttributeDexTestType = map[string]AttributeDexTestType{
+	"http":       AttributeDexTestTypeHttp,
+	"traceroute": AttributeDexTestTypeTraceroute,
+}
+
+// AttributeDirection specifies the value direction attribute.
+type AttributeDirection int
+
+const (
+	_ AttributeDirection = iota
+	AttributeDirectionReceive
+	AttributeDirectionTransmit
+)
+
+// String returns the string representation of the AttributeDirection.
+func (av AttributeDirection) String() string {
+	switch av {
+	case AttributeDirectionReceive:
+		return "receive"
+	case AttributeDirectionTransmit:
+		return "transmit"
+	}
+	return ""
+}
+
+// MapAttributeDirection is a helper map of string to AttributeDirection attribute value.
+var MapAttributeDirection = map[string]AttributeDirection{
+	"receive":  AttributeDirectionReceive,
+	"transmit": AttributeDirectionTransmit,
+}
+
+// AttributeErrorType specifies the value error_type attribute.
+type AttributeErrorType int
+
+const (
+	_ AttributeErrorType = iota
+	AttributeErrorTypeAuthentication
+	AttributeErrorTypeNotFound
+	AttributeErrorTypeRateLimited
+	AttributeErrorTypePlan
+	AttributeErrorTypeQueryLimit
+	AttributeErrorTypeOther
+)
+
+// String returns the string representation of the AttributeErrorType.
+func (av AttributeErrorType) String() string {
+	switch av {
+	case AttributeErrorTypeAuthentication:
+		return "authentication"
+	case AttributeErrorTypeNotFound:
+		return "not_found"
+	case AttributeErrorTypeRateLimited:
+		return "rate_limited"
+	case AttributeErrorTypePlan:
+		return "plan"
+	case AttributeErrorTypeQueryLimit:
+		return "query_limit"
+	case AttributeErrorTypeOther:
+		return "other"
+	}
+	return ""
+}
+
+// MapAttributeErrorType is a helper map of string to AttributeErrorType attribute value.
+var MapAttributeErrorType = map[string]AttributeErrorType{
+	"authentication": AttributeErrorTypeAuthentication,
+	"not_found":      AttributeErrorTypeNotFound,
+	"rate_limited":   AttributeErrorTypeRateLimited,
+	"plan":           AttributeErrorTypePlan,
+	"query_limit":    AttributeErrorTypeQueryLimit,
+	"other":          AttributeErrorTypeOther,
+}
+
+// AttributeQuantile specifies the value quantile attribute.
+type AttributeQuantile int
+
+const (
+	_ AttributeQuantile = iota
+	AttributeQuantileP50
+	AttributeQuantileP99
+)
+
+// String returns the string representation of the AttributeQuantile.
+func (av AttributeQuantile) String() string {
+	switch av {
+	case AttributeQuantileP50:
+		return "p50"
+	case AttributeQuantileP99:
+		return "p99"
+	}
+	return ""
+}
+
+// MapAttributeQuantile is a helper map of string to AttributeQuantile attribute value.
+var MapAttributeQuantile = map[string]AttributeQuantile{
+	"p50": AttributeQuantileP50,
+	"p99": AttributeQuantileP99,
+}
+
+// AttributeReason specifies the value reason attribute.
+type AttributeReason int
+
+const (
+	_ AttributeReason = iota
+	AttributeReasonMalformed
+	AttributeReasonMissingTimestamp
+	AttributeReasonInvalidTimestamp
+)
+
+// String returns the string representation of the AttributeReason.
+func (av AttributeReason) String() string {
+	switch av {
+	case AttributeReasonMalformed:
+		return "malformed"
+	case AttributeReasonMissingTimestamp:
+		return "missing_timestamp"
+	case AttributeReasonInvalidTimestamp:
+		return "invalid_timestamp"
+	}
+	return ""
+}
+
+// MapAttributeReason is a helper map of string to AttributeReason attribute value.
+var MapAttributeReason = map[string]AttributeReason{
+	"malformed":         AttributeReasonMalformed,
+	"missing_timestamp": AttributeReasonMissingTimestamp,
+	"invalid_timestamp": AttributeReasonInvalidTimestamp,
+}
+
+// AttributeTokenType specifies the value token_type attribute.
+type AttributeTokenType int
+
+const (
+	_ AttributeTokenType = iota
+	AttributeTokenTypeInput
+	AttributeTokenTypeOutput
+)
+
+// String returns the string representation of the AttributeTokenType.
+func (av AttributeTokenType) String() string {
+	switch av {
+	case AttributeTokenTypeInput:
+		return "input"
+	case AttributeTokenTypeOutput:
+		return "output"
+	}
+	return ""
+}
+
+// MapAttributeTokenType is a helper map of string to AttributeTokenType attribute value.
+var MapAttributeTokenType = map[string]AttributeTokenType{
+	"input":  AttributeTokenTypeInput,
+	"output": AttributeTokenTypeOutput,
+}
+
+var MetricsInfo = metricsInfo{
+	CloudflareAccessLogins: metricInfo{
+		Name: "cloudflare.access.logins",
+	},
+	CloudflareAiGatewayCachedRequests: metricInfo{
+		Name: "cloudflare.ai_gateway.cached_requests",
+	},
+	CloudflareAiGatewayCost: metricInfo{
+		Name: "cloudflare.ai_gateway.cost",
+	},
+	CloudflareAiGatewayErrors: metricInfo{
+		Name: "cloudflare.ai_gateway.errors",
+	},
+	CloudflareAiGatewayRequests: metricInfo{
+		Name: "cloudflare.ai_gateway.requests",
+	},
+	CloudflareAiGatewayTokens: metricInfo{
+		Name: "cloudflare.ai_gateway.tokens",
+	},
+	CloudflareAPIGatewayAbuseAnomalies: metricInfo{
+		Name: "cloudflare.api_gateway.abuse_anomalies",
+	},
+	CloudflareAPIGatewayRequests: metricInfo{
+		Name: "cloudflare.api_gateway.requests",
+	},
+	CloudflareAPIGatewaySchemaValidationFailures: metricInfo{
+		Name: "cloudflare.api_gateway.schema_validation_failures",
+	},
+	CloudflareArgoOriginResponseTime: metricInfo{
+		Name: "cloudflare.argo.origin_response_time",
+	},
+	CloudflareArgoRequests: metricInfo{
+		Name: "cloudflare.argo.requests",
+	},
+	CloudflareBotManagementRequests: metricInfo{
+		Name: "cloudflare.bot_management.requests",
+	},
+	CloudflareCacheReserveOperations: metricInfo{
+		Name: "cloudflare.cache_reserve.operations",
+	},
+	CloudflareCacheReserveStorage: metricInfo{
+		Name: "cloudflare.cache_reserve.storage",
+	},
+	CloudflareDdosAttackEvents: metricInfo{
+		Name: "cloudflare.ddos.attack_events",
+	},
+	CloudflareDdosMitigatedBitRate: metricInfo{
+		Name: "cloudflare.ddos.mitigated_bit_rate",
+	},
+	CloudflareDdosMitigatedPacketRate: metricInfo{
+		Name: "cloudflare.ddos.mitigated_packet_rate",
+	},
+	CloudflareDexTestAvailability: metricInfo{
+		Name: "cloudflare.dex.test.availability",
+	},
+	CloudflareDexTestDuration: metricInfo{
+		Name: "cloudflare.dex.test.duration",
+	},
+	CloudflareEmailSecurityMessages: metricInfo{
+		Name: "cloudflare.email_security.messages",
+	},
+	CloudflareFirewallEvents: metricInfo{
+		Name: "cloudflare.firewall.events",
+	},
+	CloudflareGatewayDNSQueries: metricInfo{
+		Name: "cloudflare.gateway.dns.queries",
+	},
+	CloudflareGatewayHTTPRequests: metricInfo{
+		Name: "cloudflare.gateway.http.requests",
+	},
+	CloudflareGatewayNetworkIo: metricInfo{
+		Name: "cloudflare.gateway.network.io",
+	},
+	CloudflareGatewayNetworkSessions: metricInfo{
+		Name: "cloudflare.gateway.network.sessions",
+	},
+	CloudflareHTTPBlockedRatio: metricInfo{
+		Name: "cloudflare.http.blocked_ratio",
+	},
+	CloudflareHTTPCacheHitRatio: metricInfo{
+		Name: "cloudflare.http.cache_hit_ratio",
+	},
+	CloudflareHTTPOriginErrorRatio: metricInfo{
+		Name: "cloudflare.http.origin_error_ratio",
+	},
+	CloudflareHTTPRequests: metricInfo{
+		Name: "cloudflare.http.requests",
+	},
+	CloudflareHTTPUniqueVisitors: metricInfo{
+		Name: "cloudflare.http.unique_visitors",
+	},
+	CloudflareHyperdriveOriginLatency: metricInfo{
+		Name: "cloudflare.hyperdrive.origin_latency",
+	},
+	CloudflareHyperdriveQueries: metricInfo{
+		Name: "cloudflare.hyperdrive.queries",
+	},
+	CloudflareImagesRequests: metricInfo{
+		Name: "cloudflare.images.requests",
+	},
+	CloudflareImagesStored: metricInfo{
+		Name: "cloudflare.images.stored",
+	},
+	CloudflareImagesTransformations: metricInfo{
+		Name: "cloudflare.images.transformations",
+	},
+	CloudflareLogpushJobEnabled: metricInfo{
+		Name: "cloudflare.logpush.job.enabled",
+	},
+	CloudflareLogpushJobErrors: metricInfo{
+		Name: "cloudflare.logpush.job.errors",
+	},
+	CloudflareLogpushJobLastComplete: metricInfo{
+		Name: "cloudflare.logpush.job.last_complete",
+	},
+	CloudflareLogpushJobLastError: metricInfo{
+		Name: "cloudflare.logpush.job.last_error",
+	},
+	CloudflareLogpushRecords: metricInfo{
+		Name: "cloudflare.logpush.records",
+	},
+	CloudflarePageShieldViolations: metricInfo{
+		Name: "cloudflare.page_shield.violations",
+	},
+	CloudflarePagesFunctionsCPUTime: metricInfo{
+		Name: "cloudflare.pages.functions.cpu_time",
+	},
+	CloudflarePagesFunctionsErrors: metricInfo{
+		Name: "cloudflare.pages.functions.errors",
+	},
+	CloudflarePagesFunctionsRequests: metricInfo{
+		Name: "cloudflare.pages.functions.requests",
+	},
+	CloudflareRateLimitingRequests: metricInfo{
+		Name: "cloudflare.rate_limiting.requests",
+	},
+	CloudflareStreamMinutesViewed: metricInfo{
+		Name: "cloudflare.stream.minutes_viewed",
+	},
+	CloudflareStreamStorage: metricInfo{
+		Name: "cloudflare.stream.storage",
+	},
+	CloudflareStreamViewers: metricInfo{
+		Name: "cloudflare.stream.viewers",
+	},
+	CloudflareTurnstileChallenges: metricInfo{
+		Name: "cloudflare.turnstile.challenges",
+	},
+	CloudflareWaitingRoomAcceptedUsers: metricInfo{
+		Name: "cloudflare.waiting_room.accepted_users",
+	},
+	CloudflareWaitingRoomActiveUsers: metricInfo{
+		Name: "cloudflare.waiting_room.active_users",
+	},
+	CloudflareWaitingRoomEstimatedWaitTime: metricInfo{
+		Name: "cloudflare.waiting_room.estimated_wait_time",
+	},
+	CloudflareWaitingRoomQueuedUsers: metricInfo{
+		Name: "cloudflare.waiting_room.queued_users",
+	},
+	CloudflareWarpDevices: metricInfo{
+		Name: "cloudflare.warp.devices",
+	},
+	CloudflareWorkersCPUTime: metricInfo{
+		Name: "cloudflare.workers.cpu_time",
+	},
+	CloudflareWorkersErrors: metricInfo{
+		Name: "cloudflare.workers.errors",
+	},
+	CloudflareWorkersRequests: metricInfo{
+		Name: "cloudflare.workers.requests",
+	},
+	CloudflareWorkersAiInferenceTime: metricInfo{
+		Name: "cloudflare.workers_ai.inference_time",
+	},
+	CloudflareWorkersAiNeurons: metricInfo{
+		Name: "cloudflare.workers_ai.neurons",
+	},
+	CloudflareWorkersAiRequests: metricInfo{
+		Name: "cloudflare.workers_ai.requests",
+	},
+}
+
+type metricsInfo struct {
+	CloudflareAccessLogins                       metricInfo
+	CloudflareAiGatewayCachedRequests            metricInfo
+	CloudflareAiGatewayCost                      metricInfo
+	CloudflareAiGatewayErrors                    metricInfo
+	CloudflareAiGatewayRequests                  metricInfo
+	CloudflareAiGatewayTokens                    metricInfo
+	CloudflareAPIGatewayAbuseAnomalies           metricInfo
+	CloudflareAPIGatewayRequests                 metricInfo
+	CloudflareAPIGatewaySchemaValidationFailures metricInfo
+	CloudflareArgoOriginResponseTime             metricInfo
+	CloudflareArgoRequests                       metricInfo
+	CloudflareBotManagementRequests              metricInfo
+	CloudflareCacheReserveOperations             metricInfo
+	CloudflareCacheReserveStorage                metricInfo
+	CloudflareDdosAttackEvents                   metricInfo
+	CloudflareDdosMitigatedBitRate               metricInfo
+	CloudflareDdosMitigatedPacketRate            metricInfo
+	CloudflareDexTestAvailability                metricInfo
+	CloudflareDexTestDuration                    metricInfo
+	CloudflareEmailSecurityMessages              metricInfo
+	CloudflareFirewallEvents                     metricInfo
+	CloudflareGatewayDNSQueries                  metricInfo
+	CloudflareGatewayHTTPRequests                metricInfo
+	CloudflareGatewayNetworkIo                   metricInfo
+	CloudflareGatewayNetworkSessions             metricInfo
+	CloudflareHTTPBlockedRatio                   metricInfo
+	CloudflareHTTPCacheHitRatio                  metricInfo
+	CloudflareHTTPOriginErrorRatio               metricInfo
+	CloudflareHTTPRequests                       metricInfo
+	CloudflareHTTPUniqueVisitors                 metricInfo
+	CloudflareHyperdriveOriginLatency            metricInfo
+	CloudflareHyperdriveQueries                  metricInfo
+	CloudflareImagesRequests                     metricInfo
+	CloudflareImagesStored                       metricInfo
+	CloudflareImagesTransformations              metricInfo
+	CloudflareLogpushJobEnabled                  metricInfo
+	CloudflareLogpushJobErrors                   metricInfo
+	CloudflareLogpushJobLastComplete             metricInfo
+	CloudflareLogpushJobLastError                metricInfo
+	CloudflareLogpushRecords                     metricInfo
+	CloudflarePageShieldViolations               metricInfo
+	CloudflarePagesFunctionsCPUTime              metricInfo
+	CloudflarePagesFunctionsErrors               metricInfo
+	CloudflarePagesFunctionsRequests             metricInfo
+	CloudflareRateLimitingRequests               metricInfo
+	CloudflareStreamMinutesViewed                metricInfo
+	CloudflareStreamStorage                      metricInfo
+	CloudflareStreamViewers                      metricInfo
+	CloudflareTurnstileChallenges                metricInfo
+	CloudflareWaitingRoomAcceptedUsers           metricInfo
+	CloudflareWaitingRoomActiveUsers             metricInfo
+	CloudflareWaitingRoomEstimatedWaitTime       metricInfo
+	CloudflareWaitingRoomQueuedUsers             metricInfo
+	CloudflareWarpDevices                        metricInfo
+	CloudflareWorkersCPUTime                     metricInfo
+	CloudflareWorkersErrors                      metricInfo
+	CloudflareWorkersRequests                    metricInfo
+	CloudflareWorkersAiInferenceTime             metricInfo
+	CloudflareWorkersAiNeurons                   metricInfo
+	CloudflareWorkersAiRequests                  metricInfo
+}
+
+type metricInfo struct {
+	Name string
+}
+
+type MetricAttributeOption interface {
+	apply(pmetric.NumberDataPoint)
+}
+
+type metricAttributeOptionFunc func(pmetric.NumberDataPoint)
+
+func (maof metricAttributeOptionFunc) apply(dp pmetric.NumberDataPoint) {
+	maof(dp)
+}
+
+func WithClientAsnMetricAttribute(clientAsnAttributeValue int64) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutInt("cloudflare.client.asn", clientAsnAttributeValue)
+	})
+}
+
+func WithClientAsnOrganizationMetricAttribute(clientAsnOrganizationAttributeValue string) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutStr("cloudflare.client.asn.organization", clientAsnOrganizationAttributeValue)
+	})
+}
+
+func WithJa3MetricAttribute(ja3AttributeValue string) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutStr("tls.client.ja3", ja3AttributeValue)
+	})
+}
+
+func WithJa4MetricAttribute(ja4AttributeValue string) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutStr("tls.client.ja4", ja4AttributeValue)
+	})
+}
+
+func WithPathMetricAttribute(pathAttributeValue string) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutStr("url.path", pathAttributeValue)
+	})
+}
+
+func WithWafAttackScoreClassMetricAttribute(wafAttackScoreClassAttributeValue string) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutStr("cloudflare.waf.attack_score_class", wafAttackScoreClassAttributeValue)
+	})
+}
+
+func WithWafSqliAttackScoreClassMetricAttribute(wafSqliAttackScoreClassAttributeValue string) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutStr("cloudflare.waf.sqli_attack_score_class", wafSqliAttackScoreClassAttributeValue)
+	})
+}
+
+func WithWafXSSAttackScoreClassMetricAttribute(wafXSSAttackScoreClassAttributeValue string) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutStr("cloudflare.waf.xss_attack_score_class", wafXSSAttackScoreClassAttributeValue)
+	})
+}
+
+type metricCloudflareAccessLogins struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.access.logins metric with initial data.
+func (m *metricCloudflareAccessLogins) init() {
+	m.data.SetName("cloudflare.access.logins")
+	m.data.SetDescription("The number of logins to Access applications during the polled window. Only emitted when the `access_logins` dataset of `analytics` is collected.")
+	m.data.SetUnit("{login}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareAccessLogins) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, accessAllowedAttributeValue bool, accessAppUIDAttributeValue string, accessIdentityProviderAttributeValue string, countryAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutBool("cloudflare.access.allowed", accessAllowedAttributeValue)
+	dp.Attributes().PutStr("cloudflare.access.app.uid", accessAppUIDAttributeValue)
+	dp.Attributes().PutStr("cloudflare.access.identity_provider", accessIdentityProviderAttributeValue)
+	dp.Attributes().PutStr("geo.country.iso_code", countryAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareAccessLogins) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareAccessLogins) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareAccessLogins(cfg MetricConfig) metricCloudflareAccessLogins {
+	m := metricCloudflareAccessLogins{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareAiGatewayCachedRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.ai_gateway.cached_requests metric with initial data.
+func (m *metricCloudflareAiGatewayCachedRequests) init() {
+	m.data.SetName("cloudflare.ai_gateway.cached_requests")
+	m.data.SetDescription("The number of requests answered from the cache of the AI Gateway during the polled window. Only emitted when the `ai_gateway` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareAiGatewayCachedRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, aiGatewayIDAttributeValue string, aiProviderAttributeValue string, aiModelAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.ai_gateway.id", aiGatewayIDAttributeValue)
+	dp.Attributes().PutStr("gen_ai.provider.name", aiProviderAttributeValue)
+	dp.Attributes().PutStr("gen_ai.request.model", aiModelAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareAiGatewayCachedRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareAiGatewayCachedRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareAiGatewayCachedRequests(cfg MetricConfig) metricCloudflareAiGatewayCachedRequests {
+	m := metricCloudflareAiGatewayCachedRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareAiGatewayCost struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.ai_gateway.cost metric with initial data.
+func (m *metricCloudflareAiGatewayCost) init() {
+	m.data.SetName("cloudflare.ai_gateway.cost")
+	m.data.SetDescription("The estimated cost of the requests proxied by the AI Gateway during the polled window. Only emitted when the `ai_gateway` dataset of `analytics` is collected.")
+	m.data.SetUnit("{USD}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareAiGatewayCost) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, aiGatewayIDAttributeValue string, aiProviderAttributeValue string, aiModelAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("cloudflare.ai_gateway.id", aiGatewayIDAttributeValue)
+	dp.Attributes().PutStr("gen_ai.provider.name", aiProviderAttributeValue)
+	dp.Attributes().PutStr("gen_ai.request.model", aiModelAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareAiGatewayCost) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareAiGatewayCost) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareAiGatewayCost(cfg MetricConfig) metricCloudflareAiGatewayCost {
+	m := metricCloudflareAiGatewayCost{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareAiGatewayErrors struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.ai_gateway.errors metric with initial data.
+func (m *metricCloudflareAiGatewayErrors) init() {
+	m.data.SetName("cloudflare.ai_gateway.errors")
+	m.data.SetDescription("The number of requests proxied by the AI Gateway that failed during the polled window. Only emitted when the `ai_gateway` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareAiGatewayErrors) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, aiGatewayIDAttributeValue string, aiProviderAttributeValue string, aiModelAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.ai_gateway.id", aiGatewayIDAttributeValue)
+	dp.Attributes().PutStr("gen_ai.provider.name", aiProviderAttributeValue)
+	dp.Attributes().PutStr("gen_ai.request.model", aiModelAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareAiGatewayErrors) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareAiGatewayErrors) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareAiGatewayErrors(cfg MetricConfig) metricCloudflareAiGatewayErrors {
+	m := metricCloudflareAiGatewayErrors{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareAiGatewayRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.ai_gateway.requests metric with initial data.
+func (m *metricCloudflareAiGatewayRequests) init() {
+	m.data.SetName("cloudflare.ai_gateway.requests")
+	m.data.SetDescription("The number of requests proxied by the AI Gateway during the polled window. Only emitted when the `ai_gateway` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareAiGatewayRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, aiGatewayIDAttributeValue string, aiProviderAttributeValue string, aiModelAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.ai_gateway.id", aiGatewayIDAttributeValue)
+	dp.Attributes().PutStr("gen_ai.provider.name", aiProviderAttributeValue)
+	dp.Attributes().PutStr("gen_ai.request.model", aiModelAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareAiGatewayRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareAiGatewayRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareAiGatewayRequests(cfg MetricConfig) metricCloudflareAiGatewayRequests {
+	m := metricCloudflareAiGatewayRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareAiGatewayTokens struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.ai_gateway.tokens metric with initial data.
+func (m *metricCloudflareAiGatewayTokens) init() {
+	m.data.SetName("cloudflare.ai_gateway.tokens")
+	m.data.SetDescription("The number of tokens sent to and generated by the models during the polled window. Only emitted when the `ai_gateway` dataset of `analytics` is collected.")
+	m.data.SetUnit("{token}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareAiGatewayTokens) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, aiGatewayIDAttributeValue string, aiProviderAttributeValue string, aiModelAttributeValue string, tokenTypeAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.ai_gateway.id", aiGatewayIDAttributeValue)
+	dp.Attributes().PutStr("gen_ai.provider.name", aiProviderAttributeValue)
+	dp.Attributes().PutStr("gen_ai.request.model", aiModelAttributeValue)
+	dp.Attributes().PutStr("gen_ai.token.type", tokenTypeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareAiGatewayTokens) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareAiGatewayTokens) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareAiGatewayTokens(cfg MetricConfig) metricCloudflareAiGatewayTokens {
+	m := metricCloudflareAiGatewayTokens{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareAPIGatewayAbuseAnomalies struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.api_gateway.abuse_anomalies metric with initial data.
+func (m *metricCloudflareAPIGatewayAbuseAnomalies) init() {
+	m.data.SetName("cloudflare.api_gateway.abuse_anomalies")
+	m.data.SetDescription("The number of requests flagged as abusive by the sequence and volumetric abuse detections of API Gateway during the polled window. Only emitted when the `api_gateway` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareAPIGatewayAbuseAnomalies) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, hostAttributeValue string, apiAbuseSourceAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("server.address", hostAttributeValue)
+	dp.Attributes().PutStr("cloudflare.api_gateway.abuse_source", apiAbuseSourceAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareAPIGatewayAbuseAnomalies) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareAPIGatewayAbuseAnomalies) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareAPIGatewayAbuseAnomalies(cfg MetricConfig) metricCloudflareAPIGatewayAbuseAnomalies {
+	m := metricCloudflareAPIGatewayAbuseAnomalies{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareAPIGatewayRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.api_gateway.requests metric with initial data.
+func (m *metricCloudflareAPIGatewayRequests) init() {
+	m.data.SetName("cloudflare.api_gateway.requests")
+	m.data.SetDescription("The number of requests that matched an endpoint of API Gateway during the polled window. Only emitted when the `api_gateway` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareAPIGatewayRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, hostAttributeValue string, apiEndpointAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("server.address", hostAttributeValue)
+	dp.Attributes().PutStr("cloudflare.api_gateway.endpoint", apiEndpointAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareAPIGatewayRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareAPIGatewayRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareAPIGatewayRequests(cfg MetricConfig) metricCloudflareAPIGatewayRequests {
+	m := metricCloudflareAPIGatewayRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareAPIGatewaySchemaValidationFailures struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.api_gateway.schema_validation_failures metric with initial data.
+func (m *metricCloudflareAPIGatewaySchemaValidationFailures) init() {
+	m.data.SetName("cloudflare.api_gateway.schema_validation_failures")
+	m.data.SetDescription("The number of requests that failed the schema validation of API Gateway during the polled window. Only emitted when the `api_gateway` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareAPIGatewaySchemaValidationFailures) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, hostAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("server.address", hostAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareAPIGatewaySchemaValidationFailures) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareAPIGatewaySchemaValidationFailures) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareAPIGatewaySchemaValidationFailures(cfg MetricConfig) metricCloudflareAPIGatewaySchemaValidationFailures {
+	m := metricCloudflareAPIGatewaySchemaValidationFailures{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareArgoOriginResponseTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.argo.origin_response_time metric with initial data.
+func (m *metricCloudflareArgoOriginResponseTime) init() {
+	m.data.SetName("cloudflare.argo.origin_response_time")
+	m.data.SetDescription("The average time the origin took to respond during the polled window, by whether Argo Smart Routing routed the requests, comparing both quantifying the improvement of Argo. Only emitted when the `argo` dataset of `analytics` is collected.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareArgoOriginResponseTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, argoRoutedAttributeValue bool) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutBool("cloudflare.argo.routed", argoRoutedAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareArgoOriginResponseTime) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareArgoOriginResponseTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareArgoOriginResponseTime(cfg MetricConfig) metricCloudflareArgoOriginResponseTime {
+	m := metricCloudflareArgoOriginResponseTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareArgoRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.argo.requests metric with initial data.
+func (m *metricCloudflareArgoRequests) init() {
+	m.data.SetName("cloudflare.argo.requests")
+	m.data.SetDescription("The number of requests sent to the origin during the polled window, by whether Argo Smart Routing routed them. Only emitted when the `argo` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareArgoRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, argoRoutedAttributeValue bool) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutBool("cloudflare.argo.routed", argoRoutedAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareArgoRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareArgoRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareArgoRequests(cfg MetricConfig) metricCloudflareArgoRequests {
+	m := metricCloudflareArgoRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareBotManagementRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.bot_management.requests metric with initial data.
+func (m *metricCloudflareBotManagementRequests) init() {
+	m.data.SetName("cloudflare.bot_management.requests")
+	m.data.SetDescription("The number of requests scored by Bot Management during the polled window, by class of bot score. Only emitted when the `bot_management` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareBotManagementRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, botScoreClassAttributeValue string, botScoreSourceAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.bot_management.score_class", botScoreClassAttributeValue)
+	dp.Attributes().PutStr("cloudflare.bot_management.score_source", botScoreSourceAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareBotManagementRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareBotManagementRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareBotManagementRequests(cfg MetricConfig) metricCloudflareBotManagementRequests {
+	m := metricCloudflareBotManagementRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareCacheReserveOperations struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.cache_reserve.operations metric with initial data.
+func (m *metricCloudflareCacheReserveOperations) init() {
+	m.data.SetName("cloudflare.cache_reserve.operations")
+	m.data.SetDescription("The number of Cache Reserve operations during the polled window, by billing class. Only emitted when the `cache_reserve` dataset of `analytics` is collected.")
+	m.data.SetUnit("{operation}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareCacheReserveOperations) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, operationClassAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.cache_reserve.operation_class", operationClassAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareCacheReserveOperations) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareCacheReserveOperations) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareCacheReserveOperations(cfg MetricConfig) metricCloudflareCacheReserveOperations {
+	m := metricCloudflareCacheReserveOperations{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareCacheReserveStorage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.cache_reserve.storage metric with initial data.
+func (m *metricCloudflareCacheReserveStorage) init() {
+	m.data.SetName("cloudflare.cache_reserve.storage")
+	m.data.SetDescription("The peak number of bytes stored in Cache Reserve during the polled window. Only emitted when the `cache_reserve` dataset of `analytics` is collected.")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricCloudflareCacheReserveStorage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareCacheReserveStorage) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareCacheReserveStorage) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareCacheReserveStorage(cfg MetricConfig) metricCloudflareCacheReserveStorage {
+	m := metricCloudflareCacheReserveStorage{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareDdosAttackEvents struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.ddos.attack_events metric with initial data.
+func (m *metricCloudflareDdosAttackEvents) init() {
+	m.data.SetName("cloudflare.ddos.attack_events")
+	m.data.SetDescription("The number of DDoS attack events mitigated during the polled window. Only emitted when the `ddos` dataset of `analytics` is collected.")
+	m.data.SetUnit("{event}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareDdosAttackEvents) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, attackVectorAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.ddos.attack_vector", attackVectorAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareDdosAttackEvents) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareDdosAttackEvents) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareDdosAttackEvents(cfg MetricConfig) metricCloudflareDdosAttackEvents {
+	m := metricCloudflareDdosAttackEvents{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareDdosMitigatedBitRate struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.ddos.mitigated_bit_rate metric with initial data.
+func (m *metricCloudflareDdosMitigatedBitRate) init() {
+	m.data.SetName("cloudflare.ddos.mitigated_bit_rate")
+	m.data.SetDescription("The peak rate of the DDoS attack traffic mitigated during the polled window. Only emitted when the `ddos` dataset of `analytics` is collected.")
+	m.data.SetUnit("bit/s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareDdosMitigatedBitRate) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, attackVectorAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.ddos.attack_vector", attackVectorAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareDdosMitigatedBitRate) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareDdosMitigatedBitRate) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareDdosMitigatedBitRate(cfg MetricConfig) metricCloudflareDdosMitigatedBitRate {
+	m := metricCloudflareDdosMitigatedBitRate{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareDdosMitigatedPacketRate struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.ddos.mitigated_packet_rate metric with initial data.
+func (m *metricCloudflareDdosMitigatedPacketRate) init() {
+	m.data.SetName("cloudflare.ddos.mitigated_packet_rate")
+	m.data.SetDescription("The peak rate of the DDoS attack packets mitigated during the polled window. Only emitted when the `ddos` dataset of `analytics` is collected.")
+	m.data.SetUnit("{packet}/s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareDdosMitigatedPacketRate) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, attackVectorAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.ddos.attack_vector", attackVectorAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareDdosMitigatedPacketRate) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareDdosMitigatedPacketRate) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareDdosMitigatedPacketRate(cfg MetricConfig) metricCloudflareDdosMitigatedPacketRate {
+	m := metricCloudflareDdosMitigatedPacketRate{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareDexTestAvailability struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.dex.test.availability metric with initial data.
+func (m *metricCloudflareDexTestAvailability) init() {
+	m.data.SetName("cloudflare.dex.test.availability")
+	m.data.SetDescription("The share of the runs of the Digital Experience Monitoring test that succeeded during the polled window. Only emitted when the `dex` dataset of `analytics` is collected.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareDexTestAvailability) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, dexTestNameAttributeValue string, dexTestTypeAttributeValue string, coloAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("cloudflare.dex.test.name", dexTestNameAttributeValue)
+	dp.Attributes().PutStr("cloudflare.dex.test.type", dexTestTypeAttributeValue)
+	dp.Attributes().PutStr("cloudflare.colo.code", coloAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareDexTestAvailability) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareDexTestAvailability) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareDexTestAvailability(cfg MetricConfig) metricCloudflareDexTestAvailability {
+	m := metricCloudflareDexTestAvailability{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareDexTestDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.dex.test.duration metric with initial data.
+func (m *metricCloudflareDexTestDuration) init() {
+	m.data.SetName("cloudflare.dex.test.duration")
+	m.data.SetDescription("The average time the Digital Experience Monitoring test took to fetch the resource, or the average round-trip time of the traceroute tests, during the polled window. Only emitted when the `dex` dataset of `analytics` is collected.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareDexTestDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, dexTestNameAttributeValue string, dexTestTypeAttributeValue string, coloAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("cloudflare.dex.test.name", dexTestNameAttributeValue)
+	dp.Attributes().PutStr("cloudflare.dex.test.type", dexTestTypeAttributeValue)
+	dp.Attributes().PutStr("cloudflare.colo.code", coloAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareDexTestDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareDexTestDuration) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareDexTestDuration(cfg MetricConfig) metricCloudflareDexTestDuration {
+	m := metricCloudflareDexTestDuration{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareEmailSecurityMessages struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.email_security.messages metric with initial data.
+func (m *metricCloudflareEmailSecurityMessages) init() {
+	m.data.SetName("cloudflare.email_security.messages")
+	m.data.SetDescription("The number of messages processed by Email Security during the polled window, by disposition and action. Only emitted when the `email_security` dataset of `analytics` is collected.")
+	m.data.SetUnit("{message}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareEmailSecurityMessages) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, emailDispositionAttributeValue string, emailActionAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.email_security.disposition", emailDispositionAttributeValue)
+	dp.Attributes().PutStr("cloudflare.email_security.action", emailActionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareEmailSecurityMessages) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareEmailSecurityMessages) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareEmailSecurityMessages(cfg MetricConfig) metricCloudflareEmailSecurityMessages {
+	m := metricCloudflareEmailSecurityMessages{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareFirewallEvents struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.firewall.events metric with initial data.
+func (m *metricCloudflareFirewallEvents) init() {
+	m.data.SetName("cloudflare.firewall.events")
+	m.data.SetDescription("The number of firewall events of the zone during the polled window, by action, security product and host. Only emitted when the `firewall_events` dataset of `analytics` is collected.")
+	m.data.SetUnit("{event}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareFirewallEvents) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, actionAttributeValue string, firewallSourceAttributeValue string, hostAttributeValue string, options ...MetricAttributeOption) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.action", actionAttributeValue)
+	dp.Attributes().PutStr("cloudflare.firewall.source", firewallSourceAttributeValue)
+	dp.Attributes().PutStr("server.address", hostAttributeValue)
+	for _, op := range options {
+		op.apply(dp)
+	}
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareFirewallEvents) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareFirewallEvents) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareFirewallEvents(cfg MetricConfig) metricCloudflareFirewallEvents {
+	m := metricCloudflareFirewallEvents{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareGatewayDNSQueries struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.gateway.dns.queries metric with initial data.
+func (m *metricCloudflareGatewayDNSQueries) init() {
+	m.data.SetName("cloudflare.gateway.dns.queries")
+	m.data.SetDescription("The number of DNS queries resolved by Gateway during the polled window. Only emitted when the `gateway_dns` dataset of `analytics` is collected.")
+	m.data.SetUnit("{query}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareGatewayDNSQueries) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, gatewayDecisionAttributeValue string, gatewayCategoriesAttributeValue string, gatewayLocationAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.gateway.decision", gatewayDecisionAttributeValue)
+	dp.Attributes().PutStr("cloudflare.gateway.categories", gatewayCategoriesAttributeValue)
+	dp.Attributes().PutStr("cloudflare.gateway.location", gatewayLocationAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareGatewayDNSQueries) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareGatewayDNSQueries) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareGatewayDNSQueries(cfg MetricConfig) metricCloudflareGatewayDNSQueries {
+	m := metricCloudflareGatewayDNSQueries{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareGatewayHTTPRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.gateway.http.requests metric with initial data.
+func (m *metricCloudflareGatewayHTTPRequests) init() {
+	m.data.SetName("cloudflare.gateway.http.requests")
+	m.data.SetDescription("The number of HTTP requests filtered by Gateway during the polled window. Only emitted when the `gateway_http` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareGatewayHTTPRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, actionAttributeValue string, gatewayCategoriesAttributeValue string, userEmailAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.action", actionAttributeValue)
+	dp.Attributes().PutStr("cloudflare.gateway.categories", gatewayCategoriesAttributeValue)
+	dp.Attributes().PutStr("user.email", userEmailAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareGatewayHTTPRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareGatewayHTTPRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareGatewayHTTPRequests(cfg MetricConfig) metricCloudflareGatewayHTTPRequests {
+	m := metricCloudflareGatewayHTTPRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareGatewayNetworkIo struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.gateway.network.io metric with initial data.
+func (m *metricCloudflareGatewayNetworkIo) init() {
+	m.data.SetName("cloudflare.gateway.network.io")
+	m.data.SetDescription("The number of bytes of the network sessions filtered by Gateway during the polled window. Only emitted when the `gateway_network` dataset of `analytics` is collected.")
+	m.data.SetUnit("By")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareGatewayNetworkIo) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, actionAttributeValue string, networkTransportAttributeValue string, directionAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.action", actionAttributeValue)
+	dp.Attributes().PutStr("network.transport", networkTransportAttributeValue)
+	dp.Attributes().PutStr("network.io.direction", directionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareGatewayNetworkIo) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareGatewayNetworkIo) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareGatewayNetworkIo(cfg MetricConfig) metricCloudflareGatewayNetworkIo {
+	m := metricCloudflareGatewayNetworkIo{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareGatewayNetworkSessions struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.gateway.network.sessions metric with initial data.
+func (m *metricCloudflareGatewayNetworkSessions) init() {
+	m.data.SetName("cloudflare.gateway.network.sessions")
+	m.data.SetDescription("The number of network sessions filtered by Gateway during the polled window. Only emitted when the `gateway_network` dataset of `analytics` is collected.")
+	m.data.SetUnit("{session}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareGatewayNetworkSessions) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, actionAttributeValue string, networkTransportAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.action", actionAttributeValue)
+	dp.Attributes().PutStr("network.transport", networkTransportAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareGatewayNetworkSessions) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareGatewayNetworkSessions) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareGatewayNetworkSessions(cfg MetricConfig) metricCloudflareGatewayNetworkSessions {
+	m := metricCloudflareGatewayNetworkSessions{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareHTTPBlockedRatio struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.http.blocked_ratio metric with initial data.
+func (m *metricCloudflareHTTPBlockedRatio) init() {
+	m.data.SetName("cloudflare.http.blocked_ratio")
+	m.data.SetDescription("The share of the requests of the zone whose security action is block during the polled window. Only emitted when `analytics.derive_ratios` is enabled.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricCloudflareHTTPBlockedRatio) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareHTTPBlockedRatio) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareHTTPBlockedRatio) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareHTTPBlockedRatio(cfg MetricConfig) metricCloudflareHTTPBlockedRatio {
+	m := metricCloudflareHTTPBlockedRatio{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareHTTPCacheHitRatio struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.http.cache_hit_ratio metric with initial data.
+func (m *metricCloudflareHTTPCacheHitRatio) init() {
+	m.data.SetName("cloudflare.http.cache_hit_ratio")
+	m.data.SetDescription("The share of the requests of the zone with a cache status that were served from the Cloudflare cache during the polled window. Only emitted when `analytics.derive_ratios` is enabled.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricCloudflareHTTPCacheHitRatio) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareHTTPCacheHitRatio) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareHTTPCacheHitRatio) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareHTTPCacheHitRatio(cfg MetricConfig) metricCloudflareHTTPCacheHitRatio {
+	m := metricCloudflareHTTPCacheHitRatio{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareHTTPOriginErrorRatio struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.http.origin_error_ratio metric with initial data.
+func (m *metricCloudflareHTTPOriginErrorRatio) init() {
+	m.data.SetName("cloudflare.http.origin_error_ratio")
+	m.data.SetDescription("The share of the requests of the zone sent to the origin that the origin answered with a 5xx status during the polled window. Only emitted when `analytics.derive_ratios` is enabled.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricCloudflareHTTPOriginErrorRatio) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareHTTPOriginErrorRatio) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareHTTPOriginErrorRatio) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareHTTPOriginErrorRatio(cfg MetricConfig) metricCloudflareHTTPOriginErrorRatio {
+	m := metricCloudflareHTTPOriginErrorRatio{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareHTTPRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.http.requests metric with initial data.
+func (m *metricCloudflareHTTPRequests) init() {
+	m.data.SetName("cloudflare.http.requests")
+	m.data.SetDescription("The number of requests of the zone during the polled window, by class of the status code returned by the edge, security action and host. Only emitted when the `http_requests` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareHTTPRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, statusClassAttributeValue string, actionAttributeValue string, hostAttributeValue string, options ...MetricAttributeOption) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.edge.response.status_class", statusClassAttributeValue)
+	dp.Attributes().PutStr("cloudflare.action", actionAttributeValue)
+	dp.Attributes().PutStr("server.address", hostAttributeValue)
+	for _, op := range options {
+		op.apply(dp)
+	}
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareHTTPRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareHTTPRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareHTTPRequests(cfg MetricConfig) metricCloudflareHTTPRequests {
+	m := metricCloudflareHTTPRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareHTTPUniqueVisitors struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.http.unique_visitors metric with initial data.
+func (m *metricCloudflareHTTPUniqueVisitors) init() {
+	m.data.SetName("cloudflare.http.unique_visitors")
+	m.data.SetDescription("The number of distinct client IP addresses of the requests of the zone during the polled window, like the unique visitors of the Cloudflare dashboard. Only emitted when the `http_requests` dataset of `analytics` is collected.")
+	m.data.SetUnit("{visitor}")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricCloudflareHTTPUniqueVisitors) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareHTTPUniqueVisitors) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareHTTPUniqueVisitors) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareHTTPUniqueVisitors(cfg MetricConfig) metricCloudflareHTTPUniqueVisitors {
+	m := metricCloudflareHTTPUniqueVisitors{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareHyperdriveOriginLatency struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.hyperdrive.origin_latency metric with initial data.
+func (m *metricCloudflareHyperdriveOriginLatency) init() {
+	m.data.SetName("cloudflare.hyperdrive.origin_latency")
+	m.data.SetDescription("The quantiles of the time the origin database took to answer the queries of the Hyperdrive configuration during the polled window. Only emitted when the `hyperdrive` dataset of `analytics` is collected.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareHyperdriveOriginLatency) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, hyperdriveConfigIDAttributeValue string, quantileAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("cloudflare.hyperdrive.config.id", hyperdriveConfigIDAttributeValue)
+	dp.Attributes().PutStr("cloudflare.quantile", quantileAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareHyperdriveOriginLatency) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareHyperdriveOriginLatency) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareHyperdriveOriginLatency(cfg MetricConfig) metricCloudflareHyperdriveOriginLatency {
+	m := metricCloudflareHyperdriveOriginLatency{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareHyperdriveQueries struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.hyperdrive.queries metric with initial data.
+func (m *metricCloudflareHyperdriveQueries) init() {
+	m.data.SetName("cloudflare.hyperdrive.queries")
+	m.data.SetDescription("The number of queries sent through the Hyperdrive configuration during the polled window, by cache status. Only emitted when the `hyperdrive` dataset of `analytics` is collected.")
+	m.data.SetUnit("{query}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareHyperdriveQueries) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, hyperdriveConfigIDAttributeValue string, cacheStatusAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.hyperdrive.config.id", hyperdriveConfigIDAttributeValue)
+	dp.Attributes().PutStr("cloudflare.cache_status", cacheStatusAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareHyperdriveQueries) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareHyperdriveQueries) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareHyperdriveQueries(cfg MetricConfig) metricCloudflareHyperdriveQueries {
+	m := metricCloudflareHyperdriveQueries{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareImagesRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.images.requests metric with initial data.
+func (m *metricCloudflareImagesRequests) init() {
+	m.data.SetName("cloudflare.images.requests")
+	m.data.SetDescription("The number of requests for images served by Cloudflare Images during the polled window. Only emitted when the `images` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+}
+
+func (m *metricCloudflareImagesRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareImagesRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareImagesRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareImagesRequests(cfg MetricConfig) metricCloudflareImagesRequests {
+	m := metricCloudflareImagesRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareImagesStored struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.images.stored metric with initial data.
+func (m *metricCloudflareImagesStored) init() {
+	m.data.SetName("cloudflare.images.stored")
+	m.data.SetDescription("The peak number of images stored in Cloudflare Images during the polled window. Only emitted when the `images` dataset of `analytics` is collected.")
+	m.data.SetUnit("{image}")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricCloudflareImagesStored) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareImagesStored) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareImagesStored) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareImagesStored(cfg MetricConfig) metricCloudflareImagesStored {
+	m := metricCloudflareImagesStored{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareImagesTransformations struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.images.transformations metric with initial data.
+func (m *metricCloudflareImagesTransformations) init() {
+	m.data.SetName("cloudflare.images.transformations")
+	m.data.SetDescription("The number of unique transformations of images by Cloudflare Images during the polled window. Only emitted when the `images` dataset of `analytics` is collected.")
+	m.data.SetUnit("{transformation}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+}
+
+func (m *metricCloudflareImagesTransformations) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareImagesTransformations) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareImagesTransformations) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareImagesTransformations(cfg MetricConfig) metricCloudflareImagesTransformations {
+	m := metricCloudflareImagesTransformations{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareLogpushJobEnabled struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.logpush.job.enabled metric with initial data.
+func (m *metricCloudflareLogpushJobEnabled) init() {
+	m.data.SetName("cloudflare.logpush.job.enabled")
+	m.data.SetDescription("Whether the Logpush job is enabled (1) or disabled (0).")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareLogpushJobEnabled) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, jobIDAttributeValue int64, jobNameAttributeValue string, datasetAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutInt("cloudflare.logpush.job.id", jobIDAttributeValue)
+	dp.Attributes().PutStr("cloudflare.logpush.job.name", jobNameAttributeValue)
+	dp.Attributes().PutStr("cloudflare.logpush.dataset", datasetAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareLogpushJobEnabled) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareLogpushJobEnabled) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareLogpushJobEnabled(cfg MetricConfig) metricCloudflareLogpushJobEnabled {
+	m := metricCloudflareLogpushJobEnabled{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareLogpushJobErrors struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.logpush.job.errors metric with initial data.
+func (m *metricCloudflareLogpushJobErrors) init() {
+	m.data.SetName("cloudflare.logpush.job.errors")
+	m.data.SetDescription("The number of new Logpush job failures observed since the receiver started.")
+	m.data.SetUnit("{error}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareLogpushJobErrors) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, jobIDAttributeValue int64, jobNameAttributeValue string, datasetAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutInt("cloudflare.logpush.job.id", jobIDAttributeValue)
+	dp.Attributes().PutStr("cloudflare.logpush.job.name", jobNameAttributeValue)
+	dp.Attributes().PutStr("cloudflare.logpush.dataset", datasetAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareLogpushJobErrors) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareLogpushJobErrors) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareLogpushJobErrors(cfg MetricConfig) metricCloudflareLogpushJobErrors {
+	m := metricCloudflareLogpushJobErrors{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareLogpushJobLastComplete struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.logpush.job.last_complete metric with initial data.
+func (m *metricCloudflareLogpushJobLastComplete) init() {
+	m.data.SetName("cloudflare.logpush.job.last_complete")
+	m.data.SetDescription("Unix timestamp of the last time the Logpush job successfully pushed logs.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareLogpushJobLastComplete) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, jobIDAttributeValue int64, jobNameAttributeValue string, datasetAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutInt("cloudflare.logpush.job.id", jobIDAttributeValue)
+	dp.Attributes().PutStr("cloudflare.logpush.job.name", jobNameAttributeValue)
+	dp.Attributes().PutStr("cloudflare.logpush.dataset", datasetAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareLogpushJobLastComplete) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareLogpushJobLastComplete) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareLogpushJobLastComplete(cfg MetricConfig) metricCloudflareLogpushJobLastComplete {
+	m := metricCloudflareLogpushJobLastComplete{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareLogpushJobLastError struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.logpush.job.last_error metric with initial data.
+func (m *metricCloudflareLogpushJobLastError) init() {
+	m.data.SetName("cloudflare.logpush.job.last_error")
+	m.data.SetDescription("Unix timestamp of the last time the Logpush job failed to push logs.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareLogpushJobLastError) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, jobIDAttributeValue int64, jobNameAttributeValue string, datasetAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutInt("cloudflare.logpush.job.id", jobIDAttributeValue)
+	dp.Attributes().PutStr("cloudflare.logpush.job.name", jobNameAttributeValue)
+	dp.Attributes().PutStr("cloudflare.logpush.dataset", datasetAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareLogpushJobLastError) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareLogpushJobLastError) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareLogpushJobLastError(cfg MetricConfig) metricCloudflareLogpushJobLastError {
+	m := metricCloudflareLogpushJobLastError{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareLogpushRecords struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.logpush.records metric with initial data.
+func (m *metricCloudflareLogpushRecords) init() {
+	m.data.SetName("cloudflare.logpush.records")
+	m.data.SetDescription("The number of records received by the Logpush endpoint since the receiver started. Only emitted when `logs.derive_metrics` is enabled.")
+	m.data.SetUnit("{record}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareLogpushRecords) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, datasetAttributeValue string, statusClassAttributeValue string, actionAttributeValue string, hostAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.logpush.dataset", datasetAttributeValue)
+	dp.Attributes().PutStr("cloudflare.edge.response.status_class", statusClassAttributeValue)
+	dp.Attributes().PutStr("cloudflare.action", actionAttributeValue)
+	dp.Attributes().PutStr("server.address", hostAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareLogpushRecords) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareLogpushRecords) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareLogpushRecords(cfg MetricConfig) metricCloudflareLogpushRecords {
+	m := metricCloudflareLogpushRecords{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflarePageShieldViolations struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.page_shield.violations metric with initial data.
+func (m *metricCloudflarePageShieldViolations) init() {
+	m.data.SetName("cloudflare.page_shield.violations")
+	m.data.SetDescription("The number of violations of the Page Shield policies reported by browsers during the polled window. Only emitted when the `page_shield` dataset of `analytics` is collected.")
+	m.data.SetUnit("{violation}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflarePageShieldViolations) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, hostAttributeValue string, directiveAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("server.address", hostAttributeValue)
+	dp.Attributes().PutStr("cloudflare.page_shield.directive", directiveAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflarePageShieldViolations) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflarePageShieldViolations) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflarePageShieldViolations(cfg MetricConfig) metricCloudflarePageShieldViolations {
+	m := metricCloudflarePageShieldViolations{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflarePagesFunctionsCPUTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.pages.functions.cpu_time metric with initial data.
+func (m *metricCloudflarePagesFunctionsCPUTime) init() {
+	m.data.SetName("cloudflare.pages.functions.cpu_time")
+	m.data.SetDescription("The quantiles of the CPU time of the invocations of the Functions of the Pages project during the polled window. Only emitted when the `pages_functions` dataset of `analytics` is collected.")
+	m.data.SetUnit("us")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflarePagesFunctionsCPUTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, pagesProjectAttributeValue string, quantileAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("cloudflare.pages.project.name", pagesProjectAttributeValue)
+	dp.Attributes().PutStr("cloudflare.quantile", quantileAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflarePagesFunctionsCPUTime) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflarePagesFunctionsCPUTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflarePagesFunctionsCPUTime(cfg MetricConfig) metricCloudflarePagesFunctionsCPUTime {
+	m := metricCloudflarePagesFunctionsCPUTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflarePagesFunctionsErrors struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.pages.functions.errors metric with initial data.
+func (m *metricCloudflarePagesFunctionsErrors) init() {
+	m.data.SetName("cloudflare.pages.functions.errors")
+	m.data.SetDescription("The number of invocations of the Functions of the Pages project that failed during the polled window. Only emitted when the `pages_functions` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflarePagesFunctionsErrors) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, pagesProjectAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.pages.project.name", pagesProjectAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflarePagesFunctionsErrors) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflarePagesFunctionsErrors) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflarePagesFunctionsErrors(cfg MetricConfig) metricCloudflarePagesFunctionsErrors {
+	m := metricCloudflarePagesFunctionsErrors{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflarePagesFunctionsRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.pages.functions.requests metric with initial data.
+func (m *metricCloudflarePagesFunctionsRequests) init() {
+	m.data.SetName("cloudflare.pages.functions.requests")
+	m.data.SetDescription("The number of invocations of the Functions of the Pages project during the polled window. Only emitted when the `pages_functions` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflarePagesFunctionsRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, pagesProjectAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.pages.project.name", pagesProjectAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflarePagesFunctionsRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflarePagesFunctionsRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflarePagesFunctionsRequests(cfg MetricConfig) metricCloudflarePagesFunctionsRequests {
+	m := metricCloudflarePagesFunctionsRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareRateLimitingRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.rate_limiting.requests metric with initial data.
+func (m *metricCloudflareRateLimitingRequests) init() {
+	m.data.SetName("cloudflare.rate_limiting.requests")
+	m.data.SetDescription("The number of requests of the zone matched by a rate limiting rule during the polled window, by rule and action. Only emitted when the `rate_limiting` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareRateLimitingRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, ruleIDAttributeValue string, actionAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.rule.id", ruleIDAttributeValue)
+	dp.Attributes().PutStr("cloudflare.action", actionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareRateLimitingRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareRateLimitingRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareRateLimitingRequests(cfg MetricConfig) metricCloudflareRateLimitingRequests {
+	m := metricCloudflareRateLimitingRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareStreamMinutesViewed struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.stream.minutes_viewed metric with initial data.
+func (m *metricCloudflareStreamMinutesViewed) init() {
+	m.data.SetName("cloudflare.stream.minutes_viewed")
+	m.data.SetDescription("The number of minutes of the Stream video viewed during the polled window. Only emitted when the `stream` dataset of `analytics` is collected.")
+	m.data.SetUnit("min")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareStreamMinutesViewed) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, videoUIDAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.stream.video.uid", videoUIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareStreamMinutesViewed) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareStreamMinutesViewed) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareStreamMinutesViewed(cfg MetricConfig) metricCloudflareStreamMinutesViewed {
+	m := metricCloudflareStreamMinutesViewed{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareStreamStorage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.stream.storage metric with initial data.
+func (m *metricCloudflareStreamStorage) init() {
+	m.data.SetName("cloudflare.stream.storage")
+	m.data.SetDescription("The peak number of minutes of video stored in Stream during the polled window. Only emitted when the `stream` dataset of `analytics` is collected.")
+	m.data.SetUnit("min")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricCloudflareStreamStorage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareStreamStorage) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareStreamStorage) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareStreamStorage(cfg MetricConfig) metricCloudflareStreamStorage {
+	m := metricCloudflareStreamStorage{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareStreamViewers struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.stream.viewers metric with initial data.
+func (m *metricCloudflareStreamViewers) init() {
+	m.data.SetName("cloudflare.stream.viewers")
+	m.data.SetDescription("The number of unique viewers of the Stream video during the polled window. Only emitted when the `stream` dataset of `analytics` is collected.")
+	m.data.SetUnit("{viewer}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareStreamViewers) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, videoUIDAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.stream.video.uid", videoUIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareStreamViewers) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareStreamViewers) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareStreamViewers(cfg MetricConfig) metricCloudflareStreamViewers {
+	m := metricCloudflareStreamViewers{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareTurnstileChallenges struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.turnstile.challenges metric with initial data.
+func (m *metricCloudflareTurnstileChallenges) init() {
+	m.data.SetName("cloudflare.turnstile.challenges")
+	m.data.SetDescription("The number of Turnstile challenges issued, solved or failed during the polled window, per widget. Only emitted when the `turnstile` dataset of `analytics` is collected.")
+	m.data.SetUnit("{challenge}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareTurnstileChallenges) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, turnstileSitekeyAttributeValue string, turnstileEventAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.turnstile.widget.sitekey", turnstileSitekeyAttributeValue)
+	dp.Attributes().PutStr("cloudflare.turnstile.event", turnstileEventAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareTurnstileChallenges) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareTurnstileChallenges) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareTurnstileChallenges(cfg MetricConfig) metricCloudflareTurnstileChallenges {
+	m := metricCloudflareTurnstileChallenges{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWaitingRoomAcceptedUsers struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.waiting_room.accepted_users metric with initial data.
+func (m *metricCloudflareWaitingRoomAcceptedUsers) init() {
+	m.data.SetName("cloudflare.waiting_room.accepted_users")
+	m.data.SetDescription("The number of users let through the waiting room to the origin during the polled window. Only emitted when the `waiting_room` dataset of `analytics` is collected.")
+	m.data.SetUnit("{user}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWaitingRoomAcceptedUsers) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, waitingRoomIDAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.waiting_room.id", waitingRoomIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWaitingRoomAcceptedUsers) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWaitingRoomAcceptedUsers) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWaitingRoomAcceptedUsers(cfg MetricConfig) metricCloudflareWaitingRoomAcceptedUsers {
+	m := metricCloudflareWaitingRoomAcceptedUsers{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWaitingRoomActiveUsers struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.waiting_room.active_users metric with initial data.
+func (m *metricCloudflareWaitingRoomActiveUsers) init() {
+	m.data.SetName("cloudflare.waiting_room.active_users")
+	m.data.SetDescription("The peak number of users active on the origin behind the waiting room during the polled window. Only emitted when the `waiting_room` dataset of `analytics` is collected.")
+	m.data.SetUnit("{user}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWaitingRoomActiveUsers) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, waitingRoomIDAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.waiting_room.id", waitingRoomIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWaitingRoomActiveUsers) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWaitingRoomActiveUsers) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWaitingRoomActiveUsers(cfg MetricConfig) metricCloudflareWaitingRoomActiveUsers {
+	m := metricCloudflareWaitingRoomActiveUsers{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWaitingRoomEstimatedWaitTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.waiting_room.estimated_wait_time metric with initial data.
+func (m *metricCloudflareWaitingRoomEstimatedWaitTime) init() {
+	m.data.SetName("cloudflare.waiting_room.estimated_wait_time")
+	m.data.SetDescription("The peak time users were estimated to wait in the queue of the waiting room during the polled window. Only emitted when the `waiting_room` dataset of `analytics` is collected.")
+	m.data.SetUnit("min")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWaitingRoomEstimatedWaitTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, waitingRoomIDAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.waiting_room.id", waitingRoomIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWaitingRoomEstimatedWaitTime) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWaitingRoomEstimatedWaitTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWaitingRoomEstimatedWaitTime(cfg MetricConfig) metricCloudflareWaitingRoomEstimatedWaitTime {
+	m := metricCloudflareWaitingRoomEstimatedWaitTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWaitingRoomQueuedUsers struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.waiting_room.queued_users metric with initial data.
+func (m *metricCloudflareWaitingRoomQueuedUsers) init() {
+	m.data.SetName("cloudflare.waiting_room.queued_users")
+	m.data.SetDescription("The peak number of users queued in the waiting room during the polled window. Only emitted when the `waiting_room` dataset of `analytics` is collected.")
+	m.data.SetUnit("{user}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWaitingRoomQueuedUsers) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, waitingRoomIDAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.waiting_room.id", waitingRoomIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWaitingRoomQueuedUsers) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWaitingRoomQueuedUsers) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWaitingRoomQueuedUsers(cfg MetricConfig) metricCloudflareWaitingRoomQueuedUsers {
+	m := metricCloudflareWaitingRoomQueuedUsers{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWarpDevices struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.warp.devices metric with initial data.
+func (m *metricCloudflareWarpDevices) init() {
+	m.data.SetName("cloudflare.warp.devices")
+	m.data.SetDescription("The number of devices whose WARP client reported the status during the polled window. Devices failing to connect are reported with the Failed status. Only emitted when the `warp` dataset of `analytics` is collected.")
+	m.data.SetUnit("{device}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWarpDevices) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, warpStatusAttributeValue string, osTypeAttributeValue string, osVersionAttributeValue string, warpVersionAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.warp.status", warpStatusAttributeValue)
+	dp.Attributes().PutStr("os.type", osTypeAttributeValue)
+	dp.Attributes().PutStr("os.version", osVersionAttributeValue)
+	dp.Attributes().PutStr("cloudflare.warp.version", warpVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWarpDevices) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWarpDevices) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWarpDevices(cfg MetricConfig) metricCloudflareWarpDevices {
+	m := metricCloudflareWarpDevices{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWorkersCPUTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.workers.cpu_time metric with initial data.
+func (m *metricCloudflareWorkersCPUTime) init() {
+	m.data.SetName("cloudflare.workers.cpu_time")
+	m.data.SetDescription("The quantiles of the CPU time of the invocations of the Worker during the polled window. Only emitted when the `workers` dataset of `analytics` is collected.")
+	m.data.SetUnit("us")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWorkersCPUTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, scriptNameAttributeValue string, quantileAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("cloudflare.worker.script.name", scriptNameAttributeValue)
+	dp.Attributes().PutStr("cloudflare.quantile", quantileAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWorkersCPUTime) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWorkersCPUTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWorkersCPUTime(cfg MetricConfig) metricCloudflareWorkersCPUTime {
+	m := metricCloudflareWorkersCPUTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWorkersErrors struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.workers.errors metric with initial data.
+func (m *metricCloudflareWorkersErrors) init() {
+	m.data.SetName("cloudflare.workers.errors")
+	m.data.SetDescription("The number of invocations of the Worker that failed during the polled window. Only emitted when the `workers` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWorkersErrors) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, scriptNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.worker.script.name", scriptNameAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWorkersErrors) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWorkersErrors) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWorkersErrors(cfg MetricConfig) metricCloudflareWorkersErrors {
+	m := metricCloudflareWorkersErrors{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWorkersRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.workers.requests metric with initial data.
+func (m *metricCloudflareWorkersRequests) init() {
+	m.data.SetName("cloudflare.workers.requests")
+	m.data.SetDescription("The number of invocations of the Worker during the polled window. Only emitted when the `workers` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWorkersRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, scriptNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("cloudflare.worker.script.name", scriptNameAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWorkersRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWorkersRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWorkersRequests(cfg MetricConfig) metricCloudflareWorkersRequests {
+	m := metricCloudflareWorkersRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWorkersAiInferenceTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.workers_ai.inference_time metric with initial data.
+func (m *metricCloudflareWorkersAiInferenceTime) init() {
+	m.data.SetName("cloudflare.workers_ai.inference_time")
+	m.data.SetDescription("The quantiles of the time the inference requests took during the polled window. Only emitted when the `workers_ai` dataset of `analytics` is collected.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWorkersAiInferenceTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, aiModelAttributeValue string, quantileAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("gen_ai.request.model", aiModelAttributeValue)
+	dp.Attributes().PutStr("cloudflare.quantile", quantileAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWorkersAiInferenceTime) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWorkersAiInferenceTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWorkersAiInferenceTime(cfg MetricConfig) metricCloudflareWorkersAiInferenceTime {
+	m := metricCloudflareWorkersAiInferenceTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWorkersAiNeurons struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.workers_ai.neurons metric with initial data.
+func (m *metricCloudflareWorkersAiNeurons) init() {
+	m.data.SetName("cloudflare.workers_ai.neurons")
+	m.data.SetDescription("The number of neurons consumed by the inference requests during the polled window, the unit Workers AI is billed in. Only emitted when the `workers_ai` dataset of `analytics` is collected.")
+	m.data.SetUnit("{neuron}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWorkersAiNeurons) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, aiModelAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("gen_ai.request.model", aiModelAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWorkersAiNeurons) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWorkersAiNeurons) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWorkersAiNeurons(cfg MetricConfig) metricCloudflareWorkersAiNeurons {
+	m := metricCloudflareWorkersAiNeurons{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricCloudflareWorkersAiRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills cloudflare.workers_ai.requests metric with initial data.
+func (m *metricCloudflareWorkersAiRequests) init() {
+	m.data.SetName("cloudflare.workers_ai.requests")
+	m.data.SetDescription("The number of inference requests run by Workers AI during the polled window. Only emitted when the `workers_ai` dataset of `analytics` is collected.")
+	m.data.SetUnit("{request}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricCloudflareWorkersAiRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, aiModelAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("gen_ai.request.model", aiModelAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricCloudflareWorkersAiRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricCloudflareWorkersAiRequests) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricCloudflareWorkersAiRequests(cfg MetricConfig) metricCloudflareWorkersAiRequests {
+	m := metricCloudflareWorkersAiRequests{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user config.
+type MetricsBuilder struct {
+	config                                             MetricsBuilderConfig // config of the metrics builder.
+	startTime                                          pcommon.Timestamp    // start time that will be applied to all recorded data points.
+	metricsCapacity                                    int                  // maximum observed number of metrics per resource.
+	metricsBuffer                                      pmetric.Metrics      // accumulates metrics data before emitting.
+	buildInfo                                          component.BuildInfo  // contains version information.
+	resourceAttributeIncludeFilter                     map[string]filter.Filter
+	resourceAttributeExcludeFilter                     map[string]filter.Filter
+	metricCloudflareAccessLogins                       metricCloudflareAccessLogins
+	metricCloudflareAiGatewayCachedRequests            metricCloudflareAiGatewayCachedRequests
+	metricCloudflareAiGatewayCost                      metricCloudflareAiGatewayCost
+	metricCloudflareAiGatewayErrors                    metricCloudflareAiGatewayErrors
+	metricCloudflareAiGatewayRequests                  metricCloudflareAiGatewayRequests
+	metricCloudflareAiGatewayTokens                    metricCloudflareAiGatewayTokens
+	metricCloudflareAPIGatewayAbuseAnomalies           metricCloudflareAPIGatewayAbuseAnomalies
+	metricCloudflareAPIGatewayRequests                 metricCloudflareAPIGatewayRequests
+	metricCloudflareAPIGatewaySchemaValidationFailures metricCloudflareAPIGatewaySchemaValidationFailures
+	metricCloudflareArgoOriginResponseTime             metricCloudflareArgoOriginResponseTime
+	metricCloudflareArgoRequests                       metricCloudflareArgoRequests
+	metricCloudflareBotManagementRequests              metricCloudflareBotManagementRequests
+	metricCloudflareCacheReserveOperations             metricCloudflareCacheReserveOperations
+	metricCloudflareCacheReserveStorage                metricCloudflareCacheReserveStorage
+	metricCloudflareDdosAttackEvents                   metricCloudflareDdosAttackEvents
+	metricCloudflareDdosMitigatedBitRate               metricCloudflareDdosMitigatedBitRate
+	metricCloudflareDdosMitigatedPacketRate            metricCloudflareDdosMitigatedPacketRate
+	metricCloudflareDexTestAvailability                metricCloudflareDexTestAvailability
+	metricCloudflareDexTestDuration                    metricCloudflareDexTestDuration
+	metricCloudflareEmailSecurityMessages              metricCloudflareEmailSecurityMessages
+	metricCloudflareFirewallEvents                     metricCloudflareFirewallEvents
+	metricCloudflareGatewayDNSQueries                  metricCloudflareGatewayDNSQueries
+	metricCloudflareGatewayHTTPRequests                metricCloudflareGatewayHTTPRequests
+	metricCloudflareGatewayNetworkIo                   metricCloudflareGatewayNetworkIo
+	metricCloudflareGatewayNetworkSessions             metricCloudflareGatewayNetworkSessions
+	metricCloudflareHTTPBlockedRatio                   metricCloudflareHTTPBlockedRatio
+	metricCloudflareHTTPCacheHitRatio                  metricCloudflareHTTPCacheHitRatio
+	metricCloudflareHTTPOriginErrorRatio               metricCloudflareHTTPOriginErrorRatio
+	metricCloudflareHTTPRequests                       metricCloudflareHTTPRequests
+	metricCloudflareHTTPUniqueVisitors                 metricCloudflareHTTPUniqueVisitors
+	metricCloudflareHyperdriveOriginLatency            metricCloudflareHyperdriveOriginLatency
+	metricCloudflareHyperdriveQueries                  metricCloudflareHyperdriveQueries
+	metricCloudflareImagesRequests                     metricCloudflareImagesRequests
+	metricCloudflareImagesStored                       metricCloudflareImagesStored
+	metricCloudflareImagesTransformations              metricCloudflareImagesTransformations
+	metricCloudflareLogpushJobEnabled                  metricCloudflareLogpushJobEnabled
+	metricCloudflareLogpushJobErrors                   metricCloudflareLogpushJobErrors
+	metricCloudflareLogpushJobLastComplete             metricCloudflareLogpushJobLastComplete
+	metricCloudflareLogpushJobLastError                metricCloudflareLogpushJobLastError
+	metricCloudflareLogpushRecords                     metricCloudflareLogpushRecords
+	metricCloudflarePageShieldViolations               metricCloudflarePageShieldViolations
+	metricCloudflarePagesFunctionsCPUTime              metricCloudflarePagesFunctionsCPUTime
+	metricCloudflarePagesFunctionsErrors               metricCloudflarePagesFunctionsErrors
+	metricCloudflarePagesFunctionsRequests             metricCloudflarePagesFunctionsRequests
+	metricCloudflareRateLimitingRequests               metricCloudflareRateLimitingRequests
+	metricCloudflareStreamMinutesViewed                metricCloudflareStreamMinutesViewed
+	metricCloudflareStreamStorage                      metricCloudflareStreamStorage
+	metricCloudflareStreamViewers                      metricCloudflareStreamViewers
+	metricCloudflareTurnstileChallenges                metricCloudflareTurnstileChallenges
+	metricCloudflareWaitingRoomAcceptedUsers           metricCloudflareWaitingRoomAcceptedUsers
+	metricCloudflareWaitingRoomActiveUsers             metricCloudflareWaitingRoomActiveUsers
+	metricCloudflareWaitingRoomEstimatedWaitTime       metricCloudflareWaitingRoomEstimatedWaitTime
+	metricCloudflareWaitingRoomQueuedUsers             metricCloudflareWaitingRoomQueuedUsers
+	metricCloudflareWarpDevices                        metricCloudflareWarpDevices
+	metricCloudflareWorkersCPUTime                     metricCloudflareWorkersCPUTime
+	metricCloudflareWorkersErrors                      metricCloudflareWorkersErrors
+	metricCloudflareWorkersRequests                    metricCloudflareWorkersRequests
+	metricCloudflareWorkersAiInferenceTime             metricCloudflareWorkersAiInferenceTime
+	metricCloudflareWorkersAiNeurons                   metricCloudflareWorkersAiNeurons
+	metricCloudflareWorkersAiRequests                  metricCloudflareWorkersAiRequests
+}
+
+// MetricBuilderOption applies changes to default metrics builder.
+type MetricBuilderOption interface {
+	apply(*MetricsBuilder)
+}
+
+type metricBuilderOptionFunc func(mb *MetricsBuilder)
+
+func (mbof metricBuilderOptionFunc) apply(mb *MetricsBuilder) {
+	mbof(mb)
+}
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) MetricBuilderOption {
+	return metricBuilderOptionFunc(func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	})
+}
+
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, options ...MetricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		config:                                             mbc,
+		startTime:                                          pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                                      pmetric.NewMetrics(),
+		buildInfo:                                          settings.BuildInfo,
+		metricCloudflareAccessLogins:                       newMetricCloudflareAccessLogins(mbc.Metrics.CloudflareAccessLogins),
+		metricCloudflareAiGatewayCachedRequests:            newMetricCloudflareAiGatewayCachedRequests(mbc.Metrics.CloudflareAiGatewayCachedRequests),
+		metricCloudflareAiGatewayCost:                      newMetricCloudflareAiGatewayCost(mbc.Metrics.CloudflareAiGatewayCost),
+		metricCloudflareAiGatewayErrors:                    newMetricCloudflareAiGatewayErrors(mbc.Metrics.CloudflareAiGatewayErrors),
+		metricCloudflareAiGatewayRequests:                  newMetricCloudflareAiGatewayRequests(mbc.Metrics.CloudflareAiGatewayRequests),
+		metricCloudflareAiGatewayTokens:                    newMetricCloudflareAiGatewayTokens(mbc.Metrics.CloudflareAiGatewayTokens),
+		metricCloudflareAPIGatewayAbuseAnomalies:           newMetricCloudflareAPIGatewayAbuseAnomalies(mbc.Metrics.CloudflareAPIGatewayAbuseAnomalies),
+		metricCloudflareAPIGatewayRequests:                 newMetricCloudflareAPIGatewayRequests(mbc.Metrics.CloudflareAPIGatewayRequests),
+		metricCloudflareAPIGatewaySchemaValidationFailures: newMetricCloudflareAPIGatewaySchemaValidationFailures(mbc.Metrics.CloudflareAPIGatewaySchemaValidationFailures),
+		metricCloudflareArgoOriginResponseTime:             newMetricCloudflareArgoOriginResponseTime(mbc.Metrics.CloudflareArgoOriginResponseTime),
+		metricCloudflareArgoRequests:                       newMetricCloudflareArgoRequests(mbc.Metrics.CloudflareArgoRequests),
+		metricCloudflareBotManagementRequests:              newMetricCloudflareBotManagementRequests(mbc.Metrics.CloudflareBotManagementRequests),
+		metricCloudflareCacheReserveOperations:             newMetricCloudflareCacheReserveOperations(mbc.Metrics.CloudflareCacheReserveOperations),
+		metricCloudflareCacheReserveStorage:                newMetricCloudflareCacheReserveStorage(mbc.Metrics.CloudflareCacheReserveStorage),
+		metricCloudflareDdosAttackEvents:                   newMetricCloudflareDdosAttackEvents(mbc.Metrics.CloudflareDdosAttackEvents),
+		metricCloudflareDdosMitigatedBitRate:               newMetricCloudflareDdosMitigatedBitRate(mbc.Metrics.CloudflareDdosMitigatedBitRate),
+		metricCloudflareDdosMitigatedPacketRate:            newMetricCloudflareDdosMitigatedPacketRate(mbc.Metrics.CloudflareDdosMitigatedPacketRate),
+		metricCloudflareDexTestAvailability:                newMetricCloudflareDexTestAvailability(mbc.Metrics.CloudflareDexTestAvailability),
+		metricCloudflareDexTestDuration:                    newMetricCloudflareDexTestDuration(mbc.Metrics.CloudflareDexTestDuration),
+		metricCloudflareEmailSecurityMessages:              newMetricCloudflareEmailSecurityMessages(mbc.Metrics.CloudflareEmailSecurityMessages),
+		metricCloudflareFirewallEvents:                     newMetricCloudflareFirewallEvents(mbc.Metrics.CloudflareFirewallEvents),
+		metricCloudflareGatewayDNSQueries:                  newMetricCloudflareGatewayDNSQueries(mbc.Metrics.CloudflareGatewayDNSQueries),
+		metricCloudflareGatewayHTTPRequests:                newMetricCloudflareGatewayHTTPRequests(mbc.Metrics.CloudflareGatewayHTTPRequests),
+		metricCloudflareGatewayNetworkIo:                   newMetricCloudflareGatewayNetworkIo(mbc.Metrics.CloudflareGatewayNetworkIo),
+		metricCloudflareGatewayNetworkSessions:             newMetricCloudflareGatewayNetworkSessions(mbc.Metrics.CloudflareGatewayNetworkSessions),
+		metricCloudflareHTTPBlockedRatio:                   newMetricCloudflareHTTPBlockedRatio(mbc.Metrics.CloudflareHTTPBlockedRatio),
+		metricCloudflareHTTPCacheHitRatio:                  newMetricCloudflareHTTPCacheHitRatio(mbc.Metrics.CloudflareHTTPCacheHitRatio),
+		metricCloudflareHTTPOriginErrorRatio:               newMetricCloudflareHTTPOriginErrorRatio(mbc.Metrics.CloudflareHTTPOriginErrorRatio),
+		metricCloudflareHTTPRequests:                       newMetricCloudflareHTTPRequests(mbc.Metrics.CloudflareHTTPRequests),
+		metricCloudflareHTTPUniqueVisitors:                 newMetricCloudflareHTTPUniqueVisitors(mbc.Metrics.CloudflareHTTPUniqueVisitors),
+		metricCloudflareHyperdriveOriginLatency:            newMetricCloudflareHyperdriveOriginLatency(mbc.Metrics.CloudflareHyperdriveOriginLatency),
+		metricCloudflareHyperdriveQueries:                  newMetricCloudflareHyperdriveQueries(mbc.Metrics.CloudflareHyperdriveQueries),
+		metricCloudflareImagesRequests:                     newMetricCloudflareImagesRequests(mbc.Metrics.CloudflareImagesRequests),
+		metricCloudflareImagesStored:                       newMetricCloudflareImagesStored(mbc.Metrics.CloudflareImagesStored),
+		metricCloudflareImagesTransformations:              newMetricCloudflareImagesTransformations(mbc.Metrics.CloudflareImagesTransformations),
+		metricCloudflareLogpushJobEnabled:                  newMetricCloudflareLogpushJobEnabled(mbc.Metrics.CloudflareLogpushJobEnabled),
+		metricCloudflareLogpushJobErrors:                   newMetricCloudflareLogpushJobErrors(mbc.Metrics.CloudflareLogpushJobErrors),
+		metricCloudflareLogpushJobLastComplete:             newMetricCloudflareLogpushJobLastComplete(mbc.Metrics.CloudflareLogpushJobLastComplete),
+		metricCloudflareLogpushJobLastError:                newMetricCloudflareLogpushJobLastError(mbc.Metrics.CloudflareLogpushJobLastError),
+		metricCloudflareLogpushRecords:                     newMetricCloudflareLogpushRecords(mbc.Metrics.CloudflareLogpushRecords),
+		metricCloudflarePageShieldViolations:               newMetricCloudflarePageShieldViolations(mbc.Metrics.CloudflarePageShieldViolations),
+		metricCloudflarePagesFunctionsCPUTime:              newMetricCloudflarePagesFunctionsCPUTime(mbc.Metrics.CloudflarePagesFunctionsCPUTime),
+		metricCloudflarePagesFunctionsErrors:               newMetricCloudflarePagesFunctionsErrors(mbc.Metrics.CloudflarePagesFunctionsErrors),
+		metricCloudflarePagesFunctionsRequests:             newMetricCloudflarePagesFunctionsRequests(mbc.Metrics.CloudflarePagesFunctionsRequests),
+		metricCloudflareRateLimitingRequests:               newMetricCloudflareRateLimitingRequests(mbc.Metrics.CloudflareRateLimitingRequests),
+		metricCloudflareStreamMinutesViewed:                newMetricCloudflareStreamMinutesViewed(mbc.Metrics.CloudflareStreamMinutesViewed),
+		metricCloudflareStreamStorage:                      newMetricCloudflareStreamStorage(mbc.Metrics.CloudflareStreamStorage),
+		metricCloudflareStreamViewers:                      newMetricCloudflareStreamViewers(mbc.Metrics.CloudflareStreamViewers),
+		metricCloudflareTurnstileChallenges:                newMetricCloudflareTurnstileChallenges(mbc.Metrics.CloudflareTurnstileChallenges),
+		metricCloudflareWaitingRoomAcceptedUsers:           newMetricCloudflareWaitingRoomAcceptedUsers(mbc.Metrics.CloudflareWaitingRoomAcceptedUsers),
+		metricCloudflareWaitingRoomActiveUsers:             newMetricCloudflareWaitingRoomActiveUsers(mbc.Metrics.CloudflareWaitingRoomActiveUsers),
+		metricCloudflareWaitingRoomEstimatedWaitTime:       newMetricCloudflareWaitingRoomEstimatedWaitTime(mbc.Metrics.CloudflareWaitingRoomEstimatedWaitTime),
+		metricCloudflareWaitingRoomQueuedUsers:             newMetricCloudflareWaitingRoomQueuedUsers(mbc.Metrics.CloudflareWaitingRoomQueuedUsers),
+		metricCloudflareWarpDevices:                        newMetricCloudflareWarpDevices(mbc.Metrics.CloudflareWarpDevices),
+		metricCloudflareWorkersCPUTime:                     newMetricCloudflareWorkersCPUTime(mbc.Metrics.CloudflareWorkersCPUTime),
+		metricCloudflareWorkersErrors:                      newMetricCloudflareWorkersErrors(mbc.Metrics.CloudflareWorkersErrors),
+		metricCloudflareWorkersRequests:                    newMetricCloudflareWorkersRequests(mbc.Metrics.CloudflareWorkersRequests),
+		metricCloudflareWorkersAiInferenceTime:             newMetricCloudflareWorkersAiInferenceTime(mbc.Metrics.CloudflareWorkersAiInferenceTime),
+		metricCloudflareWorkersAiNeurons:                   newMetricCloudflareWorkersAiNeurons(mbc.Metrics.CloudflareWorkersAiNeurons),
+		metricCloudflareWorkersAiRequests:                  newMetricCloudflareWorkersAiRequests(mbc.Metrics.CloudflareWorkersAiRequests),
+		resourceAttributeIncludeFilter:                     make(map[string]filter.Filter),
+		resourceAttributeExcludeFilter:                     make(map[string]filter.Filter),
+	}
+	if mbc.ResourceAttributes.CloudflareAccountID.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["cloudflare.account.id"] = filter.CreateFilter(mbc.ResourceAttributes.CloudflareAccountID.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.CloudflareAccountID.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["cloudflare.account.id"] = filter.CreateFilter(mbc.ResourceAttributes.CloudflareAccountID.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.CloudflareZoneID.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["cloudflare.zone.id"] = filter.CreateFilter(mbc.ResourceAttributes.CloudflareZoneID.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.CloudflareZoneID.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["cloudflare.zone.id"] = filter.CreateFilter(mbc.ResourceAttributes.CloudflareZoneID.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.CloudflareZoneName.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["cloudflare.zone.name"] = filter.CreateFilter(mbc.ResourceAttributes.CloudflareZoneName.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.CloudflareZoneName.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["cloudflare.zone.name"] = filter.CreateFilter(mbc.ResourceAttributes.CloudflareZoneName.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.CloudflareZonePlan.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["cloudflare.zone.plan"] = filter.CreateFilter(mbc.ResourceAttributes.CloudflareZonePlan.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.CloudflareZonePlan.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["cloudflare.zone.plan"] = filter.CreateFilter(mbc.ResourceAttributes.CloudflareZonePlan.MetricsExclude)
+	}
+
+	for _, op := range options {
+		op.apply(mb)
+	}
+	return mb
+}
+
+// NewResourceBuilder returns a new resource builder that should be used to build a resource associated with for the emitted metrics.
+func (mb *MetricsBuilder) NewResourceBuilder() *ResourceBuilder {
+	return NewResourceBuilder(mb.config.ResourceAttributes)
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption interface {
+	apply(pmetric.ResourceMetrics)
+}
+
+type resourceMetricsOptionFunc func(pmetric.ResourceMetrics)
+
+func (rmof resourceMetricsOptionFunc) apply(rm pmetric.ResourceMetrics) {
+	rmof(rm)
+}
+
+// WithResource sets the provided resource on the emitted ResourceMetrics.
+// It's recommended to use ResourceBuilder to create the resource.
+func WithResource(res pcommon.Resource) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		res.CopyTo(rm.Resource())
+	})
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	})
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	rm.SetSchemaUrl(conventions.SchemaURL)
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName(ScopeName)
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricCloudflareAccessLogins.emit(ils.Metrics())
+	mb.metricCloudflareAiGatewayCachedRequests.emit(ils.Metrics())
+	mb.metricCloudflareAiGatewayCost.emit(ils.Metrics())
+	mb.metricCloudflareAiGatewayErrors.emit(ils.Metrics())
+	mb.metricCloudflareAiGatewayRequests.emit(ils.Metrics())
+	mb.metricCloudflareAiGatewayTokens.emit(ils.Metrics())
+	mb.metricCloudflareAPIGatewayAbuseAnomalies.emit(ils.Metrics())
+	mb.metricCloudflareAPIGatewayRequests.emit(ils.Metrics())
+	mb.metricCloudflareAPIGatewaySchemaValidationFailures.emit(ils.Metrics())
+	mb.metricCloudflareArgoOriginResponseTime.emit(ils.Metrics())
+	mb.metricCloudflareArgoRequests.emit(ils.Metrics())
+	mb.metricCloudflareBotManagementRequests.emit(ils.Metrics())
+	mb.metricCloudflareCacheReserveOperations.emit(ils.Metrics())
+	mb.metricCloudflareCacheReserveStorage.emit(ils.Metrics())
+	mb.metricCloudflareDdosAttackEvents.emit(ils.Metrics())
+	mb.metricCloudflareDdosMitigatedBitRate.emit(ils.Metrics())
+	mb.metricCloudflareDdosMitigatedPacketRate.emit(ils.Metrics())
+	mb.metricCloudflareDexTestAvailability.emit(ils.Metrics())
+	mb.metricCloudflareDexTestDuration.emit(ils.Metrics())
+	mb.metricCloudflareEmailSecurityMessages.emit(ils.Metrics())
+	mb.metricCloudflareFirewallEvents.emit(ils.Metrics())
+	mb.metricCloudflareGatewayDNSQueries.emit(ils.Metrics())
+	mb.metricCloudflareGatewayHTTPRequests.emit(ils.Metrics())
+	mb.metricCloudflareGatewayNetworkIo.emit(ils.Metrics())
+	mb.metricCloudflareGatewayNetworkSessions.emit(ils.Metrics())
+	mb.metricCloudflareHTTPBlockedRatio.emit(ils.Metrics())
+	mb.metricCloudflareHTTPCacheHitRatio.emit(ils.Metrics())
+	mb.metricCloudflareHTTPOriginErrorRatio.emit(ils.Metrics())
+	mb.metricCloudflareHTTPRequests.emit(ils.Metrics())
+	mb.metricCloudflareHTTPUniqueVisitors.emit(ils.Metrics())
+	mb.metricCloudflareHyperdriveOriginLatency.emit(ils.Metrics())
+	mb.metricCloudflareHyperdriveQueries.emit(ils.Metrics())
+	mb.metricCloudflareImagesRequests.emit(ils.Metrics())
+	mb.metricCloudflareImagesStored.emit(ils.Metrics())
+	mb.metricCloudflareImagesTransformations.emit(ils.Metrics())
+	mb.metricCloudflareLogpushJobEnabled.emit(ils.Metrics())
+	mb.metricCloudflareLogpushJobErrors.emit(ils.Metrics())
+	mb.metricCloudflareLogpushJobLastComplete.emit(ils.Metrics())
+	mb.metricCloudflareLogpushJobLastError.emit(ils.Metrics())
+	mb.metricCloudflareLogpushRecords.emit(ils.Metrics())
+	mb.metricCloudflarePageShieldViolations.emit(ils.Metrics())
+	mb.metricCloudflarePagesFunctionsCPUTime.emit(ils.Metrics())
+	mb.metricCloudflarePagesFunctionsErrors.emit(ils.Metrics())
+	mb.metricCloudflarePagesFunctionsRequests.emit(ils.Metrics())
+	mb.metricCloudflareRateLimitingRequests.emit(ils.Metrics())
+	mb.metricCloudflareStreamMinutesViewed.emit(ils.Metrics())
+	mb.metricCloudflareStreamStorage.emit(ils.Metrics())
+	mb.metricCloudflareStreamViewers.emit(ils.Metrics())
+	mb.metricCloudflareTurnstileChallenges.emit(ils.Metrics())
+	mb.metricCloudflareWaitingRoomAcceptedUsers.emit(ils.Metrics())
+	mb.metricCloudflareWaitingRoomActiveUsers.emit(ils.Metrics())
+	mb.metricCloudflareWaitingRoomEstimatedWaitTime.emit(ils.Metrics())
+	mb.metricCloudflareWaitingRoomQueuedUsers.emit(ils.Metrics())
+	mb.metricCloudflareWarpDevices.emit(ils.Metrics())
+	mb.metricCloudflareWorkersCPUTime.emit(ils.Metrics())
+	mb.metricCloudflareWorkersErrors.emit(ils.Metrics())
+	mb.metricCloudflareWorkersRequests.emit(ils.Metrics())
+	mb.metricCloudflareWorkersAiInferenceTime.emit(ils.Metrics())
+	mb.metricCloudflareWorkersAiNeurons.emit(ils.Metrics())
+	mb.metricCloudflareWorkersAiRequests.emit(ils.Metrics())
+
+	for _, op := range options {
+		op.apply(rm)
+	}
+	for attr, filter := range mb.resourceAttributeIncludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && !filter.Matches(val.AsString()) {
+			return
+		}
+	}
+	for attr, filter := range mb.resourceAttributeExcludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && filter.Matches(val.AsString()) {
+			return
+		}
+	}
+
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user config, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(options ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(options...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordCloudflareAccessLoginsDataPoint adds a data point to cloudflare.access.logins metric.
+func (mb *MetricsBuilder) RecordCloudflareAccessLoginsDataPoint(ts pcommon.Timestamp, val int64, accessAllowedAttributeValue bool, accessAppUIDAttributeValue string, accessIdentityProviderAttributeValue string, countryAttributeValue string) {
+	mb.metricCloudflareAccessLogins.recordDataPoint(mb.startTime, ts, val, accessAllowedAttributeValue, accessAppUIDAttributeValue, accessIdentityProviderAttributeValue, countryAttributeValue)
+}
+
+// RecordCloudflareAiGatewayCachedRequestsDataPoint adds a data point to cloudflare.ai_gateway.cached_requests metric.
+func (mb *MetricsBuilder) RecordCloudflareAiGatewayCachedRequestsDataPoint(ts pcommon.Timestamp, val int64, aiGatewayIDAttributeValue string, aiProviderAttributeValue string, aiModelAttributeValue string) {
+	mb.metricCloudflareAiGatewayCachedRequests.recordDataPoint(mb.startTime, ts, val, aiGatewayIDAttributeValue, aiProviderAttributeValue, aiModelAttributeValue)
+}
+
+// RecordCloudflareAiGatewayCostDataPoint adds a data point to cloudflare.ai_gateway.cost metric.
+func (mb *MetricsBuilder) RecordCloudflareAiGatewayCostDataPoint(ts pcommon.Timestamp, val float64, aiGatewayIDAttributeValue string, aiProviderAttributeValue string, aiModelAttributeValue string) {
+	mb.metricCloudflareAiGatewayCost.recordDataPoint(mb.startTime, ts, val, aiGatewayIDAttributeValue, aiProviderAttributeValue, aiModelAttributeValue)
+}
+
+// RecordCloudflareAiGatewayErrorsDataPoint adds a data point to cloudflare.ai_gateway.errors metric.
+func (mb *MetricsBuilder) RecordCloudflareAiGatewayErrorsDataPoint(ts pcommon.Timestamp, val int64, aiGatewayIDAttributeValue string, aiProviderAttributeValue string, aiModelAttributeValue string) {
+	mb.metricCloudflareAiGatewayErrors.recordDataPoint(mb.startTime, ts, val, aiGatewayIDAttributeValue, aiProviderAttributeValue, aiModelAttributeValue)
+}
+
+// RecordCloudflareAiGatewayRequestsDataPoint adds a data point to cloudflare.ai_gateway.requests metric.
+func (mb *MetricsBuilder) RecordCloudflareAiGatewayRequestsDataPoint(ts pcommon.Timestamp, val int64, aiGatewayIDAttributeValue string, aiProviderAttributeValue string, aiModelAttributeValue string) {
+	mb.metricCloudflareAiGatewayRequests.recordDataPoint(mb.startTime, ts, val, aiGatewayIDAttributeValue, aiProviderAttributeValue, aiModelAttributeValue)
+}
+
+// RecordCloudflareAiGatewayTokensDataPoint adds a data point to cloudflare.ai_gateway.tokens metric.
+func (mb *MetricsBuilder) RecordCloudflareAiGatewayTokensDataPoint(ts pcommon.Timestamp, val int64, aiGatewayIDAttributeValue string, aiProviderAttributeValue string, aiModelAttributeValue string, tokenTypeAttributeValue AttributeTokenType) {
+	mb.metricCloudflareAiGatewayTokens.recordDataPoint(mb.startTime, ts, val, aiGatewayIDAttributeValue, aiProviderAttributeValue, aiModelAttributeValue, tokenTypeAttributeValue.String())
+}
+
+// RecordCloudflareAPIGatewayAbuseAnomaliesDataPoint adds a data point to cloudflare.api_gateway.abuse_anomalies metric.
+func (mb *MetricsBuilder) RecordCloudflareAPIGatewayAbuseAnomaliesDataPoint(ts pcommon.Timestamp, val int64, hostAttributeValue string, apiAbuseSourceAttributeValue string) {
+	mb.metricCloudflareAPIGatewayAbuseAnomalies.recordDataPoint(mb.startTime, ts, val, hostAttributeValue, apiAbuseSourceAttributeValue)
+}
+
+// RecordCloudflareAPIGatewayRequestsDataPoint adds a data point to cloudflare.api_gateway.requests metric.
+func (mb *MetricsBuilder) RecordCloudflareAPIGatewayRequestsDataPoint(ts pcommon.Timestamp, val int64, hostAttributeValue string, apiEndpointAttributeValue string) {
+	mb.metricCloudflareAPIGatewayRequests.recordDataPoint(mb.startTime, ts, val, hostAttributeValue, apiEndpointAttributeValue)
+}
+
+// RecordCloudflareAPIGatewaySchemaValidationFailuresDataPoint adds a data point to cloudflare.api_gateway.schema_validation_failures metric.
+func (mb *MetricsBuilder) RecordCloudflareAPIGatewaySchemaValidationFailuresDataPoint(ts pcommon.Timestamp, val int64, hostAttributeValue string) {
+	mb.metricCloudflareAPIGatewaySchemaValidationFailures.recordDataPoint(mb.startTime, ts, val, hostAttributeValue)
+}
+
+// RecordCloudflareArgoOriginResponseTimeDataPoint adds a data point to cloudflare.argo.origin_response_time metric.
+func (mb *MetricsBuilder) RecordCloudflareArgoOriginResponseTimeDataPoint(ts pcommon.Timestamp, val float64, argoRoutedAttributeValue bool) {
+	mb.metricCloudflareArgoOriginResponseTime.recordDataPoint(mb.startTime, ts, val, argoRoutedAttributeValue)
+}
+
+// RecordCloudflareArgoRequestsDataPoint adds a data point to cloudflare.argo.requests metric.
+func (mb *MetricsBuilder) RecordCloudflareArgoRequestsDataPoint(ts pcommon.Timestamp, val int64, argoRoutedAttributeValue bool) {
+	mb.metricCloudflareArgoRequests.recordDataPoint(mb.startTime, ts, val, argoRoutedAttributeValue)
+}
+
+// RecordCloudflareBotManagementRequestsDataPoint adds a data point to cloudflare.bot_management.requests metric.
+func (mb *MetricsBuilder) RecordCloudflareBotManagementRequestsDataPoint(ts pcommon.Timestamp, val int64, botScoreClassAttributeValue AttributeBotScoreClass, botScoreSourceAttributeValue string) {
+	mb.metricCloudflareBotManagementRequests.recordDataPoint(mb.startTime, ts, val, botScoreClassAttributeValue.String(), botScoreSourceAttributeValue)
+}
+
+// RecordCloudflareCacheReserveOperationsDataPoint adds a data point to cloudflare.cache_reserve.operations metric.
+func (mb *MetricsBuilder) RecordCloudflareCacheReserveOperationsDataPoint(ts pcommon.Timestamp, val int64, operationClassAttributeValue string) {
+	mb.metricCloudflareCacheReserveOperations.recordDataPoint(mb.startTime, ts, val, operationClassAttributeValue)
+}
+
+// RecordCloudflareCacheReserveStorageDataPoint adds a data point to cloudflare.cache_reserve.storage metric.
+func (mb *MetricsBuilder) RecordCloudflareCacheReserveStorageDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricCloudflareCacheReserveStorage.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCloudflareDdosAttackEventsDataPoint adds a data point to cloudflare.ddos.attack_events metric.
+func (mb *MetricsBuilder) RecordCloudflareDdosAttackEventsDataPoint(ts pcommon.Timestamp, val int64, attackVectorAttributeValue string) {
+	mb.metricCloudflareDdosAttackEvents.recordDataPoint(mb.startTime, ts, val, attackVectorAttributeValue)
+}
+
+// RecordCloudflareDdosMitigatedBitRateDataPoint adds a data point to cloudflare.ddos.mitigated_bit_rate metric.
+func (mb *MetricsBuilder) RecordCloudflareDdosMitigatedBitRateDataPoint(ts pcommon.Timestamp, val int64, attackVectorAttributeValue string) {
+	mb.metricCloudflareDdosMitigatedBitRate.recordDataPoint(mb.startTime, ts, val, attackVectorAttributeValue)
+}
+
+// RecordCloudflareDdosMitigatedPacketRateDataPoint adds a data point to cloudflare.ddos.mitigated_packet_rate metric.
+func (mb *MetricsBuilder) RecordCloudflareDdosMitigatedPacketRateDataPoint(ts pcommon.Timestamp, val int64, attackVectorAttributeValue string) {
+	mb.metricCloudflareDdosMitigatedPacketRate.recordDataPoint(mb.startTime, ts, val, attackVectorAttributeValue)
+}
+
+// RecordCloudflareDexTestAvailabilityDataPoint adds a data point to cloudflare.dex.test.availability metric.
+func (mb *MetricsBuilder) RecordCloudflareDexTestAvailabilityDataPoint(ts pcommon.Timestamp, val float64, dexTestNameAttributeValue string, dexTestTypeAttributeValue AttributeDexTestType, coloAttributeValue string) {
+	mb.metricCloudflareDexTestAvailability.recordDataPoint(mb.startTime, ts, val, dexTestNameAttributeValue, dexTestTypeAttributeValue.String(), coloAttributeValue)
+}
+
+// RecordCloudflareDexTestDurationDataPoint adds a data point to cloudflare.dex.test.duration metric.
+func (mb *MetricsBuilder) RecordCloudflareDexTestDurationDataPoint(ts pcommon.Timestamp, val float64, dexTestNameAttributeValue string, dexTestTypeAttributeValue AttributeDexTestType, coloAttributeValue string) {
+	mb.metricCloudflareDexTestDuration.recordDataPoint(mb.startTime, ts, val, dexTestNameAttributeValue, dexTestTypeAttributeValue.String(), coloAttributeValue)
+}
+
+// RecordCloudflareEmailSecurityMessagesDataPoint adds a data point to cloudflare.email_security.messages metric.
+func (mb *MetricsBuilder) RecordCloudflareEmailSecurityMessagesDataPoint(ts pcommon.Timestamp, val int64, emailDispositionAttributeValue string, emailActionAttributeValue string) {
+	mb.metricCloudflareEmailSecurityMessages.recordDataPoint(mb.startTime, ts, val, emailDispositionAttributeValue, emailActionAttributeValue)
+}
+
+// RecordCloudflareFirewallEventsDataPoint adds a data point to cloudflare.firewall.events metric.
+func (mb *MetricsBuilder) RecordCloudflareFirewallEventsDataPoint(ts pcommon.Timestamp, val int64, actionAttributeValue string, firewallSourceAttributeValue string, hostAttributeValue string, options ...MetricAttributeOption) {
+	mb.metricCloudflareFirewallEvents.recordDataPoint(mb.startTime, ts, val, actionAttributeValue, firewallSourceAttributeValue, hostAttributeValue, options...)
+}
+
+// RecordCloudflareGatewayDNSQueriesDataPoint adds a data point to cloudflare.gateway.dns.queries metric.
+func (mb *MetricsBuilder) RecordCloudflareGatewayDNSQueriesDataPoint(ts pcommon.Timestamp, val int64, gatewayDecisionAttributeValue string, gatewayCategoriesAttributeValue string, gatewayLocationAttributeValue string) {
+	mb.metricCloudflareGatewayDNSQueries.recordDataPoint(mb.startTime, ts, val, gatewayDecisionAttributeValue, gatewayCategoriesAttributeValue, gatewayLocationAttributeValue)
+}
+
+// RecordCloudflareGatewayHTTPRequestsDataPoint adds a data point to cloudflare.gateway.http.requests metric.
+func (mb *MetricsBuilder) RecordCloudflareGatewayHTTPRequestsDataPoint(ts pcommon.Timestamp, val int64, actionAttributeValue string, gatewayCategoriesAttributeValue string, userEmailAttributeValue string) {
+	mb.metricCloudflareGatewayHTTPRequests.recordDataPoint(mb.startTime, ts, val, actionAttributeValue, gatewayCategoriesAttributeValue, userEmailAttributeValue)
+}
+
+// RecordCloudflareGatewayNetworkIoDataPoint adds a data point to cloudflare.gateway.network.io metric.
+func (mb *MetricsBuilder) RecordCloudflareGatewayNetworkIoDataPoint(ts pcommon.Timestamp, val int64, actionAttributeValue string, networkTransportAttributeValue string, directionAttributeValue AttributeDirection) {
+	mb.metricCloudflareGatewayNetworkIo.recordDataPoint(mb.startTime, ts, val, actionAttributeValue, networkTransportAttributeValue, directionAttributeValue.String())
+}
+
+// RecordCloudflareGatewayNetworkSessionsDataPoint adds a data point to cloudflare.gateway.network.sessions metric.
+func (mb *MetricsBuilder) RecordCloudflareGatewayNetworkSessionsDataPoint(ts pcommon.Timestamp, val int64, actionAttributeValue string, networkTransportAttributeValue string) {
+	mb.metricCloudflareGatewayNetworkSessions.recordDataPoint(mb.startTime, ts, val, actionAttributeValue, networkTransportAttributeValue)
+}
+
+// RecordCloudflareHTTPBlockedRatioDataPoint adds a data point to cloudflare.http.blocked_ratio metric.
+func (mb *MetricsBuilder) RecordCloudflareHTTPBlockedRatioDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricCloudflareHTTPBlockedRatio.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCloudflareHTTPCacheHitRatioDataPoint adds a data point to cloudflare.http.cache_hit_ratio metric.
+func (mb *MetricsBuilder) RecordCloudflareHTTPCacheHitRatioDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricCloudflareHTTPCacheHitRatio.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCloudflareHTTPOriginErrorRatioDataPoint adds a data point to cloudflare.http.origin_error_ratio metric.
+func (mb *MetricsBuilder) RecordCloudflareHTTPOriginErrorRatioDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricCloudflareHTTPOriginErrorRatio.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCloudflareHTTPRequestsDataPoint adds a data point to cloudflare.http.requests metric.
+func (mb *MetricsBuilder) RecordCloudflareHTTPRequestsDataPoint(ts pcommon.Timestamp, val int64, statusClassAttributeValue string, actionAttributeValue string, hostAttributeValue string, options ...MetricAttributeOption) {
+	mb.metricCloudflareHTTPRequests.recordDataPoint(mb.startTime, ts, val, statusClassAttributeValue, actionAttributeValue, hostAttributeValue, options...)
+}
+
+// RecordCloudflareHTTPUniqueVisitorsDataPoint adds a data point to cloudflare.http.unique_visitors metric.
+func (mb *MetricsBuilder) RecordCloudflareHTTPUniqueVisitorsDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricCloudflareHTTPUniqueVisitors.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCloudflareHyperdriveOriginLatencyDataPoint adds a data point to cloudflare.hyperdrive.origin_latency metric.
+func (mb *MetricsBuilder) RecordCloudflareHyperdriveOriginLatencyDataPoint(ts pcommon.Timestamp, val float64, hyperdriveConfigIDAttributeValue string, quantileAttributeValue AttributeQuantile) {
+	mb.metricCloudflareHyperdriveOriginLatency.recordDataPoint(mb.startTime, ts, val, hyperdriveConfigIDAttributeValue, quantileAttributeValue.String())
+}
+
+// RecordCloudflareHyperdriveQueriesDataPoint adds a data point to cloudflare.hyperdrive.queries metric.
+func (mb *MetricsBuilder) RecordCloudflareHyperdriveQueriesDataPoint(ts pcommon.Timestamp, val int64, hyperdriveConfigIDAttributeValue string, cacheStatusAttributeValue string) {
+	mb.metricCloudflareHyperdriveQueries.recordDataPoint(mb.startTime, ts, val, hyperdriveConfigIDAttributeValue, cacheStatusAttributeValue)
+}
+
+// RecordCloudflareImagesRequestsDataPoint adds a data point to cloudflare.images.requests metric.
+func (mb *MetricsBuilder) RecordCloudflareImagesRequestsDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricCloudflareImagesRequests.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCloudflareImagesStoredDataPoint adds a data point to cloudflare.images.stored metric.
+func (mb *MetricsBuilder) RecordCloudflareImagesStoredDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricCloudflareImagesStored.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCloudflareImagesTransformationsDataPoint adds a data point to cloudflare.images.transformations metric.
+func (mb *MetricsBuilder) RecordCloudflareImagesTransformationsDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricCloudflareImagesTransformations.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCloudflareLogpushJobEnabledDataPoint adds a data point to cloudflare.logpush.job.enabled metric.
+func (mb *MetricsBuilder) RecordCloudflareLogpushJobEnabledDataPoint(ts pcommon.Timestamp, val int64, jobIDAttributeValue int64, jobNameAttributeValue string, datasetAttributeValue string) {
+	mb.metricCloudflareLogpushJobEnabled.recordDataPoint(mb.startTime, ts, val, jobIDAttributeValue, jobNameAttributeValue, datasetAttributeValue)
+}
+
+// RecordCloudflareLogpushJobErrorsDataPoint adds a data point to cloudflare.logpush.job.errors metric.
+func (mb *MetricsBuilder) RecordCloudflareLogpushJobErrorsDataPoint(ts pcommon.Timestamp, val int64, jobIDAttributeValue int64, jobNameAttributeValue string, datasetAttributeValue string) {
+	mb.metricCloudflareLogpushJobErrors.recordDataPoint(mb.startTime, ts, val, jobIDAttributeValue, jobNameAttributeValue, datasetAttributeValue)
+}
+
+// RecordCloudflareLogpushJobLastCompleteDataPoint adds a data point to cloudflare.logpush.job.last_complete metric.
+func (mb *MetricsBuilder) RecordCloudflareLogpushJobLastCompleteDataPoint(ts pcommon.Timestamp, val int64, jobIDAttributeValue int64, jobNameAttributeValue string, datasetAttributeValue string) {
+	mb.metricCloudflareLogpushJobLastComplete.recordDataPoint(mb.startTime, ts, val, jobIDAttributeValue, jobNameAttributeValue, datasetAttributeValue)
+}
+
+// RecordCloudflareLogpushJobLastErrorDataPoint adds a data point to cloudflare.logpush.job.last_error metric.
+func (mb *MetricsBuilder) RecordCloudflareLogpushJobLastErrorDataPoint(ts pcommon.Timestamp, val int64, jobIDAttributeValue int64, jobNameAttributeValue string, datasetAttributeValue string) {
+	mb.metricCloudflareLogpushJobLastError.recordDataPoint(mb.startTime, ts, val, jobIDAttributeValue, jobNameAttributeValue, datasetAttributeValue)
+}
+
+// RecordCloudflareLogpushRecordsDataPoint adds a data point to cloudflare.logpush.records metric.
+func (mb *MetricsBuilder) RecordCloudflareLogpushRecordsDataPoint(ts pcommon.Timestamp, val int64, datasetAttributeValue string, statusClassAttributeValue string, actionAttributeValue string, hostAttributeValue string) {
+	mb.metricCloudflareLogpushRecords.recordDataPoint(mb.startTime, ts, val, datasetAttributeValue, statusClassAttributeValue, actionAttributeValue, hostAttributeValue)
+}
+
+// RecordCloudflarePageShieldViolationsDataPoint adds a data point to cloudflare.page_shield.violations metric.
+func (mb *MetricsBuilder) RecordCloudflarePageShieldViolationsDataPoint(ts pcommon.Timestamp, val int64, hostAttributeValue string, directiveAttributeValue string) {
+	mb.metricCloudflarePageShieldViolations.recordDataPoint(mb.startTime, ts, val, hostAttributeValue, directiveAttributeValue)
+}
+
+// RecordCloudflarePagesFunctionsCPUTimeDataPoint adds a data point to cloudflare.pages.functions.cpu_time metric.
+func (mb *MetricsBuilder) RecordCloudflarePagesFunctionsCPUTimeDataPoint(ts pcommon.Timestamp, val float64, pagesProjectAttributeValue string, quantileAttributeValue AttributeQuantile) {
+	mb.metricCloudflarePagesFunctionsCPUTime.recordDataPoint(mb.startTime, ts, val, pagesProjectAttributeValue, quantileAttributeValue.String())
+}
+
+// RecordCloudflarePagesFunctionsErrorsDataPoint adds a data point to cloudflare.pages.functions.errors metric.
+func (mb *MetricsBuilder) RecordCloudflarePagesFunctionsErrorsDataPoint(ts pcommon.Timestamp, val int64, pagesProjectAttributeValue string) {
+	mb.metricCloudflarePagesFunctionsErrors.recordDataPoint(mb.startTime, ts, val, pagesProjectAttributeValue)
+}
+
+// RecordCloudflarePagesFunctionsRequestsDataPoint adds a data point to cloudflare.pages.functions.requests metric.
+func (mb *MetricsBuilder) RecordCloudflarePagesFunctionsRequestsDataPoint(ts pcommon.Timestamp, val int64, pagesProjectAttributeValue string) {
+	mb.metricCloudflarePagesFunctionsRequests.recordDataPoint(mb.startTime, ts, val, pagesProjectAttributeValue)
+}
+
+// RecordCloudflareRateLimitingRequestsDataPoint adds a data point to cloudflare.rate_limiting.requests metric.
+func (mb *MetricsBuilder) RecordCloudflareRateLimitingRequestsDataPoint(ts pcommon.Timestamp, val int64, ruleIDAttributeValue string, actionAttributeValue string) {
+	mb.metricCloudflareRateLimitingRequests.recordDataPoint(mb.startTime, ts, val, ruleIDAttributeValue, actionAttributeValue)
+}
+
+// RecordCloudflareStreamMinutesViewedDataPoint adds a data point to cloudflare.stream.minutes_viewed metric.
+func (mb *MetricsBuilder) RecordCloudflareStreamMinutesViewedDataPoint(ts pcommon.Timestamp, val int64, videoUIDAttributeValue string) {
+	mb.metricCloudflareStreamMinutesViewed.recordDataPoint(mb.startTime, ts, val, videoUIDAttributeValue)
+}
+
+// RecordCloudflareStreamStorageDataPoint adds a data point to cloudflare.stream.storage metric.
+func (mb *MetricsBuilder) RecordCloudflareStreamStorageDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricCloudflareStreamStorage.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordCloudflareStreamViewersDataPoint adds a data point to cloudflare.stream.viewers metric.
+func (mb *MetricsBuilder) RecordCloudflareStreamViewersDataPoint(ts pcommon.Timestamp, val int64, videoUIDAttributeValue string) {
+	mb.metricCloudflareStreamViewers.recordDataPoint(mb.startTime, ts, val, videoUIDAttributeValue)
+}
+
+// RecordCloudflareTurnstileChallengesDataPoint adds a data point to cloudflare.turnstile.challenges metric.
+func (mb *MetricsBuilder) RecordCloudflareTurnstileChallengesDataPoint(ts pcommon.Timestamp, val int64, turnstileSitekeyAttributeValue string, turnstileEventAttributeValue string) {
+	mb.metricCloudflareTurnstileChallenges.recordDataPoint(mb.startTime, ts, val, turnstileSitekeyAttributeValue, turnstileEventAttributeValue)
+}
+
+// RecordCloudflareWaitingRoomAcceptedUsersDataPoint adds a data point to cloudflare.waiting_room.accepted_users metric.
+func (mb *MetricsBuilder) RecordCloudflareWaitingRoomAcceptedUsersDataPoint(ts pcommon.Timestamp, val int64, waitingRoomIDAttributeValue string) {
+	mb.metricCloudflareWaitingRoomAcceptedUsers.recordDataPoint(mb.startTime, ts, val, waitingRoomIDAttributeValue)
+}
+
+// RecordCloudflareWaitingRoomActiveUsersDataPoint adds a data point to cloudflare.waiting_room.active_users metric.
+func (mb *MetricsBuilder) RecordCloudflareWaitingRoomActiveUsersDataPoint(ts pcommon.Timestamp, val int64, waitingRoomIDAttributeValue string) {
+	mb.metricCloudflareWaitingRoomActiveUsers.recordDataPoint(mb.startTime, ts, val, waitingRoomIDAttributeValue)
+}
+
+// RecordCloudflareWaitingRoomEstimatedWaitTimeDataPoint adds a data point to cloudflare.waiting_room.estimated_wait_time metric.
+func (mb *MetricsBuilder) RecordCloudflareWaitingRoomEstimatedWaitTimeDataPoint(ts pcommon.Timestamp, val int64, waitingRoomIDAttributeValue string) {
+	mb.metricCloudflareWaitingRoomEstimatedWaitTime.recordDataPoint(mb.startTime, ts, val, waitingRoomIDAttributeValue)
+}
+
+// RecordCloudflareWaitingRoomQueuedUsersDataPoint adds a data point to cloudflare.waiting_room.queued_users metric.
+func (mb *MetricsBuilder) RecordCloudflareWaitingRoomQueuedUsersDataPoint(ts pcommon.Timestamp, val int64, waitingRoomIDAttributeValue string) {
+	mb.metricCloudflareWaitingRoomQueuedUsers.recordDataPoint(mb.startTime, ts, val, waitingRoomIDAttributeValue)
+}
+
+// RecordCloudflareWarpDevicesDataPoint adds a data point to cloudflare.warp.devices metric.
+func (mb *MetricsBuilder) RecordCloudflareWarpDevicesDataPoint(ts pcommon.Timestamp, val int64, warpStatusAttributeValue string, osTypeAttributeValue string, osVersionAttributeValue string, warpVersionAttributeValue string) {
+	mb.metricCloudflareWarpDevices.recordDataPoint(mb.startTime, ts, val, warpStatusAttributeValue, osTypeAttributeValue, osVersionAttributeValue, warpVersionAttributeValue)
+}
+
+// RecordCloudflareWorkersCPUTimeDataPoint adds a data point to cloudflare.workers.cpu_time metric.
+func (mb *MetricsBuilder) RecordCloudflareWorkersCPUTimeDataPoint(ts pcommon.Timestamp, val float64, scriptNameAttributeValue string, quantileAttributeValue AttributeQuantile) {
+	mb.metricCloudflareWorkersCPUTime.recordDataPoint(mb.startTime, ts, val, scriptNameAttributeValue, quantileAttributeValue.String())
+}
+
+// RecordCloudflareWorkersErrorsDataPoint adds a data point to cloudflare.workers.errors metric.
+func (mb *MetricsBuilder) RecordCloudflareWorkersErrorsDataPoint(ts pcommon.Timestamp, val int64, scriptNameAttributeValue string) {
+	mb.metricCloudflareWorkersErrors.recordDataPoint(mb.startTime, ts, val, scriptNameAttributeValue)
+}
+
+// RecordCloudflareWorkersRequestsDataPoint adds a data point to cloudflare.workers.requests metric.
+func (mb *MetricsBuilder) RecordCloudflareWorkersRequestsDataPoint(ts pcommon.Timestamp, val int64, scriptNameAttributeValue string) {
+	mb.metricCloudflareWorkersRequests.recordDataPoint(mb.startTime, ts, val, scriptNameAttributeValue)
+}
+
+// RecordCloudflareWorkersAiInferenceTimeDataPoint adds a data point to cloudflare.workers_ai.inference_time metric.
+func (mb *MetricsBuilder) RecordCloudflareWorkersAiInferenceTimeDataPoint(ts pcommon.Timestamp, val float64, aiModelAttributeValue string, quantileAttributeValue AttributeQuantile) {
+	mb.metricCloudflareWorkersAiInferenceTime.recordDataPoint(mb.startTime, ts, val, aiModelAttributeValue, quantileAttributeValue.String())
+}
+
+// RecordCloudflareWorkersAiNeuronsDataPoint adds a data point to cloudflare.workers_ai.neurons metric.
+func (mb *MetricsBuilder) RecordCloudflareWorkersAiNeuronsDataPoint(ts pcommon.Timestamp, val float64, aiModelAttributeValue string) {
+	mb.metricCloudflareWorkersAiNeurons.recordDataPoint(mb.startTime, ts, val, aiModelAttributeValue)
+}
+
+// RecordCloudflareWorkersAiRequestsDataPoint adds a data point to cloudflare.workers_ai.requests metric.
+func (mb *MetricsBuilder) RecordCloudflareWorkersAiRequestsDataPoint(ts pcommon.Timestamp, val int64, aiModelAttributeValue string) {
+	mb.metricCloudflareWorkersAiRequests.recordDataPoint(mb.startTime, ts, val, aiModelAttributeValue)
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...MetricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op.apply(mb)
+	}
+}
@@ -0,0 +1,1500 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type testDataSet int
+
+const (
+	testDataSetDefault testDataSet = iota
+	testDataSetAll
+	testDataSetNone
+)
+
+func TestMetricsBuilder(t *testing.T) {
+	tests := []struct {
+		name        string
+		metricsSet  testDataSet
+		resAttrsSet testDataSet
+		expectEmpty bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:        "all_set",
+			metricsSet:  testDataSetAll,
+			resAttrsSet: testDataSetAll,
+		},
+		{
+			name:        "none_set",
+			metricsSet:  testDataSetNone,
+			resAttrsSet: testDataSetNone,
+			expectEmpty: true,
+		},
+		{
+			name:        "filter_set_include",
+			resAttrsSet: testDataSetAll,
+		},
+		{
+			name:        "filter_set_exclude",
+			resAttrsSet: testDataSetAll,
+			expectEmpty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := pcommon.Timestamp(1_000_000_000)
+			ts := pcommon.Timestamp(1_000_001_000)
+			observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+			settings := receivertest.NewNopSettings(receivertest.NopType)
+			settings.Logger = zap.New(observedZapCore)
+			mb := NewMetricsBuilder(loadMetricsBuilderConfig(t, tt.name), settings, WithStartTime(start))
+
+			expectedWarnings := 0
+
+			assert.Equal(t, expectedWarnings, observedLogs.Len())
+
+			defaultMetricsCount := 0
+			allMetricsCount := 0
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareAccessLoginsDataPoint(ts, 1, true, "access_app_uid-val", "access_identity_provider-val", "country-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareAiGatewayCachedRequestsDataPoint(ts, 1, "ai_gateway_id-val", "ai_provider-val", "ai_model-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareAiGatewayCostDataPoint(ts, 1, "ai_gateway_id-val", "ai_provider-val", "ai_model-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareAiGatewayErrorsDataPoint(ts, 1, "ai_gateway_id-val", "ai_provider-val", "ai_model-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareAiGatewayRequestsDataPoint(ts, 1, "ai_gateway_id-val", "ai_provider-val", "ai_model-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareAiGatewayTokensDataPoint(ts, 1, "ai_gateway_id-val", "ai_provider-val", "ai_model-val", AttributeTokenTypeInput)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareAPIGatewayAbuseAnomaliesDataPoint(ts, 1, "host-val", "api_abuse_source-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareAPIGatewayRequestsDataPoint(ts, 1, "host-val", "api_endpoint-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareAPIGatewaySchemaValidationFailuresDataPoint(ts, 1, "host-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareArgoOriginResponseTimeDataPoint(ts, 1, false)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareArgoRequestsDataPoint(ts, 1, false)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareBotManagementRequestsDataPoint(ts, 1, AttributeBotScoreClassAutomated, "bot_score_source-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareCacheReserveOperationsDataPoint(ts, 1, "operation_class-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareCacheReserveStorageDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareDdosAttackEventsDataPoint(ts, 1, "attack_vector-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareDdosMitigatedBitRateDataPoint(ts, 1, "attack_vector-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareDdosMitigatedPacketRateDataPoint(ts, 1, "attack_vector-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareDexTestAvailabilityDataPoint(ts, 1, "dex_test_name-val", AttributeDexTestTypeHttp, "colo-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareDexTestDurationDataPoint(ts, 1, "dex_test_name-val", AttributeDexTestTypeHttp, "colo-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareEmailSecurityMessagesDataPoint(ts, 1, "email_disposition-val", "email_action-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareFirewallEventsDataPoint(ts, 1, "action-val", "firewall_source-val", "host-val", WithClientAsnMetricAttribute(10), WithClientAsnOrganizationMetricAttribute("client_asn_organization-val"), WithPathMetricAttribute("path-val"), WithJa3MetricAttribute("ja3-val"), WithJa4MetricAttribute("ja4-val"), WithWafAttackScoreClassMetricAttribute("waf_attack_score_class-val"), WithWafSqliAttackScoreClassMetricAttribute("waf_sqli_attack_score_class-val"), WithWafXSSAttackScoreClassMetricAttribute("waf_xss_attack_score_class-val"))
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareGatewayDNSQueriesDataPoint(ts, 1, "gateway_decision-val", "gateway_categories-val", "gateway_location-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareGatewayHTTPRequestsDataPoint(ts, 1, "action-val", "gateway_categories-val", "user_email-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareGatewayNetworkIoDataPoint(ts, 1, "action-val", "network_transport-val", AttributeDirectionReceive)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareGatewayNetworkSessionsDataPoint(ts, 1, "action-val", "network_transport-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareHTTPBlockedRatioDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareHTTPCacheHitRatioDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareHTTPOriginErrorRatioDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareHTTPRequestsDataPoint(ts, 1, "status_class-val", "action-val", "host-val", WithClientAsnMetricAttribute(10), WithClientAsnOrganizationMetricAttribute("client_asn_organization-val"), WithPathMetricAttribute("path-val"), WithJa3MetricAttribute("ja3-val"), WithJa4MetricAttribute("ja4-val"), WithWafAttackScoreClassMetricAttribute("waf_attack_score_class-val"), WithWafSqliAttackScoreClassMetricAttribute("waf_sqli_attack_score_class-val"), WithWafXSSAttackScoreClassMetricAttribute("waf_xss_attack_score_class-val"))
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareHTTPUniqueVisitorsDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareHyperdriveOriginLatencyDataPoint(ts, 1, "hyperdrive_config_id-val", AttributeQuantileP50)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareHyperdriveQueriesDataPoint(ts, 1, "hyperdrive_config_id-val", "cache_status-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareImagesRequestsDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareImagesStoredDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareImagesTransformationsDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareLogpushJobEnabledDataPoint(ts, 1, 6, "job_name-val", "dataset-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareLogpushJobErrorsDataPoint(ts, 1, 6, "job_name-val", "dataset-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareLogpushJobLastCompleteDataPoint(ts, 1, 6, "job_name-val", "dataset-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareLogpushJobLastErrorDataPoint(ts, 1, 6, "job_name-val", "dataset-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareLogpushRecordsDataPoint(ts, 1, "dataset-val", "status_class-val", "action-val", "host-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflarePageShieldViolationsDataPoint(ts, 1, "host-val", "directive-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflarePagesFunctionsCPUTimeDataPoint(ts, 1, "pages_project-val", AttributeQuantileP50)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflarePagesFunctionsErrorsDataPoint(ts, 1, "pages_project-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflarePagesFunctionsRequestsDataPoint(ts, 1, "pages_project-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareRateLimitingRequestsDataPoint(ts, 1, "rule_id-val", "action-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareStreamMinutesViewedDataPoint(ts, 1, "video_uid-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareStreamStorageDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareStreamViewersDataPoint(ts, 1, "video_uid-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareTurnstileChallengesDataPoint(ts, 1, "turnstile_sitekey-val", "turnstile_event-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWaitingRoomAcceptedUsersDataPoint(ts, 1, "waiting_room_id-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWaitingRoomActiveUsersDataPoint(ts, 1, "waiting_room_id-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWaitingRoomEstimatedWaitTimeDataPoint(ts, 1, "waiting_room_id-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWaitingRoomQueuedUsersDataPoint(ts, 1, "waiting_room_id-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWarpDevicesDataPoint(ts, 1, "warp_status-val", "os_type-val", "os_version-val", "warp_version-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWorkersCPUTimeDataPoint(ts, 1, "script_name-val", AttributeQuantileP50)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWorkersErrorsDataPoint(ts, 1, "script_name-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWorkersRequestsDataPoint(ts, 1, "script_name-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWorkersAiInferenceTimeDataPoint(ts, 1, "ai_model-val", AttributeQuantileP50)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWorkersAiNeuronsDataPoint(ts, 1, "ai_model-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordCloudflareWorkersAiRequestsDataPoint(ts, 1, "ai_model-val")
+
+			rb := mb.NewResourceBuilder()
+			rb.SetCloudflareAccountID("cloudflare.account.id-val")
+			rb.SetCloudflareZoneID("cloudflare.zone.id-val")
+			rb.SetCloudflareZoneName("cloudflare.zone.name-val")
+			rb.SetCloudflareZonePlan("cloudflare.zone.plan-val")
+			res := rb.Emit()
+			metrics := mb.Emit(WithResource(res))
+
+			if tt.expectEmpty {
+				assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+				return
+			}
+
+			assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+			rm := metrics.ResourceMetrics().At(0)
+			assert.Equal(t, res, rm.Resource())
+			assert.Equal(t, 1, rm.ScopeMetrics().Len())
+			ms := rm.ScopeMetrics().At(0).Metrics()
+			if tt.metricsSet == testDataSetDefault {
+				assert.Equal(t, defaultMetricsCount, ms.Len())
+			}
+			if tt.metricsSet == testDataSetAll {
+				assert.Equal(t, allMetricsCount, ms.Len())
+			}
+			validatedMetrics := make(map[string]bool)
+			for i := 0; i < ms.Len(); i++ {
+				switch ms.At(i).Name() {
+				case "cloudflare.access.logins":
+					assert.False(t, validatedMetrics["cloudflare.access.logins"], "Found a duplicate in the metrics slice: cloudflare.access.logins")
+					validatedMetrics["cloudflare.access.logins"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of logins to Access applications during the polled window. Only emitted when the `access_logins` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{login}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.access.allowed")
+					assert.True(t, ok)
+					assert.True(t, attrVal.Bool())
+					attrVal, ok = dp.Attributes().Get("cloudflare.access.app.uid")
+					assert.True(t, ok)
+					assert.Equal(t, "access_app_uid-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.access.identity_provider")
+					assert.True(t, ok)
+					assert.Equal(t, "access_identity_provider-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("geo.country.iso_code")
+					assert.True(t, ok)
+					assert.Equal(t, "country-val", attrVal.Str())
+				case "cloudflare.ai_gateway.cached_requests":
+					assert.False(t, validatedMetrics["cloudflare.ai_gateway.cached_requests"], "Found a duplicate in the metrics slice: cloudflare.ai_gateway.cached_requests")
+					validatedMetrics["cloudflare.ai_gateway.cached_requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests answered from the cache of the AI Gateway during the polled window. Only emitted when the `ai_gateway` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.ai_gateway.id")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_gateway_id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.provider.name")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_provider-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.request.model")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_model-val", attrVal.Str())
+				case "cloudflare.ai_gateway.cost":
+					assert.False(t, validatedMetrics["cloudflare.ai_gateway.cost"], "Found a duplicate in the metrics slice: cloudflare.ai_gateway.cost")
+					validatedMetrics["cloudflare.ai_gateway.cost"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The estimated cost of the requests proxied by the AI Gateway during the polled window. Only emitted when the `ai_gateway` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{USD}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("cloudflare.ai_gateway.id")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_gateway_id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.provider.name")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_provider-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.request.model")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_model-val", attrVal.Str())
+				case "cloudflare.ai_gateway.errors":
+					assert.False(t, validatedMetrics["cloudflare.ai_gateway.errors"], "Found a duplicate in the metrics slice: cloudflare.ai_gateway.errors")
+					validatedMetrics["cloudflare.ai_gateway.errors"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests proxied by the AI Gateway that failed during the polled window. Only emitted when the `ai_gateway` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.ai_gateway.id")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_gateway_id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.provider.name")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_provider-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.request.model")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_model-val", attrVal.Str())
+				case "cloudflare.ai_gateway.requests":
+					assert.False(t, validatedMetrics["cloudflare.ai_gateway.requests"], "Found a duplicate in the metrics slice: cloudflare.ai_gateway.requests")
+					validatedMetrics["cloudflare.ai_gateway.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests proxied by the AI Gateway during the polled window. Only emitted when the `ai_gateway` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.ai_gateway.id")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_gateway_id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.provider.name")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_provider-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.request.model")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_model-val", attrVal.Str())
+				case "cloudflare.ai_gateway.tokens":
+					assert.False(t, validatedMetrics["cloudflare.ai_gateway.tokens"], "Found a duplicate in the metrics slice: cloudflare.ai_gateway.tokens")
+					validatedMetrics["cloudflare.ai_gateway.tokens"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of tokens sent to and generated by the models during the polled window. Only emitted when the `ai_gateway` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{token}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.ai_gateway.id")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_gateway_id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.provider.name")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_provider-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.request.model")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_model-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("gen_ai.token.type")
+					assert.True(t, ok)
+					assert.Equal(t, "input", attrVal.Str())
+				case "cloudflare.api_gateway.abuse_anomalies":
+					assert.False(t, validatedMetrics["cloudflare.api_gateway.abuse_anomalies"], "Found a duplicate in the metrics slice: cloudflare.api_gateway.abuse_anomalies")
+					validatedMetrics["cloudflare.api_gateway.abuse_anomalies"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests flagged as abusive by the sequence and volumetric abuse detections of API Gateway during the polled window. Only emitted when the `api_gateway` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("server.address")
+					assert.True(t, ok)
+					assert.Equal(t, "host-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.api_gateway.abuse_source")
+					assert.True(t, ok)
+					assert.Equal(t, "api_abuse_source-val", attrVal.Str())
+				case "cloudflare.api_gateway.requests":
+					assert.False(t, validatedMetrics["cloudflare.api_gateway.requests"], "Found a duplicate in the metrics slice: cloudflare.api_gateway.requests")
+					validatedMetrics["cloudflare.api_gateway.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests that matched an endpoint of API Gateway during the polled window. Only emitted when the `api_gateway` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("server.address")
+					assert.True(t, ok)
+					assert.Equal(t, "host-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.api_gateway.endpoint")
+					assert.True(t, ok)
+					assert.Equal(t, "api_endpoint-val", attrVal.Str())
+				case "cloudflare.api_gateway.schema_validation_failures":
+					assert.False(t, validatedMetrics["cloudflare.api_gateway.schema_validation_failures"], "Found a duplicate in the metrics slice: cloudflare.api_gateway.schema_validation_failures")
+					validatedMetrics["cloudflare.api_gateway.schema_validation_failures"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests that failed the schema validation of API Gateway during the polled window. Only emitted when the `api_gateway` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("server.address")
+					assert.True(t, ok)
+					assert.Equal(t, "host-val", attrVal.Str())
+				case "cloudflare.argo.origin_response_time":
+					assert.False(t, validatedMetrics["cloudflare.argo.origin_response_time"], "Found a duplicate in the metrics slice: cloudflare.argo.origin_response_time")
+					validatedMetrics["cloudflare.argo.origin_response_time"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The average time the origin took to respond during the polled window, by whether Argo Smart Routing routed the requests, comparing both quantifying the improvement of Argo. Only emitted when the `argo` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("cloudflare.argo.routed")
+					assert.True(t, ok)
+					assert.False(t, attrVal.Bool())
+				case "cloudflare.argo.requests":
+					assert.False(t, validatedMetrics["cloudflare.argo.requests"], "Found a duplicate in the metrics slice: cloudflare.argo.requests")
+					validatedMetrics["cloudflare.argo.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests sent to the origin during the polled window, by whether Argo Smart Routing routed them. Only emitted when the `argo` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.argo.routed")
+					assert.True(t, ok)
+					assert.False(t, attrVal.Bool())
+				case "cloudflare.bot_management.requests":
+					assert.False(t, validatedMetrics["cloudflare.bot_management.requests"], "Found a duplicate in the metrics slice: cloudflare.bot_management.requests")
+					validatedMetrics["cloudflare.bot_management.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests scored by Bot Management during the polled window, by class of bot score. Only emitted when the `bot_management` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.bot_management.score_class")
+					assert.True(t, ok)
+					assert.Equal(t, "automated", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.bot_management.score_source")
+					assert.True(t, ok)
+					assert.Equal(t, "bot_score_source-val", attrVal.Str())
+				case "cloudflare.cache_reserve.operations":
+					assert.False(t, validatedMetrics["cloudflare.cache_reserve.operations"], "Found a duplicate in the metrics slice: cloudflare.cache_reserve.operations")
+					validatedMetrics["cloudflare.cache_reserve.operations"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of Cache Reserve operations during the polled window, by billing class. Only emitted when the `cache_reserve` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{operation}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.cache_reserve.operation_class")
+					assert.True(t, ok)
+					assert.Equal(t, "operation_class-val", attrVal.Str())
+				case "cloudflare.cache_reserve.storage":
+					assert.False(t, validatedMetrics["cloudflare.cache_reserve.storage"], "Found a duplicate in the metrics slice: cloudflare.cache_reserve.storage")
+					validatedMetrics["cloudflare.cache_reserve.storage"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The peak number of bytes stored in Cache Reserve during the polled window. Only emitted when the `cache_reserve` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "By", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "cloudflare.ddos.attack_events":
+					assert.False(t, validatedMetrics["cloudflare.ddos.attack_events"], "Found a duplicate in the metrics slice: cloudflare.ddos.attack_events")
+					validatedMetrics["cloudflare.ddos.attack_events"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of DDoS attack events mitigated during the polled window. Only emitted when the `ddos` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{event}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.ddos.attack_vector")
+					assert.True(t, ok)
+					assert.Equal(t, "attack_vector-val", attrVal.Str())
+				case "cloudflare.ddos.mitigated_bit_rate":
+					assert.False(t, validatedMetrics["cloudflare.ddos.mitigated_bit_rate"], "Found a duplicate in the metrics slice: cloudflare.ddos.mitigated_bit_rate")
+					validatedMetrics["cloudflare.ddos.mitigated_bit_rate"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The peak rate of the DDoS attack traffic mitigated during the polled window. Only emitted when the `ddos` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "bit/s", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.ddos.attack_vector")
+					assert.True(t, ok)
+					assert.Equal(t, "attack_vector-val", attrVal.Str())
+				case "cloudflare.ddos.mitigated_packet_rate":
+					assert.False(t, validatedMetrics["cloudflare.ddos.mitigated_packet_rate"], "Found a duplicate in the metrics slice: cloudflare.ddos.mitigated_packet_rate")
+					validatedMetrics["cloudflare.ddos.mitigated_packet_rate"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The peak rate of the DDoS attack packets mitigated during the polled window. Only emitted when the `ddos` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{packet}/s", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.ddos.attack_vector")
+					assert.True(t, ok)
+					assert.Equal(t, "attack_vector-val", attrVal.Str())
+				case "cloudflare.dex.test.availability":
+					assert.False(t, validatedMetrics["cloudflare.dex.test.availability"], "Found a duplicate in the metrics slice: cloudflare.dex.test.availability")
+					validatedMetrics["cloudflare.dex.test.availability"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The share of the runs of the Digital Experience Monitoring test that succeeded during the polled window. Only emitted when the `dex` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("cloudflare.dex.test.name")
+					assert.True(t, ok)
+					assert.Equal(t, "dex_test_name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.dex.test.type")
+					assert.True(t, ok)
+					assert.Equal(t, "http", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.colo.code")
+					assert.True(t, ok)
+					assert.Equal(t, "colo-val", attrVal.Str())
+				case "cloudflare.dex.test.duration":
+					assert.False(t, validatedMetrics["cloudflare.dex.test.duration"], "Found a duplicate in the metrics slice: cloudflare.dex.test.duration")
+					validatedMetrics["cloudflare.dex.test.duration"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The average time the Digital Experience Monitoring test took to fetch the resource, or the average round-trip time of the traceroute tests, during the polled window. Only emitted when the `dex` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("cloudflare.dex.test.name")
+					assert.True(t, ok)
+					assert.Equal(t, "dex_test_name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.dex.test.type")
+					assert.True(t, ok)
+					assert.Equal(t, "http", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.colo.code")
+					assert.True(t, ok)
+					assert.Equal(t, "colo-val", attrVal.Str())
+				case "cloudflare.email_security.messages":
+					assert.False(t, validatedMetrics["cloudflare.email_security.messages"], "Found a duplicate in the metrics slice: cloudflare.email_security.messages")
+					validatedMetrics["cloudflare.email_security.messages"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of messages processed by Email Security during the polled window, by disposition and action. Only emitted when the `email_security` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{message}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.email_security.disposition")
+					assert.True(t, ok)
+					assert.Equal(t, "email_disposition-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.email_security.action")
+					assert.True(t, ok)
+					assert.Equal(t, "email_action-val", attrVal.Str())
+				case "cloudflare.firewall.events":
+					assert.False(t, validatedMetrics["cloudflare.firewall.events"], "Found a duplicate in the metrics slice: cloudflare.firewall.events")
+					validatedMetrics["cloudflare.firewall.events"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of firewall events of the zone during the polled window, by action, security product and host. Only emitted when the `firewall_events` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{event}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.action")
+					assert.True(t, ok)
+					assert.Equal(t, "action-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.firewall.source")
+					assert.True(t, ok)
+					assert.Equal(t, "firewall_source-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("server.address")
+					assert.True(t, ok)
+					assert.Equal(t, "host-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.client.asn")
+					assert.True(t, ok)
+					assert.EqualValues(t, 10, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("cloudflare.client.asn.organization")
+					assert.True(t, ok)
+					assert.Equal(t, "client_asn_organization-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("url.path")
+					assert.True(t, ok)
+					assert.Equal(t, "path-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.client.ja3")
+					assert.True(t, ok)
+					assert.Equal(t, "ja3-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.client.ja4")
+					assert.True(t, ok)
+					assert.Equal(t, "ja4-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.waf.attack_score_class")
+					assert.True(t, ok)
+					assert.Equal(t, "waf_attack_score_class-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.waf.sqli_attack_score_class")
+					assert.True(t, ok)
+					assert.Equal(t, "waf_sqli_attack_score_class-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.waf.xss_attack_score_class")
+					assert.True(t, ok)
+					assert.Equal(t, "waf_xss_attack_score_class-val", attrVal.Str())
+				case "cloudflare.gateway.dns.queries":
+					assert.False(t, validatedMetrics["cloudflare.gateway.dns.queries"], "Found a duplicate in the metrics slice: cloudflare.gateway.dns.queries")
+					validatedMetrics["cloudflare.gateway.dns.queries"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of DNS queries resolved by Gateway during the polled window. Only emitted when the `gateway_dns` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{query}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.gateway.decision")
+					assert.True(t, ok)
+					assert.Equal(t, "gateway_decision-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.gateway.categories")
+					assert.True(t, ok)
+					assert.Equal(t, "gateway_categories-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.gateway.location")
+					assert.True(t, ok)
+					assert.Equal(t, "gateway_location-val", attrVal.Str())
+				case "cloudflare.gateway.http.requests":
+					assert.False(t, validatedMetrics["cloudflare.gateway.http.requests"], "Found a duplicate in the metrics slice: cloudflare.gateway.http.requests")
+					validatedMetrics["cloudflare.gateway.http.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of HTTP requests filtered by Gateway during the polled window. Only emitted when the `gateway_http` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.action")
+					assert.True(t, ok)
+					assert.Equal(t, "action-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.gateway.categories")
+					assert.True(t, ok)
+					assert.Equal(t, "gateway_categories-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("user.email")
+					assert.True(t, ok)
+					assert.Equal(t, "user_email-val", attrVal.Str())
+				case "cloudflare.gateway.network.io":
+					assert.False(t, validatedMetrics["cloudflare.gateway.network.io"], "Found a duplicate in the metrics slice: cloudflare.gateway.network.io")
+					validatedMetrics["cloudflare.gateway.network.io"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of bytes of the network sessions filtered by Gateway during the polled window. Only emitted when the `gateway_network` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "By", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.action")
+					assert.True(t, ok)
+					assert.Equal(t, "action-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("network.transport")
+					assert.True(t, ok)
+					assert.Equal(t, "network_transport-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("network.io.direction")
+					assert.True(t, ok)
+					assert.Equal(t, "receive", attrVal.Str())
+				case "cloudflare.gateway.network.sessions":
+					assert.False(t, validatedMetrics["cloudflare.gateway.network.sessions"], "Found a duplicate in the metrics slice: cloudflare.gateway.network.sessions")
+					validatedMetrics["cloudflare.gateway.network.sessions"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of network sessions filtered by Gateway during the polled window. Only emitted when the `gateway_network` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{session}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.action")
+					assert.True(t, ok)
+					assert.Equal(t, "action-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("network.transport")
+					assert.True(t, ok)
+					assert.Equal(t, "network_transport-val", attrVal.Str())
+				case "cloudflare.http.blocked_ratio":
+					assert.False(t, validatedMetrics["cloudflare.http.blocked_ratio"], "Found a duplicate in the metrics slice: cloudflare.http.blocked_ratio")
+					validatedMetrics["cloudflare.http.blocked_ratio"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The share of the requests of the zone whose security action is block during the polled window. Only emitted when `analytics.derive_ratios` is enabled.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "cloudflare.http.cache_hit_ratio":
+					assert.False(t, validatedMetrics["cloudflare.http.cache_hit_ratio"], "Found a duplicate in the metrics slice: cloudflare.http.cache_hit_ratio")
+					validatedMetrics["cloudflare.http.cache_hit_ratio"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The share of the requests of the zone with a cache status that were served from the Cloudflare cache during the polled window. Only emitted when `analytics.derive_ratios` is enabled.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "cloudflare.http.origin_error_ratio":
+					assert.False(t, validatedMetrics["cloudflare.http.origin_error_ratio"], "Found a duplicate in the metrics slice: cloudflare.http.origin_error_ratio")
+					validatedMetrics["cloudflare.http.origin_error_ratio"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The share of the requests of the zone sent to the origin that the origin answered with a 5xx status during the polled window. Only emitted when `analytics.derive_ratios` is enabled.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "cloudflare.http.requests":
+					assert.False(t, validatedMetrics["cloudflare.http.requests"], "Found a duplicate in the metrics slice: cloudflare.http.requests")
+					validatedMetrics["cloudflare.http.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests of the zone during the polled window, by class of the status code returned by the edge, security action and host. Only emitted when the `http_requests` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.edge.response.status_class")
+					assert.True(t, ok)
+					assert.Equal(t, "status_class-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.action")
+					assert.True(t, ok)
+					assert.Equal(t, "action-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("server.address")
+					assert.True(t, ok)
+					assert.Equal(t, "host-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.client.asn")
+					assert.True(t, ok)
+					assert.EqualValues(t, 10, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("cloudflare.client.asn.organization")
+					assert.True(t, ok)
+					assert.Equal(t, "client_asn_organization-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("url.path")
+					assert.True(t, ok)
+					assert.Equal(t, "path-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.client.ja3")
+					assert.True(t, ok)
+					assert.Equal(t, "ja3-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.client.ja4")
+					assert.True(t, ok)
+					assert.Equal(t, "ja4-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.waf.attack_score_class")
+					assert.True(t, ok)
+					assert.Equal(t, "waf_attack_score_class-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.waf.sqli_attack_score_class")
+					assert.True(t, ok)
+					assert.Equal(t, "waf_sqli_attack_score_class-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.waf.xss_attack_score_class")
+					assert.True(t, ok)
+					assert.Equal(t, "waf_xss_attack_score_class-val", attrVal.Str())
+				case "cloudflare.http.unique_visitors":
+					assert.False(t, validatedMetrics["cloudflare.http.unique_visitors"], "Found a duplicate in the metrics slice: cloudflare.http.unique_visitors")
+					validatedMetrics["cloudflare.http.unique_visitors"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The number of distinct client IP addresses of the requests of the zone during the polled window, like the unique visitors of the Cloudflare dashboard. Only emitted when the `http_requests` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{visitor}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "cloudflare.hyperdrive.origin_latency":
+					assert.False(t, validatedMetrics["cloudflare.hyperdrive.origin_latency"], "Found a duplicate in the metrics slice: cloudflare.hyperdrive.origin_latency")
+					validatedMetrics["cloudflare.hyperdrive.origin_latency"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The quantiles of the time the origin database took to answer the queries of the Hyperdrive configuration during the polled window. Only emitted when the `hyperdrive` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("cloudflare.hyperdrive.config.id")
+					assert.True(t, ok)
+					assert.Equal(t, "hyperdrive_config_id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.quantile")
+					assert.True(t, ok)
+					assert.Equal(t, "p50", attrVal.Str())
+				case "cloudflare.hyperdrive.queries":
+					assert.False(t, validatedMetrics["cloudflare.hyperdrive.queries"], "Found a duplicate in the metrics slice: cloudflare.hyperdrive.queries")
+					validatedMetrics["cloudflare.hyperdrive.queries"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of queries sent through the Hyperdrive configuration during the polled window, by cache status. Only emitted when the `hyperdrive` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{query}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.hyperdrive.config.id")
+					assert.True(t, ok)
+					assert.Equal(t, "hyperdrive_config_id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.cache_status")
+					assert.True(t, ok)
+					assert.Equal(t, "cache_status-val", attrVal.Str())
+				case "cloudflare.images.requests":
+					assert.False(t, validatedMetrics["cloudflare.images.requests"], "Found a duplicate in the metrics slice: cloudflare.images.requests")
+					validatedMetrics["cloudflare.images.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests for images served by Cloudflare Images during the polled window. Only emitted when the `images` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "cloudflare.images.stored":
+					assert.False(t, validatedMetrics["cloudflare.images.stored"], "Found a duplicate in the metrics slice: cloudflare.images.stored")
+					validatedMetrics["cloudflare.images.stored"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The peak number of images stored in Cloudflare Images during the polled window. Only emitted when the `images` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{image}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "cloudflare.images.transformations":
+					assert.False(t, validatedMetrics["cloudflare.images.transformations"], "Found a duplicate in the metrics slice: cloudflare.images.transformations")
+					validatedMetrics["cloudflare.images.transformations"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of unique transformations of images by Cloudflare Images during the polled window. Only emitted when the `images` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{transformation}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "cloudflare.logpush.job.enabled":
+					assert.False(t, validatedMetrics["cloudflare.logpush.job.enabled"], "Found a duplicate in the metrics slice: cloudflare.logpush.job.enabled")
+					validatedMetrics["cloudflare.logpush.job.enabled"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Whether the Logpush job is enabled (1) or disabled (0).", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.logpush.job.id")
+					assert.True(t, ok)
+					assert.EqualValues(t, 6, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("cloudflare.logpush.job.name")
+					assert.True(t, ok)
+					assert.Equal(t, "job_name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.logpush.dataset")
+					assert.True(t, ok)
+					assert.Equal(t, "dataset-val", attrVal.Str())
+				case "cloudflare.logpush.job.errors":
+					assert.False(t, validatedMetrics["cloudflare.logpush.job.errors"], "Found a duplicate in the metrics slice: cloudflare.logpush.job.errors")
+					validatedMetrics["cloudflare.logpush.job.errors"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of new Logpush job failures observed since the receiver started.", ms.At(i).Description())
+					assert.Equal(t, "{error}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.logpush.job.id")
+					assert.True(t, ok)
+					assert.EqualValues(t, 6, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("cloudflare.logpush.job.name")
+					assert.True(t, ok)
+					assert.Equal(t, "job_name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.logpush.dataset")
+					assert.True(t, ok)
+					assert.Equal(t, "dataset-val", attrVal.Str())
+				case "cloudflare.logpush.job.last_complete":
+					assert.False(t, validatedMetrics["cloudflare.logpush.job.last_complete"], "Found a duplicate in the metrics slice: cloudflare.logpush.job.last_complete")
+					validatedMetrics["cloudflare.logpush.job.last_complete"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Unix timestamp of the last time the Logpush job successfully pushed logs.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.logpush.job.id")
+					assert.True(t, ok)
+					assert.EqualValues(t, 6, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("cloudflare.logpush.job.name")
+					assert.True(t, ok)
+					assert.Equal(t, "job_name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.logpush.dataset")
+					assert.True(t, ok)
+					assert.Equal(t, "dataset-val", attrVal.Str())
+				case "cloudflare.logpush.job.last_error":
+					assert.False(t, validatedMetrics["cloudflare.logpush.job.last_error"], "Found a duplicate in the metrics slice: cloudflare.logpush.job.last_error")
+					validatedMetrics["cloudflare.logpush.job.last_error"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Unix timestamp of the last time the Logpush job failed to push logs.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.logpush.job.id")
+					assert.True(t, ok)
+					assert.EqualValues(t, 6, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("cloudflare.logpush.job.name")
+					assert.True(t, ok)
+					assert.Equal(t, "job_name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.logpush.dataset")
+					assert.True(t, ok)
+					assert.Equal(t, "dataset-val", attrVal.Str())
+				case "cloudflare.logpush.records":
+					assert.False(t, validatedMetrics["cloudflare.logpush.records"], "Found a duplicate in the metrics slice: cloudflare.logpush.records")
+					validatedMetrics["cloudflare.logpush.records"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of records received by the Logpush endpoint since the receiver started. Only emitted when `logs.derive_metrics` is enabled.", ms.At(i).Description())
+					assert.Equal(t, "{record}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.logpush.dataset")
+					assert.True(t, ok)
+					assert.Equal(t, "dataset-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.edge.response.status_class")
+					assert.True(t, ok)
+					assert.Equal(t, "status_class-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.action")
+					assert.True(t, ok)
+					assert.Equal(t, "action-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("server.address")
+					assert.True(t, ok)
+					assert.Equal(t, "host-val", attrVal.Str())
+				case "cloudflare.page_shield.violations":
+					assert.False(t, validatedMetrics["cloudflare.page_shield.violations"], "Found a duplicate in the metrics slice: cloudflare.page_shield.violations")
+					validatedMetrics["cloudflare.page_shield.violations"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of violations of the Page Shield policies reported by browsers during the polled window. Only emitted when the `page_shield` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{violation}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("server.address")
+					assert.True(t, ok)
+					assert.Equal(t, "host-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.page_shield.directive")
+					assert.True(t, ok)
+					assert.Equal(t, "directive-val", attrVal.Str())
+				case "cloudflare.pages.functions.cpu_time":
+					assert.False(t, validatedMetrics["cloudflare.pages.functions.cpu_time"], "Found a duplicate in the metrics slice: cloudflare.pages.functions.cpu_time")
+					validatedMetrics["cloudflare.pages.functions.cpu_time"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The quantiles of the CPU time of the invocations of the Functions of the Pages project during the polled window. Only emitted when the `pages_functions` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "us", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("cloudflare.pages.project.name")
+					assert.True(t, ok)
+					assert.Equal(t, "pages_project-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.quantile")
+					assert.True(t, ok)
+					assert.Equal(t, "p50", attrVal.Str())
+				case "cloudflare.pages.functions.errors":
+					assert.False(t, validatedMetrics["cloudflare.pages.functions.errors"], "Found a duplicate in the metrics slice: cloudflare.pages.functions.errors")
+					validatedMetrics["cloudflare.pages.functions.errors"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of invocations of the Functions of the Pages project that failed during the polled window. Only emitted when the `pages_functions` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.pages.project.name")
+					assert.True(t, ok)
+					assert.Equal(t, "pages_project-val", attrVal.Str())
+				case "cloudflare.pages.functions.requests":
+					assert.False(t, validatedMetrics["cloudflare.pages.functions.requests"], "Found a duplicate in the metrics slice: cloudflare.pages.functions.requests")
+					validatedMetrics["cloudflare.pages.functions.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of invocations of the Functions of the Pages project during the polled window. Only emitted when the `pages_functions` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.pages.project.name")
+					assert.True(t, ok)
+					assert.Equal(t, "pages_project-val", attrVal.Str())
+				case "cloudflare.rate_limiting.requests":
+					assert.False(t, validatedMetrics["cloudflare.rate_limiting.requests"], "Found a duplicate in the metrics slice: cloudflare.rate_limiting.requests")
+					validatedMetrics["cloudflare.rate_limiting.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of requests of the zone matched by a rate limiting rule during the polled window, by rule and action. Only emitted when the `rate_limiting` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.rule.id")
+					assert.True(t, ok)
+					assert.Equal(t, "rule_id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.action")
+					assert.True(t, ok)
+					assert.Equal(t, "action-val", attrVal.Str())
+				case "cloudflare.stream.minutes_viewed":
+					assert.False(t, validatedMetrics["cloudflare.stream.minutes_viewed"], "Found a duplicate in the metrics slice: cloudflare.stream.minutes_viewed")
+					validatedMetrics["cloudflare.stream.minutes_viewed"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of minutes of the Stream video viewed during the polled window. Only emitted when the `stream` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "min", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.stream.video.uid")
+					assert.True(t, ok)
+					assert.Equal(t, "video_uid-val", attrVal.Str())
+				case "cloudflare.stream.storage":
+					assert.False(t, validatedMetrics["cloudflare.stream.storage"], "Found a duplicate in the metrics slice: cloudflare.stream.storage")
+					validatedMetrics["cloudflare.stream.storage"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The peak number of minutes of video stored in Stream during the polled window. Only emitted when the `stream` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "min", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "cloudflare.stream.viewers":
+					assert.False(t, validatedMetrics["cloudflare.stream.viewers"], "Found a duplicate in the metrics slice: cloudflare.stream.viewers")
+					validatedMetrics["cloudflare.stream.viewers"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The number of unique viewers of the Stream video during the polled window. Only emitted when the `stream` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{viewer}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.stream.video.uid")
+					assert.True(t, ok)
+					assert.Equal(t, "video_uid-val", attrVal.Str())
+				case "cloudflare.turnstile.challenges":
+					assert.False(t, validatedMetrics["cloudflare.turnstile.challenges"], "Found a duplicate in the metrics slice: cloudflare.turnstile.challenges")
+					validatedMetrics["cloudflare.turnstile.challenges"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of Turnstile challenges issued, solved or failed during the polled window, per widget. Only emitted when the `turnstile` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{challenge}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.turnstile.widget.sitekey")
+					assert.True(t, ok)
+					assert.Equal(t, "turnstile_sitekey-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.turnstile.event")
+					assert.True(t, ok)
+					assert.Equal(t, "turnstile_event-val", attrVal.Str())
+				case "cloudflare.waiting_room.accepted_users":
+					assert.False(t, validatedMetrics["cloudflare.waiting_room.accepted_users"], "Found a duplicate in the metrics slice: cloudflare.waiting_room.accepted_users")
+					validatedMetrics["cloudflare.waiting_room.accepted_users"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of users let through the waiting room to the origin during the polled window. Only emitted when the `waiting_room` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{user}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.waiting_room.id")
+					assert.True(t, ok)
+					assert.Equal(t, "waiting_room_id-val", attrVal.Str())
+				case "cloudflare.waiting_room.active_users":
+					assert.False(t, validatedMetrics["cloudflare.waiting_room.active_users"], "Found a duplicate in the metrics slice: cloudflare.waiting_room.active_users")
+					validatedMetrics["cloudflare.waiting_room.active_users"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The peak number of users active on the origin behind the waiting room during the polled window. Only emitted when the `waiting_room` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{user}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.waiting_room.id")
+					assert.True(t, ok)
+					assert.Equal(t, "waiting_room_id-val", attrVal.Str())
+				case "cloudflare.waiting_room.estimated_wait_time":
+					assert.False(t, validatedMetrics["cloudflare.waiting_room.estimated_wait_time"], "Found a duplicate in the metrics slice: cloudflare.waiting_room.estimated_wait_time")
+					validatedMetrics["cloudflare.waiting_room.estimated_wait_time"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The peak time users were estimated to wait in the queue of the waiting room during the polled window. Only emitted when the `waiting_room` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "min", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.waiting_room.id")
+					assert.True(t, ok)
+					assert.Equal(t, "waiting_room_id-val", attrVal.Str())
+				case "cloudflare.waiting_room.queued_users":
+					assert.False(t, validatedMetrics["cloudflare.waiting_room.queued_users"], "Found a duplicate in the metrics slice: cloudflare.waiting_room.queued_users")
+					validatedMetrics["cloudflare.waiting_room.queued_users"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The peak number of users queued in the waiting room during the polled window. Only emitted when the `waiting_room` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{user}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.waiting_room.id")
+					assert.True(t, ok)
+					assert.Equal(t, "waiting_room_id-val", attrVal.Str())
+				case "cloudflare.warp.devices":
+					assert.False(t, validatedMetrics["cloudflare.warp.devices"], "Found a duplicate in the metrics slice: cloudflare.warp.devices")
+					validatedMetrics["cloudflare.warp.devices"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The number of devices whose WARP client reported the status during the polled window. Devices failing to connect are reported with the Failed status. Only emitted when the `warp` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{device}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.warp.status")
+					assert.True(t, ok)
+					assert.Equal(t, "warp_status-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("os.type")
+					assert.True(t, ok)
+					assert.Equal(t, "os_type-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("os.version")
+					assert.True(t, ok)
+					assert.Equal(t, "os_version-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.warp.version")
+					assert.True(t, ok)
+					assert.Equal(t, "warp_version-val", attrVal.Str())
+				case "cloudflare.workers.cpu_time":
+					assert.False(t, validatedMetrics["cloudflare.workers.cpu_time"], "Found a duplicate in the metrics slice: cloudflare.workers.cpu_time")
+					validatedMetrics["cloudflare.workers.cpu_time"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The quantiles of the CPU time of the invocations of the Worker during the polled window. Only emitted when the `workers` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "us", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("cloudflare.worker.script.name")
+					assert.True(t, ok)
+					assert.Equal(t, "script_name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.quantile")
+					assert.True(t, ok)
+					assert.Equal(t, "p50", attrVal.Str())
+				case "cloudflare.workers.errors":
+					assert.False(t, validatedMetrics["cloudflare.workers.errors"], "Found a duplicate in the metrics slice: cloudflare.workers.errors")
+					validatedMetrics["cloudflare.workers.errors"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of invocations of the Worker that failed during the polled window. Only emitted when the `workers` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.worker.script.name")
+					assert.True(t, ok)
+					assert.Equal(t, "script_name-val", attrVal.Str())
+				case "cloudflare.workers.requests":
+					assert.False(t, validatedMetrics["cloudflare.workers.requests"], "Found a duplicate in the metrics slice: cloudflare.workers.requests")
+					validatedMetrics["cloudflare.workers.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of invocations of the Worker during the polled window. Only emitted when the `workers` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("cloudflare.worker.script.name")
+					assert.True(t, ok)
+					assert.Equal(t, "script_name-val", attrVal.Str())
+				case "cloudflare.workers_ai.inference_time":
+					assert.False(t, validatedMetrics["cloudflare.workers_ai.inference_time"], "Found a duplicate in the metrics slice: cloudflare.workers_ai.inference_time")
+					validatedMetrics["cloudflare.workers_ai.inference_time"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The quantiles of the time the inference requests took during the polled window. Only emitted when the `workers_ai` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("gen_ai.request.model")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_model-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("cloudflare.quantile")
+					assert.True(t, ok)
+					assert.Equal(t, "p50", attrVal.Str())
+				case "cloudflare.workers_ai.neurons":
+					assert.False(t, validatedMetrics["cloudflare.workers_ai.neurons"], "Found a duplicate in the metrics slice: cloudflare.workers_ai.neurons")
+					validatedMetrics["cloudflare.workers_ai.neurons"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of neurons consumed by the inference requests during the polled window, the unit Workers AI is billed in. Only emitted when the `workers_ai` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{neuron}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("gen_ai.request.model")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_model-val", attrVal.Str())
+				case "cloudflare.workers_ai.requests":
+					assert.False(t, validatedMetrics["cloudflare.workers_ai.requests"], "Found a duplicate in the metrics slice: cloudflare.workers_ai.requests")
+					validatedMetrics["cloudflare.workers_ai.requests"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of inference requests run by Workers AI during the polled window. Only emitted when the `workers_ai` dataset of `analytics` is collected.", ms.At(i).Description())
+					assert.Equal(t, "{request}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityDelta, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("gen_ai.request.model")
+					assert.True(t, ok)
+					assert.Equal(t, "ai_model-val", attrVal.Str())
+				}
+			}
+		})
+	}
+}
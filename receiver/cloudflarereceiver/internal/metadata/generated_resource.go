@@ -0,0 +1,57 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ResourceBuilder is a helper struct to build resources predefined in metadata.yaml.
+// The ResourceBuilder is not thread-safe and must not to be used in multiple goroutines.
+type ResourceBuilder struct {
+	config ResourceAttributesConfig
+	res    pcommon.Resource
+}
+
+// NewResourceBuilder creates a new ResourceBuilder. This method should be called on the start of the application.
+func NewResourceBuilder(rac ResourceAttributesConfig) *ResourceBuilder {
+	return &ResourceBuilder{
+		config: rac,
+		res:    pcommon.NewResource(),
+	}
+}
+
+// SetCloudflareAccountID sets provided value as "cloudflare.account.id" attribute.
+func (rb *ResourceBuilder) SetCloudflareAccountID(val string) {
+	if rb.config.CloudflareAccountID.Enabled {
+		rb.res.Attributes().PutStr("cloudflare.account.id", val)
+	}
+}
+
+// SetCloudflareZoneID sets provided value as "cloudflare.zone.id" attribute.
+func (rb *ResourceBuilder) SetCloudflareZoneID(val string) {
+	if rb.config.CloudflareZoneID.Enabled {
+		rb.res.Attributes().PutStr("cloudflare.zone.id", val)
+	}
+}
+
+// SetCloudflareZoneName sets provided value as "cloudflare.zone.name" attribute.
+func (rb *ResourceBuilder) SetCloudflareZoneName(val string) {
+	if rb.config.CloudflareZoneName.Enabled {
+		rb.res.Attributes().PutStr("cloudflare.zone.name", val)
+	}
+}
+
+// SetCloudflareZonePlan sets provided value as "cloudflare.zone.plan" attribute.
+func (rb *ResourceBuilder) SetCloudflareZonePlan(val string) {
+	if rb.config.CloudflareZonePlan.Enabled {
+		rb.res.Attributes().PutStr("cloudflare.zone.plan", val)
+	}
+}
+
+// Emit returns the built resource and resets the internal builder state.
+func (rb *ResourceBuilder) Emit() pcommon.Resource {
+	r := rb.res
+	rb.res = pcommon.NewResource()
+	return r
+}
@@ -0,0 +1,58 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceBuilder(t *testing.T) {
+	for _, tt := range []string{"default", "all_set", "none_set"} {
+		t.Run(tt, func(t *testing.T) {
+			cfg := loadResourceAttributesConfig(t, tt)
+			rb := NewResourceBuilder(cfg)
+			rb.SetCloudflareAccountID("cloudflare.account.id-val")
+			rb.SetCloudflareZoneID("cloudflare.zone.id-val")
+			rb.SetCloudflareZoneName("cloudflare.zone.name-val")
+			rb.SetCloudflareZonePlan("cloudflare.zone.plan-val")
+
+			res := rb.Emit()
+			assert.Equal(t, 0, rb.Emit().Attributes().Len()) // Second call should return empty Resource
+
+			switch tt {
+			case "default":
+				assert.Equal(t, 4, res.Attributes().Len())
+			case "all_set":
+				assert.Equal(t, 4, res.Attributes().Len())
+			case "none_set":
+				assert.Equal(t, 0, res.Attributes().Len())
+				return
+			default:
+				assert.Failf(t, "unexpected test case: %s", tt)
+			}
+
+			val, ok := res.Attributes().Get("cloudflare.account.id")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "cloudflare.account.id-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("cloudflare.zone.id")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "cloudflare.zone.id-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("cloudflare.zone.name")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "cloudflare.zone.name-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("cloudflare.zone.plan")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "cloudflare.zone.plan-val", val.Str())
+			}
+		})
+	}
+}
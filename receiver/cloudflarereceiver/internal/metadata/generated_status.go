@@ -12,5 +12,7 @@ var (
 )
 
 const (
-	LogsStability = component.StabilityLevelAlpha
+	MetricsStability = component.StabilityLevelDevelopment
+	TracesStability  = component.StabilityLevelDevelopment
+	LogsStability    = component.StabilityLevelAlpha
 )
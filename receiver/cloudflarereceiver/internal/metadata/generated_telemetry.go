@@ -0,0 +1,110 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func Meter(settings component.TelemetrySettings) metric.Meter {
+	return settings.MeterProvider.Meter("github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver")
+}
+
+func Tracer(settings component.TelemetrySettings) trace.Tracer {
+	return settings.TracerProvider.Tracer("github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver")
+}
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                            metric.Meter
+	mu                               sync.Mutex
+	registrations                    []metric.Registration
+	CloudflareAPIErrors              metric.Int64Counter
+	CloudflareAPIGraphqlRows         metric.Int64Counter
+	CloudflareAPIRateLimited         metric.Int64Counter
+	CloudflareAPIRequests            metric.Int64Counter
+	CloudflareAPIResponseSize        metric.Int64Counter
+	CloudflareAPIRetries             metric.Int64Counter
+	CloudflareLogpushRecordsRejected metric.Int64Counter
+}
+
+// TelemetryBuilderOption applies changes to default builder.
+type TelemetryBuilderOption interface {
+	apply(*TelemetryBuilder)
+}
+
+type telemetryBuilderOptionFunc func(mb *TelemetryBuilder)
+
+func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
+	tbof(mb)
+}
+
+// Shutdown unregister all registered callbacks for async instruments.
+func (builder *TelemetryBuilder) Shutdown() {
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	for _, reg := range builder.registrations {
+		reg.Unregister()
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...TelemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{}
+	for _, op := range options {
+		op.apply(&builder)
+	}
+	builder.meter = Meter(settings)
+	var err, errs error
+	builder.CloudflareAPIErrors, err = builder.meter.Int64Counter(
+		"otelcol_cloudflare_api_errors",
+		metric.WithDescription("The number of requests to the Cloudflare API that failed with an error response, by kind of failure."),
+		metric.WithUnit("{request}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.CloudflareAPIGraphqlRows, err = builder.meter.Int64Counter(
+		"otelcol_cloudflare_api_graphql_rows",
+		metric.WithDescription("The number of groups and events returned by the GraphQL Analytics API, by dataset or custom query."),
+		metric.WithUnit("{row}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.CloudflareAPIRateLimited, err = builder.meter.Int64Counter(
+		"otelcol_cloudflare_api_rate_limited",
+		metric.WithDescription("The number of requests to the Cloudflare API rejected because the rate limit of the API token was exceeded."),
+		metric.WithUnit("{request}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.CloudflareAPIRequests, err = builder.meter.Int64Counter(
+		"otelcol_cloudflare_api_requests",
+		metric.WithDescription("The number of requests sent to the Cloudflare API, including retries."),
+		metric.WithUnit("{request}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.CloudflareAPIResponseSize, err = builder.meter.Int64Counter(
+		"otelcol_cloudflare_api_response_size",
+		metric.WithDescription("The number of bytes of the responses received from the Cloudflare API."),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	builder.CloudflareAPIRetries, err = builder.meter.Int64Counter(
+		"otelcol_cloudflare_api_retries",
+		metric.WithDescription("The number of requests to the Cloudflare API that were retried after a transient failure."),
+		metric.WithUnit("{request}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.CloudflareLogpushRecordsRejected, err = builder.meter.Int64Counter(
+		"otelcol_cloudflare_logpush_records_rejected",
+		metric.WithDescription("The number of Logpush records that could not be parsed, or did not match the schema of their dataset."),
+		metric.WithUnit("{record}"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
@@ -0,0 +1,299 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+var errClientNotInit = errors.New("client not initialized")
+
+// logpushJobsScraper polls the Cloudflare API for the state of Logpush jobs, so that
+// jobs which silently stopped delivering to the push endpoint can be detected.
+type logpushJobsScraper struct {
+	client   client
+	cfg      *LogpushJobsConfig
+	settings component.TelemetrySettings
+	logger   *zap.Logger
+	mb       *metadata.MetricsBuilder
+
+	// errorCounts tracks the failures observed per job, keyed by job ID.
+	errorCounts map[int64]*jobErrorCount
+	// zones caches the details of the monitored zones.
+	zones *zoneCache
+	// zoneIDs holds the IDs of the monitored zones, the configured names being resolved on start.
+	zoneIDs []string
+	// accountIDs holds the IDs of the monitored accounts, the configured names being resolved on start.
+	accountIDs []string
+	// breaker pauses the zones and accounts whose jobs repeatedly fail to be listed.
+	breaker *circuitBreaker
+	// unavailable holds the zones and accounts whose plan doesn't include Logpush, along with the
+	// time they're checked again, which are not polled until then.
+	unavailable map[string]time.Time
+}
+
+type jobErrorCount struct {
+	lastError time.Time
+	count     int64
+}
+
+func newLogpushJobsScraper(settings receiver.Settings, cfg *LogpushJobsConfig) *logpushJobsScraper {
+	s := &logpushJobsScraper{
+		cfg:         cfg,
+		settings:    settings.TelemetrySettings,
+		logger:      settings.Logger,
+		mb:          metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
+		errorCounts: map[int64]*jobErrorCount{},
+		breaker:     newCircuitBreaker(cfg.CircuitBreaker),
+		unavailable: map[string]time.Time{},
+		zoneIDs:     cfg.Zones,
+		accountIDs:  cfg.Accounts,
+	}
+	s.zones = newZoneCache(cfg.ZoneCacheTTL, s.lookupZone, settings.Logger)
+	return s
+}
+
+func (s *logpushJobsScraper) start(ctx context.Context, host component.Host) (err error) {
+	s.client, err = newClient(ctx, &s.cfg.APIConfig, host, s.settings)
+	if err != nil {
+		return err
+	}
+	verifyToken(ctx, s.client, s.logger, "logpush_jobs", logpushJobsPermission)
+	if s.zoneIDs, err = resolveZoneIDs(ctx, s.client, s.cfg.Zones); err != nil {
+		return err
+	}
+	if s.accountIDs, err = resolveAccountIDs(ctx, s.client, s.cfg.Accounts); err != nil {
+		return err
+	}
+	s.probe(ctx)
+	return nil
+}
+
+// probe lists the jobs of every zone and account once, so that the ones whose plan doesn't include
+// Logpush are reported on start rather than by the first scrape. Other failures are left for the
+// scrapes to report. The requests aren't retried, and probing stops once the API can't be reached,
+// so that an unreachable API doesn't delay the start of the receiver.
+func (s *logpushJobsScraper) probe(ctx context.Context) {
+	ctx = withoutRetries(ctx)
+	now := time.Now()
+	targets := make(map[string]func(context.Context) ([]logpushJob, error), len(s.zoneIDs)+len(s.accountIDs))
+	for _, zoneID := range s.zoneIDs {
+		targets["zone "+zoneID] = func(ctx context.Context) ([]logpushJob, error) {
+			return s.client.ListZoneLogpushJobs(ctx, zoneID)
+		}
+	}
+	for _, accountID := range s.accountIDs {
+		targets["account "+accountID] = func(ctx context.Context) ([]logpushJob, error) {
+			return s.client.ListAccountLogpushJobs(ctx, accountID)
+		}
+	}
+	for target, list := range targets {
+		_, err := s.listJobs(ctx, target, now, list)
+		var statusErr *statusError
+		var partialErr *partialResultError
+		if err != nil && !errors.As(err, &statusErr) && !errors.As(err, &partialErr) {
+			s.logger.Debug("Failed to probe the Logpush jobs", zap.String("target", target), zap.Error(err))
+			return
+		}
+	}
+}
+
+func (s *logpushJobsScraper) shutdown(context.Context) error {
+	s.zones.shutdown()
+	return nil
+}
+
+func (s *logpushJobsScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if s.client == nil {
+		return pmetric.NewMetrics(), errClientNotInit
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	var scrapeErrors scrapererror.ScrapeErrors
+
+	// A failing zone or account must not prevent the others from being reported. Once the deadline of
+	// the scrape is exceeded, the metrics of the zones and accounts already listed are still emitted,
+	// and so are the jobs of a partial result, along with its errors.
+	for _, zoneID := range s.zoneIDs {
+		target := "zone " + zoneID
+		if s.isUnavailable(target, now.AsTime()) {
+			continue
+		}
+		jobs, err := s.listJobs(ctx, target, now.AsTime(), func(ctx context.Context) ([]logpushJob, error) {
+			return s.client.ListZoneLogpushJobs(ctx, zoneID)
+		})
+		if err != nil {
+			scrapeErrors.AddPartial(0, err)
+			if len(jobs) == 0 {
+				continue
+			}
+		}
+		s.recordJobs(now, jobs)
+		rb := s.mb.NewResourceBuilder()
+		rb.SetCloudflareZoneID(zoneID)
+		if z, ok := s.zones.get(ctx, zoneID, now.AsTime()); ok {
+			rb.SetCloudflareZoneName(z.Name)
+			rb.SetCloudflareZonePlan(z.Plan.Name)
+			rb.SetCloudflareAccountID(z.Account.ID)
+		}
+		s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+
+	for _, accountID := range s.accountIDs {
+		target := "account " + accountID
+		if s.isUnavailable(target, now.AsTime()) {
+			continue
+		}
+		jobs, err := s.listJobs(ctx, target, now.AsTime(), func(ctx context.Context) ([]logpushJob, error) {
+			return s.client.ListAccountLogpushJobs(ctx, accountID)
+		})
+		if err != nil {
+			scrapeErrors.AddPartial(0, err)
+			if len(jobs) == 0 {
+				continue
+			}
+		}
+		s.recordJobs(now, jobs)
+		rb := s.mb.NewResourceBuilder()
+		rb.SetCloudflareAccountID(accountID)
+		s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+
+	return s.mb.Emit(), scrapeErrors.Combine()
+}
+
+// isUnavailable returns whether the plan of the target doesn't include Logpush, and it isn't time to
+// check it again.
+func (s *logpushJobsScraper) isUnavailable(target string, now time.Time) bool {
+	recheckAt, ok := s.unavailable[target]
+	return ok && (s.cfg.PlanRecheckInterval <= 0 || now.Before(recheckAt))
+}
+
+// listJobs lists the jobs of the target, a zone or an account, within the query timeout, unless the
+// target is paused after repeated failures or the scrape deadline is exceeded. The jobs of a partial
+// result are returned alongside its errors, while a target whose plan doesn't include Logpush is
+// reported and then only checked again every plan recheck interval.
+func (s *logpushJobsScraper) listJobs(ctx context.Context, target string, now time.Time, list func(context.Context) ([]logpushJob, error)) ([]logpushJob, error) {
+	if ok, probeAt := s.breaker.allow(target, now); !ok {
+		return nil, fmt.Errorf("%s is paused until %s after repeated failures", target, probeAt.Format(time.RFC3339))
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("skipped listing logpush jobs for %s: %w", target, err)
+	}
+
+	queryCtx, cancel := s.queryContext(ctx)
+	defer cancel()
+	jobs, err := list(queryCtx)
+	var partialErr *partialResultError
+	if errors.As(err, &partialErr) {
+		s.breaker.recordSuccess(target)
+		s.recordAvailable(target)
+		return jobs, fmt.Errorf("partially listed logpush jobs for %s: %w", target, err)
+	}
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.errorType == metadata.AttributeErrorTypePlan {
+		// Plans rarely change, so the target is only checked again every recheck interval rather than
+		// failing every scrape.
+		if _, ok := s.unavailable[target]; !ok {
+			s.logger.Warn("Logpush isn't available on the plan, pausing the polling of its Logpush jobs",
+				zap.String("target", target),
+				zap.Duration("plan_recheck_interval", s.cfg.PlanRecheckInterval),
+				zap.Error(err))
+		}
+		s.unavailable[target] = now.Add(s.cfg.PlanRecheckInterval)
+		return nil, fmt.Errorf("logpush jobs aren't available for %s: %w", target, err)
+	}
+	if err != nil {
+		// Running out of scrape time isn't a failure of the target.
+		if ctx.Err() == nil && s.breaker.recordFailure(target, now) {
+			s.logger.Warn("Pausing the polling of Logpush jobs after repeated failures",
+				zap.String("target", target),
+				zap.Duration("cooldown", s.cfg.CircuitBreaker.Cooldown),
+				zap.Error(err))
+		}
+		return nil, fmt.Errorf("failed to list logpush jobs for %s: %w", target, err)
+	}
+	s.breaker.recordSuccess(target)
+	s.recordAvailable(target)
+	return jobs, nil
+}
+
+// recordAvailable resumes the polling of the target if its plan didn't include Logpush before.
+func (s *logpushJobsScraper) recordAvailable(target string) {
+	if _, ok := s.unavailable[target]; ok {
+		delete(s.unavailable, target)
+		s.logger.Info("Logpush is now available on the plan, resuming the polling of its Logpush jobs", zap.String("target", target))
+	}
+}
+
+// queryContext returns the context of a request to the API, bounded by the query timeout.
+func (s *logpushJobsScraper) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.cfg.QueryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.cfg.QueryTimeout)
+}
+
+// lookupZone looks up the details of the zone within the query timeout.
+func (s *logpushJobsScraper) lookupZone(ctx context.Context, zoneID string) (zone, error) {
+	queryCtx, cancel := s.queryContext(ctx)
+	defer cancel()
+	return s.client.GetZone(queryCtx, zoneID)
+}
+
+func (s *logpushJobsScraper) recordJobs(now pcommon.Timestamp, jobs []logpushJob) {
+	for _, job := range jobs {
+		enabled := int64(0)
+		if job.Enabled {
+			enabled = 1
+		}
+		s.mb.RecordCloudflareLogpushJobEnabledDataPoint(now, enabled, job.ID, job.Name, job.Dataset)
+
+		if job.LastComplete != nil {
+			s.mb.RecordCloudflareLogpushJobLastCompleteDataPoint(now, job.LastComplete.Unix(), job.ID, job.Name, job.Dataset)
+		}
+		if job.LastError != nil {
+			s.mb.RecordCloudflareLogpushJobLastErrorDataPoint(now, job.LastError.Unix(), job.ID, job.Name, job.Dataset)
+		}
+
+		s.mb.RecordCloudflareLogpushJobErrorsDataPoint(now, s.countErrors(job), job.ID, job.Name, job.Dataset)
+	}
+}
+
+// countErrors returns the number of failures observed for the job. The API only exposes the
+// time of the most recent failure, so a failure is counted whenever that time moves forward.
+// Failures that happened before the job was first seen are not counted.
+func (s *logpushJobsScraper) countErrors(job logpushJob) int64 {
+	state, ok := s.errorCounts[job.ID]
+	if !ok {
+		state = &jobErrorCount{}
+		if job.LastError != nil {
+			state.lastError = *job.LastError
+		}
+		s.errorCounts[job.ID] = state
+		return 0
+	}
+
+	if job.LastError != nil && job.LastError.After(state.lastError) {
+		state.lastError = *job.LastError
+		state.count++
+		s.logger.Debug("Logpush job reported a new error",
+			zap.Int64("job_id", job.ID),
+			zap.String("job_name", job.Name),
+			zap.String("error_message", job.ErrorMessage))
+	}
+	return state.count
+}
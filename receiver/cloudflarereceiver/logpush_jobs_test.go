@@ -0,0 +1,351 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+func TestLogpushJobsScraper(t *testing.T) {
+	zoneJobs, err := os.ReadFile(filepath.Join("testdata", "logpush_jobs", "zone_jobs.json"))
+	require.NoError(t, err)
+	accountError, err := os.ReadFile(filepath.Join("testdata", "logpush_jobs", "account_jobs_error.json"))
+	require.NoError(t, err)
+	zoneDetails, err := os.ReadFile(filepath.Join("testdata", "logpush_jobs", "zone.json"))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/zones/023e105f4ecef8ad9ca31a8372d0c353/logpush/jobs", func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "Bearer abc123", req.Header.Get("Authorization"))
+		_, _ = rw.Write(zoneJobs)
+	})
+	zoneLookups := 0
+	mux.HandleFunc("/zones/023e105f4ecef8ad9ca31a8372d0c353", func(rw http.ResponseWriter, _ *http.Request) {
+		zoneLookups++
+		_, _ = rw.Write(zoneDetails)
+	})
+	mux.HandleFunc("/accounts/01a7362d577a6c3019a474fd6f485823/logpush/jobs", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+		_, _ = rw.Write(accountError)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	cfg := &LogpushJobsConfig{
+		APIConfig:            APIConfig{ClientConfig: clientConfig, APIToken: "abc123"},
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+		Accounts:             []string{"01a7362d577a6c3019a474fd6f485823"},
+	}
+
+	s := newLogpushJobsScraper(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+
+	actualMetrics, err := s.scrape(t.Context())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.ErrorContains(t, err, "failed to list logpush jobs for account 01a7362d577a6c3019a474fd6f485823")
+	require.ErrorContains(t, err, "10000: Authentication error")
+
+	expectedMetrics, err := golden.ReadMetrics(filepath.Join("testdata", "logpush_jobs", "expected.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, pmetrictest.CompareMetrics(expectedMetrics, actualMetrics,
+		pmetrictest.IgnoreStartTimestamp(),
+		pmetrictest.IgnoreTimestamp(),
+		pmetrictest.IgnoreMetricDataPointsOrder(),
+	))
+
+	// The zone details are cached after the first lookup.
+	_, err = s.scrape(t.Context())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.Equal(t, 1, zoneLookups)
+}
+
+// fakeZoneJobsClient lists the jobs of zones, failing for the zones without jobs.
+type fakeZoneJobsClient struct {
+	client
+	jobs map[string][]logpushJob
+}
+
+func (f *fakeZoneJobsClient) ListZoneLogpushJobs(_ context.Context, zoneID string) ([]logpushJob, error) {
+	jobs, ok := f.jobs[zoneID]
+	if !ok {
+		return nil, errors.New("9109: Invalid access token")
+	}
+	return jobs, nil
+}
+
+func (*fakeZoneJobsClient) GetZone(context.Context, string) (zone, error) {
+	return zone{}, errors.New("zone lookup failed")
+}
+
+func TestLogpushJobsScraperFailingZone(t *testing.T) {
+	s := newLogpushJobsScraper(receivertest.NewNopSettings(metadata.Type), &LogpushJobsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"healthy1", "failing", "healthy2"},
+	})
+	s.client = &fakeZoneJobsClient{jobs: map[string][]logpushJob{
+		"healthy1": {{ID: 1, Name: "example.com", Dataset: "http_requests", Enabled: true}},
+		"healthy2": {{ID: 2, Name: "example.net", Dataset: "firewall_events", Enabled: true}},
+	}}
+
+	metrics, err := s.scrape(t.Context())
+	// The failing zone is reported as a partial error, while the healthy zones are still reported.
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.EqualError(t, err, "failed to list logpush jobs for zone failing: 9109: Invalid access token")
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		zoneID, ok := metrics.ResourceMetrics().At(i).Resource().Attributes().Get("cloudflare.zone.id")
+		require.True(t, ok)
+		require.NotEqual(t, "failing", zoneID.Str())
+	}
+}
+
+// partialZoneJobsClient lists the jobs of zones along with an error reported by the API.
+type partialZoneJobsClient struct {
+	fakeZoneJobsClient
+}
+
+func (p *partialZoneJobsClient) ListZoneLogpushJobs(ctx context.Context, zoneID string) ([]logpushJob, error) {
+	jobs, err := p.fakeZoneJobsClient.ListZoneLogpushJobs(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, &partialResultError{path: "/zones/" + zoneID + "/logpush/jobs", errs: []error{apiError{Code: 1000, Message: "job 2 unavailable"}}}
+}
+
+func TestLogpushJobsScraperPartialResult(t *testing.T) {
+	s := newLogpushJobsScraper(receivertest.NewNopSettings(metadata.Type), &LogpushJobsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"partial"},
+		CircuitBreaker:       CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour},
+	})
+	s.client = &partialZoneJobsClient{fakeZoneJobsClient{jobs: map[string][]logpushJob{
+		"partial": {{ID: 1, Name: "example.com", Dataset: "http_requests", Enabled: true}},
+	}}}
+
+	for range 2 {
+		metrics, err := s.scrape(t.Context())
+		// The jobs that were listed are reported along with the error, which doesn't pause the zone.
+		require.True(t, scrapererror.IsPartialScrapeError(err))
+		require.EqualError(t, err, "partially listed logpush jobs for zone partial: response to /zones/partial/logpush/jobs holds a partial result: 1000: job 2 unavailable")
+		require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	}
+}
+
+// planZoneJobsClient fails to list the jobs of the zones without jobs as unavailable on their plan.
+type planZoneJobsClient struct {
+	fakeZoneJobsClient
+	calls map[string]int
+}
+
+func (p *planZoneJobsClient) ListZoneLogpushJobs(ctx context.Context, zoneID string) ([]logpushJob, error) {
+	p.calls[zoneID]++
+	if _, ok := p.jobs[zoneID]; !ok {
+		return nil, &statusError{path: "/zones/" + zoneID + "/logpush/jobs", statusCode: http.StatusForbidden, errorType: metadata.AttributeErrorTypePlan}
+	}
+	return p.fakeZoneJobsClient.ListZoneLogpushJobs(ctx, zoneID)
+}
+
+func TestLogpushJobsScraperPlanUnavailable(t *testing.T) {
+	s := newLogpushJobsScraper(receivertest.NewNopSettings(metadata.Type), &LogpushJobsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"enterprise", "free"},
+		PlanRecheckInterval:  time.Hour,
+	})
+	fake := &planZoneJobsClient{
+		fakeZoneJobsClient: fakeZoneJobsClient{jobs: map[string][]logpushJob{
+			"enterprise": {{ID: 1, Name: "example.com", Dataset: "http_requests", Enabled: true}},
+		}},
+		calls: map[string]int{},
+	}
+	s.client = fake
+
+	// The zone is reported, and then no longer polled until it's checked again.
+	metrics, err := s.scrape(t.Context())
+	require.ErrorContains(t, err, "logpush jobs aren't available for zone free: request to /zones/free/logpush/jobs failed with status code 403")
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+
+	metrics, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	require.Equal(t, map[string]int{"enterprise": 2, "free": 1}, fake.calls)
+
+	// Once the plan includes Logpush, the zone is polled again when it's checked again.
+	fake.jobs["free"] = []logpushJob{{ID: 2, Name: "example.net", Dataset: "http_requests", Enabled: true}}
+	s.unavailable["zone free"] = time.Now().Add(-time.Second)
+	metrics, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+	require.Empty(t, s.unavailable)
+}
+
+func TestLogpushJobsScraperProbe(t *testing.T) {
+	var accountCalls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/zones/023e105f4ecef8ad9ca31a8372d0c353/logpush/jobs", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(`{"success":true,"errors":[],"result":[]}`))
+	})
+	mux.HandleFunc("/accounts/01a7362d577a6c3019a474fd6f485823/logpush/jobs", func(rw http.ResponseWriter, _ *http.Request) {
+		accountCalls.Add(1)
+		rw.WriteHeader(http.StatusForbidden)
+		_, _ = rw.Write([]byte(`{"success":false,"errors":[{"code":1002,"message":"not entitled"}],"result":null}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	s := newLogpushJobsScraper(receivertest.NewNopSettings(metadata.Type), &LogpushJobsConfig{
+		APIConfig:            APIConfig{ClientConfig: clientConfig, APIToken: "abc123"},
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"023e105f4ecef8ad9ca31a8372d0c353"},
+		Accounts:             []string{"01a7362d577a6c3019a474fd6f485823"},
+		PlanRecheckInterval:  time.Hour,
+	})
+
+	// The account whose plan doesn't include Logpush is found on start, and not polled by the scrapes.
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+	require.Contains(t, s.unavailable, "account 01a7362d577a6c3019a474fd6f485823")
+	_, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int32(1), accountCalls.Load())
+}
+
+// slowZoneJobsClient hangs when listing the jobs of the slow zone, until the request is cancelled.
+type slowZoneJobsClient struct {
+	fakeZoneJobsClient
+}
+
+func (f *slowZoneJobsClient) ListZoneLogpushJobs(ctx context.Context, zoneID string) ([]logpushJob, error) {
+	if zoneID == "slow" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return f.fakeZoneJobsClient.ListZoneLogpushJobs(ctx, zoneID)
+}
+
+func TestLogpushJobsScraperQueryTimeout(t *testing.T) {
+	s := newLogpushJobsScraper(receivertest.NewNopSettings(metadata.Type), &LogpushJobsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"slow", "healthy"},
+		QueryTimeout:         10 * time.Millisecond,
+	})
+	s.client = &slowZoneJobsClient{fakeZoneJobsClient{jobs: map[string][]logpushJob{
+		"healthy": {{ID: 1, Name: "example.com", Dataset: "http_requests", Enabled: true}},
+	}}}
+
+	// The slow zone times out without preventing the next zone from being listed.
+	metrics, err := s.scrape(t.Context())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.EqualError(t, err, "failed to list logpush jobs for zone slow: context deadline exceeded")
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+}
+
+func TestLogpushJobsScraperDeadline(t *testing.T) {
+	s := newLogpushJobsScraper(receivertest.NewNopSettings(metadata.Type), &LogpushJobsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"healthy", "slow", "skipped"},
+		CircuitBreaker:       CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour},
+	})
+	s.client = &slowZoneJobsClient{fakeZoneJobsClient{jobs: map[string][]logpushJob{
+		"healthy": {{ID: 1, Name: "example.com", Dataset: "http_requests", Enabled: true}},
+		"skipped": {{ID: 2, Name: "example.net", Dataset: "http_requests", Enabled: true}},
+	}}}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	metrics, err := s.scrape(ctx)
+	// The zones listed before the deadline are still reported.
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.ErrorContains(t, err, "skipped listing logpush jobs for zone skipped")
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+
+	// Running out of time isn't counted as a failure of the zones.
+	ok, _ := s.breaker.allow("zone slow", time.Now())
+	require.True(t, ok)
+}
+
+// countingZoneJobsClient fails to list the jobs of every zone, counting the calls.
+type countingZoneJobsClient struct {
+	client
+	calls int
+}
+
+func (c *countingZoneJobsClient) ListZoneLogpushJobs(context.Context, string) ([]logpushJob, error) {
+	c.calls++
+	return nil, errors.New("7003: Could not route to /zones/deleted/logpush/jobs")
+}
+
+func TestLogpushJobsScraperCircuitBreaker(t *testing.T) {
+	s := newLogpushJobsScraper(receivertest.NewNopSettings(metadata.Type), &LogpushJobsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Zones:                []string{"deleted"},
+		CircuitBreaker:       CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Hour},
+	})
+	fake := &countingZoneJobsClient{}
+	s.client = fake
+
+	for range 2 {
+		_, err := s.scrape(t.Context())
+		require.ErrorContains(t, err, "failed to list logpush jobs for zone deleted")
+	}
+
+	// The zone is no longer queried during the cooldown, but is still reported as failing.
+	_, err := s.scrape(t.Context())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.ErrorContains(t, err, "zone deleted is paused until")
+	require.Equal(t, 2, fake.calls)
+}
+
+func TestLogpushJobsScraperClientNotInitialized(t *testing.T) {
+	s := newLogpushJobsScraper(receivertest.NewNopSettings(metadata.Type), &LogpushJobsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+	})
+	_, err := s.scrape(t.Context())
+	require.ErrorIs(t, err, errClientNotInit)
+}
+
+func TestLogpushJobsErrorCount(t *testing.T) {
+	s := newLogpushJobsScraper(receivertest.NewNopSettings(metadata.Type), &LogpushJobsConfig{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+	})
+
+	firstError := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	secondError := firstError.Add(time.Hour)
+	job := logpushJob{ID: 1, Name: "example.com", Dataset: "http_requests", LastError: &firstError}
+
+	// Failures that happened before the job was first seen are not counted.
+	require.Equal(t, int64(0), s.countErrors(job))
+	require.Equal(t, int64(0), s.countErrors(job))
+
+	job.LastError = &secondError
+	require.Equal(t, int64(1), s.countErrors(job))
+	require.Equal(t, int64(1), s.countErrors(job))
+
+	// A job that has never failed starts counting from its first failure.
+	other := logpushJob{ID: 2}
+	require.Equal(t, int64(0), s.countErrors(other))
+	other.LastError = &firstError
+	require.Equal(t, int64(1), s.countErrors(other))
+}
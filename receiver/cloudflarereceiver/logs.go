@@ -4,22 +4,20 @@
 package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
 
 import (
-	"bytes"
-	"compress/gzip"
 	"context"
-	"encoding/json"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -32,17 +30,37 @@ import (
 )
 
 type logsReceiver struct {
-	logger   *zap.Logger
-	cfg      *LogsConfig
-	server   *http.Server
-	consumer consumer.Logs
-	wg       *sync.WaitGroup
-	id       component.ID // ID of the receiver component
-	obsrecv  *receiverhelper.ObsReport
+	logger    *zap.Logger
+	cfg       *LogsConfig
+	server    *http.Server
+	consumer  consumer.Logs
+	wg        *sync.WaitGroup
+	id        component.ID // ID of the receiver component
+	obsrecv   *receiverhelper.ObsReport
+	buildInfo component.BuildInfo
+
+	converter *logsConverter
+	// metrics counts the received records when the receiver is also part of a metrics pipeline.
+	metrics *derivedMetrics
+	// traces receives the spans of Workers executions when the receiver is also part of a traces pipeline.
+	traces consumer.Traces
+	// jobs provisions the Logpush jobs sending logs to the endpoint, when manage_jobs is configured.
+	jobs *jobsManager
+	// inFlightSize is the total size of the payloads being processed.
+	inFlightSize atomic.Int64
+	health       health
 }
 
 const secretHeaderName = "X-CF-Secret"
 
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+	errPayloadTooLarge  = errors.New("decompressed payload exceeds max_decompressed_size")
+	errInFlightExceeded = errors.New("payloads being processed exceed max_in_flight_size")
+)
+
 func newLogsReceiver(params rcvr.Settings, cfg *Config, consumer consumer.Logs) (*logsReceiver, error) {
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
 		ReceiverID:             params.ID,
@@ -53,38 +71,65 @@ func newLogsReceiver(params rcvr.Settings, cfg *Config, consumer consumer.Logs)
 		return nil, err
 	}
 
-	recv := &logsReceiver{
-		cfg:      &cfg.Logs,
-		consumer: consumer,
-		logger:   params.Logger,
-		wg:       &sync.WaitGroup{},
-		obsrecv:  obsrecv,
-		id:       params.ID,
+	converter, err := newLogsConverter(params, &cfg.Logs)
+	if err != nil {
+		return nil, err
 	}
 
-	recv.server = &http.Server{
-		Handler:           http.HandlerFunc(recv.handleRequest),
-		ReadHeaderTimeout: 20 * time.Second,
+	recv := &logsReceiver{
+		cfg:       &cfg.Logs,
+		consumer:  consumer,
+		logger:    params.Logger,
+		wg:        &sync.WaitGroup{},
+		obsrecv:   obsrecv,
+		id:        params.ID,
+		buildInfo: params.BuildInfo,
+		converter: converter,
 	}
+	recv.health.failureTimeout = cfg.Logs.HealthCheckFailureTimeout
 
-	if recv.cfg.TLS != nil {
-		tlsConfig, err := recv.cfg.TLS.LoadTLSConfig(context.Background())
-		if err != nil {
-			return nil, err
-		}
-
-		recv.server.TLSConfig = tlsConfig
+	var handler http.Handler = http.HandlerFunc(recv.handleRequest)
+	if recv.cfg.MaxConcurrentRequests > 0 {
+		handler = withConcurrencyLimit(handler, recv.cfg.MaxConcurrentRequests, recv.logger)
+	}
+	recv.server, err = newServer(handler, recv.cfg.TLS)
+	if err != nil {
+		return nil, err
 	}
+	recv.server.ReadTimeout = recv.cfg.ReadTimeout
+	recv.server.IdleTimeout = recv.cfg.IdleTimeout
 
 	return recv, nil
 }
 
 func (l *logsReceiver) Start(ctx context.Context, host component.Host) error {
-	return l.startListening(ctx, host)
+	if l.cfg.Auth.HasValue() {
+		if err := withAuthentication(ctx, host, l.server, *l.cfg.Auth.Get()); err != nil {
+			return fmt.Errorf("failed to get server authenticator: %w", err)
+		}
+	}
+	if l.cfg.HealthCheckPath != "" {
+		withHealthCheck(l.server, l.cfg.HealthCheckPath, &l.health)
+	}
+	if err := l.startListening(ctx, host); err != nil {
+		return err
+	}
+	if l.jobs != nil {
+		if err := l.jobs.start(ctx, host); err != nil {
+			return err
+		}
+	}
+	l.health.ready.Store(true)
+	return nil
 }
 
 func (l *logsReceiver) Shutdown(ctx context.Context) error {
 	l.logger.Debug("Shutting down server")
+	l.health.ready.Store(false)
+	if l.jobs != nil {
+		l.jobs.shutdown()
+	}
+	l.converter.shutdown()
 	err := l.server.Shutdown(ctx)
 	if err != nil {
 		return err
@@ -96,310 +141,188 @@ func (l *logsReceiver) Shutdown(ctx context.Context) error {
 }
 
 func (l *logsReceiver) startListening(ctx context.Context, host component.Host) error {
-	l.logger.Debug("starting receiver HTTP server")
-	// We use l.server.Serve* over l.server.ListenAndServe*
-	// So that we can catch and return errors relating to binding to network interface on start.
-	var lc net.ListenConfig
-
-	listener, err := lc.Listen(ctx, "tcp", l.cfg.Endpoint)
-	if err != nil {
-		return err
-	}
-
-	l.wg.Add(1)
-	go func() {
-		defer l.wg.Done()
-
-		if l.cfg.TLS != nil {
-			l.logger.Debug("Starting ServeTLS",
-				zap.String("address", l.cfg.Endpoint),
-				zap.String("certfile", l.cfg.TLS.CertFile),
-				zap.String("keyfile", l.cfg.TLS.KeyFile))
-
-			err := l.server.ServeTLS(listener, l.cfg.TLS.CertFile, l.cfg.TLS.KeyFile)
-
-			l.logger.Debug("ServeTLS done")
-
-			if !errors.Is(err, http.ErrServerClosed) {
-				l.logger.Error("ServeTLS failed", zap.Error(err))
-				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
-			}
-		} else {
-			l.logger.Debug("Starting Serve",
-				zap.String("address", l.cfg.Endpoint))
-
-			err := l.server.Serve(listener)
-
-			l.logger.Debug("Serve done")
-
-			if !errors.Is(err, http.ErrServerClosed) {
-				l.logger.Error("Serve failed", zap.Error(err))
-				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
-			}
-		}
-	}()
-	return nil
+	return startServer(ctx, host, l.logger, l.server, l.wg, l.cfg.Endpoint, l.cfg.TLS)
 }
 
 func (l *logsReceiver) handleRequest(rw http.ResponseWriter, req *http.Request) {
-	if l.cfg.Secret != "" {
+	if secrets := l.cfg.acceptedSecrets(); len(secrets) != 0 {
 		secretHeader := req.Header.Get(secretHeaderName)
 		if secretHeader == "" {
 			rw.WriteHeader(http.StatusUnauthorized)
 			l.logger.Debug("Got payload with no Secret when it was specified in config, dropping...")
 			return
-		} else if secretHeader != l.cfg.Secret {
+		} else if !isAcceptedSecret(secretHeader, secrets) {
 			rw.WriteHeader(http.StatusUnauthorized)
 			l.logger.Debug("Got payload with invalid Secret, dropping...")
 			return
 		}
 	}
 
-	var payload []byte
-	if req.Header.Get("Content-Encoding") == "gzip" {
-		reader, err := gzip.NewReader(req.Body)
-		if err != nil {
-			rw.WriteHeader(http.StatusUnprocessableEntity)
-			l.logger.Debug("Got payload with gzip, but failed to read", zap.Error(err))
-			return
-		}
-		defer reader.Close()
-		// Read the decompressed response body
-		payload, err = io.ReadAll(reader)
-		if err != nil {
-			rw.WriteHeader(http.StatusUnprocessableEntity)
-			l.logger.Debug("Got payload with gzip, but failed to read", zap.Error(err))
+	if l.cfg.MaxRequestBodySize > 0 {
+		req.Body = http.MaxBytesReader(rw, req.Body, l.cfg.MaxRequestBodySize)
+	}
+	logs, rejected, size, err := l.converter.readLogs(req.Body, req.Header.Get("Content-Encoding"), l.acquireInFlight)
+	defer l.inFlightSize.Add(-size)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.Is(err, errInFlightExceeded):
+			rw.WriteHeader(http.StatusTooManyRequests)
+			l.logger.Warn("Refused payload, max_in_flight_size is reached", zap.Int64("max_in_flight_size", l.cfg.MaxInFlightSize))
 			return
-		}
-	} else {
-		var err error
-		payload, err = io.ReadAll(req.Body)
-		if err != nil {
+		case errors.Is(err, errPayloadTooLarge) || errors.As(err, &maxBytesErr):
+			rw.WriteHeader(http.StatusRequestEntityTooLarge)
+		default:
 			rw.WriteHeader(http.StatusUnprocessableEntity)
-			l.logger.Debug("Failed to read alerts payload", zap.Error(err), zap.String("remote", req.RemoteAddr))
-			return
 		}
+		l.logger.Debug("Failed to read logs payload", zap.Error(err), zap.String("remote", req.RemoteAddr))
+		return
 	}
 
-	if string(payload) == "test" {
+	if isTestPayload(logs, rejected) {
 		l.logger.Info("Received test request from Cloudflare")
 		rw.WriteHeader(http.StatusOK)
 		return
 	}
 
-	logs, err := parsePayload(payload)
-	if err != nil {
+	ds := l.converter.datasetFor(req.URL.Path, logs)
+	if len(logs) == 0 && len(rejected) != 0 && !l.cfg.ForwardUnparseable {
+		l.converter.reportRejected(req.Context(), 0, plog.NewLogs(), rejected, ds)
 		rw.WriteHeader(http.StatusUnprocessableEntity)
-		l.logger.Error("Failed to convert cloudflare request payload to maps", zap.Error(err))
-		return
-	}
-
-	pLogs := l.processLogs(pcommon.NewTimestampFromTime(time.Now()), logs)
-	obsCtx := l.obsrecv.StartLogsOp(req.Context())
-	if err := l.consumer.ConsumeLogs(obsCtx, pLogs); err != nil {
-		l.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), pLogs.LogRecordCount(), err)
-		errorutil.HTTPError(rw, err)
-		l.logger.Error("Failed to consumer alert as log", zap.Error(err))
+		l.logger.Error("Failed to convert cloudflare request payload to maps", zap.Error(rejected[0].err))
 		return
 	}
 
-	l.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), pLogs.LogRecordCount(), nil)
-	rw.WriteHeader(http.StatusOK)
-}
-
-func parsePayload(payload []byte) ([]map[string]any, error) {
-	lines := bytes.Split(payload, []byte("\n"))
-	logs := make([]map[string]any, 0, len(lines))
-	for _, line := range lines {
-		if len(line) == 0 {
-			continue
+	// A blocked pipeline would otherwise hold the request, and the payload, until Cloudflare gives up.
+	ctx, cancel := l.consumeContext(req.Context())
+	defer cancel()
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	pLogs := l.converter.processDatasetLogs(ctx, now, logs, rejected, ds)
+	if l.consumer != nil {
+		obsCtx := l.obsrecv.StartLogsOp(ctx)
+		err := l.consumer.ConsumeLogs(obsCtx, pLogs)
+		if err == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = ctx.Err()
 		}
-		var log map[string]any
-		err := json.Unmarshal(line, &log)
 		if err != nil {
-			return logs, err
+			l.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), pLogs.LogRecordCount(), err)
+			l.health.recordFailure(time.Now())
+			if errors.Is(err, context.DeadlineExceeded) {
+				// The payload is retried by Cloudflare, whatever the consumer made of the deadline.
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				l.logger.Warn("Timed out consuming logs", zap.Duration("consume_timeout", l.cfg.ConsumeTimeout))
+				return
+			}
+			errorutil.HTTPError(rw, err)
+			l.logger.Error("Failed to consumer alert as log", zap.Error(err))
+			return
 		}
-		logs = append(logs, log)
+		l.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), pLogs.LogRecordCount(), nil)
 	}
-	return logs, nil
-}
 
-func (l *logsReceiver) processLogs(now pcommon.Timestamp, logs []map[string]any) plog.Logs {
-	pLogs := plog.NewLogs()
-
-	// Group logs by ZoneName field if it was configured so it can be used as a resource attribute
-	groupedLogs := make(map[string][]map[string]any)
-	for _, log := range logs {
-		zone := ""
-		if v, ok := log["ZoneName"]; ok {
-			if stringV, ok := v.(string); ok {
-				zone = stringV
+	if l.metrics != nil {
+		if err := l.metrics.consume(ctx, now, logs, ds); err != nil {
+			if l.consumer == nil {
+				// The metrics are the only output, so Cloudflare has to retry the payload.
+				l.health.recordFailure(time.Now())
+				errorutil.HTTPError(rw, err)
+				l.logger.Error("Failed to consume metrics derived from logs", zap.Error(err))
+				return
 			}
+			l.logger.Warn("Failed to consume metrics derived from logs", zap.Error(err))
 		}
-		groupedLogs[zone] = append(groupedLogs[zone], log)
 	}
 
-	for zone, logGroup := range groupedLogs {
-		resourceLogs := pLogs.ResourceLogs().AppendEmpty()
-		if zone != "" {
-			resource := resourceLogs.Resource()
-			resource.Attributes().PutStr("cloudflare.zone", zone)
-		}
-		scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
-		scopeLogs.Scope().SetName(metadata.ScopeName)
-
-		for _, log := range logGroup {
-			logRecord := scopeLogs.LogRecords().AppendEmpty()
-			logRecord.SetObservedTimestamp(now)
-
-			if v, ok := log[l.cfg.TimestampField]; ok {
-				switch l.cfg.TimestampFormat {
-				case "unix":
-					var sec int64
-					switch val := v.(type) {
-					case int:
-						sec = int64(val)
-					case int64:
-						sec = val
-					case float64:
-						sec = int64(val)
-					case string:
-						i, err := strconv.ParseInt(val, 10, 64)
-						if err != nil {
-							l.logger.Warn("unable to parse "+l.cfg.TimestampField+" as unix seconds", zap.Error(err), zap.String("value", val))
-							continue
-						}
-						sec = i
-					default:
-						l.logger.Warn("unable to parse "+l.cfg.TimestampField, zap.String("unsupported type", fmt.Sprintf("%T", v)))
-						continue
-					}
-					logRecord.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(sec, 0)))
-				case "unixnano":
-					var nano int64
-					switch val := v.(type) {
-					case int:
-						nano = int64(val)
-					case int64:
-						nano = val
-					case float64:
-						nano = int64(val)
-					case string:
-						i, err := strconv.ParseInt(val, 10, 64)
-						if err != nil {
-							l.logger.Warn("unable to parse "+l.cfg.TimestampField+" as unixnano", zap.Error(err), zap.String("value", val))
-							continue
-						}
-						nano = i
-					default:
-						l.logger.Warn("unable to parse "+l.cfg.TimestampField, zap.String("unsupported type", fmt.Sprintf("%T", v)))
-						continue
-					}
-					logRecord.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, nano)))
-				case "rfc3339":
-					strVal, ok := v.(string)
-					if !ok {
-						l.logger.Warn("unable to parse "+l.cfg.TimestampField+" as rfc3339, not a string", zap.Any("value", v), zap.String("type", fmt.Sprintf("%T", v)))
-						continue
-					}
-					ts, err := time.Parse(time.RFC3339, strVal)
-					if err != nil {
-						l.logger.Warn("unable to parse "+l.cfg.TimestampField+" as rfc3339", zap.Error(err), zap.String("value", strVal))
-						continue
-					}
-					logRecord.SetTimestamp(pcommon.NewTimestampFromTime(ts))
-				default:
-					l.logger.Warn("unknown timestamp_format configuration", zap.String("timestamp_format", l.cfg.TimestampFormat))
-				}
-			} else {
-				l.logger.Warn("unable to parse "+l.cfg.TimestampField, zap.Any("value", v))
+	if l.traces != nil && ds.Dataset == workersTraceEventsDataset {
+		if err := l.consumeWorkersSpans(ctx, logs, ds); err != nil {
+			if l.consumer == nil && l.metrics == nil {
+				// The spans are the only output, so Cloudflare has to retry the payload.
+				l.health.recordFailure(time.Now())
+				errorutil.HTTPError(rw, err)
+				l.logger.Error("Failed to consume spans of Workers executions", zap.Error(err))
+				return
 			}
+			l.logger.Warn("Failed to consume spans of Workers executions", zap.Error(err))
+		}
+	}
 
-			if v, ok := log["EdgeResponseStatus"]; ok {
-				sev := plog.SeverityNumberUnspecified
-				switch v := v.(type) {
-				case string:
-					intV, err := strconv.ParseInt(v, 10, 64)
-					if err != nil {
-						l.logger.Warn("unable to parse EdgeResponseStatus", zap.Error(err), zap.String("value", v))
-					} else {
-						sev = severityFromStatusCode(intV)
-					}
-				case int64:
-					sev = severityFromStatusCode(v)
-				case float64:
-					sev = severityFromStatusCode(int64(v))
-				}
-				if sev != plog.SeverityNumberUnspecified {
-					logRecord.SetSeverityNumber(sev)
-					logRecord.SetSeverityText(sev.String())
-				}
-			}
+	l.health.recordSuccess(time.Now())
+	rw.WriteHeader(http.StatusOK)
+}
 
-			attrs := logRecord.Attributes()
-			for field, v := range log {
-				attrName := field
-				if len(l.cfg.Attributes) != 0 {
-					// Only process fields that are in the config mapping
-					mappedAttr, ok := l.cfg.Attributes[field]
-					if !ok {
-						// Skip fields not in mapping when we have a config
-						continue
-					}
-					attrName = mappedAttr
-				}
-				// else if l.cfg.Attributes is empty, default to processing all fields with no renaming
-
-				switch v := v.(type) {
-				case string:
-					attrs.PutStr(attrName, v)
-				case int:
-					attrs.PutInt(attrName, int64(v))
-				case int64:
-					attrs.PutInt(attrName, v)
-				case float64:
-					attrs.PutDouble(attrName, v)
-				case bool:
-					attrs.PutBool(attrName, v)
-				case map[string]any:
-					// Flatten the map and add each field with a prefixed key
-					flattened := make(map[string]any)
-					flattenMap(v, attrName+l.cfg.Separator, l.cfg.Separator, flattened)
-					for k, val := range flattened {
-						switch v := val.(type) {
-						case string:
-							attrs.PutStr(k, v)
-						case int:
-							attrs.PutInt(k, int64(v))
-						case int64:
-							attrs.PutInt(k, v)
-						case float64:
-							attrs.PutDouble(k, v)
-						case bool:
-							attrs.PutBool(k, v)
-						default:
-							l.logger.Warn("unable to translate flattened field to attribute, unsupported type",
-								zap.String("field", k),
-								zap.Any("value", v),
-								zap.String("type", fmt.Sprintf("%T", v)))
-						}
-					}
-				default:
-					l.logger.Warn("unable to translate field to attribute, unsupported type",
-						zap.String("field", field),
-						zap.Any("value", v),
-						zap.String("type", fmt.Sprintf("%T", v)))
-				}
-			}
+// consumeContext returns the context the payload is consumed with, bounded by consume_timeout.
+func (l *logsReceiver) consumeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if l.cfg.ConsumeTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, l.cfg.ConsumeTimeout)
+}
 
-			err := logRecord.Body().SetEmptyMap().FromRaw(log)
-			if err != nil {
-				l.logger.Warn("unable to set body", zap.Error(err))
-			}
-		}
+// isTestPayload returns true if the payload is the test request Cloudflare sends when a job is created.
+func isTestPayload(logs []map[string]any, rejected []rejectedRecord) bool {
+	return len(logs) == 0 && len(rejected) == 1 && string(rejected[0].line) == "test"
+}
+
+// acquireInFlight accounts for a payload of the given size, returning false if processing it would
+// exceed max_in_flight_size.
+func (l *logsReceiver) acquireInFlight(size int64) bool {
+	if l.inFlightSize.Add(size) > l.cfg.MaxInFlightSize && l.cfg.MaxInFlightSize > 0 {
+		l.inFlightSize.Add(-size)
+		return false
+	}
+	return true
+}
+
+// isAcceptedSecret compares the secret against each accepted one in constant time.
+func isAcceptedSecret(secret string, accepted []configopaque.String) bool {
+	found := 0
+	for _, s := range accepted {
+		found |= subtle.ConstantTimeCompare([]byte(secret), []byte(string(s)))
 	}
+	return found == 1
+}
+
+// maxSizeReader fails with err once more than remaining bytes are read.
+type maxSizeReader struct {
+	reader    io.Reader
+	remaining int64
+	err       error
+}
 
-	return pLogs
+func (m *maxSizeReader) Read(p []byte) (int, error) {
+	// Read one byte past the limit to tell payloads of exactly the limit from larger ones.
+	if int64(len(p)) > m.remaining+1 {
+		p = p[:m.remaining+1]
+	}
+	n, err := m.reader.Read(p)
+	m.remaining -= int64(n)
+	if m.remaining < 0 {
+		// The byte past the limit isn't returned, so that the data read never exceeds the limit.
+		return n - 1, m.err
+	}
+	return n, err
+}
+
+// zoneKey identifies the zone logs belong to.
+type zoneKey struct {
+	id   string
+	name string
+}
+
+// logZone returns the zone of a log from its ZoneID and ZoneName fields, which are empty for logs
+// of datasets that aren't scoped to a zone.
+func logZone(log map[string]any) zoneKey {
+	var zone zoneKey
+	switch v := log["ZoneID"].(type) {
+	case string:
+		zone.id = v
+	case float64:
+		// Some datasets send the numeric ID of the zone rather than its tag.
+		zone.id = strconv.FormatInt(int64(v), 10)
+	}
+	zone.name, _ = log["ZoneName"].(string)
+	return zone
 }
 
 // severityFromStatusCode translates HTTP status code to OpenTelemetry severity number.
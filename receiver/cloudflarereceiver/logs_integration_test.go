@@ -117,6 +117,65 @@ func TestReceiverTLSIntegration(t *testing.T) {
 	}
 }
 
+func TestReceiverMTLSIntegration(t *testing.T) {
+	testAddr := testutil.GetAvailableLocalAddress(t)
+	sink := &consumertest.LogsSink{}
+
+	_, testPort, err := net.SplitHostPort(testAddr)
+	require.NoError(t, err)
+
+	recv, err := NewFactory().CreateLogs(
+		t.Context(),
+		receivertest.NewNopSettings(metadata.Type),
+		&Config{
+			Logs: LogsConfig{
+				Endpoint: testAddr,
+				TLS: &configtls.ServerConfig{
+					Config: configtls.Config{
+						CertFile: filepath.Join("testdata", "cert", "server.crt"),
+						KeyFile:  filepath.Join("testdata", "cert", "server.key"),
+					},
+					ClientCAFile: filepath.Join("testdata", "cert", "client.crt"),
+				},
+				TimestampField:  "EdgeStartTimestamp",
+				TimestampFormat: "rfc3339",
+			},
+		},
+		sink,
+	)
+	require.NoError(t, err)
+	require.NoError(t, recv.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, recv.Shutdown(t.Context()))
+	}()
+
+	payload, err := os.ReadFile(filepath.Join("testdata", "sample-payloads", "all_fields.txt"))
+	require.NoError(t, err)
+	url := fmt.Sprintf("https://localhost:%s", testPort)
+
+	// Without a client certificate the TLS handshake fails.
+	client, err := clientWithCert(filepath.Join("testdata", "cert", "ca.crt"))
+	require.NoError(t, err)
+	resp, err := client.Post(url, "application/x-ndjson", bytes.NewBuffer(payload))
+	if err == nil {
+		resp.Body.Close()
+	}
+	require.Error(t, err)
+
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join("testdata", "cert", "client.crt"), filepath.Join("testdata", "cert", "client.key"))
+	require.NoError(t, err)
+	client.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{clientCert}
+
+	resp, err = client.Post(url, "application/x-ndjson", bytes.NewBuffer(payload))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() > 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
 func clientWithCert(path string) (*http.Client, error) {
 	b, err := os.ReadFile(filepath.Clean(path))
 	if err != nil {
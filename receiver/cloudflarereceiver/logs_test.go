@@ -6,6 +6,7 @@ package cloudflarereceiver
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,17 +19,26 @@ import (
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/extensionauth"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.37.0"
 	"go.uber.org/zap/zaptest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
 )
@@ -51,8 +61,11 @@ func TestPayloadToLogRecord(t *testing.T) {
 			expectedLogs: func(t *testing.T, payload string) plog.Logs {
 				logs := plog.NewLogs()
 				rl := logs.ResourceLogs().AppendEmpty()
+				rl.SetSchemaUrl(conventions.SchemaURL)
 				sl := rl.ScopeLogs().AppendEmpty()
 				sl.Scope().SetName("github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver")
+				sl.Scope().SetVersion("latest")
+				sl.SetSchemaUrl(conventions.SchemaURL)
 
 				for idx, line := range strings.Split(payload, "\n") {
 					lr := sl.LogRecords().AppendEmpty()
@@ -85,6 +98,7 @@ func TestPayloadToLogRecord(t *testing.T) {
 			expectedLogs: func(t *testing.T, payload string) plog.Logs {
 				logs := plog.NewLogs()
 				rl := logs.ResourceLogs().AppendEmpty()
+				rl.SetSchemaUrl(conventions.SchemaURL)
 
 				require.NoError(t, rl.Resource().Attributes().FromRaw(map[string]any{
 					"cloudflare.zone": "otlpdev.net",
@@ -92,6 +106,8 @@ func TestPayloadToLogRecord(t *testing.T) {
 
 				sl := rl.ScopeLogs().AppendEmpty()
 				sl.Scope().SetName("github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver")
+				sl.Scope().SetVersion("latest")
+				sl.SetSchemaUrl(conventions.SchemaURL)
 				lr := sl.LogRecords().AppendEmpty()
 
 				require.NoError(t, lr.Attributes().FromRaw(map[string]any{
@@ -121,8 +137,11 @@ func TestPayloadToLogRecord(t *testing.T) {
 			expectedLogs: func(t *testing.T, payload string) plog.Logs {
 				logs := plog.NewLogs()
 				rl := logs.ResourceLogs().AppendEmpty()
+				rl.SetSchemaUrl(conventions.SchemaURL)
 				sl := rl.ScopeLogs().AppendEmpty()
 				sl.Scope().SetName("github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver")
+				sl.Scope().SetVersion("latest")
+				sl.SetSchemaUrl(conventions.SchemaURL)
 
 				for idx, line := range strings.Split(payload, "\n") {
 					lr := sl.LogRecords().AppendEmpty()
@@ -166,7 +185,7 @@ func TestPayloadToLogRecord(t *testing.T) {
 			var logs plog.Logs
 			rawLogs, err := parsePayload([]byte(tc.payload))
 			if err == nil {
-				logs = recv.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
+				logs = recv.converter.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
 			}
 			if tc.expectedErr != "" {
 				require.Error(t, err)
@@ -243,6 +262,34 @@ func TestHandleRequest(t *testing.T) {
 			consumerFailure:    false,
 			expectedStatusCode: http.StatusUnauthorized,
 		},
+		{
+			name: "Invalid secret provided",
+			request: &http.Request{
+				Method: http.MethodPost,
+				URL:    &url.URL{},
+				Body:   io.NopCloser(bytes.NewBufferString(`{"ClientIP": "127.0.0.1"}`)),
+				Header: map[string][]string{
+					textproto.CanonicalMIMEHeaderKey(secretHeaderName): {"ghi789"},
+				},
+			},
+			logExpected:        false,
+			consumerFailure:    false,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "Additional secret provided",
+			request: &http.Request{
+				Method: http.MethodPost,
+				URL:    &url.URL{},
+				Body:   io.NopCloser(bytes.NewBufferString(`{"ClientIP": "127.0.0.1", "MyTimestamp": "2023-03-03T05:29:06Z"}`)),
+				Header: map[string][]string{
+					textproto.CanonicalMIMEHeaderKey(secretHeaderName): {"def456"},
+				},
+			},
+			logExpected:        true,
+			consumerFailure:    false,
+			expectedStatusCode: http.StatusOK,
+		},
 		{
 			name: "Invalid payload",
 			request: &http.Request{
@@ -316,6 +363,94 @@ func TestHandleRequest(t *testing.T) {
 			consumerFailure:    false,
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name: "Request succeeds with gzip detected from magic bytes",
+			request: &http.Request{
+				Method: http.MethodPost,
+				URL:    &url.URL{},
+				Body:   io.NopCloser(bytes.NewBufferString(gzippedMessage(`{"ClientIP": "127.0.0.1", "MyTimestamp": "2023-03-03T05:29:06Z"}`))),
+				Header: map[string][]string{
+					textproto.CanonicalMIMEHeaderKey(secretHeaderName): {"abc123"},
+				},
+			},
+			logExpected:        true,
+			consumerFailure:    false,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Request fails with gzip exceeding max decompressed size",
+			request: &http.Request{
+				Method: http.MethodPost,
+				URL:    &url.URL{},
+				Body:   io.NopCloser(bytes.NewBufferString(gzippedMessage(`{"ClientIP": "` + strings.Repeat("1", 1024) + `"}`))),
+				Header: map[string][]string{
+					textproto.CanonicalMIMEHeaderKey(secretHeaderName):   {"abc123"},
+					textproto.CanonicalMIMEHeaderKey("Content-Encoding"): {"gzip"},
+				},
+			},
+			logExpected:        false,
+			consumerFailure:    false,
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "Request succeeds with zstd",
+			request: &http.Request{
+				Method: http.MethodPost,
+				URL:    &url.URL{},
+				Body:   io.NopCloser(bytes.NewBufferString(zstdMessage(`{"ClientIP": "127.0.0.1", "MyTimestamp": "2023-03-03T05:29:06Z"}`))),
+				Header: map[string][]string{
+					textproto.CanonicalMIMEHeaderKey(secretHeaderName):   {"abc123"},
+					textproto.CanonicalMIMEHeaderKey("Content-Encoding"): {"zstd"},
+				},
+			},
+			logExpected:        true,
+			consumerFailure:    false,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Request succeeds with zstd detected from magic bytes",
+			request: &http.Request{
+				Method: http.MethodPost,
+				URL:    &url.URL{},
+				Body:   io.NopCloser(bytes.NewBufferString(zstdMessage(`{"ClientIP": "127.0.0.1", "MyTimestamp": "2023-03-03T05:29:06Z"}`))),
+				Header: map[string][]string{
+					textproto.CanonicalMIMEHeaderKey(secretHeaderName): {"abc123"},
+				},
+			},
+			logExpected:        true,
+			consumerFailure:    false,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Request fails with zstd exceeding max decompressed size",
+			request: &http.Request{
+				Method: http.MethodPost,
+				URL:    &url.URL{},
+				Body:   io.NopCloser(bytes.NewBufferString(zstdMessage(`{"ClientIP": "` + strings.Repeat("1", 1024) + `"}`))),
+				Header: map[string][]string{
+					textproto.CanonicalMIMEHeaderKey(secretHeaderName):   {"abc123"},
+					textproto.CanonicalMIMEHeaderKey("Content-Encoding"): {"zstd"},
+				},
+			},
+			logExpected:        false,
+			consumerFailure:    false,
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "Request fails to decompress zstd",
+			request: &http.Request{
+				Method: http.MethodPost,
+				URL:    &url.URL{},
+				Body:   io.NopCloser(bytes.NewBufferString(`thisisnotvalidzstdcontent`)),
+				Header: map[string][]string{
+					textproto.CanonicalMIMEHeaderKey(secretHeaderName):   {"abc123"},
+					textproto.CanonicalMIMEHeaderKey("Content-Encoding"): {"zstd"},
+				},
+			},
+			logExpected:        false,
+			consumerFailure:    false,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
 		{
 			name: "Request fails to unzip gzip",
 			request: &http.Request{
@@ -364,11 +499,13 @@ func TestHandleRequest(t *testing.T) {
 				Logs: LogsConfig{
 					Endpoint:       "localhost:0",
 					Secret:         "abc123",
+					Secrets:        []configopaque.String{"def456"},
 					TimestampField: "MyTimestamp",
 					Attributes: map[string]string{
 						"ClientIP": "http_request.client_ip",
 					},
-					TLS: &configtls.ServerConfig{},
+					TLS:                 &configtls.ServerConfig{},
+					MaxDecompressedSize: 1024,
 				},
 			},
 				consumer,
@@ -415,8 +552,11 @@ func TestEmptyAttributes(t *testing.T) {
 	expectedLogs := func(t *testing.T, payload string) plog.Logs {
 		logs := plog.NewLogs()
 		rl := logs.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl(conventions.SchemaURL)
 		sl := rl.ScopeLogs().AppendEmpty()
 		sl.Scope().SetName("github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver")
+		sl.Scope().SetVersion("latest")
+		sl.SetSchemaUrl(conventions.SchemaURL)
 
 		for idx, line := range strings.Split(payload, "\n") {
 			lr := sl.LogRecords().AppendEmpty()
@@ -466,7 +606,7 @@ func TestEmptyAttributes(t *testing.T) {
 			var logs plog.Logs
 			rawLogs, err := parsePayload([]byte(tc.payload))
 			if err == nil {
-				logs = recv.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
+				logs = recv.converter.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
 			}
 			require.NoError(t, err)
 			require.NotNil(t, logs)
@@ -503,8 +643,11 @@ func TestAttributesWithSeparator(t *testing.T) {
 	expectedLogs := func(t *testing.T, payload, separator string) plog.Logs {
 		logs := plog.NewLogs()
 		rl := logs.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl(conventions.SchemaURL)
 		sl := rl.ScopeLogs().AppendEmpty()
 		sl.Scope().SetName("github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver")
+		sl.Scope().SetVersion("latest")
+		sl.SetSchemaUrl(conventions.SchemaURL)
 
 		for idx, line := range strings.Split(payload, "\n") {
 			lr := sl.LogRecords().AppendEmpty()
@@ -554,7 +697,7 @@ func TestAttributesWithSeparator(t *testing.T) {
 			var logs plog.Logs
 			rawLogs, err := parsePayload([]byte(tc.payload))
 			if err == nil {
-				logs = recv.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
+				logs = recv.converter.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
 			}
 			require.NoError(t, err)
 			require.NotNil(t, logs)
@@ -582,8 +725,11 @@ func TestMultipleMapAttributes(t *testing.T) {
 	expectedLogs := func(t *testing.T, payload string) plog.Logs {
 		logs := plog.NewLogs()
 		rl := logs.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl(conventions.SchemaURL)
 		sl := rl.ScopeLogs().AppendEmpty()
 		sl.Scope().SetName("github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver")
+		sl.Scope().SetVersion("latest")
+		sl.SetSchemaUrl(conventions.SchemaURL)
 
 		for idx, line := range strings.Split(payload, "\n") {
 			lr := sl.LogRecords().AppendEmpty()
@@ -633,7 +779,7 @@ func TestMultipleMapAttributes(t *testing.T) {
 			var logs plog.Logs
 			rawLogs, err := parsePayload([]byte(tc.payload))
 			if err == nil {
-				logs = recv.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
+				logs = recv.converter.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
 			}
 			require.NoError(t, err)
 			require.NotNil(t, logs)
@@ -653,6 +799,15 @@ func gzippedMessage(message string) string {
 	return b.String()
 }
 
+func zstdMessage(message string) string {
+	w, err := zstd.NewWriter(nil)
+	if err != nil {
+		panic(err)
+	}
+	defer w.Close()
+	return string(w.EncodeAll([]byte(message), nil))
+}
+
 func newReceiver(t *testing.T, cfg *Config, nextConsumer consumer.Logs) *logsReceiver {
 	// Default timestamp_format to rfc3339 for tests
 	if cfg.Logs.TimestampFormat == "" {
@@ -664,3 +819,416 @@ func newReceiver(t *testing.T, cfg *Config, nextConsumer consumer.Logs) *logsRec
 	require.NoError(t, err)
 	return r
 }
+
+type authHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h authHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type bearerAuthenticator struct {
+	component.StartFunc
+	component.ShutdownFunc
+	extensionauth.ServerAuthenticateFunc
+}
+
+func TestAuthenticator(t *testing.T) {
+	authID := component.MustNewID("bearertokenauth")
+	host := authHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			authID: bearerAuthenticator{
+				ServerAuthenticateFunc: func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+					if http.Header(headers).Get("Authorization") != "Bearer abc123" {
+						return ctx, errors.New("invalid token")
+					}
+					return ctx, nil
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		authorization      string
+		expectedStatusCode int
+	}{
+		{
+			name:               "valid token",
+			authorization:      "Bearer abc123",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "invalid token",
+			authorization:      "Bearer def456",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := &consumertest.LogsSink{}
+			r := newReceiver(t, &Config{
+				Logs: LogsConfig{
+					Endpoint:       "localhost:0",
+					TimestampField: "MyTimestamp",
+					Auth:           configoptional.Some(configauth.Config{AuthenticatorID: authID}),
+				},
+			}, sink)
+			require.NoError(t, r.Start(t.Context(), host))
+			defer func() {
+				require.NoError(t, r.Shutdown(t.Context()))
+			}()
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"ClientIP": "127.0.0.1", "MyTimestamp": "2023-03-03T05:29:06Z"}`))
+			req.Header.Set("Authorization", tc.authorization)
+			rec := httptest.NewRecorder()
+			r.server.Handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestAuthenticatorNotFound(t *testing.T) {
+	r := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint: "localhost:0",
+			Auth:     configoptional.Some(configauth.Config{AuthenticatorID: component.MustNewID("missing")}),
+		},
+	}, consumertest.NewNop())
+	require.ErrorContains(t, r.Start(t.Context(), componenttest.NewNopHost()), "failed to get server authenticator")
+}
+
+func TestDatasets(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	r := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint:        "localhost:0",
+			TimestampField:  "EdgeStartTimestamp",
+			TimestampFormat: "rfc3339",
+			Attributes:      map[string]string{"ClientIP": "client.address"},
+			Datasets: []DatasetConfig{
+				{
+					Path:               "/http_requests",
+					ResourceAttributes: map[string]string{"cloudflare.dataset": "http_requests"},
+				},
+				{
+					Path:               "/firewall_events",
+					TimestampField:     "Datetime",
+					TimestampFormat:    "unix",
+					Attributes:         map[string]string{"RayID": "cloudflare.ray_id"},
+					ResourceAttributes: map[string]string{"cloudflare.dataset": "firewall_events"},
+				},
+				{
+					Path:               "/gateway_http",
+					Dataset:            "gateway_http",
+					ResourceAttributes: map[string]string{"cloudflare.account.id": "01a7362d577a6c3019a474fd6f485823"},
+				},
+			},
+		},
+	}, sink)
+
+	testCases := []struct {
+		name                       string
+		path                       string
+		payload                    string
+		expectedTimestamp          time.Time
+		expectedAttributes         map[string]any
+		expectedResourceAttributes map[string]any
+	}{
+		{
+			name:                       "dataset with default settings",
+			path:                       "/http_requests",
+			payload:                    `{"ClientIP":"89.163.253.200","EdgeStartTimestamp":"2023-03-03T05:29:05Z","RayID":"3a6050bcbe121a87"}`,
+			expectedTimestamp:          time.Date(2023, 3, 3, 5, 29, 5, 0, time.UTC),
+			expectedAttributes:         map[string]any{"client.address": "89.163.253.200"},
+			expectedResourceAttributes: map[string]any{"cloudflare.dataset": "http_requests"},
+		},
+		{
+			name:                       "dataset with own settings",
+			path:                       "/firewall_events",
+			payload:                    `{"ClientIP":"89.163.253.200","Datetime":1677821346,"RayID":"3a6050bcbe121a87"}`,
+			expectedTimestamp:          time.Date(2023, 3, 3, 5, 29, 6, 0, time.UTC),
+			expectedAttributes:         map[string]any{"cloudflare.ray_id": "3a6050bcbe121a87"},
+			expectedResourceAttributes: map[string]any{"cloudflare.dataset": "firewall_events"},
+		},
+		{
+			name:               "dataset with known timestamp field",
+			path:               "/gateway_http",
+			payload:            `{"ClientIP":"89.163.253.200","Datetime":"2023-03-03T05:29:07Z"}`,
+			expectedTimestamp:  time.Date(2023, 3, 3, 5, 29, 7, 0, time.UTC),
+			expectedAttributes: map[string]any{"client.address": "89.163.253.200"},
+			expectedResourceAttributes: map[string]any{
+				"cloudflare.dataset":    "gateway_http",
+				"cloudflare.account.id": "01a7362d577a6c3019a474fd6f485823",
+			},
+		},
+		{
+			name:                       "unknown path",
+			path:                       "/",
+			payload:                    `{"ClientIP":"89.163.253.200","EdgeStartTimestamp":"2023-03-03T05:29:05Z","RayID":"3a6050bcbe121a87"}`,
+			expectedTimestamp:          time.Date(2023, 3, 3, 5, 29, 5, 0, time.UTC),
+			expectedAttributes:         map[string]any{"client.address": "89.163.253.200"},
+			expectedResourceAttributes: map[string]any{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink.Reset()
+			rec := httptest.NewRecorder()
+			r.handleRequest(rec, httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.payload)))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			require.Len(t, sink.AllLogs(), 1)
+			rl := sink.AllLogs()[0].ResourceLogs().At(0)
+			require.Equal(t, tc.expectedResourceAttributes, rl.Resource().Attributes().AsRaw())
+			lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+			require.Equal(t, tc.expectedTimestamp, lr.Timestamp().AsTime())
+			require.Equal(t, tc.expectedAttributes, lr.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestDetectDataset(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	r := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint:        "localhost:0",
+			TimestampField:  "EdgeStartTimestamp",
+			TimestampFormat: "rfc3339",
+			DetectDataset:   true,
+			Datasets:        []DatasetConfig{{Path: "/custom", TimestampField: "Datetime"}},
+		},
+	}, sink)
+
+	testCases := []struct {
+		name                       string
+		path                       string
+		payload                    string
+		expectedTimestamp          time.Time
+		expectedResourceAttributes map[string]any
+	}{
+		{
+			name:                       "http_requests",
+			path:                       "/",
+			payload:                    `{"ClientIP":"89.163.253.200","EdgeStartTimestamp":"2023-03-03T05:29:05Z","RayID":"3a6050bcbe121a87"}`,
+			expectedTimestamp:          time.Date(2023, 3, 3, 5, 29, 5, 0, time.UTC),
+			expectedResourceAttributes: map[string]any{"cloudflare.dataset": "http_requests"},
+		},
+		{
+			name:                       "firewall_events",
+			path:                       "/logs",
+			payload:                    `{"Action":"block","ClientIP":"89.163.253.200","Datetime":"2023-03-03T05:29:06Z","RayName":"3a6050bcbe121a87"}`,
+			expectedTimestamp:          time.Date(2023, 3, 3, 5, 29, 6, 0, time.UTC),
+			expectedResourceAttributes: map[string]any{"cloudflare.dataset": "firewall_events"},
+		},
+		{
+			name:                       "workers_trace_events",
+			path:                       "/",
+			payload:                    `{"EventTimestampMs":1677821347000,"Outcome":"ok","ScriptName":"api"}`,
+			expectedTimestamp:          time.Date(2023, 3, 3, 5, 29, 7, 0, time.UTC),
+			expectedResourceAttributes: map[string]any{"cloudflare.dataset": "workers_trace_events"},
+		},
+		{
+			name:                       "partial signature",
+			path:                       "/",
+			payload:                    `{"EdgeStartTimestamp":"2023-03-03T05:29:08Z","Datetime":"2023-03-03T05:29:00Z"}`,
+			expectedTimestamp:          time.Date(2023, 3, 3, 5, 29, 8, 0, time.UTC),
+			expectedResourceAttributes: map[string]any{"cloudflare.dataset": "http_requests"},
+		},
+		{
+			name:                       "path with dataset settings",
+			path:                       "/custom",
+			payload:                    `{"Action":"block","Datetime":"2023-03-03T05:29:09Z","EdgeStartTimestamp":"2023-03-03T05:29:00Z","RayName":"1"}`,
+			expectedTimestamp:          time.Date(2023, 3, 3, 5, 29, 9, 0, time.UTC),
+			expectedResourceAttributes: map[string]any{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink.Reset()
+			rec := httptest.NewRecorder()
+			r.handleRequest(rec, httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.payload)))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			require.Len(t, sink.AllLogs(), 1)
+			rl := sink.AllLogs()[0].ResourceLogs().At(0)
+			require.Equal(t, tc.expectedResourceAttributes, rl.Resource().Attributes().AsRaw())
+			require.Equal(t, tc.expectedTimestamp, rl.ScopeLogs().At(0).LogRecords().At(0).Timestamp().AsTime())
+		})
+	}
+
+	require.Empty(t, detectDataset(map[string]any{"RayID": "3a6050bcbe121a87"}))
+}
+
+// blockingConsumer blocks until released, emulating a pipeline under backpressure.
+type blockingConsumer struct {
+	consumertest.LogsSink
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingConsumer) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
+	c.started <- struct{}{}
+	<-c.release
+	return c.LogsSink.ConsumeLogs(ctx, logs)
+}
+
+func TestMaxInFlightSize(t *testing.T) {
+	payload := `{"ClientIP":"89.163.253.200","EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`
+	next := &blockingConsumer{started: make(chan struct{}), release: make(chan struct{})}
+	r := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint:        "localhost:0",
+			TimestampField:  "EdgeStartTimestamp",
+			MaxInFlightSize: int64(len(payload)) + 10,
+		},
+	}, next)
+
+	first := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.handleRequest(first, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+	}()
+	<-next.started
+
+	// The first payload is still being processed, so there is no room for the second one.
+	second := httptest.NewRecorder()
+	r.handleRequest(second, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+	require.Equal(t, http.StatusTooManyRequests, second.Code)
+
+	close(next.release)
+	<-done
+	require.Equal(t, http.StatusOK, first.Code)
+	require.Zero(t, r.inFlightSize.Load())
+
+	go func() { <-next.started }()
+	third := httptest.NewRecorder()
+	r.handleRequest(third, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+	require.Equal(t, http.StatusOK, third.Code)
+	require.Equal(t, 2, next.LogRecordCount())
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	payload := `{"ClientIP":"89.163.253.200","EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`
+	next := &blockingConsumer{started: make(chan struct{}), release: make(chan struct{})}
+	r := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint:              "localhost:0",
+			TimestampField:        "EdgeStartTimestamp",
+			MaxConcurrentRequests: 1,
+		},
+	}, next)
+
+	first := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.server.Handler.ServeHTTP(first, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+	}()
+	<-next.started
+
+	// The first request is still being handled, so the second one is refused.
+	second := httptest.NewRecorder()
+	r.server.Handler.ServeHTTP(second, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+	require.Equal(t, http.StatusTooManyRequests, second.Code)
+
+	close(next.release)
+	<-done
+	require.Equal(t, http.StatusOK, first.Code)
+
+	go func() { <-next.started }()
+	third := httptest.NewRecorder()
+	r.server.Handler.ServeHTTP(third, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+	require.Equal(t, http.StatusOK, third.Code)
+}
+
+func TestMaxRequestBodySize(t *testing.T) {
+	payload := `{"ClientIP":"89.163.253.200","EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`
+	sink := &consumertest.LogsSink{}
+	r := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint:           "localhost:0",
+			TimestampField:     "EdgeStartTimestamp",
+			MaxRequestBodySize: int64(len(payload)),
+			ReadTimeout:        time.Minute,
+			IdleTimeout:        2 * time.Minute,
+		},
+	}, sink)
+	require.Equal(t, time.Minute, r.server.ReadTimeout)
+	require.Equal(t, 2*time.Minute, r.server.IdleTimeout)
+
+	rec := httptest.NewRecorder()
+	r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// Larger bodies are refused before being processed.
+	rec = httptest.NewRecorder()
+	r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload+"\n"+payload)))
+	require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	require.Equal(t, 1, sink.LogRecordCount())
+}
+
+// stalledConsumer blocks until the context is done, emulating a pipeline that is stuck.
+type stalledConsumer struct {
+	consumertest.LogsSink
+	err error
+}
+
+func (c *stalledConsumer) ConsumeLogs(ctx context.Context, _ plog.Logs) error {
+	<-ctx.Done()
+	return c.err
+}
+
+func TestConsumeTimeout(t *testing.T) {
+	payload := `{"ClientIP":"89.163.253.200","EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`
+	for _, next := range []*stalledConsumer{{err: context.DeadlineExceeded}, {}} {
+		r := newReceiver(t, &Config{
+			Logs: LogsConfig{
+				Endpoint:       "localhost:0",
+				TimestampField: "EdgeStartTimestamp",
+				ConsumeTimeout: 10 * time.Millisecond,
+			},
+		}, next)
+
+		// The payload is answered with a 503 status once the timeout expires, whatever the consumer returns.
+		rec := httptest.NewRecorder()
+		r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		require.Zero(t, r.inFlightSize.Load())
+	}
+}
+
+func TestProcessLogsGroupsByZone(t *testing.T) {
+	defer testutil.SetFeatureGateForTest(t, zoneNameAttributeFeatureGate, true)()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Endpoint = "localhost:0"
+	recv := newReceiver(t, cfg, &consumertest.LogsSink{})
+
+	rawLogs, err := parsePayload([]byte(`{"EdgeStartTimestamp":"2023-03-03T05:29:05Z","ZoneID":"023e105f4ecef8ad9ca31a8372d0c353","ZoneName":"example.com"}
+{"EdgeStartTimestamp":"2023-03-03T05:29:06Z","ZoneID":"372e67954025e0ba6aaa6d586b9e0b59","ZoneName":"example.net"}
+{"EdgeStartTimestamp":"2023-03-03T05:29:07Z","ZoneID":"023e105f4ecef8ad9ca31a8372d0c353","ZoneName":"example.com"}
+{"EdgeStartTimestamp":"2023-03-03T05:29:08Z","ZoneID":1234}
+{"EdgeStartTimestamp":"2023-03-03T05:29:09Z"}`))
+	require.NoError(t, err)
+	logs := recv.converter.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
+
+	counts := map[string]int{}
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		counts[fmt.Sprint(rl.Resource().Attributes().AsRaw())] = rl.ScopeLogs().At(0).LogRecords().Len()
+	}
+	require.Equal(t, map[string]int{
+		fmt.Sprint(map[string]any{attrZoneID: "023e105f4ecef8ad9ca31a8372d0c353", attrZoneName: "example.com"}): 2,
+		fmt.Sprint(map[string]any{attrZoneID: "372e67954025e0ba6aaa6d586b9e0b59", attrZoneName: "example.net"}): 1,
+		fmt.Sprint(map[string]any{attrZoneID: "1234"}):                                                          1,
+		fmt.Sprint(map[string]any{}): 1,
+	}, counts)
+}
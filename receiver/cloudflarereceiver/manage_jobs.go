@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// jobsManager creates or updates the Logpush jobs of zones so that they send the logs of the
+// configured datasets to the Logpush endpoint. Jobs are identified by their name.
+type jobsManager struct {
+	cfg      *ManageJobsConfig
+	logs     *LogsConfig
+	settings component.TelemetrySettings
+	logger   *zap.Logger
+	client   client
+	// zoneIDs holds the IDs of the zones, the configured names being resolved on start.
+	zoneIDs []string
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+func newJobsManager(settings component.TelemetrySettings, cfg *Config) *jobsManager {
+	return &jobsManager{
+		cfg:      cfg.ManageJobs.Get(),
+		logs:     &cfg.Logs,
+		settings: settings,
+		logger:   settings.Logger,
+		zoneIDs:  cfg.ManageJobs.Get().Zones,
+	}
+}
+
+// start reconciles the jobs in the background, once the Logpush endpoint listens, since Cloudflare
+// sends a test request to the destination of the jobs it creates.
+func (m *jobsManager) start(ctx context.Context, host component.Host) error {
+	var err error
+	m.client, err = newClient(ctx, &m.cfg.APIConfig, host, m.settings)
+	if err != nil {
+		return err
+	}
+	verifyToken(ctx, m.client, m.logger, "manage_jobs", manageJobsPermission)
+	if m.zoneIDs, err = resolveZoneIDs(ctx, m.client, m.cfg.Zones); err != nil {
+		return err
+	}
+
+	reconcileCtx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		if err := m.reconcile(reconcileCtx); err != nil && reconcileCtx.Err() == nil {
+			m.logger.Error("Failed to provision Logpush jobs", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (m *jobsManager) shutdown() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.wg.Wait()
+}
+
+// reconcile creates the missing jobs and updates the existing ones of every zone.
+func (m *jobsManager) reconcile(ctx context.Context) error {
+	var errs error
+	for _, zoneID := range m.zoneIDs {
+		jobs, err := m.client.ListZoneLogpushJobs(ctx, zoneID)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to list Logpush jobs of zone %s: %w", zoneID, err))
+			continue
+		}
+		existing := make(map[string]int64, len(jobs))
+		for _, job := range jobs {
+			existing[job.Name] = job.ID
+		}
+
+		for i := range m.cfg.Datasets {
+			jobCfg := &m.cfg.Datasets[i]
+			request, err := m.jobRequest(jobCfg)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+
+			if id, ok := existing[jobCfg.name()]; ok {
+				// The name and dataset of a job can't be updated.
+				request.Name, request.Dataset = "", ""
+				if _, err := m.client.UpdateZoneLogpushJob(ctx, zoneID, id, request); err != nil {
+					errs = multierr.Append(errs, fmt.Errorf("failed to update Logpush job %q of zone %s: %w", jobCfg.name(), zoneID, err))
+					continue
+				}
+				m.logger.Info("Updated Logpush job", zap.String("zone", zoneID), zap.String("job", jobCfg.name()), zap.Int64("id", id))
+				continue
+			}
+
+			job, err := m.client.CreateZoneLogpushJob(ctx, zoneID, request)
+			if err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("failed to create Logpush job %q of zone %s: %w", jobCfg.name(), zoneID, err))
+				continue
+			}
+			m.logger.Info("Created Logpush job", zap.String("zone", zoneID), zap.String("job", jobCfg.name()), zap.Int64("id", job.ID))
+		}
+	}
+	return errs
+}
+
+// jobRequest returns the settings of the job of a dataset.
+func (m *jobsManager) jobRequest(jobCfg *ManagedJobConfig) (logpushJobRequest, error) {
+	destination, err := m.destinationConf(jobCfg.Dataset)
+	if err != nil {
+		return logpushJobRequest{}, err
+	}
+
+	return logpushJobRequest{
+		Name:            jobCfg.name(),
+		Dataset:         jobCfg.Dataset,
+		DestinationConf: destination,
+		Enabled:         true,
+		Filter:          jobCfg.Filter,
+		OutputOptions: logpushOutputOptions{
+			FieldNames:      jobCfg.Fields,
+			OutputType:      "ndjson",
+			TimestampFormat: m.jobTimestampFormat(jobCfg.Dataset),
+		},
+	}, nil
+}
+
+// destinationConf returns the HTTP destination of the job of a dataset: the path of the dataset in
+// the logs configuration, if any, with the secret sent in the X-CF-Secret header.
+func (m *jobsManager) destinationConf(dataset string) (string, error) {
+	destination, err := url.Parse(m.cfg.DestinationURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid destination_url: %w", err)
+	}
+	if ds := m.datasetConfig(dataset); ds != nil {
+		destination.Path = strings.TrimSuffix(destination.Path, "/") + ds.Path
+	}
+	if secrets := m.logs.acceptedSecrets(); len(secrets) != 0 {
+		query := destination.Query()
+		query.Set("header_"+secretHeaderName, string(secrets[0]))
+		destination.RawQuery = query.Encode()
+	}
+	return destination.String(), nil
+}
+
+// jobTimestampFormat returns the format of the timestamps sent by the job of a dataset, matching the
+// format the logs of the dataset are parsed with. Empty leaves the default of the job, which is used
+// for formats Logpush doesn't support.
+func (m *jobsManager) jobTimestampFormat(dataset string) string {
+	format := m.logs.TimestampFormat
+	if ds := m.datasetConfig(dataset); ds != nil && ds.TimestampFormat != "" {
+		format = ds.TimestampFormat
+	}
+	switch format {
+	case "unix", "unixnano", "rfc3339":
+		return format
+	default:
+		return ""
+	}
+}
+
+// datasetConfig returns the first dataset configuration of logs for the dataset, or nil.
+func (m *jobsManager) datasetConfig(dataset string) *DatasetConfig {
+	for i := range m.logs.Datasets {
+		if m.logs.Datasets[i].Dataset == dataset {
+			return &m.logs.Datasets[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+)
+
+// fakeJobsClient records the jobs created and updated.
+type fakeJobsClient struct {
+	client
+	jobs    []logpushJob
+	created []logpushJobRequest
+	updated map[int64]logpushJobRequest
+}
+
+func (f *fakeJobsClient) ListZoneLogpushJobs(context.Context, string) ([]logpushJob, error) {
+	return f.jobs, nil
+}
+
+func (f *fakeJobsClient) CreateZoneLogpushJob(_ context.Context, _ string, request logpushJobRequest) (logpushJob, error) {
+	f.created = append(f.created, request)
+	return logpushJob{ID: 3, Name: request.Name, Dataset: request.Dataset}, nil
+}
+
+func (f *fakeJobsClient) UpdateZoneLogpushJob(_ context.Context, _ string, jobID int64, request logpushJobRequest) (logpushJob, error) {
+	f.updated[jobID] = request
+	return logpushJob{ID: jobID}, nil
+}
+
+func TestJobsManagerReconcile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Endpoint = "localhost:0"
+	cfg.Logs.Secret = "abc123"
+	cfg.Logs.Datasets = []DatasetConfig{{Path: "/firewall", Dataset: "firewall_events", TimestampFormat: "unixnano"}}
+	manageJobsCfg := cfg.ManageJobs.GetOrInsertDefault()
+	manageJobsCfg.DestinationURL = "https://logs.example.com/cloudflare/"
+	manageJobsCfg.Zones = []string{testZoneID}
+	manageJobsCfg.Datasets = []ManagedJobConfig{
+		{Dataset: "http_requests", Fields: []string{"EdgeStartTimestamp", "RayID"}},
+		{Dataset: "firewall_events", Fields: []string{"Datetime", "RayID"}, Filter: `{"where":{"key":"Action","operator":"eq","value":"block"}}`},
+	}
+	cfg.ManageJobs = configoptional.Some(*manageJobsCfg)
+
+	fake := &fakeJobsClient{
+		jobs: []logpushJob{
+			{ID: 1, Name: "manual", Dataset: "http_requests"},
+			{ID: 2, Name: "otelcol-firewall-events", Dataset: "firewall_events"},
+		},
+		updated: map[int64]logpushJobRequest{},
+	}
+	m := newJobsManager(componenttest.NewNopTelemetrySettings(), cfg)
+	m.client = fake
+	require.NoError(t, m.reconcile(t.Context()))
+
+	// The job of http_requests is missing, so it's created with the default path.
+	require.Equal(t, []logpushJobRequest{{
+		Name:            "otelcol-http-requests",
+		Dataset:         "http_requests",
+		DestinationConf: "https://logs.example.com/cloudflare/?header_X-CF-Secret=abc123",
+		Enabled:         true,
+		OutputOptions: logpushOutputOptions{
+			FieldNames:      []string{"EdgeStartTimestamp", "RayID"},
+			OutputType:      "ndjson",
+			TimestampFormat: "rfc3339",
+		},
+	}}, fake.created)
+	// The job of firewall_events exists, so it's updated to send to the path of the dataset.
+	require.Equal(t, map[int64]logpushJobRequest{2: {
+		DestinationConf: "https://logs.example.com/cloudflare/firewall?header_X-CF-Secret=abc123",
+		Enabled:         true,
+		Filter:          `{"where":{"key":"Action","operator":"eq","value":"block"}}`,
+		OutputOptions: logpushOutputOptions{
+			FieldNames:      []string{"Datetime", "RayID"},
+			OutputType:      "ndjson",
+			TimestampFormat: "unixnano",
+		},
+	}}, fake.updated)
+}
+
+func TestLogpushJobsClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, "https://logs.example.com", body["destination_conf"])
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/zones/"+testZoneID+"/logpush/jobs":
+			require.Equal(t, "http_requests", body["dataset"])
+			require.NoError(t, json.NewEncoder(rw).Encode(map[string]any{
+				"success": true,
+				"result":  map[string]any{"id": 1, "name": "otelcol-http-requests", "dataset": "http_requests", "enabled": true},
+			}))
+		case req.Method == http.MethodPut && req.URL.Path == "/zones/"+testZoneID+"/logpush/jobs/1":
+			require.NotContains(t, body, "dataset")
+			require.NoError(t, json.NewEncoder(rw).Encode(map[string]any{
+				"success": true,
+				"result":  map[string]any{"id": 1, "name": "otelcol-http-requests", "dataset": "http_requests", "enabled": true},
+			}))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	c, err := newClient(t.Context(), &APIConfig{ClientConfig: clientConfig, APIToken: "abc123"}, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	request := logpushJobRequest{
+		Name:            "otelcol-http-requests",
+		Dataset:         "http_requests",
+		DestinationConf: "https://logs.example.com",
+		Enabled:         true,
+		OutputOptions:   logpushOutputOptions{FieldNames: []string{"RayID"}, OutputType: "ndjson"},
+	}
+	job, err := c.CreateZoneLogpushJob(t.Context(), testZoneID, request)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), job.ID)
+
+	request.Name, request.Dataset = "", ""
+	job, err = c.UpdateZoneLogpushJob(t.Context(), testZoneID, 1, request)
+	require.NoError(t, err)
+	require.Equal(t, "otelcol-http-requests", job.Name)
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	rcvr "go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/errorutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+const (
+	webhookSecretHeaderName = "cf-webhook-auth"
+	// maxNotificationSize is the maximum size in bytes of a notification payload, which is a small
+	// JSON document.
+	maxNotificationSize = 1 << 20
+)
+
+// Attributes set on notification log records.
+const (
+	attrNotificationAlertType  = "cloudflare.notification.alert_type"
+	attrNotificationPolicyID   = "cloudflare.notification.policy.id"
+	attrNotificationPolicyName = "cloudflare.notification.policy.name"
+)
+
+// notificationSeverities maps the alert types of Cloudflare Notifications to log severities.
+// Alert types that are not listed are informational.
+var notificationSeverities = map[string]plog.SeverityNumber{
+	"dos_attack_l4":                 plog.SeverityNumberError,
+	"dos_attack_l7":                 plog.SeverityNumberError,
+	"advanced_ddos_attack_l4_alert": plog.SeverityNumberError,
+	"advanced_ddos_attack_l7_alert": plog.SeverityNumberError,
+	"fbm_volumetric_attack":         plog.SeverityNumberError,
+	"http_alert_origin_error":       plog.SeverityNumberError,
+	"http_alert_edge_error":         plog.SeverityNumberError,
+	"real_origin_monitoring":        plog.SeverityNumberError,
+
+	"health_check_status_notification":            plog.SeverityNumberWarn,
+	"load_balancing_health_alert":                 plog.SeverityNumberWarn,
+	"tunnel_health_event":                         plog.SeverityNumberWarn,
+	"universal_ssl_event_type":                    plog.SeverityNumberWarn,
+	"dedicated_ssl_certificate_event_type":        plog.SeverityNumberWarn,
+	"custom_ssl_certificate_event_type":           plog.SeverityNumberWarn,
+	"access_custom_certificate_expiration_type":   plog.SeverityNumberWarn,
+	"zone_aop_custom_certificate_expiration_type": plog.SeverityNumberWarn,
+	"secondary_dns_zone_validation_warning":       plog.SeverityNumberWarn,
+	"billing_usage_alert":                         plog.SeverityNumberWarn,
+}
+
+// notification is the payload of a Cloudflare Notifications webhook. The raw payload is kept
+// alongside the decoded fields so it can be used as the log body.
+type notification struct {
+	// Name is the name of the notification policy that fired.
+	Name      string `json:"name"`
+	Timestamp int64  `json:"ts"`
+	AccountID string `json:"account_id"`
+	PolicyID  string `json:"policy_id"`
+	AlertType string `json:"alert_type"`
+
+	raw map[string]any
+}
+
+func (n *notification) UnmarshalJSON(data []byte) error {
+	type fields notification
+	if err := json.Unmarshal(data, (*fields)(n)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &n.raw)
+}
+
+// notificationsReceiver accepts Cloudflare Notifications webhooks and emits them as log records.
+type notificationsReceiver struct {
+	logger    *zap.Logger
+	cfg       *NotificationsConfig
+	server    *http.Server
+	consumer  consumer.Logs
+	wg        *sync.WaitGroup
+	obsrecv   *receiverhelper.ObsReport
+	buildInfo component.BuildInfo
+}
+
+func newNotificationsReceiver(params rcvr.Settings, cfg *NotificationsConfig, consumer consumer.Logs) (*notificationsReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             params.ID,
+		Transport:              "http",
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recv := &notificationsReceiver{
+		cfg:       cfg,
+		consumer:  consumer,
+		logger:    params.Logger,
+		wg:        &sync.WaitGroup{},
+		obsrecv:   obsrecv,
+		buildInfo: params.BuildInfo,
+	}
+
+	recv.server, err = newServer(http.HandlerFunc(recv.handleRequest), cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return recv, nil
+}
+
+func (n *notificationsReceiver) Start(ctx context.Context, host component.Host) error {
+	return startServer(ctx, host, n.logger, n.server, n.wg, n.cfg.Endpoint, n.cfg.TLS)
+}
+
+func (n *notificationsReceiver) Shutdown(ctx context.Context) error {
+	n.logger.Debug("Shutting down notifications server")
+	if err := n.server.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	n.wg.Wait()
+	return nil
+}
+
+func (n *notificationsReceiver) handleRequest(rw http.ResponseWriter, req *http.Request) {
+	if n.cfg.Secret != "" {
+		secretHeader := req.Header.Get(webhookSecretHeaderName)
+		if subtle.ConstantTimeCompare([]byte(secretHeader), []byte(string(n.cfg.Secret))) != 1 {
+			rw.WriteHeader(http.StatusUnauthorized)
+			n.logger.Debug("Got notification with missing or invalid secret, dropping...")
+			return
+		}
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(rw, req.Body, maxNotificationSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			rw.WriteHeader(http.StatusRequestEntityTooLarge)
+		} else {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		n.logger.Debug("Failed to read notification payload", zap.Error(err), zap.String("remote", req.RemoteAddr))
+		return
+	}
+
+	var notif notification
+	if err := json.Unmarshal(payload, &notif); err != nil {
+		rw.WriteHeader(http.StatusUnprocessableEntity)
+		n.logger.Error("Failed to decode notification payload", zap.Error(err))
+		return
+	}
+
+	pLogs := n.processNotification(pcommon.NewTimestampFromTime(time.Now()), notif)
+	obsCtx := n.obsrecv.StartLogsOp(req.Context())
+	err = n.consumer.ConsumeLogs(obsCtx, pLogs)
+	n.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), pLogs.LogRecordCount(), err)
+	if err != nil {
+		errorutil.HTTPError(rw, err)
+		n.logger.Error("Failed to consume notification as log", zap.Error(err))
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+}
+
+func (n *notificationsReceiver) processNotification(now pcommon.Timestamp, notif notification) plog.Logs {
+	logs := plog.NewLogs()
+	resourceLogs, scopeLogs := appendResourceLogs(logs, n.buildInfo)
+	if notif.AccountID != "" {
+		resourceLogs.Resource().Attributes().PutStr(attrAccountID, notif.AccountID)
+	}
+
+	logRecord := scopeLogs.LogRecords().AppendEmpty()
+	logRecord.SetObservedTimestamp(now)
+	if notif.Timestamp != 0 {
+		logRecord.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(notif.Timestamp, 0)))
+	}
+
+	sev, ok := notificationSeverities[notif.AlertType]
+	if !ok {
+		sev = plog.SeverityNumberInfo
+	}
+	logRecord.SetSeverityNumber(sev)
+	logRecord.SetSeverityText(sev.String())
+
+	attrs := logRecord.Attributes()
+	if notif.AlertType != "" {
+		attrs.PutStr(attrNotificationAlertType, notif.AlertType)
+	}
+	if notif.PolicyID != "" {
+		attrs.PutStr(attrNotificationPolicyID, notif.PolicyID)
+	}
+	if notif.Name != "" {
+		attrs.PutStr(attrNotificationPolicyName, notif.Name)
+	}
+
+	if err := logRecord.Body().SetEmptyMap().FromRaw(notif.raw); err != nil {
+		n.logger.Warn("unable to set body", zap.Error(err))
+	}
+
+	return logs
+}
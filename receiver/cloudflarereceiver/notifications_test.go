@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+const ddosNotification = `{
+	"name": "DDoS alerts",
+	"text": "Cloudflare has detected and mitigated a DDoS attack targeting example.com.",
+	"data": {"attack_id": "a1b2c3", "max_pps": "120000"},
+	"ts": 1714557600,
+	"account_id": "01a7362d577a6c3019a474fd6f485823",
+	"policy_id": "0da2b59e-f118-439d-8097-bdfb215203c9",
+	"alert_type": "dos_attack_l7"
+}`
+
+func newTestNotificationsReceiver(t *testing.T, next consumer.Logs, secret configopaque.String) *notificationsReceiver {
+	r, err := newNotificationsReceiver(receivertest.NewNopSettings(metadata.Type), &NotificationsConfig{
+		Endpoint: "localhost:0",
+		Secret:   secret,
+	}, next)
+	require.NoError(t, err)
+	return r
+}
+
+func TestNotificationsHandleRequest(t *testing.T) {
+	testCases := []struct {
+		name           string
+		payload        string
+		secret         string
+		consumer       consumer.Logs
+		expectedStatus int
+		expectedLogs   int
+	}{
+		{
+			name:           "valid notification",
+			payload:        ddosNotification,
+			secret:         "abc123",
+			expectedStatus: http.StatusOK,
+			expectedLogs:   1,
+		},
+		{
+			name:           "wrong secret",
+			payload:        ddosNotification,
+			secret:         "wrong",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid payload",
+			payload:        `{"name":`,
+			secret:         "abc123",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "payload too large",
+			payload:        `{"name":"` + strings.Repeat("a", maxNotificationSize) + `"}`,
+			secret:         "abc123",
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "consumer error",
+			payload:        ddosNotification,
+			secret:         "abc123",
+			consumer:       consumertest.NewErr(errors.New("consumer failed")),
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := &consumertest.LogsSink{}
+			next := tc.consumer
+			if next == nil {
+				next = sink
+			}
+			r := newTestNotificationsReceiver(t, next, "abc123")
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.payload))
+			req.Header.Set("cf-webhook-auth", tc.secret)
+			rec := httptest.NewRecorder()
+			r.handleRequest(rec, req)
+
+			require.Equal(t, tc.expectedStatus, rec.Code)
+			require.Equal(t, tc.expectedLogs, sink.LogRecordCount())
+		})
+	}
+}
+
+func TestNotificationsProcessNotification(t *testing.T) {
+	var notif notification
+	require.NoError(t, notif.UnmarshalJSON([]byte(ddosNotification)))
+
+	r := newTestNotificationsReceiver(t, consumertest.NewNop(), "")
+	logs := r.processNotification(pcommon.NewTimestampFromTime(time.Now()), notif)
+
+	expected := plog.NewLogs()
+	rl := expected.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl(conventions.SchemaURL)
+	rl.Resource().Attributes().PutStr("cloudflare.account.id", "01a7362d577a6c3019a474fd6f485823")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(metadata.ScopeName)
+	sl.Scope().SetVersion("latest")
+	sl.SetSchemaUrl(conventions.SchemaURL)
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1714557600, 0)))
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetSeverityText(plog.SeverityNumberError.String())
+	require.NoError(t, lr.Attributes().FromRaw(map[string]any{
+		"cloudflare.notification.alert_type":  "dos_attack_l7",
+		"cloudflare.notification.policy.id":   "0da2b59e-f118-439d-8097-bdfb215203c9",
+		"cloudflare.notification.policy.name": "DDoS alerts",
+	}))
+	require.NoError(t, lr.Body().SetEmptyMap().FromRaw(notif.raw))
+
+	require.NoError(t, plogtest.CompareLogs(expected, logs, plogtest.IgnoreObservedTimestamp()))
+}
+
+func TestNotificationsSeverity(t *testing.T) {
+	r := newTestNotificationsReceiver(t, consumertest.NewNop(), "")
+	for alertType, expected := range map[string]plog.SeverityNumber{
+		"universal_ssl_event_type":       plog.SeverityNumberWarn,
+		"dos_attack_l4":                  plog.SeverityNumberError,
+		"maintenance_event_notification": plog.SeverityNumberInfo,
+		"":                               plog.SeverityNumberInfo,
+	} {
+		logs := r.processNotification(0, notification{AlertType: alertType})
+		require.Equal(t, expected, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityNumber(), alertType)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"math"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	// attrOCSF is the attribute holding the OCSF representation of a security event.
+	attrOCSF = "ocsf"
+
+	ocsfVersion = "1.3.0"
+
+	// The events are mapped to the HTTP Activity class of the Network Activity category.
+	ocsfCategoryNetworkActivity = 4
+	ocsfClassHTTPActivity       = 4002
+
+	ocsfSeverityInformational = 1
+)
+
+// ocsfHTTPActivities maps HTTP methods to the activity IDs of the HTTP Activity class.
+var ocsfHTTPActivities = map[string]int64{
+	"CONNECT": 1,
+	"DELETE":  2,
+	"GET":     3,
+	"HEAD":    4,
+	"OPTIONS": 5,
+	"POST":    6,
+	"PUT":     7,
+	"TRACE":   8,
+}
+
+// ocsfFieldMapping maps a field of a Logpush dataset to a dot-separated path in the OCSF event.
+type ocsfFieldMapping struct {
+	field string
+	path  string
+}
+
+var firewallEventOCSFFields = []ocsfFieldMapping{
+	{"ClientIP", "src_endpoint.ip"},
+	{"ClientCountry", "src_endpoint.location.country"},
+	{"ClientRequestMethod", "http_request.http_method"},
+	{"ClientRequestUserAgent", "http_request.user_agent"},
+	{"ClientRequestScheme", "http_request.url.scheme"},
+	{"ClientRequestHost", "http_request.url.hostname"},
+	{"ClientRequestPath", "http_request.url.path"},
+	{"ClientRequestQuery", "http_request.url.query_string"},
+	{"RayID", "http_request.uid"},
+	{"EdgeResponseStatus", "http_response.code"},
+	{"RuleID", "firewall_rule.uid"},
+	{"Description", "firewall_rule.desc"},
+	{"Source", "firewall_rule.type"},
+}
+
+var gatewayHTTPOCSFFields = []ocsfFieldMapping{
+	{"SourceIP", "src_endpoint.ip"},
+	{"SourcePort", "src_endpoint.port"},
+	{"DestinationIP", "dst_endpoint.ip"},
+	{"DestinationPort", "dst_endpoint.port"},
+	{"HTTPMethod", "http_request.http_method"},
+	{"UserAgent", "http_request.user_agent"},
+	{"HTTPHost", "http_request.url.hostname"},
+	{"URL", "http_request.url.url_string"},
+	{"RequestID", "http_request.uid"},
+	{"Email", "actor.user.email_addr"},
+	{"PolicyID", "firewall_rule.uid"},
+	{"PolicyName", "firewall_rule.name"},
+}
+
+// isGatewayHTTPEvent returns true if the log belongs to the gateway_http dataset, identified by
+// the HTTPHost and PolicyID fields that the other datasets don't have.
+func isGatewayHTTPEvent(log map[string]any) bool {
+	_, hasHost := log["HTTPHost"]
+	_, hasPolicy := log["PolicyID"]
+	return hasHost && hasPolicy
+}
+
+// addOCSFAttributes sets the OCSF HTTP Activity representation of firewall and Gateway HTTP events.
+// Other logs are left untouched.
+func addOCSFAttributes(attrs pcommon.Map, log map[string]any, timestamp pcommon.Timestamp) error {
+	var product string
+	var fields []ocsfFieldMapping
+	var method any
+	switch {
+	case isFirewallEvent(log):
+		product, fields, method = "Cloudflare WAF", firewallEventOCSFFields, log["ClientRequestMethod"]
+	case isGatewayHTTPEvent(log):
+		product, fields, method = "Cloudflare Gateway", gatewayHTTPOCSFFields, log["HTTPMethod"]
+	default:
+		return nil
+	}
+
+	activityID := int64(99)
+	if m, ok := method.(string); ok {
+		if id, ok := ocsfHTTPActivities[strings.ToUpper(m)]; ok {
+			activityID = id
+		}
+	}
+
+	event := map[string]any{
+		"category_uid": int64(ocsfCategoryNetworkActivity),
+		"class_uid":    int64(ocsfClassHTTPActivity),
+		"activity_id":  activityID,
+		"type_uid":     int64(ocsfClassHTTPActivity)*100 + activityID,
+		"severity_id":  int64(ocsfSeverityInformational),
+		"metadata": map[string]any{
+			"version": ocsfVersion,
+			"product": map[string]any{
+				"name":        product,
+				"vendor_name": "Cloudflare",
+			},
+		},
+	}
+	if timestamp != 0 {
+		event["time"] = timestamp.AsTime().UnixMilli()
+	}
+
+	if action, ok := log["Action"].(string); ok {
+		event["action"], event["action_id"] = ocsfAction(action)
+	}
+
+	for _, mapping := range fields {
+		v, ok := log[mapping.field]
+		if !ok || v == "" {
+			continue
+		}
+		// Numbers are decoded as floats, but the OCSF codes and ports are integers.
+		if f, ok := v.(float64); ok && f == math.Trunc(f) {
+			v = int64(f)
+		}
+		setOCSFPath(event, mapping.path, v)
+	}
+
+	return attrs.PutEmptyMap(attrOCSF).FromRaw(event)
+}
+
+// ocsfAction maps a Cloudflare action to the OCSF action and its ID.
+func ocsfAction(action string) (string, int64) {
+	switch firewallEventOutcomes[action] {
+	case "success":
+		return "Allowed", 1
+	case "failure":
+		return "Denied", 2
+	default:
+		return "Other", 99
+	}
+}
+
+// setOCSFPath sets the value at a dot-separated path, creating the intermediate objects.
+func setOCSFPath(event map[string]any, path string, value any) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := event[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			event[key] = next
+		}
+		event = next
+	}
+	event[keys[len(keys)-1]] = value
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestOCSFAttributes(t *testing.T) {
+	payload := `{"Action":"block","ClientIP":"89.163.253.200","ClientRequestHost":"www.example.com","ClientRequestMethod":"GET","ClientRequestPath":"/login","Datetime":"2023-03-03T05:29:05Z","Description":"Block bad bots","EdgeResponseStatus":403,"Kind":"firewall","RayID":"3a6050bcbe121a87","RuleID":"8c0ff4e7a1b64dd4b4d8f3b0e5ee0a21","Source":"firewallCustom"}
+{"Action":"isolate","Datetime":"2023-03-03T05:29:06Z","DestinationIP":"104.16.132.229","DestinationPort":443,"Email":"user@example.com","HTTPHost":"example.com","HTTPMethod":"POST","PolicyID":"1412cc48-ee11-4b6d-bd63-eb9d04bb7d7f","PolicyName":"Isolate news","SourceIP":"10.0.0.1","URL":"https://example.com/upload"}
+{"ClientRequestMethod":"GET","Datetime":"2023-03-03T05:29:07Z","EdgeResponseStatus":200}`
+
+	recv := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint:        "localhost:0",
+			TimestampField:  "Datetime",
+			TimestampFormat: "rfc3339",
+			Attributes:      map[string]string{"RayID": "cloudflare.ray_id"},
+			OCSF:            true,
+		},
+	}, &consumertest.LogsSink{})
+
+	rawLogs, err := parsePayload([]byte(payload))
+	require.NoError(t, err)
+	logs := recv.converter.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, records.Len())
+
+	firewallEvent, ok := records.At(0).Attributes().Get("ocsf")
+	require.True(t, ok)
+	require.Equal(t, map[string]any{
+		"category_uid": int64(4),
+		"class_uid":    int64(4002),
+		"activity_id":  int64(3),
+		"type_uid":     int64(400203),
+		"severity_id":  int64(1),
+		"time":         int64(1677821345000),
+		"action":       "Denied",
+		"action_id":    int64(2),
+		"metadata": map[string]any{
+			"version": "1.3.0",
+			"product": map[string]any{"name": "Cloudflare WAF", "vendor_name": "Cloudflare"},
+		},
+		"src_endpoint": map[string]any{"ip": "89.163.253.200"},
+		"http_request": map[string]any{
+			"http_method": "GET",
+			"uid":         "3a6050bcbe121a87",
+			"url":         map[string]any{"hostname": "www.example.com", "path": "/login"},
+		},
+		"http_response": map[string]any{"code": int64(403)},
+		"firewall_rule": map[string]any{
+			"uid":  "8c0ff4e7a1b64dd4b4d8f3b0e5ee0a21",
+			"desc": "Block bad bots",
+			"type": "firewallCustom",
+		},
+	}, firewallEvent.Map().AsRaw())
+
+	gatewayEvent, ok := records.At(1).Attributes().Get("ocsf")
+	require.True(t, ok)
+	require.Equal(t, map[string]any{
+		"category_uid": int64(4),
+		"class_uid":    int64(4002),
+		"activity_id":  int64(6),
+		"type_uid":     int64(400206),
+		"severity_id":  int64(1),
+		"time":         int64(1677821346000),
+		"action":       "Other",
+		"action_id":    int64(99),
+		"metadata": map[string]any{
+			"version": "1.3.0",
+			"product": map[string]any{"name": "Cloudflare Gateway", "vendor_name": "Cloudflare"},
+		},
+		"src_endpoint": map[string]any{"ip": "10.0.0.1"},
+		"dst_endpoint": map[string]any{"ip": "104.16.132.229", "port": int64(443)},
+		"http_request": map[string]any{
+			"http_method": "POST",
+			"url":         map[string]any{"hostname": "example.com", "url_string": "https://example.com/upload"},
+		},
+		"actor":         map[string]any{"user": map[string]any{"email_addr": "user@example.com"}},
+		"firewall_rule": map[string]any{"uid": "1412cc48-ee11-4b6d-bd63-eb9d04bb7d7f", "name": "Isolate news"},
+	}, gatewayEvent.Map().AsRaw())
+
+	_, ok = records.At(2).Attributes().Get("ocsf")
+	require.False(t, ok)
+}
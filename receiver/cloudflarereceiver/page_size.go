@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+// shrinkPageSize halves the page size once a response exceeded max_response_size, so that polling
+// adjusts to the size of the entries instead of failing on every poll. It returns false if the error
+// isn't caused by the size of the response, or if the page size can't be reduced any further.
+func shrinkPageSize(logger *zap.Logger, pageSize *int, err error) bool {
+	if !errors.Is(err, errResponseTooLarge) || *pageSize <= 1 {
+		return false
+	}
+	*pageSize /= 2
+	logger.Warn("Halving the page size after a response exceeded max_response_size",
+		zap.Int("page_size", *pageSize),
+		zap.Error(err))
+	return true
+}
+
+// shrinkLimit halves the limit of the GraphQL queries of the dataset once a query requested too many
+// groups, so that polling adjusts to the dataset instead of failing on every poll. It returns false if
+// the error isn't caused by the number of groups, or if the limit can't be reduced any further.
+func shrinkLimit(logger *zap.Logger, dataset string, limit *int, err error) bool {
+	if !limitExceeded(err) || *limit <= 1 {
+		return false
+	}
+	*limit /= 2
+	logger.Warn("Halving the limit of the GraphQL query after it requested too many groups",
+		zap.String("dataset", dataset),
+		zap.Int("limit", *limit),
+		zap.Error(err))
+	return true
+}
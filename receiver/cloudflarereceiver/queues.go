@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	rcvr "go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// queueContentTypeKey is the metadata key holding how the body of a queue message is encoded.
+const queueContentTypeKey = "CF-Content-Type"
+
+// queuesReceiver pulls log events from a Cloudflare Queue and emits them as log records. Messages
+// are only acknowledged once their logs have been consumed, so the queue delivers them again
+// otherwise.
+type queuesReceiver struct {
+	cfg      *QueuesConfig
+	settings component.TelemetrySettings
+	logger   *zap.Logger
+	consumer consumer.Logs
+	obsrecv  *receiverhelper.ObsReport
+	client   client
+	// accountID is the ID of the account, the configured name being resolved on start.
+	accountID string
+
+	// converter converts the log events like the Logpush endpoint does.
+	converter *logsConverter
+	dataset   *DatasetConfig
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+func newQueuesReceiver(params rcvr.Settings, cfg *Config, consumer consumer.Logs) (*queuesReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             params.ID,
+		Transport:              "http",
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := newLogsConverter(params, &cfg.Logs)
+	if err != nil {
+		return nil, err
+	}
+
+	queuesCfg := cfg.Queues.Get()
+	return &queuesReceiver{
+		cfg:       queuesCfg,
+		settings:  params.TelemetrySettings,
+		logger:    params.Logger,
+		consumer:  consumer,
+		obsrecv:   obsrecv,
+		converter: converter,
+		dataset:   converter.defaultDataset.merge(DatasetConfig{Dataset: queuesCfg.Dataset}),
+		accountID: queuesCfg.Account,
+	}, nil
+}
+
+func (r *queuesReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.client, err = newClient(ctx, &r.cfg.APIConfig, host, r.settings)
+	if err != nil {
+		return err
+	}
+	verifyToken(ctx, r.client, r.logger, "queues", queuesPermission)
+	accountIDs, err := resolveAccountIDs(ctx, r.client, []string{r.cfg.Account})
+	if err != nil {
+		return err
+	}
+	r.accountID = accountIDs[0]
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.startPolling(pollCtx)
+	return nil
+}
+
+func (r *queuesReceiver) Shutdown(_ context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	r.converter.shutdown()
+	return nil
+}
+
+func (r *queuesReceiver) startPolling(ctx context.Context) {
+	defer r.wg.Done()
+
+	t := time.NewTicker(r.cfg.PollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := r.poll(ctx); err != nil {
+				r.logger.Error("Failed to pull log events from queue", zap.String("queue", r.cfg.Queue), zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll pulls batches of messages until the queue is emptied.
+func (r *queuesReceiver) poll(ctx context.Context) error {
+	for {
+		messages, err := r.client.PullQueueMessages(ctx, r.accountID, r.cfg.Queue, r.cfg.BatchSize, r.cfg.VisibilityTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to pull messages: %w", err)
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		if err := r.processMessages(ctx, messages); err != nil {
+			return err
+		}
+
+		if len(messages) < r.cfg.BatchSize {
+			return nil
+		}
+	}
+}
+
+// processMessages emits the log events of the messages and acknowledges them. Each message holds
+// one or more JSON lines. Messages whose lines can't be parsed are acknowledged too, since they would
+// fail again when delivered again.
+func (r *queuesReceiver) processMessages(ctx context.Context, messages []queueMessage) error {
+	var logs []map[string]any
+	var rejected []rejectedRecord
+	leaseIDs := make([]string, 0, len(messages))
+	for _, message := range messages {
+		body, err := decodeQueueMessage(message)
+		if err != nil {
+			rejected = append(rejected, rejectedRecord{line: []byte(message.Body), reason: metadata.AttributeReasonMalformed, err: err})
+		} else {
+			messageLogs, messageRejected := parseLines(body)
+			logs = append(logs, messageLogs...)
+			rejected = append(rejected, messageRejected...)
+		}
+		leaseIDs = append(leaseIDs, message.LeaseID)
+	}
+
+	pLogs := r.converter.processDatasetLogs(ctx, pcommon.NewTimestampFromTime(time.Now()), logs, rejected, r.dataset)
+	if pLogs.LogRecordCount() > 0 {
+		obsCtx := r.obsrecv.StartLogsOp(ctx)
+		err := r.consumer.ConsumeLogs(obsCtx, pLogs)
+		r.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), pLogs.LogRecordCount(), err)
+		if err != nil {
+			return errors.Join(errors.New("failed to consume logs"), err)
+		}
+	}
+
+	if err := r.client.AckQueueMessages(ctx, r.accountID, r.cfg.Queue, leaseIDs); err != nil {
+		return fmt.Errorf("failed to acknowledge messages: %w", err)
+	}
+	return nil
+}
+
+// decodeQueueMessage returns the body of the message, which is base64-encoded when it was sent as bytes.
+func decodeQueueMessage(message queueMessage) ([]byte, error) {
+	if message.Metadata[queueContentTypeKey] == "bytes" {
+		return base64.StdEncoding.DecodeString(message.Body)
+	}
+	return []byte(message.Body), nil
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+const testQueueID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+// fakeQueuesClient leases its messages in batches, emulating the queue.
+type fakeQueuesClient struct {
+	client
+	messages []queueMessage
+	leased   int
+	acked    []string
+}
+
+func (f *fakeQueuesClient) PullQueueMessages(_ context.Context, _, _ string, batchSize int, _ time.Duration) ([]queueMessage, error) {
+	end := min(f.leased+batchSize, len(f.messages))
+	batch := f.messages[f.leased:end]
+	f.leased = end
+	return batch, nil
+}
+
+func (f *fakeQueuesClient) AckQueueMessages(_ context.Context, _, _ string, leaseIDs []string) error {
+	f.acked = append(f.acked, leaseIDs...)
+	return nil
+}
+
+func newTestQueuesReceiver(t *testing.T, next consumer.Logs, c client) *queuesReceiver {
+	cfg := createDefaultConfig().(*Config)
+	queuesCfg := cfg.Queues.GetOrInsertDefault()
+	queuesCfg.Account = testAccountID
+	queuesCfg.Queue = testQueueID
+	queuesCfg.BatchSize = 2
+	cfg.Queues = configoptional.Some(*queuesCfg)
+
+	r, err := newQueuesReceiver(receivertest.NewNopSettings(metadata.Type), cfg, next)
+	require.NoError(t, err)
+	r.client = c
+	return r
+}
+
+func TestQueuesPoll(t *testing.T) {
+	fake := &fakeQueuesClient{
+		messages: []queueMessage{
+			{LeaseID: "1", Body: `{"EdgeStartTimestamp":"2023-03-03T05:00:05Z","RayID":"1"}`},
+			{LeaseID: "2", Body: `{"EdgeStartTimestamp":"2023-03-03T05:00:06Z","RayID":"2"}` + "\n" + `{"EdgeStartTimestamp":"2023-03-03T05:00:07Z","RayID":"3"}`},
+			{
+				LeaseID:  "3",
+				Body:     base64.StdEncoding.EncodeToString([]byte(`{"EdgeStartTimestamp":"2023-03-03T05:00:08Z","RayID":"4"}`)),
+				Metadata: map[string]string{queueContentTypeKey: "bytes"},
+			},
+			{LeaseID: "4", Body: `not json`},
+		},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestQueuesReceiver(t, sink, fake)
+
+	require.NoError(t, r.poll(t.Context()))
+	// The queue is pulled until it's emptied, and malformed messages are acknowledged too.
+	require.Equal(t, 4, sink.LogRecordCount())
+	require.Equal(t, []string{"1", "2", "3", "4"}, fake.acked)
+
+	lr := sink.AllLogs()[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	require.Equal(t, time.Date(2023, 3, 3, 5, 0, 8, 0, time.UTC), lr.Timestamp().AsTime())
+}
+
+func TestQueuesPollConsumerError(t *testing.T) {
+	fake := &fakeQueuesClient{
+		messages: []queueMessage{{LeaseID: "1", Body: `{"EdgeStartTimestamp":"2023-03-03T05:00:05Z"}`}},
+	}
+	r := newTestQueuesReceiver(t, consumertest.NewErr(errors.New("consumer failed")), fake)
+
+	require.ErrorContains(t, r.poll(t.Context()), "consumer failed")
+	// The message is not acknowledged, so the queue delivers it again.
+	require.Empty(t, fake.acked)
+}
+
+func TestQueueMessagesClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, http.MethodPost, req.Method)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		switch req.URL.Path {
+		case "/accounts/" + testAccountID + "/queues/" + testQueueID + "/messages/pull":
+			require.Equal(t, map[string]any{"batch_size": float64(10), "visibility_timeout_ms": float64(30000)}, body)
+			require.NoError(t, json.NewEncoder(rw).Encode(map[string]any{
+				"success": true,
+				"result": map[string]any{
+					"message_backlog_count": 0,
+					"messages": []map[string]any{{
+						"id":       "b01b5594f784d0165c2985833f5660dd",
+						"lease_id": "eyJhbGciOiJkaXIiLCJlbmMiOiJBMjU2Q0JDLUhTNTEyIn0",
+						"body":     `{"RayID":"1"}`,
+						"attempts": 1,
+						"metadata": map[string]string{queueContentTypeKey: "json"},
+					}},
+				},
+			}))
+		case "/accounts/" + testAccountID + "/queues/" + testQueueID + "/messages/ack":
+			require.Equal(t, map[string]any{"acks": []any{map[string]any{"lease_id": "eyJhbGciOiJkaXIiLCJlbmMiOiJBMjU2Q0JDLUhTNTEyIn0"}}}, body)
+			require.NoError(t, json.NewEncoder(rw).Encode(map[string]any{
+				"success": true,
+				"result":  map[string]any{"ackCount": 1, "retryCount": 0, "warnings": []string{}},
+			}))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	c, err := newClient(t.Context(), &APIConfig{ClientConfig: clientConfig, APIToken: "abc123"}, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	messages, err := c.PullQueueMessages(t.Context(), testAccountID, testQueueID, 10, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.JSONEq(t, `{"RayID":"1"}`, messages[0].Body)
+	require.Equal(t, 1, messages[0].Attempts)
+
+	require.NoError(t, c.AckQueueMessages(t.Context(), testAccountID, testQueueID, []string{messages[0].LeaseID}))
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// redactFields removes the dropped fields from the log and replaces the value of the hashed ones
+// with their SHA-256 hash, so that they can still be correlated without being stored.
+func redactFields(log map[string]any, dropFields, hashFields []string) {
+	for _, field := range dropFields {
+		delete(log, field)
+	}
+
+	for _, field := range hashFields {
+		v, ok := log[field]
+		if !ok {
+			continue
+		}
+
+		var value []byte
+		if s, ok := v.(string); ok {
+			value = []byte(s)
+		} else {
+			// Values decoded from JSON can always be encoded back.
+			value, _ = json.Marshal(v)
+		}
+		sum := sha256.Sum256(value)
+		log[field] = hex.EncodeToString(sum[:])
+	}
+}
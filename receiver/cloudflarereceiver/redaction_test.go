@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestRedactFields(t *testing.T) {
+	log := map[string]any{
+		"ClientIP":  "89.163.253.200",
+		"Cookies":   map[string]any{"session": "abc123"},
+		"Email":     "user@example.com",
+		"RayID":     "3a6050bcbe121a87",
+		"ClientASN": float64(20115),
+	}
+	redactFields(log, []string{"Cookies", "Unknown"}, []string{"ClientIP", "Email", "ClientASN", "Absent"})
+
+	require.Equal(t, map[string]any{
+		"ClientIP":  "e13778ecf814b95b75b0144b013629714ac9205c03534416aa787daba567a9be",
+		"Email":     "b4c9a289323b21a01c3e940f150eb9b8c542587f1abfd8f0e1cc1ffc5e475514",
+		"RayID":     "3a6050bcbe121a87",
+		"ClientASN": "16da0883c54e755a9c7028bcdbdea8a50623351290840d449bdf6bf4c5d45183",
+	}, log)
+}
+
+func TestDatasetRedaction(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	r := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint:       "localhost:0",
+			TimestampField: "EdgeStartTimestamp",
+			DropFields:     []string{"Cookies"},
+			Datasets: []DatasetConfig{
+				{
+					Path:       "/gateway_http",
+					Dataset:    "gateway_http",
+					DropFields: []string{"UserAgent"},
+					HashFields: []string{"Email"},
+				},
+			},
+		},
+	}, sink)
+
+	rec := httptest.NewRecorder()
+	r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"ClientIP":"89.163.253.200","Cookies":{"session":"abc123"},"EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/gateway_http", strings.NewReader(`{"Cookies":"session=abc123","Datetime":"2023-03-03T05:29:05Z","Email":"user@example.com","UserAgent":"curl/8.4.0"}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	require.Len(t, sink.AllLogs(), 2)
+	defaultRecord := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	require.Equal(t, map[string]any{
+		"ClientIP":           "89.163.253.200",
+		"EdgeStartTimestamp": "2023-03-03T05:29:05Z",
+	}, defaultRecord.Body().Map().AsRaw())
+	require.Equal(t, defaultRecord.Body().Map().AsRaw(), defaultRecord.Attributes().AsRaw())
+
+	gatewayRecord := sink.AllLogs()[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	require.Equal(t, map[string]any{
+		"Cookies":  "session=abc123",
+		"Datetime": "2023-03-03T05:29:05Z",
+		"Email":    "b4c9a289323b21a01c3e940f150eb9b8c542587f1abfd8f0e1cc1ffc5e475514",
+	}, gatewayRecord.Body().Map().AsRaw())
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// cloudflareIDPattern matches the IDs of Cloudflare resources such as zones and accounts.
+var cloudflareIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// resolveZoneIDs returns the IDs of the zones, which are configured either by ID or by name.
+func resolveZoneIDs(ctx context.Context, c client, zones []string) ([]string, error) {
+	return resolveIDs(ctx, "zone", zones, func(ctx context.Context, name string) ([]string, error) {
+		matches, err := c.ListZones(ctx, name)
+		ids := make([]string, 0, len(matches))
+		for _, match := range matches {
+			ids = append(ids, match.ID)
+		}
+		return ids, err
+	})
+}
+
+// resolveAccountIDs returns the IDs of the accounts, which are configured either by ID or by name.
+func resolveAccountIDs(ctx context.Context, c client, accounts []string) ([]string, error) {
+	return resolveIDs(ctx, "account", accounts, func(ctx context.Context, name string) ([]string, error) {
+		matches, err := c.ListAccounts(ctx, name)
+		ids := make([]string, 0, len(matches))
+		for _, match := range matches {
+			ids = append(ids, match.ID)
+		}
+		return ids, err
+	})
+}
+
+// resolveIDs returns the IDs of the resources of the kind. Names are resolved with list, which returns
+// the IDs of the resources of a name, failing if a name matches no resource, or several resources the
+// token has access to.
+func resolveIDs(ctx context.Context, kind string, values []string, list func(context.Context, string) ([]string, error)) ([]string, error) {
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		if cloudflareIDPattern.MatchString(v) {
+			ids = append(ids, v)
+			continue
+		}
+
+		matches, err := list(ctx, v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s %q: %w", kind, v, err)
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("%s %q not found, check its name and that the API token has access to it", kind, v)
+		case 1:
+			ids = append(ids, matches[0])
+		default:
+			return nil, fmt.Errorf("%s name %q is ambiguous, it matches the %ss %s: configure the ID of the %s instead",
+				kind, v, kind, strings.Join(matches, ", "), kind)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// fakeZonesClient lists the zones and accounts by name.
+type fakeZonesClient struct {
+	client
+	zones    map[string][]zone
+	accounts map[string][]account
+}
+
+func (f *fakeZonesClient) ListAccounts(_ context.Context, name string) ([]account, error) {
+	return f.accounts[name], nil
+}
+
+func (f *fakeZonesClient) ListZones(_ context.Context, name string) ([]zone, error) {
+	if name == "error.example" {
+		return nil, errors.New("9109: Invalid access token")
+	}
+	return f.zones[name], nil
+}
+
+func TestResolveZoneIDs(t *testing.T) {
+	c := &fakeZonesClient{zones: map[string][]zone{
+		"example.com": {{ID: "1a79a4d60de6718e8e5b326e338ae533", Name: "example.com"}},
+		"example.net": {{ID: "2b79a4d60de6718e8e5b326e338ae533"}, {ID: "3c79a4d60de6718e8e5b326e338ae533"}},
+	}}
+
+	// IDs are kept as is, while names are resolved.
+	ids, err := resolveZoneIDs(t.Context(), c, []string{testZoneID, "example.com"})
+	require.NoError(t, err)
+	require.Equal(t, []string{testZoneID, "1a79a4d60de6718e8e5b326e338ae533"}, ids)
+
+	_, err = resolveZoneIDs(t.Context(), c, []string{"missing.example"})
+	require.EqualError(t, err, `zone "missing.example" not found, check its name and that the API token has access to it`)
+
+	_, err = resolveZoneIDs(t.Context(), c, []string{"example.net"})
+	require.EqualError(t, err, `zone name "example.net" is ambiguous, it matches the zones 2b79a4d60de6718e8e5b326e338ae533, 3c79a4d60de6718e8e5b326e338ae533: configure the ID of the zone instead`)
+
+	_, err = resolveZoneIDs(t.Context(), c, []string{"error.example"})
+	require.EqualError(t, err, `failed to resolve zone "error.example": 9109: Invalid access token`)
+}
+
+func TestResolveAccountIDs(t *testing.T) {
+	c := &fakeZonesClient{accounts: map[string][]account{
+		"Example": {{ID: testAccountID, Name: "Example"}},
+	}}
+
+	ids, err := resolveAccountIDs(t.Context(), c, []string{"Example", "4d79a4d60de6718e8e5b326e338ae533"})
+	require.NoError(t, err)
+	require.Equal(t, []string{testAccountID, "4d79a4d60de6718e8e5b326e338ae533"}, ids)
+
+	_, err = resolveAccountIDs(t.Context(), c, []string{"Staging"})
+	require.EqualError(t, err, `account "Staging" not found, check its name and that the API token has access to it`)
+}
+
+func TestListZonesClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/zones", req.URL.Path)
+		require.Equal(t, "example.com", req.URL.Query().Get("name"))
+		require.NoError(t, json.NewEncoder(rw).Encode(map[string]any{
+			"success": true,
+			"result":  []map[string]any{{"id": testZoneID, "name": "example.com"}},
+		}))
+	}))
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	c, err := newClient(t.Context(), &APIConfig{ClientConfig: clientConfig, APIToken: "abc123"}, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	zones, err := c.ListZones(t.Context(), "example.com")
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	require.Equal(t, testZoneID, zones[0].ID)
+}
+
+func TestListAccountsClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/accounts", req.URL.Path)
+		require.Equal(t, "Example", req.URL.Query().Get("name"))
+		require.NoError(t, json.NewEncoder(rw).Encode(map[string]any{
+			"success": true,
+			"result":  []map[string]any{{"id": testAccountID, "name": "Example"}},
+		}))
+	}))
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	c, err := newClient(t.Context(), &APIConfig{ClientConfig: clientConfig, APIToken: "abc123"}, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	accounts, err := c.ListAccounts(t.Context(), "Example")
+	require.NoError(t, err)
+	require.Equal(t, []account{{ID: testAccountID, Name: "Example"}}, accounts)
+}
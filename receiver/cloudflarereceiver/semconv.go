@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// semconvAttributes maps Logpush fields to their OpenTelemetry semantic convention equivalents.
+var semconvAttributes = map[string]string{
+	"ClientRequestMethod":    "http.request.method",
+	"ClientRequestScheme":    "url.scheme",
+	"ClientRequestHost":      "server.address",
+	"ClientRequestPath":      "url.path",
+	"ClientRequestQuery":     "url.query",
+	"ClientRequestBytes":     "http.request.size",
+	"ClientRequestUserAgent": "user_agent.original",
+	"ClientIP":               "client.address",
+	"ClientSrcPort":          "client.port",
+	"EdgeResponseStatus":     "http.response.status_code",
+	"EdgeResponseBytes":      "http.response.size",
+	"ClientCountry":          "geo.country.iso_code",
+	"ClientCity":             "geo.locality.name",
+	"ClientLatitude":         "geo.location.lat",
+	"ClientLongitude":        "geo.location.lon",
+}
+
+// semconvIntAttributes lists the semantic convention attributes that are integers. Logpush
+// encodes numbers as JSON numbers or, depending on the job options, as strings.
+var semconvIntAttributes = map[string]struct{}{
+	"http.request.size":         {},
+	"http.response.size":        {},
+	"http.response.status_code": {},
+	"client.port":               {},
+}
+
+// semconvDoubleAttributes lists the semantic convention attributes that are doubles. Logpush
+// encodes the client coordinates as strings.
+var semconvDoubleAttributes = map[string]struct{}{
+	"geo.location.lat": {},
+	"geo.location.lon": {},
+}
+
+// putSemconvAttribute sets semantic convention attributes whose value needs to be converted,
+// returning false if no conversion applies and the value should be set as is.
+func putSemconvAttribute(attrs pcommon.Map, name string, v any) bool {
+	if _, ok := semconvIntAttributes[name]; ok {
+		return putIntAttribute(attrs, name, v)
+	}
+	if _, ok := semconvDoubleAttributes[name]; ok {
+		return putDoubleAttribute(attrs, name, v)
+	}
+	if name == "geo.country.iso_code" {
+		// Cloudflare reports lower case country codes, ISO 3166-1 uses upper case.
+		if code, ok := v.(string); ok {
+			attrs.PutStr(name, strings.ToUpper(code))
+			return true
+		}
+	}
+	return false
+}
+
+func putIntAttribute(attrs pcommon.Map, name string, v any) bool {
+	switch val := v.(type) {
+	case float64:
+		attrs.PutInt(name, int64(val))
+		return true
+	case string:
+		i, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return false
+		}
+		attrs.PutInt(name, i)
+		return true
+	default:
+		return false
+	}
+}
+
+func putDoubleAttribute(attrs pcommon.Map, name string, v any) bool {
+	switch val := v.(type) {
+	case float64:
+		attrs.PutDouble(name, val)
+		return true
+	case string:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return false
+		}
+		attrs.PutDouble(name, f)
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestSemanticConventions(t *testing.T) {
+	payload := `{"ClientIP":"89.163.253.200","ClientSrcPort":54321,"ClientRequestHost":"www.example.com","ClientRequestMethod":"GET","ClientRequestPath":"/static/img.png","ClientRequestQuery":"?size=large","ClientRequestScheme":"https","EdgeResponseBytes":"69045","EdgeResponseStatus":200,"EdgeStartTimestamp":"2023-03-03T05:29:05Z","RayID":"3a6050bcbe121a87","OriginResponseStatus":"unknown","ClientCountry":"us","ClientCity":"San Francisco","ClientLatitude":"37.78","ClientLongitude":"-122.39","ClientRegionCode":"CA"}`
+
+	testCases := []struct {
+		name       string
+		attributes map[string]string
+		expected   map[string]any
+	}{
+		{
+			name: "automatic ingestion",
+			expected: map[string]any{
+				"client.address":            "89.163.253.200",
+				"client.port":               int64(54321),
+				"server.address":            "www.example.com",
+				"http.request.method":       "GET",
+				"url.path":                  "/static/img.png",
+				"url.query":                 "?size=large",
+				"url.scheme":                "https",
+				"http.response.size":        int64(69045),
+				"http.response.status_code": int64(200),
+				"EdgeStartTimestamp":        "2023-03-03T05:29:05Z",
+				"RayID":                     "3a6050bcbe121a87",
+				"OriginResponseStatus":      "unknown",
+				"geo.country.iso_code":      "US",
+				"geo.locality.name":         "San Francisco",
+				"geo.location.lat":          37.78,
+				"geo.location.lon":          -122.39,
+				"ClientRegionCode":          "CA",
+			},
+		},
+		{
+			name:       "explicit attributes take precedence",
+			attributes: map[string]string{"ClientIP": "http_request.client_ip"},
+			expected: map[string]any{
+				"http_request.client_ip": "89.163.253.200",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recv := newReceiver(t, &Config{
+				Logs: LogsConfig{
+					Endpoint:            "localhost:0",
+					TimestampField:      "EdgeStartTimestamp",
+					TimestampFormat:     "rfc3339",
+					Attributes:          tc.attributes,
+					SemanticConventions: true,
+				},
+			}, &consumertest.LogsSink{})
+
+			rawLogs, err := parsePayload([]byte(payload))
+			require.NoError(t, err)
+			logs := recv.converter.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
+			require.Equal(t, tc.expected, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+)
+
+// newServer creates the HTTP server Cloudflare pushes data to.
+func newServer(handler http.Handler, tls *configtls.ServerConfig) (*http.Server, error) {
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 20 * time.Second,
+	}
+
+	if tls != nil {
+		tlsConfig, err := tls.LoadTLSConfig(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		server.TLSConfig = tlsConfig
+	}
+
+	return server, nil
+}
+
+// withConcurrencyLimit wraps the handler so that requests received while limit requests are being
+// handled are refused with a 429 status, which Cloudflare retries later.
+func withConcurrencyLimit(handler http.Handler, limit int, logger *zap.Logger) http.Handler {
+	slots := make(chan struct{}, limit)
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			handler.ServeHTTP(rw, req)
+		default:
+			rw.WriteHeader(http.StatusTooManyRequests)
+			logger.Warn("Refused request, max_concurrent_requests is reached", zap.Int("max_concurrent_requests", limit))
+		}
+	})
+}
+
+// withAuthentication wraps the handler of the server so that requests are authenticated by the
+// configured authenticator extension before being handled.
+func withAuthentication(ctx context.Context, host component.Host, server *http.Server, auth configauth.Config) error {
+	authenticator, err := auth.GetServerAuthenticator(ctx, host.GetExtensions())
+	if err != nil {
+		return err
+	}
+
+	next := server.Handler
+	server.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx, err := authenticator.Authenticate(req.Context(), req.Header)
+		if err != nil {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(rw, req.WithContext(ctx))
+	})
+	return nil
+}
+
+// startServer binds to endpoint and serves requests in the background until the server is shut down.
+func startServer(ctx context.Context, host component.Host, logger *zap.Logger, server *http.Server, wg *sync.WaitGroup, endpoint string, tls *configtls.ServerConfig) error {
+	logger.Debug("starting receiver HTTP server")
+	// We use server.Serve* over server.ListenAndServe*
+	// So that we can catch and return errors relating to binding to network interface on start.
+	var lc net.ListenConfig
+
+	listener, err := lc.Listen(ctx, "tcp", endpoint)
+	if err != nil {
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		if tls != nil {
+			logger.Debug("Starting ServeTLS",
+				zap.String("address", endpoint),
+				zap.String("certfile", tls.CertFile),
+				zap.String("keyfile", tls.KeyFile))
+
+			err := server.ServeTLS(listener, tls.CertFile, tls.KeyFile)
+
+			logger.Debug("ServeTLS done")
+
+			if !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("ServeTLS failed", zap.Error(err))
+				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
+			}
+		} else {
+			logger.Debug("Starting Serve",
+				zap.String("address", endpoint))
+
+			err := server.Serve(listener)
+
+			logger.Debug("Serve done")
+
+			if !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("Serve failed", zap.Error(err))
+				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
+			}
+		}
+	}()
+	return nil
+}
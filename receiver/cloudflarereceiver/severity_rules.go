@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// severityNumbers maps the severities of severity rules to severity numbers.
+var severityNumbers = map[string]plog.SeverityNumber{
+	"trace": plog.SeverityNumberTrace,
+	"debug": plog.SeverityNumberDebug,
+	"info":  plog.SeverityNumberInfo,
+	"warn":  plog.SeverityNumberWarn,
+	"error": plog.SeverityNumberError,
+	"fatal": plog.SeverityNumberFatal,
+}
+
+// matches returns true if the field of the rule matches the log.
+func (r *SeverityRule) matches(log map[string]any) bool {
+	v, ok := log[r.Field]
+	if !ok {
+		return false
+	}
+
+	if r.Equals != nil {
+		return fmt.Sprint(v) == *r.Equals
+	}
+
+	var n float64
+	switch v := v.(type) {
+	case float64:
+		n = v
+	case int64:
+		n = float64(v)
+	case string:
+		var err error
+		if n, err = strconv.ParseFloat(v, 64); err != nil {
+			return false
+		}
+	default:
+		return false
+	}
+	return (r.Min == nil || n >= *r.Min) && (r.Max == nil || n <= *r.Max)
+}
+
+// severityFromRules returns the severity of the first rule matching the log.
+func severityFromRules(rules []SeverityRule, log map[string]any) (plog.SeverityNumber, bool) {
+	for i := range rules {
+		if rules[i].matches(log) {
+			return severityNumbers[rules[i].Severity], true
+		}
+	}
+	return plog.SeverityNumberUnspecified, false
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestSeverityRules(t *testing.T) {
+	block := "block"
+	minStatus := 500.0
+	recv := newReceiver(t, &Config{
+		Logs: LogsConfig{
+			Endpoint:        "localhost:0",
+			TimestampField:  "EdgeStartTimestamp",
+			TimestampFormat: "rfc3339",
+			SeverityRules: []SeverityRule{
+				{Field: "SecurityAction", Equals: &block, Severity: "warn"},
+				{Field: "OriginResponseStatus", Min: &minStatus, Severity: "error"},
+			},
+		},
+	}, &consumertest.LogsSink{})
+
+	testCases := []struct {
+		name     string
+		payload  string
+		expected plog.SeverityNumber
+	}{
+		{
+			name:     "first matching rule",
+			payload:  `{"SecurityAction":"block","OriginResponseStatus":502,"EdgeResponseStatus":403,"EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`,
+			expected: plog.SeverityNumberWarn,
+		},
+		{
+			name:     "numeric range",
+			payload:  `{"SecurityAction":"allow","OriginResponseStatus":"503","EdgeResponseStatus":200,"EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`,
+			expected: plog.SeverityNumberError,
+		},
+		{
+			name:     "no matching rule keeps status severity",
+			payload:  `{"SecurityAction":"allow","OriginResponseStatus":200,"EdgeResponseStatus":404,"EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`,
+			expected: plog.SeverityNumberWarn,
+		},
+		{
+			name:     "no matching rule without status",
+			payload:  `{"OriginResponseStatus":"unknown","EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`,
+			expected: plog.SeverityNumberUnspecified,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rawLogs, err := parsePayload([]byte(tc.payload))
+			require.NoError(t, err)
+			logs := recv.converter.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
+			require.Equal(t, tc.expected, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityNumber())
+		})
+	}
+}
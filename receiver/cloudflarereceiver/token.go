@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// Permissions the API token of every section needs.
+const (
+	logpushJobsPermission    = "Logs:Read"
+	analyticsPermission      = "Analytics:Read"
+	accessRequestsPermission = "Access: Audit Logs:Read"
+	auditLogsPermission      = "Account Settings:Read"
+	instantLogsPermission    = "Zone Logs:Edit"
+	queuesPermission         = "Queues:Edit"
+	manageJobsPermission     = "Logs:Edit"
+)
+
+// verifyToken checks on start that the API token of the section is valid and active, logging the
+// permission the section needs otherwise, rather than leaving every request of the section to fail
+// with an authentication error. The verify endpoint doesn't report the permissions of the token, so
+// a token missing the permission is only reported by the failing requests. Since tokens owned by an
+// account can't be verified through this endpoint, failures are logged without preventing the start.
+func verifyToken(ctx context.Context, c client, logger *zap.Logger, section, permission string) {
+	status, err := c.VerifyToken(ctx)
+	var statusErr *statusError
+	switch {
+	case errors.As(err, &statusErr) && statusErr.errorType == metadata.AttributeErrorTypeAuthentication:
+		logger.Error("The API token was rejected by Cloudflare, a valid token with the required permission must be configured",
+			zap.String("section", section),
+			zap.String("required_permission", permission),
+			zap.Error(err))
+	case err != nil:
+		logger.Warn("Failed to verify the API token", zap.String("section", section), zap.Error(err))
+	case status.Status != "active":
+		logger.Error("The API token isn't active, an active token with the required permission must be configured",
+			zap.String("section", section),
+			zap.String("status", status.Status),
+			zap.String("required_permission", permission))
+	default:
+		fields := []zap.Field{zap.String("section", section), zap.String("required_permission", permission)}
+		if status.ExpiresOn != nil {
+			fields = append(fields, zap.Time("expires_on", *status.ExpiresOn))
+		}
+		logger.Debug("Verified the API token", fields...)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestVerifyToken(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		response        string
+		expectedLevel   zapcore.Level
+		expectedMessage string
+	}{
+		{
+			name:            "active",
+			status:          http.StatusOK,
+			response:        `{"success":true,"errors":[],"result":{"id":"ed17574386854bf78a67040be0a770b0","status":"active","expires_on":"2030-01-01T00:00:00Z"}}`,
+			expectedLevel:   zapcore.DebugLevel,
+			expectedMessage: "Verified the API token",
+		},
+		{
+			name:            "disabled",
+			status:          http.StatusOK,
+			response:        `{"success":true,"errors":[],"result":{"id":"ed17574386854bf78a67040be0a770b0","status":"disabled"}}`,
+			expectedLevel:   zapcore.ErrorLevel,
+			expectedMessage: "The API token isn't active, an active token with the required permission must be configured",
+		},
+		{
+			name:            "rejected",
+			status:          http.StatusUnauthorized,
+			response:        `{"success":false,"errors":[{"code":1000,"message":"Invalid API Token"}],"result":null}`,
+			expectedLevel:   zapcore.ErrorLevel,
+			expectedMessage: "The API token was rejected by Cloudflare, a valid token with the required permission must be configured",
+		},
+		{
+			name:            "unavailable",
+			status:          http.StatusServiceUnavailable,
+			response:        `{"success":false,"errors":[{"code":10000,"message":"Internal error"}],"result":null}`,
+			expectedLevel:   zapcore.WarnLevel,
+			expectedMessage: "Failed to verify the API token",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				calls++
+				require.Equal(t, "/user/tokens/verify", req.URL.Path)
+				rw.WriteHeader(tc.status)
+				_, err := rw.Write([]byte(tc.response))
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			core, logs := observer.New(zapcore.DebugLevel)
+			verifyToken(t.Context(), newTestClient(t, server.URL, newDefaultAPIConfig().BackOffConfig), zap.New(core), "logpush_jobs", logpushJobsPermission)
+
+			// The verification is never retried.
+			require.Equal(t, 1, calls)
+			require.Equal(t, 1, logs.Len())
+			entry := logs.All()[0]
+			require.Equal(t, tc.expectedLevel, entry.Level)
+			require.Equal(t, tc.expectedMessage, entry.Message)
+			require.Equal(t, "logpush_jobs", entry.ContextMap()["section"])
+			if tc.expectedLevel != zapcore.WarnLevel {
+				require.Equal(t, logpushJobsPermission, entry.ContextMap()["required_permission"])
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// rayIDHeader is the header carrying the Ray ID, which can be exported through the custom
+// RequestHeaders and ResponseHeaders fields.
+const rayIDHeader = "cf-ray"
+
+// parseRayID decodes a Ray ID as sent in the cf-ray header, optionally suffixed with the
+// data center it was served from, e.g. 3a6050bcbe121a87-SJC.
+func parseRayID(v any) (pcommon.SpanID, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return pcommon.SpanID{}, false
+	}
+	s, _, _ = strings.Cut(s, "-")
+
+	var id pcommon.SpanID
+	if n, err := hex.Decode(id[:], []byte(s)); err != nil || n != len(id) || id.IsEmpty() {
+		return pcommon.SpanID{}, false
+	}
+	return id, true
+}
+
+// traceIDFromRayID derives a trace ID from a Ray ID by left-padding it with zeros, so that it can
+// be reproduced by anything that sees the cf-ray header.
+func traceIDFromRayID(rayID pcommon.SpanID) pcommon.TraceID {
+	var id pcommon.TraceID
+	copy(id[len(id)-len(rayID):], rayID[:])
+	return id
+}
+
+func newTraceID() pcommon.TraceID {
+	var id pcommon.TraceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() pcommon.SpanID {
+	var id pcommon.SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+// rayIDFromLog returns the Ray ID of the request from the RayID field, falling back to the
+// cf-ray request or response header.
+func rayIDFromLog(log map[string]any) (pcommon.SpanID, bool) {
+	if rayID, ok := parseRayID(log["RayID"]); ok {
+		return rayID, true
+	}
+	for _, field := range []string{"RequestHeaders", "ResponseHeaders"} {
+		headers, ok := log[field].(map[string]any)
+		if !ok {
+			continue
+		}
+		for name, v := range headers {
+			if strings.EqualFold(name, rayIDHeader) {
+				if rayID, ok := parseRayID(v); ok {
+					return rayID, true
+				}
+			}
+		}
+	}
+	return pcommon.SpanID{}, false
+}
+
+// setTraceContextFromRayID sets the trace and span IDs of the log record from the Ray ID of the
+// request. Subrequests made by Workers share the trace of the request that triggered them, which
+// is identified by the ParentRayID field.
+func setTraceContextFromRayID(logRecord plog.LogRecord, log map[string]any) {
+	rayID, ok := rayIDFromLog(log)
+	if !ok {
+		return
+	}
+
+	traceRayID := rayID
+	if parentRayID, ok := parseRayID(log["ParentRayID"]); ok {
+		traceRayID = parentRayID
+	}
+	logRecord.SetTraceID(traceIDFromRayID(traceRayID))
+	logRecord.SetSpanID(rayID)
+}
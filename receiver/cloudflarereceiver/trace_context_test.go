@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestSetTraceContextFromRayID(t *testing.T) {
+	testCases := []struct {
+		name            string
+		log             map[string]any
+		expectedTraceID pcommon.TraceID
+		expectedSpanID  pcommon.SpanID
+	}{
+		{
+			name:            "ray id",
+			log:             map[string]any{"RayID": "3a6050bcbe121a87", "ParentRayID": "00"},
+			expectedTraceID: pcommon.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0x3a, 0x60, 0x50, 0xbc, 0xbe, 0x12, 0x1a, 0x87},
+			expectedSpanID:  pcommon.SpanID{0x3a, 0x60, 0x50, 0xbc, 0xbe, 0x12, 0x1a, 0x87},
+		},
+		{
+			name:            "worker subrequest",
+			log:             map[string]any{"RayID": "7a1f7ad4df2f870a", "ParentRayID": "3a6050bcbe121a87"},
+			expectedTraceID: pcommon.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0x3a, 0x60, 0x50, 0xbc, 0xbe, 0x12, 0x1a, 0x87},
+			expectedSpanID:  pcommon.SpanID{0x7a, 0x1f, 0x7a, 0xd4, 0xdf, 0x2f, 0x87, 0x0a},
+		},
+		{
+			name:            "cf-ray response header",
+			log:             map[string]any{"ResponseHeaders": map[string]any{"Cf-Ray": "3a6050bcbe121a87-SJC"}},
+			expectedTraceID: pcommon.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0x3a, 0x60, 0x50, 0xbc, 0xbe, 0x12, 0x1a, 0x87},
+			expectedSpanID:  pcommon.SpanID{0x3a, 0x60, 0x50, 0xbc, 0xbe, 0x12, 0x1a, 0x87},
+		},
+		{
+			name: "invalid ray id",
+			log:  map[string]any{"RayID": "not-a-ray-id"},
+		},
+		{
+			name: "no ray id",
+			log:  map[string]any{"ClientIP": "89.163.253.200"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logRecord := plog.NewLogRecord()
+			setTraceContextFromRayID(logRecord, tc.log)
+			require.Equal(t, tc.expectedTraceID, logRecord.TraceID())
+			require.Equal(t, tc.expectedSpanID, logRecord.SpanID())
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"github.com/ua-parser/uap-go/uaparser"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Attributes set from the parsed user agent of a request.
+const (
+	attrUserAgentName      = "user_agent.name"
+	attrUserAgentVersion   = "user_agent.version"
+	attrUserAgentOSName    = "user_agent.os.name"
+	attrUserAgentOSVersion = "user_agent.os.version"
+)
+
+// addUserAgentAttributes sets the browser and operating system parsed from the user agent.
+func addUserAgentAttributes(attrs pcommon.Map, parser *uaparser.Parser, userAgent string) {
+	client := parser.Parse(userAgent)
+
+	attrs.PutStr(attrUserAgentName, client.UserAgent.Family)
+	if version := client.UserAgent.ToVersionString(); version != "" {
+		attrs.PutStr(attrUserAgentVersion, version)
+	}
+
+	attrs.PutStr(attrUserAgentOSName, client.Os.Family)
+	if version := client.Os.ToVersionString(); version != "" {
+		attrs.PutStr(attrUserAgentOSVersion, version)
+	}
+}
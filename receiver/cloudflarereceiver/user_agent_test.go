@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	payload := `{"ClientRequestUserAgent":"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.109 Safari/537.36","EdgeStartTimestamp":"2023-03-03T05:29:05Z"}`
+
+	testCases := []struct {
+		name     string
+		cfg      LogsConfig
+		expected map[string]any
+	}{
+		{
+			name: "parsing disabled",
+			cfg:  LogsConfig{SemanticConventions: true},
+			expected: map[string]any{
+				"user_agent.original": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.109 Safari/537.36",
+				"EdgeStartTimestamp":  "2023-03-03T05:29:05Z",
+			},
+		},
+		{
+			name: "parsing enabled",
+			cfg: LogsConfig{
+				Attributes:     map[string]string{"EdgeStartTimestamp": "cloudflare.edge_start_timestamp"},
+				ParseUserAgent: true,
+			},
+			expected: map[string]any{
+				"cloudflare.edge_start_timestamp": "2023-03-03T05:29:05Z",
+				"user_agent.name":                 "Chrome",
+				"user_agent.version":              "120.0.6099",
+				"user_agent.os.name":              "Windows",
+				"user_agent.os.version":           "10",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.Endpoint = "localhost:0"
+			cfg.TimestampField = "EdgeStartTimestamp"
+			cfg.TimestampFormat = "rfc3339"
+			recv := newReceiver(t, &Config{Logs: cfg}, &consumertest.LogsSink{})
+
+			rawLogs, err := parsePayload([]byte(payload))
+			require.NoError(t, err)
+			logs := recv.converter.processLogs(pcommon.NewTimestampFromTime(time.Now()), rawLogs)
+			require.Equal(t, tc.expected, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+		})
+	}
+}
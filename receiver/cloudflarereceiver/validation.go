@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// attrParseError is the attribute holding why a forwarded record was rejected.
+const attrParseError = "cloudflare.logpush.parse_error"
+
+// rejectedRecord is a Logpush record that could not be parsed, or did not match the schema of its dataset.
+type rejectedRecord struct {
+	line   []byte
+	reason metadata.AttributeReason
+	err    error
+}
+
+// newRejectedRecord returns the rejected record of a parsed log, re-encoding it as its raw line.
+func newRejectedRecord(log map[string]any, reason metadata.AttributeReason, err error) rejectedRecord {
+	line, _ := json.Marshal(log)
+	return rejectedRecord{line: line, reason: reason, err: err}
+}
+
+// parseLines decodes each line of the payload as a JSON object. Lines that can't be decoded are
+// returned as rejected records.
+func parseLines(payload []byte) ([]map[string]any, []rejectedRecord) {
+	logs, rejected, _, _ := readLines(bytes.NewReader(payload), nil)
+	return logs, rejected
+}
+
+// readLines decodes each line read from r as a JSON object, as the lines are read. Lines that can't be
+// decoded are returned as rejected records. Unless nil, reserve is called with the size of every line
+// before it's decoded, and reading stops with errInFlightExceeded when it returns false. The total
+// size reserved is returned along with the error that stopped reading, if any.
+func readLines(r io.Reader, reserve func(int64) bool) ([]map[string]any, []rejectedRecord, int64, error) {
+	reader := bufio.NewReader(r)
+	var logs []map[string]any
+	var rejected []rejectedRecord
+	var size int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return logs, rejected, size, err
+		}
+		if len(line) != 0 && reserve != nil {
+			if !reserve(int64(len(line))) {
+				return logs, rejected, size, errInFlightExceeded
+			}
+			size += int64(len(line))
+		}
+		if line = bytes.TrimSuffix(line, []byte("\n")); len(line) != 0 {
+			log, rejectedLine := parseLine(line)
+			if rejectedLine != nil {
+				rejected = append(rejected, *rejectedLine)
+			} else {
+				logs = append(logs, log)
+			}
+		}
+		if err != nil {
+			return logs, rejected, size, nil
+		}
+	}
+}
+
+// parseLine decodes a line as a JSON object, or returns it as a rejected record.
+func parseLine(line []byte) (map[string]any, *rejectedRecord) {
+	var log map[string]any
+	if err := json.Unmarshal(line, &log); err != nil {
+		return nil, &rejectedRecord{line: line, reason: metadata.AttributeReasonMalformed, err: err}
+	}
+	if log == nil {
+		return nil, &rejectedRecord{line: line, reason: metadata.AttributeReasonMalformed, err: errors.New("record is not a JSON object")}
+	}
+	return log, nil
+}
+
+// parsePayload decodes the lines of the payload, failing on the first line that can't be decoded.
+func parsePayload(payload []byte) ([]map[string]any, error) {
+	logs, rejected := parseLines(payload)
+	if len(rejected) != 0 {
+		return logs, rejected[0].err
+	}
+	return logs, nil
+}
+
+// parseTimestamp parses the value of a timestamp field in the given format.
+func parseTimestamp(v any, format string) (pcommon.Timestamp, error) {
+	switch format {
+	case "unix", "unixmilli", "unixnano":
+		var i int64
+		switch val := v.(type) {
+		case int:
+			i = int64(val)
+		case int64:
+			i = val
+		case float64:
+			i = int64(val)
+		case string:
+			parsed, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("unable to parse %q as %s: %w", val, format, err)
+			}
+			i = parsed
+		default:
+			return 0, fmt.Errorf("unable to parse %s timestamp of unsupported type %T", format, v)
+		}
+		switch format {
+		case "unix":
+			return pcommon.NewTimestampFromTime(time.Unix(i, 0)), nil
+		case "unixmilli":
+			return pcommon.NewTimestampFromTime(time.UnixMilli(i)), nil
+		default:
+			return pcommon.NewTimestampFromTime(time.Unix(0, i)), nil
+		}
+	case "rfc3339":
+		strVal, ok := v.(string)
+		if !ok {
+			return 0, fmt.Errorf("unable to parse rfc3339 timestamp of type %T, not a string", v)
+		}
+		ts, err := time.Parse(time.RFC3339, strVal)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse %q as rfc3339: %w", strVal, err)
+		}
+		return pcommon.NewTimestampFromTime(ts), nil
+	default:
+		return 0, fmt.Errorf("unknown timestamp_format %q", format)
+	}
+}
+
+// datasetLabel returns the value of the dataset attribute of the telemetry about the dataset's records.
+func datasetLabel(ds *DatasetConfig) string {
+	switch {
+	case ds.Dataset != "":
+		return ds.Dataset
+	case ds.Path != "":
+		return ds.Path
+	default:
+		return "default"
+	}
+}
+
+// reportRejected counts the rejected records of the dataset and, if configured, forwards them as log
+// records with the raw line as body.
+func (c *logsConverter) reportRejected(ctx context.Context, now pcommon.Timestamp, pLogs plog.Logs, rejected []rejectedRecord, ds *DatasetConfig) {
+	if len(rejected) == 0 {
+		return
+	}
+
+	counts := make(map[metadata.AttributeReason]int64)
+	for _, r := range rejected {
+		counts[r.reason]++
+	}
+	for reason, count := range counts {
+		c.telemetryBuilder.CloudflareLogpushRecordsRejected.Add(ctx, count, metric.WithAttributes(
+			attribute.String("cloudflare.logpush.dataset", datasetLabel(ds)),
+			attribute.String("reason", reason.String()),
+		))
+	}
+
+	if !c.cfg.ForwardUnparseable {
+		c.logger.Debug("Dropped rejected Logpush records", zap.String("dataset", datasetLabel(ds)), zap.Int("count", len(rejected)))
+		return
+	}
+
+	resourceLogs, scopeLogs := appendResourceLogs(pLogs, c.buildInfo)
+	for k, v := range ds.ResourceAttributes {
+		resourceLogs.Resource().Attributes().PutStr(k, v)
+	}
+	for _, r := range rejected {
+		logRecord := scopeLogs.LogRecords().AppendEmpty()
+		logRecord.SetObservedTimestamp(now)
+		logRecord.Body().SetStr(string(r.line))
+		logRecord.Attributes().PutStr(attrParseError, r.err.Error())
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadatatest"
+)
+
+func TestParseLines(t *testing.T) {
+	logs, rejected := parseLines([]byte("{\"RayID\":\"1\"}\n{\"RayID\":\n\n[1,2]\nnull\n{\"RayID\":\"2\"}\n"))
+	require.Equal(t, []map[string]any{{"RayID": "1"}, {"RayID": "2"}}, logs)
+	require.Len(t, rejected, 3)
+	require.Equal(t, `{"RayID":`, string(rejected[0].line))
+	require.Equal(t, "[1,2]", string(rejected[1].line))
+	require.EqualError(t, rejected[2].err, "record is not a JSON object")
+}
+
+func TestReadLines(t *testing.T) {
+	payload := "{\"RayID\":\"1\"}\n{\"RayID\":\"2\"}\n{\"RayID\":\"3\"}"
+
+	var reserved int64
+	reserve := func(size int64) bool {
+		if reserved+size > 30 {
+			return false
+		}
+		reserved += size
+		return true
+	}
+	// Reading stops at the line that can't be reserved, reporting the size of the lines read so far.
+	logs, _, size, err := readLines(strings.NewReader(payload), reserve)
+	require.ErrorIs(t, err, errInFlightExceeded)
+	require.Equal(t, []map[string]any{{"RayID": "1"}, {"RayID": "2"}}, logs)
+	require.Equal(t, int64(28), size)
+
+	c := &logsConverter{cfg: &LogsConfig{MaxDecompressedSize: int64(len(payload))}}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err = gz.Write([]byte(payload))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	logs, rejected, _, err := c.readLogs(bytes.NewReader(compressed.Bytes()), "", nil)
+	require.NoError(t, err)
+	require.Empty(t, rejected)
+	require.Len(t, logs, 3)
+
+	c.cfg.MaxDecompressedSize--
+	_, _, _, err = c.readLogs(bytes.NewReader(compressed.Bytes()), "gzip", nil)
+	require.ErrorIs(t, err, errPayloadTooLarge)
+}
+
+func TestParseTimestamp(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       any
+		format      string
+		expected    time.Time
+		expectedErr string
+	}{
+		{
+			name:     "unix string",
+			value:    "1677821346",
+			format:   "unix",
+			expected: time.Unix(1677821346, 0),
+		},
+		{
+			name:     "unixnano number",
+			value:    float64(1677821346000000000),
+			format:   "unixnano",
+			expected: time.Unix(1677821346, 0),
+		},
+		{
+			name:     "unixmilli number",
+			value:    float64(1677821346000),
+			format:   "unixmilli",
+			expected: time.Unix(1677821346, 0),
+		},
+		{
+			name:     "rfc3339",
+			value:    "2023-03-03T05:29:06Z",
+			format:   "rfc3339",
+			expected: time.Date(2023, 3, 3, 5, 29, 6, 0, time.UTC),
+		},
+		{
+			name:        "invalid unix",
+			value:       "yesterday",
+			format:      "unix",
+			expectedErr: `unable to parse "yesterday" as unix`,
+		},
+		{
+			name:        "rfc3339 not a string",
+			value:       true,
+			format:      "rfc3339",
+			expectedErr: "unable to parse rfc3339 timestamp of type bool, not a string",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts, err := parseTimestamp(tc.value, tc.format)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.UTC(), ts.AsTime())
+		})
+	}
+}
+
+func TestRejectedRecords(t *testing.T) {
+	payload := strings.Join([]string{
+		`{"ClientIP":"89.163.253.200","Datetime":"2023-03-03T05:29:07Z"}`,
+		`{"ClientIP":"89.163.253.201","Datetime":"yesterday"}`,
+		`{"ClientIP":"89.163.253.202"}`,
+		`{"ClientIP":`,
+	}, "\n")
+
+	testCases := []struct {
+		name               string
+		forwardUnparseable bool
+		expectedBodies     []any
+	}{
+		{
+			name:           "dropped",
+			expectedBodies: []any{map[string]any{"ClientIP": "89.163.253.200", "Datetime": "2023-03-03T05:29:07Z"}},
+		},
+		{
+			name:               "forwarded",
+			forwardUnparseable: true,
+			expectedBodies: []any{
+				map[string]any{"ClientIP": "89.163.253.200", "Datetime": "2023-03-03T05:29:07Z"},
+				`{"ClientIP":`,
+				`{"ClientIP":"89.163.253.201","Datetime":"yesterday"}`,
+				`{"ClientIP":"89.163.253.202"}`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := componenttest.NewTelemetry()
+			defer func() { require.NoError(t, tt.Shutdown(t.Context())) }()
+
+			set := metadatatest.NewSettings(tt)
+			set.Logger = zaptest.NewLogger(t)
+			sink := &consumertest.LogsSink{}
+			r, err := newLogsReceiver(set, &Config{
+				Logs: LogsConfig{
+					Endpoint:           "localhost:0",
+					TimestampFormat:    "rfc3339",
+					ForwardUnparseable: tc.forwardUnparseable,
+					Datasets:           []DatasetConfig{{Path: "/gateway_http", Dataset: "gateway_http"}},
+				},
+			}, sink)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/gateway_http", strings.NewReader(payload)))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			require.Len(t, sink.AllLogs(), 1)
+			var bodies []any
+			rls := sink.AllLogs()[0].ResourceLogs()
+			for i := 0; i < rls.Len(); i++ {
+				records := rls.At(i).ScopeLogs().At(0).LogRecords()
+				for j := 0; j < records.Len(); j++ {
+					bodies = append(bodies, records.At(j).Body().AsRaw())
+					if tc.forwardUnparseable && i == 1 {
+						_, ok := records.At(j).Attributes().Get(attrParseError)
+						require.True(t, ok)
+					}
+				}
+			}
+			require.Equal(t, tc.expectedBodies, bodies)
+
+			metadatatest.AssertEqualCloudflareLogpushRecordsRejected(t, tt, []metricdata.DataPoint[int64]{
+				{Value: 1, Attributes: attribute.NewSet(attribute.String("cloudflare.logpush.dataset", "gateway_http"), attribute.String("reason", "malformed"))},
+				{Value: 1, Attributes: attribute.NewSet(attribute.String("cloudflare.logpush.dataset", "gateway_http"), attribute.String("reason", "invalid_timestamp"))},
+				{Value: 1, Attributes: attribute.NewSet(attribute.String("cloudflare.logpush.dataset", "gateway_http"), attribute.String("reason", "missing_timestamp"))},
+			}, metricdatatest.IgnoreTimestamp())
+		})
+	}
+}
+
+func TestRejectedPayload(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	defer func() { require.NoError(t, tt.Shutdown(t.Context())) }()
+
+	set := metadatatest.NewSettings(tt)
+	set.Logger = zaptest.NewLogger(t)
+	sink := &consumertest.LogsSink{}
+	r, err := newLogsReceiver(set, &Config{Logs: LogsConfig{Endpoint: "localhost:0", TimestampFormat: "rfc3339"}}, sink)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{\"ClientIP\":\nnot json")))
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	require.Empty(t, sink.AllLogs())
+
+	metadatatest.AssertEqualCloudflareLogpushRecordsRejected(t, tt, []metricdata.DataPoint[int64]{
+		{Value: 2, Attributes: attribute.NewSet(attribute.String("cloudflare.logpush.dataset", "default"), attribute.String("reason", "malformed"))},
+	}, metricdatatest.IgnoreTimestamp())
+}
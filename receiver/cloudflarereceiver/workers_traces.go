@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+// workersTraceEventsDataset is the Logpush dataset of the executions of Workers.
+const workersTraceEventsDataset = "workers_trace_events"
+
+// Attributes set on the spans of Workers executions.
+const (
+	attrWorkersOutcome           = "cloudflare.workers.outcome"
+	attrWorkersEventType         = "cloudflare.workers.event_type"
+	attrWorkersEntrypoint        = "cloudflare.workers.entrypoint"
+	attrWorkersDispatchNamespace = "cloudflare.workers.dispatch_namespace"
+	attrWorkersCPUTime           = "cloudflare.workers.cpu_time"
+	attrWorkersLogLevel          = "cloudflare.workers.log.level"
+	attrWorkersLogMessage        = "cloudflare.workers.log.message"
+)
+
+// workersSpans converts the Workers trace events into spans, one per execution, grouped in a
+// resource per script. Console logs and uncaught exceptions are added as span events. Events without
+// a valid timestamp are skipped.
+func workersSpans(logs []map[string]any, ds *DatasetConfig, buildInfo component.BuildInfo) ptrace.Traces {
+	traces := ptrace.NewTraces()
+	scriptSpans := make(map[string]ptrace.SpanSlice)
+	for _, log := range logs {
+		start, err := parseTimestamp(log[ds.TimestampField], ds.TimestampFormat)
+		if err != nil {
+			continue
+		}
+
+		script := stringField(log, "ScriptName")
+		spans, ok := scriptSpans[script]
+		if !ok {
+			resourceSpans := traces.ResourceSpans().AppendEmpty()
+			resourceSpans.SetSchemaUrl(conventions.SchemaURL)
+			attrs := resourceSpans.Resource().Attributes()
+			for k, v := range ds.ResourceAttributes {
+				attrs.PutStr(k, v)
+			}
+			attrs.PutStr(string(conventions.CloudProviderKey), "cloudflare")
+			if script != "" {
+				attrs.PutStr(string(conventions.ServiceNameKey), script)
+				attrs.PutStr(string(conventions.FaaSNameKey), script)
+			}
+			scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+			scopeSpans.SetSchemaUrl(conventions.SchemaURL)
+			scopeSpans.Scope().SetName(metadata.ScopeName)
+			scopeSpans.Scope().SetVersion(buildInfo.Version)
+			spans = scopeSpans.Spans()
+			scriptSpans[script] = spans
+		}
+		convertWorkersTraceEvent(spans.AppendEmpty(), start, log)
+	}
+	return traces
+}
+
+func convertWorkersTraceEvent(span ptrace.Span, start pcommon.Timestamp, log map[string]any) {
+	event, _ := log["Event"].(map[string]any)
+
+	// Executions triggered by requests share the trace of the request, and are children of its span.
+	if rayID, ok := parseRayID(lookupField(event, "RayID")); ok {
+		span.SetTraceID(traceIDFromRayID(rayID))
+		span.SetParentSpanID(rayID)
+	} else {
+		span.SetTraceID(newTraceID())
+	}
+	span.SetSpanID(newSpanID())
+
+	eventType := stringField(log, "EventType")
+	span.SetName(eventType)
+	if span.Name() == "" {
+		span.SetName("worker")
+	}
+	span.SetKind(workersSpanKind(eventType))
+
+	span.SetStartTimestamp(start)
+	end := start
+	if wallTime, ok := log["WallTimeMs"].(float64); ok {
+		end = pcommon.NewTimestampFromTime(start.AsTime().Add(time.Duration(wallTime * float64(time.Millisecond))))
+	}
+	span.SetEndTimestamp(end)
+
+	outcome := stringField(log, "Outcome")
+	if outcome != "" && outcome != "ok" {
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage(outcome)
+	}
+
+	attrs := span.Attributes()
+	putStrIfNotEmpty(attrs, attrWorkersOutcome, outcome)
+	putStrIfNotEmpty(attrs, attrWorkersEventType, eventType)
+	putStrIfNotEmpty(attrs, attrWorkersEntrypoint, stringField(log, "Entrypoint"))
+	putStrIfNotEmpty(attrs, attrWorkersDispatchNamespace, stringField(log, "DispatchNamespace"))
+	putStrIfNotEmpty(attrs, string(conventions.FaaSTriggerKey), workersTrigger(eventType))
+	if version, ok := log["ScriptVersion"].(map[string]any); ok {
+		if id, ok := lookupField(version, "ID").(string); ok {
+			attrs.PutStr(string(conventions.FaaSVersionKey), id)
+		}
+	}
+	if cpuTime, ok := log["CPUTimeMs"].(float64); ok {
+		attrs.PutDouble(attrWorkersCPUTime, cpuTime/1000)
+	}
+	if request, ok := lookupField(event, "Request").(map[string]any); ok {
+		if method, ok := lookupField(request, "Method").(string); ok {
+			attrs.PutStr(string(conventions.HTTPRequestMethodKey), method)
+		}
+		if url, ok := lookupField(request, "URL").(string); ok {
+			attrs.PutStr(string(conventions.URLFullKey), url)
+		}
+	}
+	if response, ok := lookupField(event, "Response").(map[string]any); ok {
+		if status, ok := lookupField(response, "Status").(float64); ok {
+			attrs.PutInt(string(conventions.HTTPResponseStatusCodeKey), int64(status))
+		}
+	}
+
+	if entries, ok := log["Logs"].([]any); ok {
+		for _, entry := range entries {
+			entry, ok := entry.(map[string]any)
+			if !ok {
+				continue
+			}
+			spanEvent := span.Events().AppendEmpty()
+			spanEvent.SetName("log")
+			spanEvent.SetTimestamp(entryTimestamp(entry))
+			level, _ := lookupField(entry, "Level").(string)
+			putStrIfNotEmpty(spanEvent.Attributes(), attrWorkersLogLevel, level)
+			spanEvent.Attributes().PutStr(attrWorkersLogMessage, logMessage(lookupField(entry, "Message")))
+		}
+	}
+	if exceptions, ok := log["Exceptions"].([]any); ok {
+		for _, exception := range exceptions {
+			exception, ok := exception.(map[string]any)
+			if !ok {
+				continue
+			}
+			spanEvent := span.Events().AppendEmpty()
+			spanEvent.SetName("exception")
+			spanEvent.SetTimestamp(entryTimestamp(exception))
+			if name, ok := lookupField(exception, "Name").(string); ok {
+				spanEvent.Attributes().PutStr(string(conventions.ExceptionTypeKey), name)
+			}
+			if message, ok := lookupField(exception, "Message").(string); ok {
+				spanEvent.Attributes().PutStr(string(conventions.ExceptionMessageKey), message)
+			}
+		}
+	}
+}
+
+// workersSpanKind returns the kind of the span of an execution triggered by the event type.
+func workersSpanKind(eventType string) ptrace.SpanKind {
+	switch eventType {
+	case "fetch", "rpc":
+		return ptrace.SpanKindServer
+	case "queue", "email":
+		return ptrace.SpanKindConsumer
+	default:
+		return ptrace.SpanKindInternal
+	}
+}
+
+// workersTrigger returns the faas.trigger of the event type.
+func workersTrigger(eventType string) string {
+	switch eventType {
+	case "fetch", "rpc":
+		return conventions.FaaSTriggerHTTP.Value.AsString()
+	case "scheduled", "alarm":
+		return conventions.FaaSTriggerTimer.Value.AsString()
+	case "queue", "email":
+		return conventions.FaaSTriggerPubSub.Value.AsString()
+	case "":
+		return ""
+	default:
+		return conventions.FaaSTriggerOther.Value.AsString()
+	}
+}
+
+// lookupField returns the value of the field, matching its name case-insensitively since nested
+// fields are sent in camel case by some producers.
+func lookupField(m map[string]any, name string) any {
+	if v, ok := m[name]; ok {
+		return v
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+// entryTimestamp returns the time of a log or exception of an execution, in milliseconds.
+func entryTimestamp(entry map[string]any) pcommon.Timestamp {
+	for _, field := range []string{"TimestampMs", "Timestamp"} {
+		if ms, ok := lookupField(entry, field).(float64); ok {
+			return pcommon.NewTimestampFromTime(time.UnixMilli(int64(ms)))
+		}
+	}
+	return 0
+}
+
+// logMessage formats the arguments of a console call as a single message.
+func logMessage(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, part := range v {
+			parts = append(parts, fmt.Sprint(part))
+		}
+		return strings.Join(parts, " ")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func putStrIfNotEmpty(attrs pcommon.Map, key, value string) {
+	if value != "" {
+		attrs.PutStr(key, value)
+	}
+}
+
+// consumeWorkersSpans sends the spans of the Workers trace events to the traces pipeline.
+func (l *logsReceiver) consumeWorkersSpans(ctx context.Context, logs []map[string]any, ds *DatasetConfig) error {
+	traces := workersSpans(logs, ds, l.buildInfo)
+	if traces.SpanCount() == 0 {
+		return nil
+	}
+	obsCtx := l.obsrecv.StartTracesOp(ctx)
+	err := l.traces.ConsumeTraces(obsCtx, traces)
+	l.obsrecv.EndTracesOp(obsCtx, metadata.Type.String(), traces.SpanCount(), err)
+	return err
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver/internal/metadata"
+)
+
+const workersTraceEvent = `{"DispatchNamespace":"","Entrypoint":"default","Event":{"RayID":"3a6050bcbe121a87","Request":{"URL":"https://example.com/api","Method":"GET"},"Response":{"Status":500}},"EventTimestampMs":1677821346000,"EventType":"fetch","Exceptions":[{"Name":"TypeError","Message":"x is undefined","TimestampMs":1677821346010}],"Logs":[{"Level":"log","Message":["fetching",3],"TimestampMs":1677821346005}],"Outcome":"exception","ScriptName":"api-worker","ScriptVersion":{"ID":"a5f4c2b1"},"CPUTimeMs":12,"WallTimeMs":20}`
+
+func TestWorkersSpans(t *testing.T) {
+	logs, rejected := parseLines([]byte(workersTraceEvent + "\n" + `{"EventType":"scheduled","EventTimestampMs":"yesterday","ScriptName":"cron"}`))
+	require.Empty(t, rejected)
+
+	ds := (&DatasetConfig{TimestampFormat: "rfc3339"}).merge(DatasetConfig{Dataset: workersTraceEventsDataset})
+	require.Equal(t, "unixmilli", ds.TimestampFormat)
+
+	traces := workersSpans(logs, ds, receivertest.NewNopSettings(metadata.Type).BuildInfo)
+	// The event without a valid timestamp is skipped.
+	require.Equal(t, 1, traces.SpanCount())
+
+	rs := traces.ResourceSpans().At(0)
+	serviceName, _ := rs.Resource().Attributes().Get("service.name")
+	require.Equal(t, "api-worker", serviceName.Str())
+	dataset, _ := rs.Resource().Attributes().Get(attrDataset)
+	require.Equal(t, workersTraceEventsDataset, dataset.Str())
+
+	span := rs.ScopeSpans().At(0).Spans().At(0)
+	rayID, _ := parseRayID("3a6050bcbe121a87")
+	require.Equal(t, traceIDFromRayID(rayID), span.TraceID())
+	require.Equal(t, rayID, span.ParentSpanID())
+	require.False(t, span.SpanID().IsEmpty())
+	require.Equal(t, "fetch", span.Name())
+	require.Equal(t, ptrace.SpanKindServer, span.Kind())
+	require.Equal(t, time.UnixMilli(1677821346000).UTC(), span.StartTimestamp().AsTime())
+	require.Equal(t, 20*time.Millisecond, span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime()))
+	require.Equal(t, ptrace.StatusCodeError, span.Status().Code())
+	require.Equal(t, "exception", span.Status().Message())
+	require.Equal(t, map[string]any{
+		attrWorkersOutcome:          "exception",
+		attrWorkersEventType:        "fetch",
+		attrWorkersEntrypoint:       "default",
+		attrWorkersCPUTime:          0.012,
+		"faas.trigger":              "http",
+		"faas.version":              "a5f4c2b1",
+		"http.request.method":       "GET",
+		"url.full":                  "https://example.com/api",
+		"http.response.status_code": int64(500),
+	}, span.Attributes().AsRaw())
+
+	require.Equal(t, 2, span.Events().Len())
+	logEvent := span.Events().At(0)
+	require.Equal(t, "log", logEvent.Name())
+	require.Equal(t, pcommon.NewTimestampFromTime(time.UnixMilli(1677821346005)), logEvent.Timestamp())
+	require.Equal(t, map[string]any{attrWorkersLogLevel: "log", attrWorkersLogMessage: "fetching 3"}, logEvent.Attributes().AsRaw())
+	exceptionEvent := span.Events().At(1)
+	require.Equal(t, "exception", exceptionEvent.Name())
+	require.Equal(t, map[string]any{"exception.type": "TypeError", "exception.message": "x is undefined"}, exceptionEvent.Attributes().AsRaw())
+}
+
+func TestWorkersSpansFromEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Endpoint = "localhost:0"
+	cfg.Logs.Datasets = []DatasetConfig{{Path: "/workers", Dataset: workersTraceEventsDataset}}
+
+	logsSink := &consumertest.LogsSink{}
+	tracesSink := &consumertest.TracesSink{}
+	r, err := newLogsReceiver(receivertest.NewNopSettings(metadata.Type), cfg, logsSink)
+	require.NoError(t, err)
+	r.traces = tracesSink
+
+	rec := httptest.NewRecorder()
+	r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/workers", strings.NewReader(workersTraceEvent)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, 1, logsSink.LogRecordCount())
+	require.Equal(t, 1, tracesSink.SpanCount())
+
+	// Only the Workers trace events are converted into spans.
+	rec = httptest.NewRecorder()
+	r.handleRequest(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"EdgeStartTimestamp":"2023-03-03T05:00:05Z"}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, 2, logsSink.LogRecordCount())
+	require.Equal(t, 1, tracesSink.SpanCount())
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/cloudflarereceiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// zoneCache caches the details of zones, looking them up on first use. Once the details of a zone are
+// older than the TTL, they're refreshed in the background while the cached details keep being used,
+// so that scrapes only wait for the lookup of zones that were never seen.
+type zoneCache struct {
+	ttl    time.Duration
+	lookup func(ctx context.Context, zoneID string) (zone, error)
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	entries map[string]*zoneEntry
+
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type zoneEntry struct {
+	zone       zone
+	expires    time.Time
+	refreshing bool
+}
+
+func newZoneCache(ttl time.Duration, lookup func(context.Context, string) (zone, error), logger *zap.Logger) *zoneCache {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &zoneCache{
+		ttl:     ttl,
+		lookup:  lookup,
+		logger:  logger,
+		entries: map[string]*zoneEntry{},
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// get returns the details of the zone. A failed lookup of a zone that was never seen is retried on the
+// next call.
+func (c *zoneCache) get(ctx context.Context, zoneID string, now time.Time) (zone, bool) {
+	c.mu.Lock()
+	if entry, ok := c.entries[zoneID]; ok {
+		if c.ttl > 0 && !now.Before(entry.expires) && !entry.refreshing {
+			entry.refreshing = true
+			c.wg.Add(1)
+			go c.refresh(zoneID)
+		}
+		z := entry.zone
+		c.mu.Unlock()
+		return z, true
+	}
+	c.mu.Unlock()
+
+	z, err := c.lookup(ctx, zoneID)
+	if err != nil {
+		c.logger.Warn("Failed to look up zone details", zap.String("zone", zoneID), zap.Error(err))
+		return zone{}, false
+	}
+	c.mu.Lock()
+	c.entries[zoneID] = &zoneEntry{zone: z, expires: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return z, true
+}
+
+// refresh looks up the details of the zone again, keeping the cached details if the lookup fails.
+func (c *zoneCache) refresh(zoneID string) {
+	defer c.wg.Done()
+	z, err := c.lookup(c.ctx, zoneID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.entries[zoneID]
+	entry.refreshing = false
+	if err != nil {
+		if c.ctx.Err() == nil {
+			c.logger.Warn("Failed to refresh zone details", zap.String("zone", zoneID), zap.Error(err))
+		}
+		return
+	}
+	entry.zone = z
+	entry.expires = time.Now().Add(c.ttl)
+}
+
+// shutdown cancels the refreshes in progress and waits for them to return.
+func (c *zoneCache) shutdown() {
+	c.cancel()
+	c.wg.Wait()
+}
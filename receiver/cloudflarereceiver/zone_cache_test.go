@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflarereceiver
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestZoneCache(t *testing.T) {
+	var lookups atomic.Int32
+	var failing atomic.Bool
+	lookup := func(_ context.Context, zoneID string) (zone, error) {
+		n := lookups.Add(1)
+		if failing.Load() {
+			return zone{}, errors.New("9109: Invalid access token")
+		}
+		z := zone{ID: zoneID, Name: "example.com"}
+		if n > 1 {
+			z.Name = "example.net"
+		}
+		return z, nil
+	}
+	c := newZoneCache(time.Hour, lookup, zap.NewNop())
+	defer c.shutdown()
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	z, ok := c.get(t.Context(), testZoneID, now)
+	require.True(t, ok)
+	require.Equal(t, "example.com", z.Name)
+
+	// The details are cached until the TTL expires.
+	z, ok = c.get(t.Context(), testZoneID, now.Add(30*time.Minute))
+	require.True(t, ok)
+	require.Equal(t, "example.com", z.Name)
+	require.Equal(t, int32(1), lookups.Load())
+
+	// Once expired, the cached details are returned while they're refreshed in the background.
+	z, ok = c.get(t.Context(), testZoneID, now.Add(time.Hour))
+	require.True(t, ok)
+	require.Equal(t, "example.com", z.Name)
+	c.wg.Wait()
+	require.Equal(t, int32(2), lookups.Load())
+	z, ok = c.get(t.Context(), testZoneID, now.Add(time.Hour))
+	require.True(t, ok)
+	require.Equal(t, "example.net", z.Name)
+
+	// A failed refresh keeps the cached details.
+	failing.Store(true)
+	_, _ = c.get(t.Context(), testZoneID, time.Now().Add(2*time.Hour))
+	c.wg.Wait()
+	z, ok = c.get(t.Context(), testZoneID, time.Now())
+	require.True(t, ok)
+	require.Equal(t, "example.net", z.Name)
+
+	// A failed lookup of a new zone isn't cached.
+	_, ok = c.get(t.Context(), "unknown", now)
+	require.False(t, ok)
+	lookupsBefore := lookups.Load()
+	_, ok = c.get(t.Context(), "unknown", now)
+	require.False(t, ok)
+	require.Equal(t, lookupsBefore+1, lookups.Load())
+}
+
+func TestZoneCacheNoTTL(t *testing.T) {
+	var lookups atomic.Int32
+	c := newZoneCache(0, func(_ context.Context, zoneID string) (zone, error) {
+		lookups.Add(1)
+		return zone{ID: zoneID}, nil
+	}, zap.NewNop())
+	defer c.shutdown()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		_, ok := c.get(t.Context(), testZoneID, now.Add(time.Duration(i)*24*time.Hour))
+		require.True(t, ok)
+	}
+	c.wg.Wait()
+	require.Equal(t, int32(1), lookups.Load())
+}